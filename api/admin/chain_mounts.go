@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/lasthyphen/beacongo/api/server"
+	"github.com/lasthyphen/beacongo/chains"
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/constants"
+)
+
+// ChainMount describes the friendly paths a chain's API is served under, in
+// addition to the default /ext/bc/<chainID> path.
+type ChainMount struct {
+	ChainID ids.ID   `json:"chainID"`
+	Aliases []string `json:"aliases"`
+	Paths   []string `json:"paths"`
+}
+
+// chainMounts persists chain aliases registered through the admin API so that
+// they are re-mounted when the node restarts.
+//
+// Keys are the alias and values are the aliased chain's ID.
+type chainMounts struct {
+	db database.Database
+}
+
+func (m chainMounts) put(alias string, chainID ids.ID) error {
+	return m.db.Put([]byte(alias), chainID[:])
+}
+
+// getAll returns the persisted aliases, grouped by chain
+func (m chainMounts) getAll() (map[ids.ID][]string, error) {
+	it := m.db.NewIterator()
+	defer it.Release()
+
+	mounts := make(map[ids.ID][]string)
+	for it.Next() {
+		chainID, err := ids.ToID(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse chainID of alias %q: %w", it.Key(), err)
+		}
+		mounts[chainID] = append(mounts[chainID], string(it.Key()))
+	}
+	return mounts, it.Error()
+}
+
+// RestoreChainMounts re-registers every chain alias that was persisted in [db]
+// with the chain manager and mounts the chain's API under the alias.
+func RestoreChainMounts(
+	db database.Database,
+	chainManager chains.Manager,
+	httpServer server.PathAdder,
+) error {
+	mounts, err := chainMounts{db: db}.getAll()
+	if err != nil {
+		return err
+	}
+	for chainID, aliases := range mounts {
+		endpoint := path.Join(constants.ChainAliasPrefix, chainID.String())
+		for _, alias := range aliases {
+			if err := chainManager.Alias(chainID, alias); err != nil {
+				return fmt.Errorf("couldn't alias chain %s to %q: %w", chainID, alias, err)
+			}
+			if err := httpServer.AddAliases(endpoint, path.Join(constants.ChainAliasPrefix, alias)); err != nil {
+				return fmt.Errorf("couldn't mount chain %s at %q: %w", chainID, alias, err)
+			}
+		}
+	}
+	return nil
+}
+
+// sortedChainMounts converts [mounts] into a deterministically ordered list.
+func sortedChainMounts(mounts map[ids.ID][]string) []ChainMount {
+	chainIDs := make([]ids.ID, 0, len(mounts))
+	for chainID := range mounts {
+		chainIDs = append(chainIDs, chainID)
+	}
+	ids.SortIDs(chainIDs)
+
+	result := make([]ChainMount, len(chainIDs))
+	for i, chainID := range chainIDs {
+		aliases := mounts[chainID]
+		sort.Strings(aliases)
+
+		paths := make([]string, len(aliases))
+		for j, alias := range aliases {
+			paths[j] = path.Join("/ext", constants.ChainAliasPrefix, alias)
+		}
+		result[i] = ChainMount{
+			ChainID: chainID,
+			Aliases: aliases,
+			Paths:   paths,
+		}
+	}
+	return result
+}
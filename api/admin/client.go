@@ -5,10 +5,14 @@ package admin
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/api/apikeys"
+	"github.com/lasthyphen/beacongo/chains"
 	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/json"
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/utils/rpc"
 )
@@ -24,11 +28,22 @@ type Client interface {
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) (bool, error)
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) (bool, error)
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	GetChainMounts(context.Context, ...rpc.Option) ([]ChainMount, error)
+	GetChainStateDigest(ctx context.Context, chain string, options ...rpc.Option) (ids.ID, error)
+	SetChainMaintenance(ctx context.Context, chain string, enabled bool, options ...rpc.Option) (bool, error)
+	GetChainMaintenance(ctx context.Context, chain string, options ...rpc.Option) (bool, error)
+	GetChainBundle(ctx context.Context, chain string, options ...rpc.Option) (chains.ChainBundle, error)
+	ImportChainBundle(ctx context.Context, bundle chains.ChainBundle, options ...rpc.Option) (bool, error)
+	GetChainDiskUsage(ctx context.Context, chain string, options ...rpc.Option) (chains.DiskUsage, error)
 	Stacktrace(context.Context, ...rpc.Option) (bool, error)
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (bool, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	CreateAPIKey(ctx context.Context, name string, rateLimit uint64, options ...rpc.Option) (string, error)
+	RevokeAPIKey(ctx context.Context, name string, options ...rpc.Option) (bool, error)
+	GetAPIKeyUsage(ctx context.Context, names []string, options ...rpc.Option) ([]apikeys.Usage, error)
+	Decode(ctx context.Context, codec string, payload []byte, options ...rpc.Option) (*DecodeReply, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -37,10 +52,11 @@ type client struct {
 }
 
 // NewClient returns a new Info API Client
-func NewClient(uri string) Client {
+func NewClient(uri string, options ...rpc.Option) Client {
 	return &client{requester: rpc.NewEndpointRequester(
 		uri+"/ext/admin",
 		"admin",
+		options...,
 	)}
 }
 
@@ -94,6 +110,61 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) GetChainMounts(ctx context.Context, options ...rpc.Option) ([]ChainMount, error) {
+	res := &GetChainMountsReply{}
+	err := c.requester.SendRequest(ctx, "getChainMounts", struct{}{}, res, options...)
+	return res.Mounts, err
+}
+
+func (c *client) GetChainStateDigest(ctx context.Context, chain string, options ...rpc.Option) (ids.ID, error) {
+	res := &GetChainStateDigestReply{}
+	err := c.requester.SendRequest(ctx, "getChainStateDigest", &GetChainStateDigestArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.Digest, err
+}
+
+func (c *client) SetChainMaintenance(ctx context.Context, chain string, enabled bool, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "setChainMaintenance", &SetChainMaintenanceArgs{
+		Chain:   chain,
+		Enabled: enabled,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) GetChainMaintenance(ctx context.Context, chain string, options ...rpc.Option) (bool, error) {
+	res := &GetChainMaintenanceReply{}
+	err := c.requester.SendRequest(ctx, "getChainMaintenance", &GetChainMaintenanceArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.Enabled, err
+}
+
+func (c *client) GetChainBundle(ctx context.Context, chain string, options ...rpc.Option) (chains.ChainBundle, error) {
+	res := &GetChainBundleReply{}
+	err := c.requester.SendRequest(ctx, "getChainBundle", &GetChainBundleArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.Bundle, err
+}
+
+func (c *client) ImportChainBundle(ctx context.Context, bundle chains.ChainBundle, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "importChainBundle", &ImportChainBundleArgs{
+		Bundle: bundle,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) GetChainDiskUsage(ctx context.Context, chain string, options ...rpc.Option) (chains.DiskUsage, error) {
+	res := &GetChainDiskUsageReply{}
+	err := c.requester.SendRequest(ctx, "getChainDiskUsage", &GetChainDiskUsageArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.DiskUsage, err
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) (bool, error) {
 	res := &api.SuccessResponse{}
 	err := c.requester.SendRequest(ctx, "stacktrace", struct{}{}, res, options...)
@@ -161,3 +232,37 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) CreateAPIKey(ctx context.Context, name string, rateLimit uint64, options ...rpc.Option) (string, error) {
+	res := &CreateAPIKeyReply{}
+	err := c.requester.SendRequest(ctx, "createAPIKey", &CreateAPIKeyArgs{
+		Name:      name,
+		RateLimit: json.Uint64(rateLimit),
+	}, res, options...)
+	return res.Key, err
+}
+
+func (c *client) RevokeAPIKey(ctx context.Context, name string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "revokeAPIKey", &RevokeAPIKeyArgs{
+		Name: name,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) GetAPIKeyUsage(ctx context.Context, names []string, options ...rpc.Option) ([]apikeys.Usage, error) {
+	res := &GetAPIKeyUsageReply{}
+	err := c.requester.SendRequest(ctx, "getAPIKeyUsage", &GetAPIKeyUsageArgs{
+		Names: names,
+	}, res, options...)
+	return res.Usage, err
+}
+
+func (c *client) Decode(ctx context.Context, codec string, payload []byte, options ...rpc.Option) (*DecodeReply, error) {
+	res := &DecodeReply{}
+	err := c.requester.SendRequest(ctx, "decode", &DecodeArgs{
+		Codec:   codec,
+		Payload: "0x" + hex.EncodeToString(payload),
+	}, res, options...)
+	return res, err
+}
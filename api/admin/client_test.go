@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/chains"
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/utils/rpc"
@@ -64,6 +65,15 @@ func (mc *mockClient) SendRequest(ctx context.Context, method string, params int
 	case *GetChainAliasesReply:
 		response := mc.response.(*GetChainAliasesReply)
 		*p = *response
+	case *GetChainStateDigestReply:
+		response := mc.response.(*GetChainStateDigestReply)
+		*p = *response
+	case *GetChainBundleReply:
+		response := mc.response.(*GetChainBundleReply)
+		*p = *response
+	case *GetChainDiskUsageReply:
+		response := mc.response.(*GetChainDiskUsageReply)
+		*p = *response
 	case *LoadVMsReply:
 		response := mc.response.(*LoadVMsReply)
 		*p = *response
@@ -214,6 +224,99 @@ func TestGetChainAliases(t *testing.T) {
 	})
 }
 
+func TestGetChainStateDigest(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedDigest := ids.GenerateTestID()
+		mockClient := client{requester: NewMockClient(&GetChainStateDigestReply{
+			Digest: expectedDigest,
+		}, nil)}
+
+		digest, err := mockClient.GetChainStateDigest(context.Background(), "X")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedDigest, digest)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetChainStateDigestReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetChainStateDigest(context.Background(), "X")
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetChainBundle(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedBundle := chains.ChainBundle{
+			ChainID:  ids.GenerateTestID(),
+			SubnetID: ids.GenerateTestID(),
+			VMID:     ids.GenerateTestID(),
+			FxIDs:    []ids.ID{ids.GenerateTestID()},
+			Genesis:  []byte{1, 2, 3},
+		}
+		mockClient := client{requester: NewMockClient(&GetChainBundleReply{
+			Bundle: expectedBundle,
+		}, nil)}
+
+		bundle, err := mockClient.GetChainBundle(context.Background(), "X")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBundle, bundle)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetChainBundleReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetChainBundle(context.Background(), "X")
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestImportChainBundle(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.ImportChainBundle(context.Background(), chains.ChainBundle{})
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestGetChainDiskUsage(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedUsage := chains.DiskUsage{
+			ChainID:    ids.GenerateTestID(),
+			Bytes:      1024,
+			Keys:       10,
+			GrowthRate: 256,
+		}
+		mockClient := client{requester: NewMockClient(&GetChainDiskUsageReply{
+			DiskUsage: expectedUsage,
+		}, nil)}
+
+		usage, err := mockClient.GetChainDiskUsage(context.Background(), "X")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedUsage, usage)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetChainDiskUsageReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetChainDiskUsage(context.Background(), "X")
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
 func TestStacktrace(t *testing.T) {
 	tests := GetSuccessResponseTests()
 
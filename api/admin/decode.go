@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/lasthyphen/beacongo/codec"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/avm/fxs"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/nftfx"
+	"github.com/lasthyphen/beacongo/vms/platformvm"
+	"github.com/lasthyphen/beacongo/vms/propertyfx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+// Kinds of payloads that can be decoded by Decode
+const (
+	// A signed transaction of the AVM
+	AVMTx = "avmTx"
+	// A UTXO in shared memory. UTXOs exported by the AVM and by the platform
+	// chain share the type IDs of their outputs.
+	AtomicUTXO = "atomicUTXO"
+	// A block of the platform chain
+	PlatformBlock = "platformBlock"
+	// A signed transaction of the platform chain
+	PlatformTx = "platformTx"
+)
+
+var errUnknownCodec = errors.New("unknown codec")
+
+// DecodeArgs are the arguments for calling Decode
+type DecodeArgs struct {
+	// Kind of the payload. One of "avmTx", "atomicUTXO", "platformBlock" or
+	// "platformTx".
+	Codec string `json:"codec"`
+	// Hex encoded payload, without a checksum. The 0x prefix is optional.
+	Payload string `json:"payload"`
+}
+
+// DecodeReply is the response from calling Decode
+type DecodeReply struct {
+	// Codec version the payload was encoded with
+	Version json.Uint16 `json:"version"`
+	// Go type the payload was decoded into
+	Type string `json:"type,omitempty"`
+	// The decoded payload, if it could be decoded
+	Value interface{} `json:"value,omitempty"`
+	// Why the payload couldn't be decoded
+	Error string `json:"error,omitempty"`
+	// Index of the byte of the payload at which decoding failed, if known
+	ErrorOffset *json.Uint32 `json:"errorOffset,omitempty"`
+}
+
+// Decode decodes a serialized payload, such as a transaction captured from the
+// network, without issuing it to a chain. If the payload is malformed, the
+// reply describes where decoding failed.
+func (service *Admin) Decode(_ *http.Request, args *DecodeArgs, reply *DecodeReply) error {
+	service.Log.Debug("Admin: Decode called with Codec: %s", args.Codec)
+
+	payload, err := hex.DecodeString(strings.TrimPrefix(args.Payload, "0x"))
+	if err != nil {
+		return fmt.Errorf("couldn't decode payload as hex: %w", err)
+	}
+	manager, dest, err := newDecodeDest(args.Codec)
+	if err != nil {
+		return err
+	}
+
+	version, err := manager.Unmarshal(payload, dest)
+	reply.Version = json.Uint16(version)
+	if err != nil {
+		reply.Error = err.Error()
+		if offset, ok := codec.ErrorOffset(err); ok {
+			errorOffset := json.Uint32(offset)
+			reply.ErrorOffset = &errorOffset
+		}
+		return nil
+	}
+
+	// Report the concrete type of payloads decoded into an interface
+	value := reflect.ValueOf(dest).Elem()
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	valueType := value.Type()
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	reply.Type = valueType.String()
+	reply.Value = dest
+	return nil
+}
+
+// newDecodeDest returns the codec that payloads of [codecName] are encoded
+// with and a pointer to decode a payload into.
+func newDecodeDest(codecName string) (codec.Manager, interface{}, error) {
+	switch codecName {
+	case AVMTx, AtomicUTXO:
+		// The fxs of the X-chain
+		parser, err := txs.NewParser([]fxs.Fx{
+			&secp256k1fx.Fx{},
+			&nftfx.Fx{},
+			&propertyfx.Fx{},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if codecName == AVMTx {
+			return parser.Codec(), &txs.Tx{}, nil
+		}
+		return parser.Codec(), &djtx.UTXO{}, nil
+	case PlatformBlock:
+		var blk platformvm.Block
+		return platformvm.Codec, &blk, nil
+	case PlatformTx:
+		return platformvm.Codec, &platformvm.Tx{}, nil
+	default:
+		return nil, nil, fmt.Errorf("%w %q", errUnknownCodec, codecName)
+	}
+}
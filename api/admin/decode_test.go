@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/vms/avm/fxs"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+func TestDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := &Admin{Config: Config{
+		Log: logging.NoLog{},
+	}}
+
+	parser, err := txs.NewParser([]fxs.Fx{&secp256k1fx.Fx{}})
+	assert.NoError(err)
+	tx := &txs.Tx{UnsignedTx: &txs.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    1,
+		BlockchainID: ids.GenerateTestID(),
+		Outs: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+		}},
+	}}}
+	txBytes, err := parser.Codec().Marshal(txs.CodecVersion, tx)
+	assert.NoError(err)
+
+	reply := DecodeReply{}
+	assert.NoError(admin.Decode(nil, &DecodeArgs{
+		Codec:   AVMTx,
+		Payload: "0x" + hex.EncodeToString(txBytes),
+	}, &reply))
+	assert.Empty(reply.Error)
+	assert.Equal("txs.Tx", reply.Type)
+	decodedTx, ok := reply.Value.(*txs.Tx)
+	if assert.True(ok) {
+		decodedBytes, err := parser.Codec().Marshal(txs.CodecVersion, decodedTx)
+		assert.NoError(err)
+		assert.Equal(txBytes, decodedBytes)
+	}
+
+	// Trailing bytes are reported at the offset they start at
+	reply = DecodeReply{}
+	assert.NoError(admin.Decode(nil, &DecodeArgs{
+		Codec:   AVMTx,
+		Payload: hex.EncodeToString(append(txBytes, 0)),
+	}, &reply))
+	assert.NotEmpty(reply.Error)
+	assert.Nil(reply.Value)
+	if assert.NotNil(reply.ErrorOffset) {
+		assert.Equal(json.Uint32(len(txBytes)), *reply.ErrorOffset)
+	}
+
+	err = admin.Decode(nil, &DecodeArgs{
+		Codec:   "unknown",
+		Payload: hex.EncodeToString(txBytes),
+	}, &DecodeReply{})
+	assert.ErrorIs(err, errUnknownCodec)
+}
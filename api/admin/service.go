@@ -5,14 +5,17 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
 
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/api/apikeys"
 	"github.com/lasthyphen/beacongo/api/server"
 	"github.com/lasthyphen/beacongo/chains"
+	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/snow/engine/common"
 	"github.com/lasthyphen/beacongo/utils/constants"
@@ -32,8 +35,10 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong     = errors.New("alias length is too long")
+	errNoLogLevel       = errors.New("need to specify either displayLevel or logLevel")
+	errNoAPIKeys        = errors.New("API keys are disabled")
+	errNoChainConfigDir = errors.New("chain configs aren't read from a directory")
 )
 
 type Config struct {
@@ -45,6 +50,15 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	// DB that chain aliases are persisted to. If nil, chain aliases are only
+	// kept in memory.
+	DB database.Database
+	// Manager of the API keys that requests are metered by. If nil, API keys
+	// can't be managed.
+	APIKeys apikeys.Manager
+	// Directory that chain configs are read from. If empty, chain bundles
+	// can't be imported.
+	ChainConfigDir string
 }
 
 // Admin is the API service for node admin management
@@ -140,11 +154,41 @@ func (service *Admin) AliasChain(_ *http.Request, args *AliasChainArgs, reply *a
 		return err
 	}
 
-	reply.Success = true
-
 	endpoint := path.Join(constants.ChainAliasPrefix, chainID.String())
 	alias := path.Join(constants.ChainAliasPrefix, args.Alias)
-	return service.HTTPServer.AddAliasesWithReadLock(endpoint, alias)
+	if err := service.HTTPServer.AddAliasesWithReadLock(endpoint, alias); err != nil {
+		return err
+	}
+
+	if service.DB != nil {
+		if err := (chainMounts{db: service.DB}).put(args.Alias, chainID); err != nil {
+			return fmt.Errorf("couldn't persist chain alias: %w", err)
+		}
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetChainMountsReply are the persisted chain aliases
+type GetChainMountsReply struct {
+	Mounts []ChainMount `json:"mounts"`
+}
+
+// GetChainMounts returns the chain aliases that were registered through
+// AliasChain and the API paths they are mounted at.
+func (service *Admin) GetChainMounts(_ *http.Request, _ *struct{}, reply *GetChainMountsReply) error {
+	service.Log.Debug("Admin: GetChainMounts called")
+
+	reply.Mounts = []ChainMount{}
+	if service.DB == nil {
+		return nil
+	}
+	mounts, err := chainMounts{db: service.DB}.getAll()
+	if err != nil {
+		return err
+	}
+	reply.Mounts = sortedChainMounts(mounts)
+	return nil
 }
 
 // GetChainAliasesArgs are the arguments for calling GetChainAliases
@@ -170,6 +214,167 @@ func (service *Admin) GetChainAliases(_ *http.Request, args *GetChainAliasesArgs
 	return err
 }
 
+// GetChainStateDigestArgs are the arguments for calling GetChainStateDigest
+type GetChainStateDigestArgs struct {
+	// ID or alias of the chain
+	Chain string `json:"chain"`
+}
+
+// GetChainStateDigestReply is the response from calling GetChainStateDigest
+type GetChainStateDigestReply struct {
+	ChainID ids.ID `json:"chainID"`
+	Digest  ids.ID `json:"digest"`
+}
+
+// GetChainStateDigest returns a deterministic digest of the chain's committed
+// state. Nodes whose digests differ for a chain have diverged, unless one of
+// them accepted a transaction the other hasn't accepted yet.
+func (service *Admin) GetChainStateDigest(_ *http.Request, args *GetChainStateDigestArgs, reply *GetChainStateDigestReply) error {
+	service.Log.Debug("Admin: GetChainStateDigest called with Chain: %s", args.Chain)
+
+	chainID, err := service.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.ChainID = chainID
+	reply.Digest, err = service.ChainManager.StateDigest(chainID)
+	return err
+}
+
+// SetChainMaintenanceArgs are the arguments for calling SetChainMaintenance
+type SetChainMaintenanceArgs struct {
+	// ID or alias of the chain
+	Chain   string `json:"chain"`
+	Enabled bool   `json:"enabled"`
+}
+
+// SetChainMaintenance puts a chain into, or takes it out of, maintenance mode.
+// While in maintenance mode, the chain doesn't participate in consensus and
+// rejects API calls that write to it. The rest of the node keeps running.
+// Maintenance mode isn't persisted across restarts.
+func (service *Admin) SetChainMaintenance(_ *http.Request, args *SetChainMaintenanceArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: SetChainMaintenance called with Chain: %s, Enabled: %t", args.Chain, args.Enabled)
+
+	chainID, err := service.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	if err := service.ChainManager.SetMaintenance(chainID, args.Enabled); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetChainMaintenanceArgs are the arguments for calling GetChainMaintenance
+type GetChainMaintenanceArgs struct {
+	// ID or alias of the chain
+	Chain string `json:"chain"`
+}
+
+// GetChainMaintenanceReply is the response from calling GetChainMaintenance
+type GetChainMaintenanceReply struct {
+	ChainID ids.ID `json:"chainID"`
+	Enabled bool   `json:"enabled"`
+}
+
+// GetChainMaintenance returns whether a chain is in maintenance mode
+func (service *Admin) GetChainMaintenance(_ *http.Request, args *GetChainMaintenanceArgs, reply *GetChainMaintenanceReply) error {
+	service.Log.Debug("Admin: GetChainMaintenance called with Chain: %s", args.Chain)
+
+	chainID, err := service.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.ChainID = chainID
+	reply.Enabled, err = service.ChainManager.InMaintenance(chainID)
+	return err
+}
+
+// GetChainBundleArgs are the arguments for calling GetChainBundle
+type GetChainBundleArgs struct {
+	// ID or alias of the chain
+	Chain string `json:"chain"`
+}
+
+// GetChainBundleReply is the response from calling GetChainBundle
+type GetChainBundleReply struct {
+	Bundle chains.ChainBundle `json:"bundle"`
+}
+
+// GetChainBundle returns the genesis, upgrade and config bytes of a running
+// chain, along with the IDs of its subnet, VM and fxs. The bundle can be passed
+// to ImportChainBundle on another node so that it runs the chain the same way.
+func (service *Admin) GetChainBundle(_ *http.Request, args *GetChainBundleArgs, reply *GetChainBundleReply) error {
+	service.Log.Debug("Admin: GetChainBundle called with Chain: %s", args.Chain)
+
+	chainID, err := service.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.Bundle, err = service.ChainManager.ChainBundle(chainID)
+	return err
+}
+
+// ImportChainBundleArgs are the arguments for calling ImportChainBundle
+type ImportChainBundleArgs struct {
+	Bundle chains.ChainBundle `json:"bundle"`
+}
+
+// ImportChainBundle writes the config and upgrade bytes of a chain exported by
+// GetChainBundle into this node's chain config directory. The VM and fxs of the
+// chain must be installed on this node. The chain is run with the imported
+// configs once the node restarts, if its subnet is whitelisted.
+func (service *Admin) ImportChainBundle(_ *http.Request, args *ImportChainBundleArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: ImportChainBundle called with ChainID: %s", args.Bundle.ChainID)
+
+	if service.ChainConfigDir == "" {
+		return errNoChainConfigDir
+	}
+	if _, err := service.VMManager.GetFactory(args.Bundle.VMID); err != nil {
+		return fmt.Errorf("VM %s isn't installed: %w", args.Bundle.VMID, err)
+	}
+	for _, fxID := range args.Bundle.FxIDs {
+		if _, err := service.VMManager.GetFactory(fxID); err != nil {
+			return fmt.Errorf("fx %s isn't installed: %w", fxID, err)
+		}
+	}
+	if err := args.Bundle.WriteChainConfig(service.ChainConfigDir); err != nil {
+		return fmt.Errorf("couldn't write chain config: %w", err)
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetChainDiskUsageArgs are the arguments for calling GetChainDiskUsage
+type GetChainDiskUsageArgs struct {
+	// ID or alias of the chain
+	Chain string `json:"chain"`
+}
+
+// GetChainDiskUsageReply is the response from calling GetChainDiskUsage
+type GetChainDiskUsageReply struct {
+	chains.DiskUsage
+}
+
+// GetChainDiskUsage returns an estimate of the space a chain's data takes in
+// the database, and how many bytes per hour it grew by since the last time it
+// was measured. The chain's data is scanned at most once a minute.
+func (service *Admin) GetChainDiskUsage(_ *http.Request, args *GetChainDiskUsageArgs, reply *GetChainDiskUsageReply) error {
+	service.Log.Debug("Admin: GetChainDiskUsage called with Chain: %s", args.Chain)
+
+	chainID, err := service.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.DiskUsage, err = service.ChainManager.DiskUsage(chainID)
+	return err
+}
+
 // Stacktrace returns the current global stacktrace
 func (service *Admin) Stacktrace(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
 	service.Log.Debug("Admin: Stacktrace called")
@@ -304,3 +509,71 @@ func (service *Admin) LoadVMs(_ *http.Request, _ *struct{}, reply *LoadVMsReply)
 	reply.NewVMs, err = ids.GetRelevantAliases(service.VMManager, loadedVMs)
 	return err
 }
+
+// CreateAPIKeyArgs are the arguments for calling CreateAPIKey
+type CreateAPIKeyArgs struct {
+	Name string `json:"name"`
+	// Max number of requests per minute. 0 means unlimited.
+	RateLimit json.Uint64 `json:"rateLimit"`
+}
+
+// CreateAPIKeyReply is the response from calling CreateAPIKey
+type CreateAPIKeyReply struct {
+	Key string `json:"key"`
+}
+
+// CreateAPIKey creates a new API key. The key is only returned once, so it
+// must be recorded by the caller.
+func (service *Admin) CreateAPIKey(_ *http.Request, args *CreateAPIKeyArgs, reply *CreateAPIKeyReply) error {
+	service.Log.Debug("Admin: CreateAPIKey called with name: %s", args.Name)
+
+	if service.APIKeys == nil {
+		return errNoAPIKeys
+	}
+	key, err := service.APIKeys.CreateKey(args.Name, uint64(args.RateLimit))
+	reply.Key = key
+	return err
+}
+
+// RevokeAPIKeyArgs are the arguments for calling RevokeAPIKey
+type RevokeAPIKeyArgs struct {
+	Name string `json:"name"`
+}
+
+// RevokeAPIKey revokes an API key. Its usage is retained.
+func (service *Admin) RevokeAPIKey(_ *http.Request, args *RevokeAPIKeyArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: RevokeAPIKey called with name: %s", args.Name)
+
+	if service.APIKeys == nil {
+		return errNoAPIKeys
+	}
+	if err := service.APIKeys.RevokeKey(args.Name); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetAPIKeyUsageArgs are the arguments for calling GetAPIKeyUsage
+type GetAPIKeyUsageArgs struct {
+	// Names of the keys to report. If empty, every key is reported.
+	Names []string `json:"names"`
+}
+
+// GetAPIKeyUsageReply is the response from calling GetAPIKeyUsage
+type GetAPIKeyUsageReply struct {
+	Usage []apikeys.Usage `json:"usage"`
+}
+
+// GetAPIKeyUsage returns the requests, bandwidth and issued transactions
+// metered for API keys.
+func (service *Admin) GetAPIKeyUsage(_ *http.Request, args *GetAPIKeyUsageArgs, reply *GetAPIKeyUsageReply) error {
+	service.Log.Debug("Admin: GetAPIKeyUsage called")
+
+	if service.APIKeys == nil {
+		return errNoAPIKeys
+	}
+	usage, err := service.APIKeys.Usage(args.Names...)
+	reply.Usage = usage
+	return err
+}
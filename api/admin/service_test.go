@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lasthyphen/beacongo/database/memdb"
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/vms"
@@ -120,3 +121,29 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 
 	assert.Equal(t, err, errOops)
 }
+
+func TestGetChainMounts(t *testing.T) {
+	assert := assert.New(t)
+
+	mockLog := logging.NewMockLogger(gomock.NewController(t))
+	mockLog.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+	db := memdb.New()
+	admin := &Admin{Config: Config{
+		Log: mockLog,
+		DB:  db,
+	}}
+
+	chainID := ids.GenerateTestID()
+	mounts := chainMounts{db: db}
+	assert.NoError(mounts.put("mychain", chainID))
+	assert.NoError(mounts.put("alsomychain", chainID))
+
+	reply := GetChainMountsReply{}
+	assert.NoError(admin.GetChainMounts(nil, nil, &reply))
+	assert.Equal([]ChainMount{{
+		ChainID: chainID,
+		Aliases: []string{"alsomychain", "mychain"},
+		Paths:   []string{"/ext/bc/alsomychain", "/ext/bc/mychain"},
+	}}, reply.Mounts)
+}
@@ -0,0 +1,598 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package apikeys
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/api/server"
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/timer/mockable"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+)
+
+const (
+	// HeaderKey is the HTTP header that carries the API key of a request.
+	HeaderKey = "X-API-Key"
+
+	// number of random bytes in a new API key
+	keyByteLen = 32
+
+	maxNameLength = 128
+
+	// rateLimitWindow is the period over which a key's rate limit is applied
+	rateLimitWindow = time.Minute
+
+	// flushFrequency is how often usage is persisted to the database
+	flushFrequency = time.Minute
+
+	// maxTxResponseSize is the number of bytes of a response to a
+	// transaction issuing request that are inspected to determine whether
+	// the request succeeded.
+	maxTxResponseSize = 4096
+)
+
+var (
+	_ Manager = &manager{}
+
+	errNoName       = errors.New("API key name can't be empty")
+	errNameTooLong  = fmt.Errorf("API key name can be at most %d characters", maxNameLength)
+	errDuplicateKey = errors.New("an API key with this name already exists")
+	errUnknownKey   = errors.New("unknown API key")
+	errMissingKey   = fmt.Errorf("API key not provided in the %q header", HeaderKey)
+	errRevokedKey   = errors.New("the provided API key was revoked")
+	errRateLimited  = errors.New("API key exceeded its rate limit")
+
+	// DefaultTxMethods are the JSON-RPC methods that issue transactions.
+	DefaultTxMethods = []string{
+		"issueTx",
+		"send",
+		"sendMultiple",
+		"sendMultisig",
+		"sendNFT",
+		"mint",
+		"mintNFT",
+		"createAsset",
+		"createFixedCapAsset",
+		"createVariableCapAsset",
+		"createNFTAsset",
+		"export",
+		"exportDJTX",
+		"import",
+		"importDJTX",
+		"replaceTx",
+		"consolidate",
+		"addValidator",
+		"addDelegator",
+		"addSubnetValidator",
+		"createSubnet",
+		"createBlockchain",
+	}
+)
+
+// Config describes how API keys are enforced.
+type Config struct {
+	// Enabled is true if requests are metered by API key.
+	Enabled bool `json:"enabled"`
+
+	// Required is true if requests without a valid API key are refused.
+	// Requests to the admin API are always allowed so that keys can be
+	// managed; the admin API must be protected separately.
+	Required bool `json:"required"`
+
+	// TxMethods are the JSON-RPC methods, without the service prefix, whose
+	// successful calls are counted as issued transactions. If empty,
+	// [DefaultTxMethods] is used.
+	TxMethods []string `json:"txMethods"`
+}
+
+// Usage of an API key
+type Usage struct {
+	Name string `json:"name"`
+	// Number of requests rejected because the key exceeded its rate limit
+	RateLimited uint64 `json:"rateLimited"`
+	Requests    uint64 `json:"requests"`
+	BytesIn     uint64 `json:"bytesIn"`
+	BytesOut    uint64 `json:"bytesOut"`
+	IssuedTxs   uint64 `json:"issuedTxs"`
+	// Max number of requests per minute. 0 means unlimited.
+	RateLimit uint64    `json:"rateLimit"`
+	Created   time.Time `json:"created"`
+	Revoked   bool      `json:"revoked"`
+}
+
+// Manager meters API requests by API key. API keys identify the customer
+// making a request for billing and throttling purposes; they are unrelated
+// to auth tokens and don't grant access to anything.
+type Manager interface {
+	server.Wrapper
+
+	// Initialize loads the API keys stored in [db] and starts periodically
+	// persisting their usage. Until Initialize is called, requests aren't
+	// metered.
+	Initialize(
+		config Config,
+		db database.Database,
+		namespace string,
+		registerer prometheus.Registerer,
+	) error
+
+	// CreateKey creates and returns a new API key called [name]. If
+	// [rateLimit] is non-zero, at most [rateLimit] requests per minute are
+	// served for the key.
+	CreateKey(name string, rateLimit uint64) (string, error)
+
+	// RevokeKey revokes the API key called [name]. Its usage is retained.
+	RevokeKey(name string) error
+
+	// Usage returns the usage of the API keys called [names], or of all keys
+	// if [names] is empty.
+	Usage(names ...string) ([]Usage, error)
+
+	// Shutdown persists the usage of every key and stops metering.
+	Shutdown() error
+}
+
+// record is the persisted state of an API key. It's keyed by the name of
+// the key in the database.
+type record struct {
+	Usage
+	// SHA256 hash of the key. The key itself is never stored.
+	Hash []byte `json:"hash"`
+
+	// start of the current rate limit window and the number of requests made
+	// in it
+	windowStart time.Time
+	windowCount uint64
+	// true if the record has changed since it was last persisted
+	dirty bool
+}
+
+type manager struct {
+	// Used to mock time.
+	clock mockable.Clock
+
+	log       logging.Logger
+	config    Config
+	txMethods map[string]struct{}
+
+	lock        sync.Mutex
+	initialized bool
+	db          database.Database
+	// name -> record
+	records map[string]*record
+	// key hash -> record
+	hashes map[[sha256.Size]byte]*record
+
+	requests  *prometheus.CounterVec
+	bytesIn   *prometheus.CounterVec
+	bytesOut  *prometheus.CounterVec
+	issuedTxs *prometheus.CounterVec
+	refused   *prometheus.CounterVec
+
+	closer    sync.Once
+	closeChan chan struct{}
+}
+
+// NewManager returns a manager that doesn't meter requests until it is
+// initialized.
+func NewManager(log logging.Logger) Manager {
+	return &manager{
+		log:       log,
+		records:   make(map[string]*record),
+		hashes:    make(map[[sha256.Size]byte]*record),
+		closeChan: make(chan struct{}),
+	}
+}
+
+func (m *manager) Initialize(
+	config Config,
+	db database.Database,
+	namespace string,
+	registerer prometheus.Registerer,
+) error {
+	m.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests",
+		Help:      "Number of API requests made with each API key",
+	}, []string{"key"})
+	m.bytesIn = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bytes_in",
+		Help:      "Number of request body bytes received with each API key",
+	}, []string{"key"})
+	m.bytesOut = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bytes_out",
+		Help:      "Number of response bytes sent for each API key",
+	}, []string{"key"})
+	m.issuedTxs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "issued_txs",
+		Help:      "Number of transactions issued with each API key",
+	}, []string{"key"})
+	m.refused = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "refused_requests",
+		Help:      "Number of API requests refused because of a missing, invalid or rate limited API key",
+	}, []string{"reason"})
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.requests),
+		registerer.Register(m.bytesIn),
+		registerer.Register(m.bytesOut),
+		registerer.Register(m.issuedTxs),
+		registerer.Register(m.refused),
+	)
+	if errs.Errored() {
+		return errs.Err
+	}
+
+	txMethods := config.TxMethods
+	if len(txMethods) == 0 {
+		txMethods = DefaultTxMethods
+	}
+	m.txMethods = make(map[string]struct{}, len(txMethods))
+	for _, method := range txMethods {
+		m.txMethods[method] = struct{}{}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		r := &record{}
+		if err := json.Unmarshal(it.Value(), r); err != nil {
+			return fmt.Errorf("couldn't parse API key %q: %w", it.Key(), err)
+		}
+		m.add(r)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	m.config = config
+	m.db = db
+	m.initialized = true
+	if config.Enabled {
+		go m.run()
+	}
+	return nil
+}
+
+// add assumes [m.lock] is held
+func (m *manager) add(r *record) {
+	var hash [sha256.Size]byte
+	copy(hash[:], r.Hash)
+	m.records[r.Name] = r
+	m.hashes[hash] = r
+}
+
+func (m *manager) run() {
+	ticker := time.NewTicker(flushFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.lock.Lock()
+			if err := m.flush(); err != nil {
+				m.log.Warn("failed to persist API key usage: %s", err)
+			}
+			m.lock.Unlock()
+		case <-m.closeChan:
+			return
+		}
+	}
+}
+
+// flush persists every record that changed since it was last persisted.
+// Assumes [m.lock] is held.
+func (m *manager) flush() error {
+	if m.db == nil {
+		return nil
+	}
+	batch := m.db.NewBatch()
+	for name, r := range m.records {
+		if !r.dirty {
+			continue
+		}
+		recordBytes, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put([]byte(name), recordBytes); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	for _, r := range m.records {
+		r.dirty = false
+	}
+	return nil
+}
+
+func (m *manager) CreateKey(name string, rateLimit uint64) (string, error) {
+	switch {
+	case name == "":
+		return "", errNoName
+	case len(name) > maxNameLength:
+		return "", errNameTooLong
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, exists := m.records[name]; exists {
+		return "", fmt.Errorf("%w: %q", errDuplicateKey, name)
+	}
+
+	keyBytes := [keyByteLen]byte{}
+	if _, err := rand.Read(keyBytes[:]); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := base64.RawURLEncoding.EncodeToString(keyBytes[:])
+	hash := sha256.Sum256([]byte(key))
+
+	m.add(&record{
+		Usage: Usage{
+			Name:      name,
+			RateLimit: rateLimit,
+			Created:   m.clock.Time().UTC(),
+		},
+		Hash:  hash[:],
+		dirty: true,
+	})
+	return key, m.flush()
+}
+
+func (m *manager) RevokeKey(name string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	r, exists := m.records[name]
+	if !exists {
+		return fmt.Errorf("%w: %q", errUnknownKey, name)
+	}
+	r.Revoked = true
+	r.dirty = true
+	return m.flush()
+}
+
+func (m *manager) Usage(names ...string) ([]Usage, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(names) == 0 {
+		names = make([]string, 0, len(m.records))
+		for name := range m.records {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	usage := make([]Usage, len(names))
+	for i, name := range names {
+		r, exists := m.records[name]
+		if !exists {
+			return nil, fmt.Errorf("%w: %q", errUnknownKey, name)
+		}
+		usage[i] = r.Usage
+	}
+	return usage, nil
+}
+
+func (m *manager) Shutdown() error {
+	m.closer.Do(func() {
+		close(m.closeChan)
+	})
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.flush()
+}
+
+func (m *manager) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.lock.Lock()
+		enabled := m.initialized && m.config.Enabled
+		m.lock.Unlock()
+
+		if !enabled {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		key := r.Header.Get(HeaderKey)
+		if key == "" {
+			if m.config.Required && !isAdminPath(r.URL.Path) {
+				m.refuse(w, "missing", http.StatusUnauthorized, errMissingKey)
+				return
+			}
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		rec, err := m.authorize(key)
+		switch err {
+		case nil:
+		case errRateLimited:
+			w.Header().Set("Retry-After", strconv.Itoa(int(rateLimitWindow.Seconds())))
+			m.refuse(w, "rate_limited", http.StatusTooManyRequests, err)
+			return
+		default:
+			m.refuse(w, "invalid", http.StatusUnauthorized, err)
+			return
+		}
+
+		// The body is read up front so that the JSON-RPC method can be
+		// inspected. Its size is bounded by the API server.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		mw := &meteredWriter{ResponseWriter: w}
+		if m.isTxMethod(body) {
+			mw.captured = &bytes.Buffer{}
+		}
+		h.ServeHTTP(mw, r)
+
+		m.record(rec, uint64(len(body)), mw)
+	})
+}
+
+// authorize returns the record of [key] and counts the request against its
+// rate limit.
+func (m *manager) authorize(key string) (*record, error) {
+	hash := sha256.Sum256([]byte(key))
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	r, exists := m.hashes[hash]
+	switch {
+	case !exists:
+		return nil, errUnknownKey
+	case r.Revoked:
+		return nil, errRevokedKey
+	case r.RateLimit == 0:
+		return r, nil
+	}
+
+	now := m.clock.Time()
+	if now.Sub(r.windowStart) >= rateLimitWindow {
+		r.windowStart = now
+		r.windowCount = 0
+	}
+	if r.windowCount >= r.RateLimit {
+		r.RateLimited++
+		r.dirty = true
+		return nil, errRateLimited
+	}
+	r.windowCount++
+	return r, nil
+}
+
+// record adds a served request to the usage of [r]
+func (m *manager) record(r *record, bytesIn uint64, mw *meteredWriter) {
+	issuedTx := mw.captured != nil && mw.succeeded()
+
+	m.lock.Lock()
+	r.Requests++
+	r.BytesIn += bytesIn
+	r.BytesOut += mw.written
+	if issuedTx {
+		r.IssuedTxs++
+	}
+	r.dirty = true
+	m.lock.Unlock()
+
+	m.requests.WithLabelValues(r.Name).Inc()
+	m.bytesIn.WithLabelValues(r.Name).Add(float64(bytesIn))
+	m.bytesOut.WithLabelValues(r.Name).Add(float64(mw.written))
+	if issuedTx {
+		m.issuedTxs.WithLabelValues(r.Name).Inc()
+	}
+}
+
+func (m *manager) refuse(w http.ResponseWriter, reason string, code int, err error) {
+	m.refused.WithLabelValues(reason).Inc()
+	http.Error(w, err.Error(), code)
+}
+
+// isTxMethod returns true if [body] is a JSON-RPC request to a method that
+// issues a transaction.
+func (m *manager) isTxMethod(body []byte) bool {
+	request := struct {
+		Method string `json:"method"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return false
+	}
+	method := request.Method
+	if i := strings.LastIndex(method, "."); i >= 0 {
+		method = method[i+1:]
+	}
+	_, ok := m.txMethods[method]
+	return ok
+}
+
+func isAdminPath(path string) bool {
+	return path == "/ext/admin" || strings.HasPrefix(path, "/ext/admin/")
+}
+
+// meteredWriter counts the bytes written to the response. If [captured] is
+// non-nil, the start of the response is copied into it.
+type meteredWriter struct {
+	http.ResponseWriter
+	status   int
+	written  uint64
+	captured *bytes.Buffer
+}
+
+func (w *meteredWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *meteredWriter) Write(b []byte) (int, error) {
+	if w.captured != nil && w.captured.Len() < maxTxResponseSize {
+		remaining := maxTxResponseSize - w.captured.Len()
+		if remaining > len(b) {
+			remaining = len(b)
+		}
+		w.captured.Write(b[:remaining])
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.written += uint64(n)
+	return n, err
+}
+
+// Flush is needed by handlers that stream their responses, such as gRPC
+// handlers.
+func (w *meteredWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// succeeded returns true if the response is a JSON-RPC response without an
+// error.
+func (w *meteredWriter) succeeded() bool {
+	if w.status != 0 && w.status != http.StatusOK {
+		return false
+	}
+	response := struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}{}
+	if err := json.Unmarshal(w.captured.Bytes(), &response); err != nil {
+		return false
+	}
+	return len(response.Result) > 0 && (len(response.Error) == 0 || string(response.Error) == "null")
+}
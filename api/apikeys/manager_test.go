@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package apikeys
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/memdb"
+	"github.com/lasthyphen/beacongo/utils/logging"
+)
+
+func newTestManager(t *testing.T, config Config, db database.Database) *manager {
+	m := NewManager(logging.NoLog{}).(*manager)
+	if err := m.Initialize(config, db, "", prometheus.NewRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func newTestHandler(m *manager) http.Handler {
+	return m.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "fail") {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"failed"},"id":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":{"txID":"abc"},"id":1}`))
+	}))
+}
+
+func serve(h http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(HeaderKey, key)
+	}
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestManagerMetersUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newTestManager(t, Config{Enabled: true}, memdb.New())
+	defer func() {
+		assert.NoError(m.Shutdown())
+	}()
+	h := newTestHandler(m)
+
+	key, err := m.CreateKey("customer", 0)
+	assert.NoError(err)
+
+	_, err = m.CreateKey("customer", 0)
+	assert.ErrorIs(err, errDuplicateKey)
+
+	getBody := `{"jsonrpc":"2.0","method":"avm.getBalance","params":{},"id":1}`
+	sendBody := `{"jsonrpc":"2.0","method":"avm.send","params":{},"id":1}`
+	assert.Equal(http.StatusOK, serve(h, "/ext/bc/X", key, getBody).Code)
+	assert.Equal(http.StatusOK, serve(h, "/ext/bc/X", key, sendBody).Code)
+	assert.Equal(http.StatusOK, serve(h, "/ext/bc/X/fail", key, sendBody).Code)
+
+	// Requests without a key aren't metered
+	assert.Equal(http.StatusOK, serve(h, "/ext/bc/X", "", getBody).Code)
+	assert.Equal(http.StatusUnauthorized, serve(h, "/ext/bc/X", "wrong", getBody).Code)
+
+	usage, err := m.Usage("customer")
+	assert.NoError(err)
+	assert.Len(usage, 1)
+	assert.Equal(uint64(3), usage[0].Requests)
+	assert.Equal(uint64(1), usage[0].IssuedTxs)
+	assert.Equal(uint64(len(getBody)+2*len(sendBody)), usage[0].BytesIn)
+	assert.Greater(usage[0].BytesOut, uint64(0))
+
+	_, err = m.Usage("unknown")
+	assert.ErrorIs(err, errUnknownKey)
+
+	assert.NoError(m.RevokeKey("customer"))
+	assert.Equal(http.StatusUnauthorized, serve(h, "/ext/bc/X", key, getBody).Code)
+}
+
+func TestManagerRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newTestManager(t, Config{Enabled: true}, memdb.New())
+	defer func() {
+		assert.NoError(m.Shutdown())
+	}()
+	now := time.Unix(1000, 0)
+	m.clock.Set(now)
+	h := newTestHandler(m)
+
+	key, err := m.CreateKey("customer", 2)
+	assert.NoError(err)
+
+	assert.Equal(http.StatusOK, serve(h, "/ext/info", key, "").Code)
+	assert.Equal(http.StatusOK, serve(h, "/ext/info", key, "").Code)
+	w := serve(h, "/ext/info", key, "")
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(w.Header().Get("Retry-After"))
+
+	m.clock.Set(now.Add(rateLimitWindow))
+	assert.Equal(http.StatusOK, serve(h, "/ext/info", key, "").Code)
+
+	usage, err := m.Usage()
+	assert.NoError(err)
+	assert.Len(usage, 1)
+	assert.Equal(uint64(3), usage[0].Requests)
+	assert.Equal(uint64(1), usage[0].RateLimited)
+}
+
+func TestManagerRequired(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newTestManager(t, Config{Enabled: true, Required: true}, memdb.New())
+	defer func() {
+		assert.NoError(m.Shutdown())
+	}()
+	h := newTestHandler(m)
+
+	assert.Equal(http.StatusUnauthorized, serve(h, "/ext/bc/X", "", "").Code)
+	assert.Equal(http.StatusOK, serve(h, "/ext/admin", "", "").Code)
+}
+
+func TestManagerPersistsUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	m := newTestManager(t, Config{Enabled: true}, db)
+	h := newTestHandler(m)
+
+	key, err := m.CreateKey("customer", 0)
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, serve(h, "/ext/info", key, "").Code)
+	assert.NoError(m.Shutdown())
+
+	m = newTestManager(t, Config{Enabled: true}, db)
+	defer func() {
+		assert.NoError(m.Shutdown())
+	}()
+	h = newTestHandler(m)
+
+	usage, err := m.Usage("customer")
+	assert.NoError(err)
+	assert.Equal(uint64(1), usage[0].Requests)
+
+	// The restored key is still accepted
+	assert.Equal(http.StatusOK, serve(h, "/ext/info", key, "").Code)
+}
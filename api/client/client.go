@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"time"
+
+	"github.com/lasthyphen/beacongo/api/admin"
+	"github.com/lasthyphen/beacongo/api/health"
+	"github.com/lasthyphen/beacongo/api/info"
+	"github.com/lasthyphen/beacongo/utils/rpc"
+	"github.com/lasthyphen/beacongo/vms/avm"
+	"github.com/lasthyphen/beacongo/vms/platformvm"
+)
+
+// Config configures the requests sent by a Client.
+type Config struct {
+	// Auth token sent with every request, if non-empty
+	AuthToken string
+
+	// Number of times a request that fails to reach the node, or that the node
+	// reports as temporarily unavailable, is sent. Defaults to 1.
+	MaxAttempts  int
+	RetryBackoff time.Duration
+
+	// Applied to every request, after the options above
+	Options []rpc.Option
+}
+
+// Client bundles typed clients of the APIs served by a node. Every request sent
+// by the clients applies the Config the Client was created with. Options passed
+// to a single request take precedence.
+type Client struct {
+	Info   info.Client
+	Health health.Client
+	Admin  admin.Client
+
+	// Clients of the P-chain
+	P platformvm.Client
+
+	// Clients of the X-chain
+	X       avm.Client
+	XWallet avm.WalletClient
+	XAdmin  avm.AdminClient
+}
+
+// New returns the clients of the APIs served at [uri], e.g.
+// "http://localhost:9650".
+func New(uri string, config Config) *Client {
+	options := config.rpcOptions()
+	return &Client{
+		Info:    info.NewClient(uri, options...),
+		Health:  health.NewClient(uri, options...),
+		Admin:   admin.NewClient(uri, options...),
+		P:       platformvm.NewClient(uri, options...),
+		X:       avm.NewClient(uri, "X", options...),
+		XWallet: avm.NewWalletClient(uri, "X", options...),
+		XAdmin:  avm.NewAdminClient(uri, "X", options...),
+	}
+}
+
+// NewAVM returns the clients of the AVM [chain] served at [uri].
+func NewAVM(uri, chain string, config Config) (avm.Client, avm.WalletClient) {
+	options := config.rpcOptions()
+	return avm.NewClient(uri, chain, options...), avm.NewWalletClient(uri, chain, options...)
+}
+
+func (c Config) rpcOptions() []rpc.Option {
+	options := []rpc.Option(nil)
+	if c.AuthToken != "" {
+		options = append(options, rpc.WithAuthToken(c.AuthToken))
+	}
+	if c.MaxAttempts > 1 {
+		options = append(options, rpc.WithRetries(c.MaxAttempts, c.RetryBackoff))
+	}
+	return append(options, c.Options...)
+}
@@ -31,10 +31,11 @@ type client struct {
 }
 
 // NewClient returns a client to interact with Health API endpoint
-func NewClient(uri string) Client {
+func NewClient(uri string, options ...rpc.Option) Client {
 	return &client{requester: rpc.NewEndpointRequester(
 		uri+"/ext/health",
 		"health",
+		options...,
 	)}
 }
 
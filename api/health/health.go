@@ -9,6 +9,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/lasthyphen/beacongo/utils/eventbus"
 	"github.com/lasthyphen/beacongo/utils/logging"
 )
 
@@ -46,17 +47,24 @@ type health struct {
 }
 
 func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
-	readinessWorker, err := newWorker("readiness", registerer)
+	return NewWithPublisher(log, registerer, nil)
+}
+
+// NewWithPublisher returns a Health that publishes a HealthChanged event to
+// [publisher] whenever the readiness, health or liveness of the node changes.
+// If [publisher] is nil, events aren't published.
+func NewWithPublisher(log logging.Logger, registerer prometheus.Registerer, publisher eventbus.Publisher) (Health, error) {
+	readinessWorker, err := newWorker("readiness", registerer, publisher)
 	if err != nil {
 		return nil, err
 	}
 
-	healthWorker, err := newWorker("health", registerer)
+	healthWorker, err := newWorker("health", registerer, publisher)
 	if err != nil {
 		return nil, err
 	}
 
-	livenessWorker, err := newWorker("liveness", registerer)
+	livenessWorker, err := newWorker("liveness", registerer, publisher)
 	return &health{
 		log:       log,
 		readiness: readinessWorker,
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lasthyphen/beacongo/utils"
+	"github.com/lasthyphen/beacongo/utils/eventbus"
 	"github.com/lasthyphen/beacongo/utils/logging"
 )
 
@@ -273,3 +274,36 @@ func TestDeadlockRegression(t *testing.T) {
 
 	awaitHealthy(h, true)
 }
+
+func TestWorkerPublishesChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	bus := eventbus.New(logging.NoLog{})
+	events := []eventbus.Event(nil)
+	err := bus.Subscribe("test", func(e eventbus.Event) { events = append(events, e) }, eventbus.KindHealthChanged)
+	assert.NoError(err)
+
+	w, err := newWorker("health", prometheus.NewRegistry(), bus)
+	assert.NoError(err)
+
+	var checkErr error
+	err = w.RegisterCheck("check", CheckerFunc(func() (interface{}, error) {
+		return "", checkErr
+	}))
+	assert.NoError(err)
+
+	// The first run is always published
+	w.runChecks()
+	w.runChecks()
+	assert.Equal([]eventbus.Event{
+		eventbus.HealthChanged{Checks: "health", Healthy: true, Failing: []string{}},
+	}, events)
+
+	checkErr = errors.New("unhealthy")
+	w.runChecks()
+	w.runChecks()
+	assert.Equal([]eventbus.Event{
+		eventbus.HealthChanged{Checks: "health", Healthy: true, Failing: []string{}},
+		eventbus.HealthChanged{Checks: "health", Healthy: false, Failing: []string{"check"}},
+	}, events)
+}
@@ -6,17 +6,20 @@ package health
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/lasthyphen/beacongo/utils"
+	"github.com/lasthyphen/beacongo/utils/eventbus"
 )
 
 var errDuplicateCheck = errors.New("duplicated check")
 
 type worker struct {
+	name       string
 	metrics    *metrics
 	checksLock sync.RWMutex
 	checks     map[string]Checker
@@ -24,18 +27,25 @@ type worker struct {
 	resultsLock sync.RWMutex
 	results     map[string]Result
 
+	// May be nil
+	publisher eventbus.Publisher
+	// Only accessed by the goroutine that runs the checks
+	reported, healthy bool
+
 	startOnce sync.Once
 	closeOnce sync.Once
 	closer    chan struct{}
 }
 
-func newWorker(namespace string, registerer prometheus.Registerer) (*worker, error) {
+func newWorker(namespace string, registerer prometheus.Registerer, publisher eventbus.Publisher) (*worker, error) {
 	metrics, err := newMetrics(namespace, registerer)
 	return &worker{
-		metrics: metrics,
-		checks:  make(map[string]Checker),
-		results: make(map[string]Result),
-		closer:  make(chan struct{}),
+		name:      namespace,
+		metrics:   metrics,
+		checks:    make(map[string]Checker),
+		results:   make(map[string]Result),
+		publisher: publisher,
+		closer:    make(chan struct{}),
 	}, err
 }
 
@@ -130,6 +140,36 @@ func (w *worker) runChecks() {
 		go w.runCheck(&wg, name, check)
 	}
 	wg.Wait()
+
+	w.publishChange()
+}
+
+// publishChange publishes the state of the checks if it changed since it was
+// last published
+func (w *worker) publishChange() {
+	if w.publisher == nil {
+		return
+	}
+
+	results, healthy := w.Results()
+	if w.reported && w.healthy == healthy {
+		return
+	}
+	w.reported = true
+	w.healthy = healthy
+
+	failing := []string{}
+	for name, result := range results {
+		if result.Error != nil {
+			failing = append(failing, name)
+		}
+	}
+	sort.Strings(failing)
+	w.publisher.Publish(eventbus.HealthChanged{
+		Checks:  w.name,
+		Healthy: healthy,
+		Failing: failing,
+	})
 }
 
 func (w *worker) runCheck(wg *sync.WaitGroup, name string, check Checker) {
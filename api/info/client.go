@@ -21,6 +21,7 @@ type Client interface {
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
+	PeerLatency(context.Context, []ids.NodeID, ...rpc.Option) (*PeerLatencyReply, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
@@ -33,10 +34,11 @@ type client struct {
 }
 
 // NewClient returns a new Info API Client
-func NewClient(uri string) Client {
+func NewClient(uri string, options ...rpc.Option) Client {
 	return &client{requester: rpc.NewEndpointRequester(
 		uri+"/ext/info",
 		"info",
+		options...,
 	)}
 }
 
@@ -84,6 +86,14 @@ func (c *client) Peers(ctx context.Context, options ...rpc.Option) ([]Peer, erro
 	return res.Peers, err
 }
 
+func (c *client) PeerLatency(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) (*PeerLatencyReply, error) {
+	res := &PeerLatencyReply{}
+	err := c.requester.SendRequest(ctx, "peerLatency", &PeerLatencyArgs{
+		NodeIDs: nodeIDs,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...rpc.Option) (bool, error) {
 	res := &IsBootstrappedResponse{}
 	err := c.requester.SendRequest(ctx, "isBootstrapped", &IsBootstrappedArgs{
@@ -278,6 +278,36 @@ func (_m *Client) IsBootstrapped(_a0 context.Context, _a1 string, _a2 ...rpc.Opt
 	return r0, r1
 }
 
+// PeerLatency provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Client) PeerLatency(_a0 context.Context, _a1 []ids.NodeID, _a2 ...rpc.Option) (*info.PeerLatencyReply, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *info.PeerLatencyReply
+	if rf, ok := ret.Get(0).(func(context.Context, []ids.NodeID, ...rpc.Option) *info.PeerLatencyReply); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*info.PeerLatencyReply)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []ids.NodeID, ...rpc.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Peers provides a mock function with given fields: _a0, _a1
 func (_m *Client) Peers(_a0 context.Context, _a1 ...rpc.Option) ([]info.Peer, error) {
 	_va := make([]interface{}, len(_a1))
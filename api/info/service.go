@@ -6,7 +6,10 @@ package info
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -18,6 +21,7 @@ import (
 	"github.com/lasthyphen/beacongo/snow/networking/benchlist"
 	"github.com/lasthyphen/beacongo/snow/validators"
 	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/utils/geoip"
 	"github.com/lasthyphen/beacongo/utils/ips"
 	"github.com/lasthyphen/beacongo/utils/json"
 	"github.com/lasthyphen/beacongo/utils/logging"
@@ -28,6 +32,7 @@ import (
 var (
 	errNoChainProvided = errors.New("argument 'chain' not given")
 	errNotValidator    = errors.New("this is not a validator node")
+	errLatencyDisabled = errors.New("peer latency isn't measured by this node")
 )
 
 // Info is the API service for unprivileged info on a node
@@ -41,6 +46,8 @@ type Info struct {
 	versionParser version.ApplicationParser
 	validators    validators.Set
 	benchlist     benchlist.Manager
+	// Locates peers, if a GeoIP database was provided
+	geoIP *geoip.Reader
 }
 
 type Parameters struct {
@@ -52,6 +59,10 @@ type Parameters struct {
 	CreateSubnetTxFee     uint64
 	CreateBlockchainTxFee uint64
 	VMManager             vms.Manager
+	PeerLatencyEnabled    bool
+	// Path of the MMDB file used to locate peers. If empty, peers aren't
+	// located.
+	GeoIPDBPath string
 }
 
 // NewService returns a new admin API service
@@ -66,6 +77,15 @@ func NewService(
 	validators validators.Set,
 	benchlist benchlist.Manager,
 ) (*common.HTTPHandler, error) {
+	var geoIP *geoip.Reader
+	if parameters.GeoIPDBPath != "" {
+		var err error
+		geoIP, err = geoip.Open(parameters.GeoIPDBPath)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't open GeoIP database: %w", err)
+		}
+	}
+
 	newServer := rpc.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
@@ -80,6 +100,7 @@ func NewService(
 		versionParser: versionParser,
 		validators:    validators,
 		benchlist:     benchlist,
+		geoIP:         geoIP,
 	}, "info"); err != nil {
 		return nil, err
 	}
@@ -91,6 +112,9 @@ type GetNodeVersionReply struct {
 	Version         string            `json:"version"`
 	DatabaseVersion string            `json:"databaseVersion"`
 	GitCommit       string            `json:"gitCommit"`
+	BuildDate       string            `json:"buildDate"`
+	GoVersion       string            `json:"goVersion"`
+	BuildTags       []string          `json:"buildTags"`
 	VMVersions      map[string]string `json:"vmVersions"`
 }
 
@@ -106,6 +130,9 @@ func (service *Info) GetNodeVersion(_ *http.Request, _ *struct{}, reply *GetNode
 	reply.Version = service.Version.String()
 	reply.DatabaseVersion = version.CurrentDatabase.String()
 	reply.GitCommit = version.GitCommit
+	reply.BuildDate = version.BuildDate
+	reply.GoVersion = version.GoVersion
+	reply.BuildTags = version.BuildTagList()
 	reply.VMVersions = vmVersions
 	return nil
 }
@@ -218,6 +245,130 @@ func (service *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply)
 	return nil
 }
 
+// PeerLatencyArgs are the arguments for calling PeerLatency
+type PeerLatencyArgs struct {
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+// PeerLatency is the round trip time to a peer
+type PeerLatency struct {
+	NodeID ids.NodeID    `json:"nodeID"`
+	IP     string        `json:"ip"`
+	RTT    time.Duration `json:"rtt"`
+	// Location of the peer's IP, if a GeoIP database was provided and the IP
+	// was found in it
+	Location *geoip.Location `json:"location,omitempty"`
+}
+
+// LatencySummary describes a distribution of round trip times
+type LatencySummary struct {
+	NumPeers json.Uint64   `json:"numPeers"`
+	Min      time.Duration `json:"min"`
+	Mean     time.Duration `json:"mean"`
+	Median   time.Duration `json:"median"`
+	P90      time.Duration `json:"p90"`
+	P99      time.Duration `json:"p99"`
+	Max      time.Duration `json:"max"`
+}
+
+// PeerLatencyReply are the results from calling PeerLatency
+type PeerLatencyReply struct {
+	// Distribution of the round trip times of all the measured peers
+	Summary LatencySummary `json:"summary"`
+	// Distribution of the round trip times of the located peers, by country
+	Countries map[string]LatencySummary `json:"countries,omitempty"`
+	// Each measured peer
+	Peers []PeerLatency `json:"peers"`
+}
+
+// PeerLatency returns the round trip times to the connected peers. Peers
+// whose round trip time hasn't been measured yet are omitted.
+func (service *Info) PeerLatency(_ *http.Request, args *PeerLatencyArgs, reply *PeerLatencyReply) error {
+	service.log.Debug("Info: PeerLatency called")
+
+	if !service.PeerLatencyEnabled {
+		return errLatencyDisabled
+	}
+
+	var (
+		rtts          []time.Duration
+		countryRTTs   = map[string][]time.Duration{}
+		peers         = service.networking.PeerInfo(args.NodeIDs)
+		measuredPeers = make([]PeerLatency, 0, len(peers))
+	)
+	for _, peer := range peers {
+		if peer.RTT == 0 {
+			continue
+		}
+		peerLatency := PeerLatency{
+			NodeID: peer.ID,
+			IP:     peer.IP,
+			RTT:    peer.RTT,
+		}
+		if location, ok := service.locate(peer.IP); ok {
+			peerLatency.Location = &location
+			if location.Country != "" {
+				countryRTTs[location.Country] = append(countryRTTs[location.Country], peer.RTT)
+			}
+		}
+		rtts = append(rtts, peer.RTT)
+		measuredPeers = append(measuredPeers, peerLatency)
+	}
+
+	reply.Summary = summarizeLatency(rtts)
+	if len(countryRTTs) > 0 {
+		reply.Countries = make(map[string]LatencySummary, len(countryRTTs))
+		for country, rtts := range countryRTTs {
+			reply.Countries[country] = summarizeLatency(rtts)
+		}
+	}
+	reply.Peers = measuredPeers
+	return nil
+}
+
+// locate returns the location of [ipPort], if it can be found.
+func (service *Info) locate(ipPort string) (geoip.Location, bool) {
+	if service.geoIP == nil {
+		return geoip.Location{}, false
+	}
+	host, _, err := net.SplitHostPort(ipPort)
+	if err != nil {
+		return geoip.Location{}, false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return geoip.Location{}, false
+	}
+	location, err := service.geoIP.Locate(ip)
+	return location, err == nil
+}
+
+// summarizeLatency returns the distribution of [rtts]. [rtts] is sorted in
+// place.
+func summarizeLatency(rtts []time.Duration) LatencySummary {
+	if len(rtts) == 0 {
+		return LatencySummary{}
+	}
+	sort.Slice(rtts, func(i, j int) bool { return rtts[i] < rtts[j] })
+
+	var total time.Duration
+	for _, rtt := range rtts {
+		total += rtt
+	}
+	percentile := func(p int) time.Duration {
+		return rtts[(len(rtts)-1)*p/100]
+	}
+	return LatencySummary{
+		NumPeers: json.Uint64(len(rtts)),
+		Min:      rtts[0],
+		Mean:     total / time.Duration(len(rtts)),
+		Median:   percentile(50),
+		P90:      percentile(90),
+		P99:      percentile(99),
+		Max:      rtts[len(rtts)-1],
+	}
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
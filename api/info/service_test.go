@@ -6,12 +6,14 @@ package info
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/json"
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/vms"
 )
@@ -107,3 +109,31 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 
 	assert.Equal(t, err, errOops)
 }
+
+func TestSummarizeLatency(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(LatencySummary{}, summarizeLatency(nil))
+
+	rtts := []time.Duration{}
+	for i := 100; i > 0; i-- {
+		rtts = append(rtts, time.Duration(i)*time.Millisecond)
+	}
+	summary := summarizeLatency(rtts)
+	assert.Equal(json.Uint64(100), summary.NumPeers)
+	assert.Equal(time.Millisecond, summary.Min)
+	assert.Equal(100*time.Millisecond, summary.Max)
+	assert.Equal(50*time.Millisecond, summary.Median)
+	assert.Equal(90*time.Millisecond, summary.P90)
+	assert.Equal(99*time.Millisecond, summary.P99)
+	assert.Equal(50500*time.Microsecond, summary.Mean)
+}
+
+func TestPeerLatencyDisabled(t *testing.T) {
+	resources := initGetVMsTest(t)
+	defer resources.ctrl.Finish()
+
+	resources.mockLog.EXPECT().Debug(gomock.Any()).Times(1)
+	err := resources.info.PeerLatency(nil, &PeerLatencyArgs{}, &PeerLatencyReply{})
+	assert.ErrorIs(t, err, errLatencyDisabled)
+}
@@ -29,10 +29,11 @@ type client struct {
 }
 
 // NewClient returns a Client for interacting with the IPCS endpoint
-func NewClient(uri string) Client {
+func NewClient(uri string, options ...rpc.Option) Client {
 	return &client{requester: rpc.NewEndpointRequester(
 		uri+"/ext/ipcs",
 		"ipcs",
+		options...,
 	)}
 }
 
@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/lasthyphen/beacongo/chains/atomic"
+	"github.com/lasthyphen/beacongo/database/encdb"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/database/versiondb"
+	"github.com/lasthyphen/beacongo/utils/password"
+)
+
+// Version of the format of user archives
+const archiveVersion = 1
+
+var (
+	errUnsupportedArchiveVersion = errors.New("unsupported user archive version")
+	errIncorrectArchivePassword  = errors.New("incorrect password for user archive")
+)
+
+// userArchive is an exported user that is encrypted with the user's password,
+// so that neither its keys nor its password hash are exposed.
+type userArchive struct {
+	Version uint16 `serialize:"true"`
+	// Salt used to derive the encryption key from the user's password
+	Salt  [16]byte `serialize:"true"`
+	Nonce []byte   `serialize:"true"`
+	// The encrypted serialization of the user, as returned by ExportUser
+	Ciphertext []byte `serialize:"true"`
+}
+
+func (ks *keystore) ExportUserArchive(username, pw string) ([]byte, error) {
+	userBytes, err := ks.ExportUser(username, pw)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := userArchive{
+		Version: archiveVersion,
+		Nonce:   make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	if _, err := rand.Read(archive.Salt[:]); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(archive.Nonce); err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(archiveKey(pw, archive.Salt))
+	if err != nil {
+		return nil, err
+	}
+	archive.Ciphertext = aead.Seal(nil, archive.Nonce, userBytes, nil)
+	return c.Marshal(codecVersion, &archive)
+}
+
+func (ks *keystore) ImportUserArchive(username, pw string, archiveBytes []byte) error {
+	archive := userArchive{}
+	if _, err := c.Unmarshal(archiveBytes, &archive); err != nil {
+		return err
+	}
+	if archive.Version != archiveVersion {
+		return fmt.Errorf("%w: %d", errUnsupportedArchiveVersion, archive.Version)
+	}
+
+	aead, err := chacha20poly1305.NewX(archiveKey(pw, archive.Salt))
+	if err != nil {
+		return err
+	}
+	userBytes, err := aead.Open(nil, archive.Nonce, archive.Ciphertext, nil)
+	if err != nil {
+		return errIncorrectArchivePassword
+	}
+	return ks.ImportUser(username, pw, userBytes)
+}
+
+func (ks *keystore) ChangePassword(username, oldPW, newPW string) error {
+	if username == "" {
+		return errEmptyUsername
+	}
+	if len(username) > maxUserLen {
+		return errUserMaxLength
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	passwordHash, err := ks.getPassword(username)
+	switch {
+	case err != nil:
+		return err
+	case passwordHash == nil:
+		return fmt.Errorf("user doesn't exist: %s", username)
+	case !passwordHash.Check(oldPW):
+		return fmt.Errorf("incorrect password for user %q", username)
+	}
+
+	if err := password.IsValid(newPW, password.OK); err != nil {
+		return err
+	}
+	newPasswordHash := &password.Hash{}
+	if err := newPasswordHash.Set(newPW); err != nil {
+		return err
+	}
+	passwordBytes, err := c.Marshal(codecVersion, newPasswordHash)
+	if err != nil {
+		return err
+	}
+
+	// The user's values are encrypted with its password, so they're all
+	// re-encrypted with the new password
+	userDataDB := prefixdb.New([]byte(username), ks.bcDB)
+	oldDB, err := encdb.New([]byte(oldPW), userDataDB)
+	if err != nil {
+		return err
+	}
+	vdb := versiondb.New(userDataDB)
+	newDB, err := encdb.New([]byte(newPW), vdb)
+	if err != nil {
+		return err
+	}
+	it := oldDB.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		if err := newDB.Put(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("couldn't decrypt the values of user %q: %w", username, err)
+	}
+
+	dataBatch, err := vdb.CommitBatch()
+	if err != nil {
+		return err
+	}
+	userBatch := ks.userDB.NewBatch()
+	if err := userBatch.Put([]byte(username), passwordBytes); err != nil {
+		return err
+	}
+	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+		return err
+	}
+	ks.usernameToPassword[username] = newPasswordHash
+
+	// The read-only tokens hold the old password, so they can't be used
+	// anymore
+	return ks.revokeReadOnlyTokens(username)
+}
+
+// archiveKey derives the key a user archive is encrypted with from the user's
+// password.
+func archiveKey(pw string, salt [16]byte) []byte {
+	return argon2.IDKey([]byte(pw), salt[:], 1, 64*1024, 4, chacha20poly1305.KeySize)
+}
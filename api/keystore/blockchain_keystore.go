@@ -9,7 +9,10 @@ import (
 	"github.com/lasthyphen/beacongo/ids"
 )
 
-var _ BlockchainKeystore = &blockchainKeystore{}
+var (
+	_ BlockchainKeystore         = &blockchainKeystore{}
+	_ ReadOnlyBlockchainKeystore = &blockchainKeystore{}
+)
 
 type BlockchainKeystore interface {
 	// Get a database that is able to read and write unencrypted values from the
@@ -22,6 +25,14 @@ type BlockchainKeystore interface {
 	GetRawDatabase(username, password string) (database.Database, error)
 }
 
+// ReadOnlyBlockchainKeystore is implemented by the BlockchainKeystores that
+// accept read-only tokens in place of passwords.
+type ReadOnlyBlockchainKeystore interface {
+	// Get a database that is able to read, but not write, unencrypted values
+	// of the user that [token] was created for.
+	GetReadOnlyDatabase(token string) (*encdb.Database, error)
+}
+
 type blockchainKeystore struct {
 	blockchainID ids.ID
 	ks           *keystore
@@ -38,3 +49,9 @@ func (bks *blockchainKeystore) GetRawDatabase(username, password string) (databa
 
 	return bks.ks.GetRawDatabase(bks.blockchainID, username, password)
 }
+
+func (bks *blockchainKeystore) GetReadOnlyDatabase(token string) (*encdb.Database, error) {
+	bks.ks.log.Debug("Keystore: GetReadOnlyDatabase called from %s", bks.blockchainID)
+
+	return bks.ks.GetReadOnlyDatabase(bks.blockchainID, token)
+}
@@ -22,8 +22,29 @@ type Client interface {
 	ExportUser(context.Context, api.UserPass, ...rpc.Option) ([]byte, error)
 	// Import [exportedUser] to [importTo]
 	ImportUser(ctx context.Context, importTo api.UserPass, exportedUser []byte, options ...rpc.Option) (bool, error)
-	// Delete the given user
+	// Returns the given user as an archive that is encrypted with its
+	// password
+	ExportUserArchive(context.Context, api.UserPass, ...rpc.Option) ([]byte, error)
+	// Import the user in [archive] to [importTo]. [importTo]'s password must
+	// be the one the archive was exported with.
+	ImportUserArchive(ctx context.Context, importTo api.UserPass, archive []byte, options ...rpc.Option) (bool, error)
+	// Change the password of the given user to [newPassword]
+	ChangePassword(ctx context.Context, user api.UserPass, newPassword string, options ...rpc.Option) (bool, error)
+	// Returns a token that can be used instead of the given user's password
+	// to read, but not write, the user's data
+	CreateReadOnlyToken(context.Context, api.UserPass, ...rpc.Option) (string, error)
+	// Revoke every read-only token of the given user
+	RevokeReadOnlyTokens(context.Context, api.UserPass, ...rpc.Option) (bool, error)
+	// Delete the given user. It can be restored until its retention window
+	// ends.
 	DeleteUser(context.Context, api.UserPass, ...rpc.Option) (bool, error)
+	// Restore the given deleted user
+	RestoreUser(context.Context, api.UserPass, ...rpc.Option) (bool, error)
+	// Irreversibly remove the given deleted user. [confirmUsername] must
+	// repeat the username.
+	PurgeUser(ctx context.Context, user api.UserPass, confirmUsername string, options ...rpc.Option) (bool, error)
+	// Returns the deleted users that can still be restored
+	ListDeletedUsers(context.Context, ...rpc.Option) ([]DeletedUser, error)
 }
 
 // Client implementation for Avalanche Keystore API Endpoint
@@ -31,10 +52,11 @@ type client struct {
 	requester rpc.EndpointRequester
 }
 
-func NewClient(uri string) Client {
+func NewClient(uri string, options ...rpc.Option) Client {
 	return &client{requester: rpc.NewEndpointRequester(
 		uri+"/ext/keystore",
 		"keystore",
+		options...,
 	)}
 }
 
@@ -76,8 +98,79 @@ func (c *client) ImportUser(ctx context.Context, user api.UserPass, account []by
 	return res.Success, err
 }
 
+func (c *client) ExportUserArchive(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]byte, error) {
+	res := &ExportUserReply{
+		Encoding: formatting.Hex,
+	}
+	err := c.requester.SendRequest(ctx, "exportUserArchive", &ExportUserArgs{
+		UserPass: user,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+	return formatting.Decode(res.Encoding, res.User)
+}
+
+func (c *client) ImportUserArchive(ctx context.Context, user api.UserPass, archive []byte, options ...rpc.Option) (bool, error) {
+	archiveStr, err := formatting.EncodeWithChecksum(formatting.Hex, archive)
+	if err != nil {
+		return false, err
+	}
+
+	res := &api.SuccessResponse{}
+	err = c.requester.SendRequest(ctx, "importUserArchive", &ImportUserArgs{
+		UserPass: user,
+		User:     archiveStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) ChangePassword(ctx context.Context, user api.UserPass, newPassword string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "changePassword", &ChangePasswordArgs{
+		UserPass:    user,
+		NewPassword: newPassword,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) CreateReadOnlyToken(ctx context.Context, user api.UserPass, options ...rpc.Option) (string, error) {
+	res := &CreateReadOnlyTokenReply{}
+	err := c.requester.SendRequest(ctx, "createReadOnlyToken", &user, res, options...)
+	return res.Token, err
+}
+
+func (c *client) RevokeReadOnlyTokens(ctx context.Context, user api.UserPass, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "revokeReadOnlyTokens", &user, res, options...)
+	return res.Success, err
+}
+
 func (c *client) DeleteUser(ctx context.Context, user api.UserPass, options ...rpc.Option) (bool, error) {
 	res := &api.SuccessResponse{}
 	err := c.requester.SendRequest(ctx, "deleteUser", &user, res, options...)
 	return res.Success, err
 }
+
+func (c *client) RestoreUser(ctx context.Context, user api.UserPass, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "restoreUser", &user, res, options...)
+	return res.Success, err
+}
+
+func (c *client) PurgeUser(ctx context.Context, user api.UserPass, confirmUsername string, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "purgeUser", &PurgeUserArgs{
+		UserPass:        user,
+		ConfirmUsername: confirmUsername,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *client) ListDeletedUsers(ctx context.Context, options ...rpc.Option) ([]DeletedUser, error) {
+	res := &ListDeletedUsersReply{}
+	err := c.requester.SendRequest(ctx, "listDeletedUsers", struct{}{}, res, options...)
+	return res.Users, err
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -20,19 +21,28 @@ import (
 	"github.com/lasthyphen/beacongo/utils/json"
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/utils/password"
+	"github.com/lasthyphen/beacongo/utils/timer/mockable"
 )
 
 const (
 	// maxUserLen is the maximum allowed length of a username
 	maxUserLen = 1024
+
+	// DefaultDeletedUserRetention is how long a deleted user can be restored
+	// for, by default
+	DefaultDeletedUserRetention = 7 * 24 * time.Hour
 )
 
 var (
-	errEmptyUsername = errors.New("empty username")
-	errUserMaxLength = fmt.Errorf("username exceeds maximum length of %d chars", maxUserLen)
+	errEmptyUsername      = errors.New("empty username")
+	errUserMaxLength      = fmt.Errorf("username exceeds maximum length of %d chars", maxUserLen)
+	errPurgeNotConfirmed  = errors.New("purge must be confirmed by repeating the username")
+	errDeletedUserExpired = errors.New("deleted user is past its retention window")
 
-	usersPrefix = []byte("users")
-	bcsPrefix   = []byte("bcs")
+	usersPrefix        = []byte("users")
+	bcsPrefix          = []byte("bcs")
+	deletedUsersPrefix = []byte("deletedUsers")
+	tokensPrefix       = []byte("readOnlyTokens")
 
 	_ Keystore = &keystore{}
 )
@@ -59,12 +69,24 @@ type Keystore interface {
 	CreateUser(username, pw string) error
 
 	// DeleteUser attempts to remove the provided username and all of its data
-	// from the keystore.
+	// from the keystore. The user can be restored until its retention window
+	// ends, after which it's purged.
 	DeleteUser(username, pw string) error
 
+	// RestoreUser restores the deleted user [username], with all of its data.
+	RestoreUser(username, pw string) error
+
+	// PurgeUser irreversibly removes the deleted user [username] and all of
+	// its data. [confirmUsername] must repeat [username].
+	PurgeUser(username, pw, confirmUsername string) error
+
 	// ListUsers returns all the users that currently exist in this keystore.
 	ListUsers() ([]string, error)
 
+	// ListDeletedUsers returns all the deleted users that can still be
+	// restored.
+	ListDeletedUsers() ([]DeletedUser, error)
+
 	// ImportUser imports a serialized encoding of a user's information complete
 	// with encrypted database values. The password is integrity checked.
 	ImportUser(username, pw string, user []byte) error
@@ -73,6 +95,32 @@ type Keystore interface {
 	// with encrypted database values.
 	ExportUser(username, pw string) ([]byte, error)
 
+	// ExportUserArchive exports a user like ExportUser, in a versioned archive
+	// that is encrypted with the user's password.
+	ExportUserArchive(username, pw string) ([]byte, error)
+
+	// ImportUserArchive imports a user that was exported by
+	// ExportUserArchive. [pw] must be the password the archive was exported
+	// with.
+	ImportUserArchive(username, pw string, archive []byte) error
+
+	// ChangePassword changes the password of [username] to [newPW] and
+	// re-encrypts all of the user's values with it. Fails without changing
+	// anything if any of the user's values isn't encrypted with [oldPW].
+	ChangePassword(username, oldPW, newPW string) error
+
+	// CreateReadOnlyToken returns a new token that can be used instead of the
+	// password of [username] to read, but not write, the user's databases.
+	// Tokens are revoked when the user's password is changed.
+	CreateReadOnlyToken(username, pw string) (string, error)
+
+	// RevokeReadOnlyTokens revokes every read-only token of [username].
+	RevokeReadOnlyTokens(username, pw string) error
+
+	// GetReadOnlyDatabase returns a database that is able to read, but not
+	// write, unencrypted values of the user that [token] was created for.
+	GetReadOnlyDatabase(bID ids.ID, token string) (*encdb.Database, error)
+
 	// Get the password that is used by [username]. If [username] doesn't exist,
 	// no error is returned and a nil password hash is returned.
 	getPassword(username string) (*password.Hash, error)
@@ -89,9 +137,28 @@ type user struct {
 	Data          []kvPair `serialize:"true"`
 }
 
+// deletedUser is a user that was deleted and can still be restored
+type deletedUser struct {
+	User user `serialize:"true"`
+	// Unix time the user was deleted at
+	DeletedAt uint64 `serialize:"true"`
+}
+
+// DeletedUser describes a user that was deleted and can still be restored
+type DeletedUser struct {
+	Username  string    `json:"username"`
+	DeletedAt time.Time `json:"deletedAt"`
+	// Time after which the user can't be restored anymore
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type keystore struct {
-	lock sync.Mutex
-	log  logging.Logger
+	lock  sync.Mutex
+	log   logging.Logger
+	clock mockable.Clock
+
+	// How long deleted users can be restored for
+	deletedUserRetention time.Duration
 
 	// Key: username
 	// Value: The hash of that user's password
@@ -100,22 +167,39 @@ type keystore struct {
 	// Used to persist users and their data
 	userDB database.Database
 	bcDB   database.Database
-	//           BaseDB
-	//          /      \
-	//    UserDB        BlockchainDB
-	//                 /      |     \
-	//               Usr     Usr    Usr
-	//             /  |  \
-	//          BID  BID  BID
+	// Key: username
+	// Value: The deleted user, with all of its data
+	deletedUserDB database.Database
+	// Key: hash of a read-only token
+	// Value: The user the token was created for, with its encrypted password
+	tokenDB database.Database
+	//                  BaseDB
+	//          /         |        \
+	//    UserDB   DeletedUserDB    BlockchainDB
+	//                             /      |     \
+	//                           Usr     Usr    Usr
+	//                         /  |  \
+	//                      BID  BID  BID
 }
 
+// New returns a keystore whose deleted users can be restored for
+// [DefaultDeletedUserRetention].
 func New(log logging.Logger, dbManager manager.Manager) Keystore {
+	return NewWithRetention(log, dbManager, DefaultDeletedUserRetention)
+}
+
+// NewWithRetention returns a keystore whose deleted users can be restored for
+// [deletedUserRetention].
+func NewWithRetention(log logging.Logger, dbManager manager.Manager, deletedUserRetention time.Duration) Keystore {
 	currentDB := dbManager.Current()
 	return &keystore{
-		log:                log,
-		usernameToPassword: make(map[string]*password.Hash),
-		userDB:             prefixdb.New(usersPrefix, currentDB.Database),
-		bcDB:               prefixdb.New(bcsPrefix, currentDB.Database),
+		log:                  log,
+		deletedUserRetention: deletedUserRetention,
+		usernameToPassword:   make(map[string]*password.Hash),
+		userDB:               prefixdb.New(usersPrefix, currentDB.Database),
+		bcDB:                 prefixdb.New(bcsPrefix, currentDB.Database),
+		deletedUserDB:        prefixdb.New(deletedUsersPrefix, currentDB.Database),
+		tokenDB:              prefixdb.New(tokensPrefix, currentDB.Database),
 	}
 }
 
@@ -229,37 +313,158 @@ func (ks *keystore) DeleteUser(username, pw string) error {
 		return fmt.Errorf("incorrect password for user %q", username)
 	}
 
+	if err := ks.pruneDeletedUsers(); err != nil {
+		return err
+	}
 	userNameBytes := []byte(username)
+	deleted, err := ks.deletedUserDB.Has(userNameBytes)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		// Replacing the deleted copy would make it impossible to restore
+		return fmt.Errorf("a deleted copy of user %q must be purged before it can be deleted again", username)
+	}
+
+	userData, err := ks.getUser(username, passwordHash)
+	if err != nil {
+		return err
+	}
+	deletedUserBytes, err := c.Marshal(codecVersion, &deletedUser{
+		User:      *userData,
+		DeletedAt: ks.clock.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
 	userBatch := ks.userDB.NewBatch()
 	if err := userBatch.Delete(userNameBytes); err != nil {
 		return err
 	}
 
+	deletedUserBatch := ks.deletedUserDB.NewBatch()
+	if err := deletedUserBatch.Put(userNameBytes, deletedUserBytes); err != nil {
+		return err
+	}
+
 	userDataDB := prefixdb.New(userNameBytes, ks.bcDB)
 	dataBatch := userDataDB.NewBatch()
-
-	it := userDataDB.NewIterator()
-	defer it.Release()
-
-	for it.Next() {
-		if err = dataBatch.Delete(it.Key()); err != nil {
+	for _, kvp := range userData.Data {
+		if err := dataBatch.Delete(kvp.Key); err != nil {
 			return err
 		}
 	}
 
-	if err = it.Error(); err != nil {
+	if err := atomic.WriteAll(dataBatch, userBatch, deletedUserBatch); err != nil {
 		return err
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	// delete from users map.
+	delete(ks.usernameToPassword, username)
+	return nil
+}
+
+func (ks *keystore) RestoreUser(username, pw string) error {
+	if username == "" {
+		return errEmptyUsername
+	}
+	if len(username) > maxUserLen {
+		return errUserMaxLength
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if err := ks.pruneDeletedUsers(); err != nil {
+		return err
+	}
+	userData, err := ks.getDeletedUser(username, pw)
+	if err != nil {
 		return err
 	}
 
-	// delete from users map.
-	delete(ks.usernameToPassword, username)
+	passwordHash, err := ks.getPassword(username)
+	if err != nil {
+		return err
+	}
+	if passwordHash != nil {
+		return fmt.Errorf("user already exists: %s", username)
+	}
+
+	usrBytes, err := c.Marshal(codecVersion, &userData.User.Hash)
+	if err != nil {
+		return err
+	}
+
+	userNameBytes := []byte(username)
+	userBatch := ks.userDB.NewBatch()
+	if err := userBatch.Put(userNameBytes, usrBytes); err != nil {
+		return err
+	}
+
+	deletedUserBatch := ks.deletedUserDB.NewBatch()
+	if err := deletedUserBatch.Delete(userNameBytes); err != nil {
+		return err
+	}
+
+	userDataDB := prefixdb.New(userNameBytes, ks.bcDB)
+	dataBatch := userDataDB.NewBatch()
+	for _, kvp := range userData.User.Data {
+		if err := dataBatch.Put(kvp.Key, kvp.Value); err != nil {
+			return fmt.Errorf("error on database put: %w", err)
+		}
+	}
+
+	if err := atomic.WriteAll(dataBatch, userBatch, deletedUserBatch); err != nil {
+		return err
+	}
+	ks.usernameToPassword[username] = &userData.User.Hash
 	return nil
 }
 
+func (ks *keystore) PurgeUser(username, pw, confirmUsername string) error {
+	if username == "" {
+		return errEmptyUsername
+	}
+	if len(username) > maxUserLen {
+		return errUserMaxLength
+	}
+	if confirmUsername != username {
+		return errPurgeNotConfirmed
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if _, err := ks.getDeletedUser(username, pw); err != nil && !errors.Is(err, errDeletedUserExpired) {
+		return err
+	}
+	ks.log.Info("purging deleted keystore user %q", username)
+	return ks.deletedUserDB.Delete([]byte(username))
+}
+
+func (ks *keystore) ListDeletedUsers() ([]DeletedUser, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if err := ks.pruneDeletedUsers(); err != nil {
+		return nil, err
+	}
+
+	users := []DeletedUser{}
+	err := ks.forEachDeletedUser(func(username string, userData *deletedUser) error {
+		deletedAt := time.Unix(int64(userData.DeletedAt), 0)
+		users = append(users, DeletedUser{
+			Username:  username,
+			DeletedAt: deletedAt,
+			ExpiresAt: deletedAt.Add(ks.deletedUserRetention),
+		})
+		return nil
+	})
+	return users, err
+}
+
 func (ks *keystore) ListUsers() ([]string, error) {
 	users := []string{}
 
@@ -345,9 +550,21 @@ func (ks *keystore) ExportUser(username, pw string) ([]byte, error) {
 		return nil, fmt.Errorf("incorrect password for user %q", username)
 	}
 
+	userData, err := ks.getUser(username, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the byte representation of the user
+	return c.Marshal(codecVersion, userData)
+}
+
+// getUser returns the full content of [username], whose password hash is
+// [passwordHash].
+func (ks *keystore) getUser(username string, passwordHash *password.Hash) (*user, error) {
 	userDB := prefixdb.New([]byte(username), ks.bcDB)
 
-	userData := user{Hash: *passwordHash}
+	userData := &user{Hash: *passwordHash}
 	it := userDB.NewIterator()
 	defer it.Release()
 	for it.Next() {
@@ -356,12 +573,74 @@ func (ks *keystore) ExportUser(username, pw string) ([]byte, error) {
 			Value: it.Value(),
 		})
 	}
-	if err := it.Error(); err != nil {
+	return userData, it.Error()
+}
+
+// getDeletedUser returns the deleted user [username], if [pw] is its password.
+// If the user is past its retention window, it's returned along with
+// [errDeletedUserExpired].
+func (ks *keystore) getDeletedUser(username, pw string) (*deletedUser, error) {
+	userBytes, err := ks.deletedUserDB.Get([]byte(username))
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("deleted user doesn't exist: %s", username)
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	// Return the byte representation of the user
-	return c.Marshal(codecVersion, &userData)
+	userData := &deletedUser{}
+	if _, err := c.Unmarshal(userBytes, userData); err != nil {
+		return nil, err
+	}
+	if !userData.User.Hash.Check(pw) {
+		return nil, fmt.Errorf("incorrect password for user %q", username)
+	}
+	if ks.isExpired(userData) {
+		return userData, fmt.Errorf("%w: %s", errDeletedUserExpired, username)
+	}
+	return userData, nil
+}
+
+func (ks *keystore) isExpired(userData *deletedUser) bool {
+	deletedAt := time.Unix(int64(userData.DeletedAt), 0)
+	return !ks.clock.Time().Before(deletedAt.Add(ks.deletedUserRetention))
+}
+
+// pruneDeletedUsers purges the deleted users that are past their retention
+// window.
+func (ks *keystore) pruneDeletedUsers() error {
+	expired := []string(nil)
+	err := ks.forEachDeletedUser(func(username string, userData *deletedUser) error {
+		if ks.isExpired(userData) {
+			expired = append(expired, username)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, username := range expired {
+		ks.log.Info("purging deleted keystore user %q as its retention window ended", username)
+		if err := ks.deletedUserDB.Delete([]byte(username)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ks *keystore) forEachDeletedUser(f func(username string, userData *deletedUser) error) error {
+	it := ks.deletedUserDB.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		userData := &deletedUser{}
+		if _, err := c.Unmarshal(it.Value(), userData); err != nil {
+			return err
+		}
+		if err := f(string(it.Key()), userData); err != nil {
+			return err
+		}
+	}
+	return it.Error()
 }
 
 func (ks *keystore) getPassword(username string) (*password.Hash, error) {
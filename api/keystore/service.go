@@ -33,6 +33,39 @@ func (s *service) DeleteUser(_ *http.Request, args *api.UserPass, reply *api.Suc
 	return s.ks.DeleteUser(args.Username, args.Password)
 }
 
+func (s *service) RestoreUser(_ *http.Request, args *api.UserPass, reply *api.SuccessResponse) error {
+	s.ks.log.Debug("Keystore: RestoreUser called with %.*s", maxUserLen, args.Username)
+
+	reply.Success = true
+	return s.ks.RestoreUser(args.Username, args.Password)
+}
+
+type PurgeUserArgs struct {
+	// The username and password of the deleted user being purged
+	api.UserPass
+	// Must repeat the username, as purging a user can't be undone
+	ConfirmUsername string `json:"confirmUsername"`
+}
+
+func (s *service) PurgeUser(_ *http.Request, args *PurgeUserArgs, reply *api.SuccessResponse) error {
+	s.ks.log.Debug("Keystore: PurgeUser called with %.*s", maxUserLen, args.Username)
+
+	reply.Success = true
+	return s.ks.PurgeUser(args.Username, args.Password, args.ConfirmUsername)
+}
+
+type ListDeletedUsersReply struct {
+	Users []DeletedUser `json:"users"`
+}
+
+func (s *service) ListDeletedUsers(_ *http.Request, args *struct{}, reply *ListDeletedUsersReply) error {
+	s.ks.log.Debug("Keystore: ListDeletedUsers called")
+
+	var err error
+	reply.Users, err = s.ks.ListDeletedUsers()
+	return err
+}
+
 type ListUsersReply struct {
 	Users []string `json:"users"`
 }
@@ -98,6 +131,68 @@ func (s *service) ExportUser(_ *http.Request, args *ExportUserArgs, reply *Expor
 	return nil
 }
 
+func (s *service) ExportUserArchive(_ *http.Request, args *ExportUserArgs, reply *ExportUserReply) error {
+	s.ks.log.Debug("Keystore: ExportUserArchive called for %.*s", maxUserLen, args.Username)
+
+	archiveBytes, err := s.ks.ExportUserArchive(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
+	reply.User, err = formatting.EncodeWithChecksum(args.Encoding, archiveBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode user archive to string: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+func (s *service) ImportUserArchive(_ *http.Request, args *ImportUserArgs, reply *api.SuccessResponse) error {
+	s.ks.log.Debug("Keystore: ImportUserArchive called for %.*s", maxUserLen, args.Username)
+
+	archiveBytes, err := formatting.Decode(args.Encoding, args.User)
+	if err != nil {
+		return fmt.Errorf("couldn't decode 'user' to bytes: %w", err)
+	}
+
+	reply.Success = true
+	return s.ks.ImportUserArchive(args.Username, args.Password, archiveBytes)
+}
+
+type ChangePasswordArgs struct {
+	// The username and current password of the user
+	api.UserPass
+	NewPassword string `json:"newPassword"`
+}
+
+func (s *service) ChangePassword(_ *http.Request, args *ChangePasswordArgs, reply *api.SuccessResponse) error {
+	s.ks.log.Debug("Keystore: ChangePassword called for %.*s", maxUserLen, args.Username)
+
+	reply.Success = true
+	return s.ks.ChangePassword(args.Username, args.Password, args.NewPassword)
+}
+
+type CreateReadOnlyTokenReply struct {
+	// Token that can be passed instead of the user's password to the API
+	// methods that only read the user's data
+	Token string `json:"token"`
+}
+
+func (s *service) CreateReadOnlyToken(_ *http.Request, args *api.UserPass, reply *CreateReadOnlyTokenReply) error {
+	s.ks.log.Debug("Keystore: CreateReadOnlyToken called for %.*s", maxUserLen, args.Username)
+
+	var err error
+	reply.Token, err = s.ks.CreateReadOnlyToken(args.Username, args.Password)
+	return err
+}
+
+func (s *service) RevokeReadOnlyTokens(_ *http.Request, args *api.UserPass, reply *api.SuccessResponse) error {
+	s.ks.log.Debug("Keystore: RevokeReadOnlyTokens called for %.*s", maxUserLen, args.Username)
+
+	reply.Success = true
+	return s.ks.RevokeReadOnlyTokens(args.Username, args.Password)
+}
+
 // CreateTestKeystore returns a new keystore that can be utilized for testing
 func CreateTestKeystore() (Keystore, error) {
 	dbManager, err := manager.NewManagerFromDBs([]*manager.VersionedDatabase{
@@ -9,6 +9,9 @@ import (
 	"math/rand"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lasthyphen/beacongo/api"
 	"github.com/lasthyphen/beacongo/ids"
@@ -427,3 +430,250 @@ func TestServiceDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceRestoreDeletedUser(t *testing.T) {
+	assert := assert.New(t)
+
+	ks, err := CreateTestKeystore()
+	assert.NoError(err)
+	s := service{ks: ks.(*keystore)}
+	userPass := api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}
+
+	assert.NoError(s.CreateUser(nil, &userPass, &api.SuccessResponse{}))
+	db, err := ks.GetDatabase(ids.Empty, "bob", strongPassword)
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+
+	assert.NoError(s.DeleteUser(nil, &userPass, &api.SuccessResponse{}))
+	_, err = ks.GetDatabase(ids.Empty, "bob", strongPassword)
+	assert.Error(err)
+
+	deletedReply := ListDeletedUsersReply{}
+	assert.NoError(s.ListDeletedUsers(nil, nil, &deletedReply))
+	assert.Len(deletedReply.Users, 1)
+	assert.Equal("bob", deletedReply.Users[0].Username)
+
+	// The user can't be restored without its password
+	err = s.RestoreUser(nil, &api.UserPass{Username: "bob", Password: "wrong"}, &api.SuccessResponse{})
+	assert.Error(err)
+
+	assert.NoError(s.RestoreUser(nil, &userPass, &api.SuccessResponse{}))
+	db, err = ks.GetDatabase(ids.Empty, "bob", strongPassword)
+	assert.NoError(err)
+	val, err := db.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), val)
+
+	deletedReply = ListDeletedUsersReply{}
+	assert.NoError(s.ListDeletedUsers(nil, nil, &deletedReply))
+	assert.Empty(deletedReply.Users)
+}
+
+func TestServicePurgeDeletedUser(t *testing.T) {
+	assert := assert.New(t)
+
+	ks, err := CreateTestKeystore()
+	assert.NoError(err)
+	s := service{ks: ks.(*keystore)}
+	userPass := api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}
+
+	assert.NoError(s.CreateUser(nil, &userPass, &api.SuccessResponse{}))
+	assert.NoError(s.DeleteUser(nil, &userPass, &api.SuccessResponse{}))
+
+	// The purge must be confirmed
+	err = s.PurgeUser(nil, &PurgeUserArgs{UserPass: userPass}, &api.SuccessResponse{})
+	assert.ErrorIs(err, errPurgeNotConfirmed)
+
+	assert.NoError(s.PurgeUser(nil, &PurgeUserArgs{
+		UserPass:        userPass,
+		ConfirmUsername: "bob",
+	}, &api.SuccessResponse{}))
+	assert.Error(s.RestoreUser(nil, &userPass, &api.SuccessResponse{}))
+}
+
+func TestServiceDeletedUserRetention(t *testing.T) {
+	assert := assert.New(t)
+
+	ks, err := CreateTestKeystore()
+	assert.NoError(err)
+	s := service{ks: ks.(*keystore)}
+	userPass := api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}
+
+	now := time.Now()
+	s.ks.clock.Set(now)
+	assert.NoError(s.CreateUser(nil, &userPass, &api.SuccessResponse{}))
+	assert.NoError(s.DeleteUser(nil, &userPass, &api.SuccessResponse{}))
+
+	// Once the retention window ends, the user is purged
+	s.ks.clock.Set(now.Add(DefaultDeletedUserRetention + time.Second))
+	err = s.RestoreUser(nil, &userPass, &api.SuccessResponse{})
+	assert.Error(err)
+
+	deletedReply := ListDeletedUsersReply{}
+	assert.NoError(s.ListDeletedUsers(nil, nil, &deletedReply))
+	assert.Empty(deletedReply.Users)
+}
+
+func TestServiceExportImportUserArchive(t *testing.T) {
+	assert := assert.New(t)
+
+	ks, err := CreateTestKeystore()
+	assert.NoError(err)
+	s := service{ks: ks.(*keystore)}
+	userPass := api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}
+
+	assert.NoError(s.CreateUser(nil, &userPass, &api.SuccessResponse{}))
+	db, err := ks.GetDatabase(ids.Empty, "bob", strongPassword)
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+
+	exportReply := ExportUserReply{}
+	assert.NoError(s.ExportUserArchive(nil, &ExportUserArgs{
+		UserPass: userPass,
+		Encoding: formatting.Hex,
+	}, &exportReply))
+
+	newKS, err := CreateTestKeystore()
+	assert.NoError(err)
+	newS := service{ks: newKS.(*keystore)}
+
+	// The archive can't be opened without the password it was exported with
+	err = newS.ImportUserArchive(nil, &ImportUserArgs{
+		UserPass: api.UserPass{
+			Username: "bob",
+			Password: "wrong",
+		},
+		User:     exportReply.User,
+		Encoding: formatting.Hex,
+	}, &api.SuccessResponse{})
+	assert.ErrorIs(err, errIncorrectArchivePassword)
+
+	// Archives aren't accepted as plain exported users
+	err = newS.ImportUser(nil, &ImportUserArgs{
+		UserPass: userPass,
+		User:     exportReply.User,
+		Encoding: formatting.Hex,
+	}, &api.SuccessResponse{})
+	assert.Error(err)
+
+	assert.NoError(newS.ImportUserArchive(nil, &ImportUserArgs{
+		UserPass: userPass,
+		User:     exportReply.User,
+		Encoding: formatting.Hex,
+	}, &api.SuccessResponse{}))
+	db, err = newKS.GetDatabase(ids.Empty, "bob", strongPassword)
+	assert.NoError(err)
+	val, err := db.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), val)
+}
+
+func TestServiceChangePassword(t *testing.T) {
+	assert := assert.New(t)
+
+	ks, err := CreateTestKeystore()
+	assert.NoError(err)
+	s := service{ks: ks.(*keystore)}
+	userPass := api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}
+	newPassword := strongPassword + "!"
+
+	assert.NoError(s.CreateUser(nil, &userPass, &api.SuccessResponse{}))
+	db, err := ks.GetDatabase(ids.Empty, "bob", strongPassword)
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+
+	err = s.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    api.UserPass{Username: "bob", Password: "wrong"},
+		NewPassword: newPassword,
+	}, &api.SuccessResponse{})
+	assert.Error(err)
+
+	err = s.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    userPass,
+		NewPassword: "weak",
+	}, &api.SuccessResponse{})
+	assert.Error(err)
+
+	reply := api.SuccessResponse{}
+	assert.NoError(s.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    userPass,
+		NewPassword: newPassword,
+	}, &reply))
+	assert.True(reply.Success)
+
+	_, err = ks.GetDatabase(ids.Empty, "bob", strongPassword)
+	assert.Error(err)
+
+	// The values were re-encrypted with the new password
+	db, err = ks.GetDatabase(ids.Empty, "bob", newPassword)
+	assert.NoError(err)
+	val, err := db.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), val)
+}
+
+func TestServiceReadOnlyToken(t *testing.T) {
+	assert := assert.New(t)
+
+	ks, err := CreateTestKeystore()
+	assert.NoError(err)
+	s := service{ks: ks.(*keystore)}
+	userPass := api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}
+
+	assert.NoError(s.CreateUser(nil, &userPass, &api.SuccessResponse{}))
+	db, err := ks.GetDatabase(ids.Empty, "bob", strongPassword)
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+
+	tokenReply := CreateReadOnlyTokenReply{}
+	err = s.CreateReadOnlyToken(nil, &api.UserPass{Username: "bob", Password: "wrong"}, &tokenReply)
+	assert.Error(err)
+	assert.NoError(s.CreateReadOnlyToken(nil, &userPass, &tokenReply))
+
+	// The token can read, but not write, the user's values
+	readOnlyDB, err := ks.GetReadOnlyDatabase(ids.Empty, tokenReply.Token)
+	assert.NoError(err)
+	val, err := readOnlyDB.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), val)
+	assert.ErrorIs(readOnlyDB.Put([]byte("hello"), []byte("there")), errReadOnly)
+	batch := readOnlyDB.NewBatch()
+	assert.NoError(batch.Put([]byte("hello"), []byte("there")))
+	assert.ErrorIs(batch.Write(), errReadOnly)
+
+	_, err = ks.GetReadOnlyDatabase(ids.Empty, "invalid")
+	assert.ErrorIs(err, errInvalidToken)
+
+	// Changing the password revokes the tokens
+	newPassword := strongPassword + "!"
+	assert.NoError(s.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    userPass,
+		NewPassword: newPassword,
+	}, &api.SuccessResponse{}))
+	_, err = ks.GetReadOnlyDatabase(ids.Empty, tokenReply.Token)
+	assert.ErrorIs(err, errInvalidToken)
+
+	userPass.Password = newPassword
+	assert.NoError(s.CreateReadOnlyToken(nil, &userPass, &tokenReply))
+	assert.NoError(s.RevokeReadOnlyTokens(nil, &userPass, &api.SuccessResponse{}))
+	_, err = ks.GetReadOnlyDatabase(ids.Empty, tokenReply.Token)
+	assert.ErrorIs(err, errInvalidToken)
+}
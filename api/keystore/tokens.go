@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/encdb"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/formatting"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+)
+
+const tokenLen = chacha20poly1305.KeySize
+
+var (
+	errInvalidToken = errors.New("invalid read-only token")
+	errReadOnly     = errors.New("database is read-only")
+
+	_ database.Database = &readOnlyDatabase{}
+	_ database.Batch    = &readOnlyBatch{}
+)
+
+// readOnlyToken is the stored record of a read-only token. The token itself
+// isn't stored, only its hash, so the user's password can only be recovered by
+// presenting the token.
+type readOnlyToken struct {
+	Username string `serialize:"true"`
+	Nonce    []byte `serialize:"true"`
+	// The user's password, encrypted with the token
+	EncryptedPassword []byte `serialize:"true"`
+}
+
+func (ks *keystore) CreateReadOnlyToken(username, pw string) (string, error) {
+	if username == "" {
+		return "", errEmptyUsername
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	passwordHash, err := ks.getPassword(username)
+	if err != nil {
+		return "", err
+	}
+	if passwordHash == nil || !passwordHash.Check(pw) {
+		return "", fmt.Errorf("incorrect password for user %q", username)
+	}
+
+	token := make([]byte, tokenLen)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	record := readOnlyToken{
+		Username: username,
+		Nonce:    make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	if _, err := rand.Read(record.Nonce); err != nil {
+		return "", err
+	}
+	aead, err := chacha20poly1305.NewX(token)
+	if err != nil {
+		return "", err
+	}
+	record.EncryptedPassword = aead.Seal(nil, record.Nonce, []byte(pw), nil)
+
+	recordBytes, err := c.Marshal(codecVersion, &record)
+	if err != nil {
+		return "", err
+	}
+	if err := ks.tokenDB.Put(hashing.ComputeHash256(token), recordBytes); err != nil {
+		return "", err
+	}
+	return formatting.EncodeWithChecksum(formatting.Hex, token)
+}
+
+func (ks *keystore) RevokeReadOnlyTokens(username, pw string) error {
+	if username == "" {
+		return errEmptyUsername
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	passwordHash, err := ks.getPassword(username)
+	if err != nil {
+		return err
+	}
+	if passwordHash == nil || !passwordHash.Check(pw) {
+		return fmt.Errorf("incorrect password for user %q", username)
+	}
+	return ks.revokeReadOnlyTokens(username)
+}
+
+func (ks *keystore) GetReadOnlyDatabase(bID ids.ID, token string) (*encdb.Database, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	username, pw, err := ks.openReadOnlyToken(token)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := ks.getPassword(username)
+	if err != nil {
+		return nil, err
+	}
+	if passwordHash == nil || !passwordHash.Check(pw) {
+		// The user was deleted, or its password was changed, since the token
+		// was created
+		return nil, errInvalidToken
+	}
+
+	userDB := prefixdb.New([]byte(username), ks.bcDB)
+	bcDB := prefixdb.NewNested(bID[:], userDB)
+	return encdb.New([]byte(pw), &readOnlyDatabase{Database: bcDB})
+}
+
+// openReadOnlyToken returns the user that [tokenStr] was created for, and the
+// user's password at the time.
+func (ks *keystore) openReadOnlyToken(tokenStr string) (string, string, error) {
+	token, err := formatting.Decode(formatting.Hex, tokenStr)
+	if err != nil || len(token) != tokenLen {
+		return "", "", errInvalidToken
+	}
+	recordBytes, err := ks.tokenDB.Get(hashing.ComputeHash256(token))
+	if err == database.ErrNotFound {
+		return "", "", errInvalidToken
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	record := readOnlyToken{}
+	if _, err := c.Unmarshal(recordBytes, &record); err != nil {
+		return "", "", err
+	}
+	aead, err := chacha20poly1305.NewX(token)
+	if err != nil {
+		return "", "", err
+	}
+	pw, err := aead.Open(nil, record.Nonce, record.EncryptedPassword, nil)
+	if err != nil {
+		return "", "", errInvalidToken
+	}
+	return record.Username, string(pw), nil
+}
+
+// revokeReadOnlyTokens removes every read-only token of [username].
+//
+// Assumes [ks.lock] is held.
+func (ks *keystore) revokeReadOnlyTokens(username string) error {
+	it := ks.tokenDB.NewIterator()
+	defer it.Release()
+
+	batch := ks.tokenDB.NewBatch()
+	for it.Next() {
+		record := readOnlyToken{}
+		if _, err := c.Unmarshal(it.Value(), &record); err != nil {
+			return err
+		}
+		if record.Username != username {
+			continue
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// readOnlyDatabase rejects all writes to the wrapped database
+type readOnlyDatabase struct {
+	database.Database
+}
+
+func (*readOnlyDatabase) Put([]byte, []byte) error { return errReadOnly }
+func (*readOnlyDatabase) Delete([]byte) error      { return errReadOnly }
+
+func (db *readOnlyDatabase) NewBatch() database.Batch {
+	return &readOnlyBatch{Batch: db.Database.NewBatch()}
+}
+
+// readOnlyBatch rejects all writes to the wrapped batch
+type readOnlyBatch struct {
+	database.Batch
+}
+
+func (*readOnlyBatch) Put([]byte, []byte) error { return errReadOnly }
+func (*readOnlyBatch) Delete([]byte) error      { return errReadOnly }
+func (*readOnlyBatch) Write() error             { return errReadOnly }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/mux"
@@ -87,8 +88,16 @@ func (r *router) forceAddRouter(base, endpoint string, handler http.Handler) err
 
 	endpoints[endpoint] = handler
 	r.routes[base] = endpoints
+	// Endpoints that end in "/" serve every path under them, which is needed
+	// by handlers, such as gRPC servers, that route requests by their path.
+	var route *mux.Route
+	if strings.HasSuffix(endpoint, "/") {
+		route = r.router.PathPrefix(url).Handler(handler)
+	} else {
+		route = r.router.Handle(url, handler)
+	}
 	// Name routes based on their URL for easy retrieval in the future
-	if route := r.router.Handle(url, handler); route != nil {
+	if route != nil {
 		route.Name(url)
 	} else {
 		return fmt.Errorf("failed to create new route for %s", url)
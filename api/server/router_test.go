@@ -5,6 +5,7 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -75,3 +76,34 @@ func TestBlock(t *testing.T) {
 		t.Fatalf("Permanently locked %s", "1")
 	}
 }
+
+func TestPrefixRoute(t *testing.T) {
+	r := newRouter()
+
+	prefixHandler := &testHandler{}
+	if err := r.AddRouter("/ext/bc/1", "/grpc/", prefixHandler); err != nil {
+		t.Fatal(err)
+	}
+	exactHandler := &testHandler{}
+	if err := r.AddRouter("/ext/bc/1", "/events", exactHandler); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/ext/bc/1/grpc/avm.AVM/GetTx", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	if !prefixHandler.called {
+		t.Fatal("Should have routed the request under the prefix")
+	}
+
+	req, err = http.NewRequest(http.MethodGet, "/ext/bc/1/events/other", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	if exactHandler.called {
+		t.Fatal("Shouldn't have routed the request under an exact endpoint")
+	}
+}
@@ -177,6 +177,8 @@ func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
 	config := &tls.Config{
 		MinVersion:   tls.VersionTLS12,
 		Certificates: []tls.Certificate{cert},
+		// HTTP/2 is offered so that gRPC APIs can be served
+		NextProtos: []string{"h2", "http/1.1"},
 	}
 
 	listener, err := tls.Listen("tcp", listenAddress, config)
@@ -218,7 +220,11 @@ func (s *server) registerChain(chainName string, engine common.Engine) {
 	// all subroutes to a chain begin with "bc/<the chain's ID>"
 	defaultEndpoint := path.Join(constants.ChainAliasPrefix, ctx.ChainID.String())
 
-	// Register each endpoint
+	// Wrap each endpoint's handler
+	chainHandlers := make(map[string]http.Handler, len(handlers))
+	// Maps the extension of version 1 of each API to the handlers of each of
+	// its versions
+	apiVersions := make(map[string]map[uint64]http.Handler)
 	for extension, handler := range handlers {
 		// Validate that the route being added is valid
 		// e.g. "/foo" and "" are ok but "\n" is not
@@ -227,7 +233,36 @@ func (s *server) registerChain(chainName string, engine common.Engine) {
 			s.log.Error("could not add route to chain's API handler because route is malformed: %s", err)
 			continue
 		}
-		if err := s.AddChainRoute(handler, ctx, defaultEndpoint, extension); err != nil {
+		h, err := chainMiddleware(handler, ctx)
+		if err != nil {
+			s.log.Error("error adding route: %s", err)
+			continue
+		}
+		chainHandlers[extension] = h
+
+		version, baseExtension := SplitVersion(extension)
+		versions, exists := apiVersions[baseExtension]
+		if !exists {
+			versions = make(map[uint64]http.Handler)
+			apiVersions[baseExtension] = versions
+		}
+		versions[version] = h
+	}
+
+	// APIs with versions other than version 1 serve requests to their
+	// unversioned endpoint with the version that the client asks for
+	for baseExtension, versions := range apiVersions {
+		if _, ok := versions[defaultAPIVersion]; ok && len(versions) == 1 {
+			continue
+		}
+		chainHandlers[baseExtension] = newVersionNegotiator(versions)
+	}
+
+	// Register each endpoint
+	chainURL := fmt.Sprintf("%s/%s", baseURL, defaultEndpoint)
+	for extension, h := range chainHandlers {
+		s.log.Info("adding route %s%s", chainURL, extension)
+		if err := s.router.AddRouter(chainURL, extension, h); err != nil {
 			s.log.Error("error adding route: %s", err)
 		}
 	}
@@ -236,16 +271,29 @@ func (s *server) registerChain(chainName string, engine common.Engine) {
 func (s *server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.ConsensusContext, base, endpoint string) error {
 	url := fmt.Sprintf("%s/%s", baseURL, base)
 	s.log.Info("adding route %s%s", url, endpoint)
-	// Apply middleware to grab/release chain's lock before/after calling API method
-	h, err := lockMiddleware(handler.Handler, handler.LockOptions, &ctx.Lock)
+	h, err := chainMiddleware(handler, ctx)
 	if err != nil {
 		return err
 	}
-	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
-	h = rejectMiddleware(h, ctx)
 	return s.router.AddRouter(url, endpoint, h)
 }
 
+// chainMiddleware wraps a chain's handler with the middleware that every
+// chain's API is served with.
+func chainMiddleware(handler *common.HTTPHandler, ctx *snow.ConsensusContext) (http.Handler, error) {
+	// Apply middleware to grab/release chain's lock before/after calling API method
+	h, err := lockMiddleware(handler.Handler, handler.LockOptions, &ctx.Lock)
+	if err != nil {
+		return nil, err
+	}
+	// Apply middleware to reject calls to the handler before the chain finishes
+	// bootstrapping, or writes to the chain while it's in maintenance mode
+	h = rejectMiddleware(h, handler.LockOptions, ctx)
+	// Apply middleware to warn clients of deprecated handlers, and to reject
+	// calls to them once they've been removed
+	return deprecationMiddleware(h, handler.Sunset), nil
+}
+
 func (s *server) AddRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string) error {
 	return s.addRoute(handler, lock, base, endpoint)
 }
@@ -290,14 +338,19 @@ func lockMiddleware(handler http.Handler, lockOption common.LockOption, lock *sy
 }
 
 // Reject middleware wraps a handler. If the chain that the context describes is
-// not done state-syncing/bootstrapping, writes back an error.
-func rejectMiddleware(handler http.Handler, ctx *snow.ConsensusContext) http.Handler {
+// not done state-syncing/bootstrapping, writes back an error. If the chain is
+// in maintenance mode, handlers that take the chain's write lock are rejected.
+func rejectMiddleware(handler http.Handler, lockOption common.LockOption, ctx *snow.ConsensusContext) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { // If chain isn't done bootstrapping, ignore API calls
-		if ctx.GetState() != snow.NormalOp {
+		switch {
+		case ctx.GetState() != snow.NormalOp:
 			w.WriteHeader(http.StatusServiceUnavailable)
 			// Doesn't matter if there's an error while writing. They'll get the StatusServiceUnavailable code.
 			_, _ = w.Write([]byte("API call rejected because chain is not done bootstrapping"))
-		} else {
+		case lockOption == common.WriteLock && ctx.InMaintenance():
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("API call rejected because chain is in maintenance mode"))
+		default:
 			handler.ServeHTTP(w, r)
 		}
 	})
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIVersionHeader is the header a client sets to choose which version of a
+// chain's API serves a request sent to an unversioned endpoint. Responses from
+// those endpoints carry it too, naming the version that served the request.
+const APIVersionHeader = "API-Version"
+
+// A chain's handlers are version 1 of its API, unless their extension starts
+// with "/v<N>" for some N > 1. For example, "/v2/wallet" is version 2 of
+// "/wallet" and "/v2" is version 2 of "".
+const defaultAPIVersion = 1
+
+// SplitVersion returns the API version that a chain handler registered at
+// [extension] serves, and the extension of version 1 of that API.
+func SplitVersion(extension string) (uint64, string) {
+	if !strings.HasPrefix(extension, "/v") {
+		return defaultAPIVersion, extension
+	}
+	rest := extension[2:]
+	end := strings.IndexByte(rest, '/')
+	if end == -1 {
+		end = len(rest)
+	}
+	versionStr := rest[:end]
+	if versionStr == "" || versionStr[0] == '0' {
+		return defaultAPIVersion, extension
+	}
+	version, err := strconv.ParseUint(versionStr, 10, 32)
+	if err != nil || version <= defaultAPIVersion {
+		return defaultAPIVersion, extension
+	}
+	return version, rest[end:]
+}
+
+// versionNegotiator serves requests to an unversioned endpoint with the
+// version of the API that the client asked for, so that clients can move to a
+// new version without changing the URLs they use. Clients that don't ask for a
+// version are served version 1.
+type versionNegotiator struct {
+	versions  map[uint64]http.Handler
+	supported string
+}
+
+func newVersionNegotiator(versions map[uint64]http.Handler) http.Handler {
+	versionNumbers := make([]uint64, 0, len(versions))
+	for version := range versions {
+		versionNumbers = append(versionNumbers, version)
+	}
+	sort.Slice(versionNumbers, func(i, j int) bool { return versionNumbers[i] < versionNumbers[j] })
+	versionStrs := make([]string, len(versionNumbers))
+	for i, version := range versionNumbers {
+		versionStrs[i] = strconv.FormatUint(version, 10)
+	}
+	return &versionNegotiator{
+		versions:  versions,
+		supported: strings.Join(versionStrs, ", "),
+	}
+}
+
+func (n *versionNegotiator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	version := uint64(defaultAPIVersion)
+	if requested := r.Header.Get(APIVersionHeader); requested != "" {
+		var err error
+		version, err = strconv.ParseUint(requested, 10, 32)
+		if err != nil {
+			version = 0
+		}
+	}
+	handler, ok := n.versions[version]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "unsupported API version %q, supported versions are %s", r.Header.Get(APIVersionHeader), n.supported)
+		return
+	}
+	w.Header().Set(APIVersionHeader, strconv.FormatUint(version, 10))
+	handler.ServeHTTP(w, r)
+}
+
+// Deprecation middleware wraps a handler that will be removed at [sunset].
+// Until then, responses carry the Deprecation and Sunset headers, so that
+// clients can notice before the handler is removed. After then, requests are
+// rejected.
+func deprecationMiddleware(handler http.Handler, sunset time.Time) http.Handler {
+	if sunset.IsZero() {
+		return handler
+	}
+	sunsetStr := sunset.UTC().Format(http.TimeFormat)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", sunsetStr)
+		if !time.Now().Before(sunset) {
+			w.WriteHeader(http.StatusGone)
+			_, _ = fmt.Fprintf(w, "API call rejected because this version of the API was removed at %s", sunsetStr)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitVersion(t *testing.T) {
+	tests := []struct {
+		extension     string
+		version       uint64
+		baseExtension string
+	}{
+		{extension: "", version: 1, baseExtension: ""},
+		{extension: "/wallet", version: 1, baseExtension: "/wallet"},
+		{extension: "/v2", version: 2, baseExtension: ""},
+		{extension: "/v2/wallet", version: 2, baseExtension: "/wallet"},
+		{extension: "/v10/wallet", version: 10, baseExtension: "/wallet"},
+		{extension: "/v1/wallet", version: 1, baseExtension: "/v1/wallet"},
+		{extension: "/v02", version: 1, baseExtension: "/v02"},
+		{extension: "/vm", version: 1, baseExtension: "/vm"},
+		{extension: "/v", version: 1, baseExtension: "/v"},
+	}
+	for _, test := range tests {
+		t.Run(test.extension, func(t *testing.T) {
+			version, baseExtension := SplitVersion(test.extension)
+			assert.Equal(t, test.version, version)
+			assert.Equal(t, test.baseExtension, baseExtension)
+		})
+	}
+}
+
+func TestVersionNegotiator(t *testing.T) {
+	assert := assert.New(t)
+
+	v1 := &testHandler{}
+	v2 := &testHandler{}
+	negotiator := newVersionNegotiator(map[uint64]http.Handler{
+		1: v1,
+		2: v2,
+	})
+
+	// Clients that don't ask for a version are served version 1
+	w := httptest.NewRecorder()
+	negotiator.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.True(v1.called)
+	assert.False(v2.called)
+	assert.Equal("1", w.Header().Get(APIVersionHeader))
+
+	v1.called = false
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set(APIVersionHeader, "2")
+	negotiator.ServeHTTP(w, r)
+	assert.False(v1.called)
+	assert.True(v2.called)
+	assert.Equal("2", w.Header().Get(APIVersionHeader))
+
+	v2.called = false
+	for _, version := range []string{"3", "two"} {
+		w = httptest.NewRecorder()
+		r = httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set(APIVersionHeader, version)
+		negotiator.ServeHTTP(w, r)
+		assert.Equal(http.StatusBadRequest, w.Code)
+		assert.Contains(w.Body.String(), "supported versions are 1, 2")
+	}
+	assert.False(v1.called)
+	assert.False(v2.called)
+}
+
+func TestDeprecationMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := &testHandler{}
+	assert.Equal(http.Handler(handler), deprecationMiddleware(handler, time.Time{}))
+
+	sunset := time.Now().Add(time.Hour)
+	w := httptest.NewRecorder()
+	deprecationMiddleware(handler, sunset).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.True(handler.called)
+	assert.Equal("true", w.Header().Get("Deprecation"))
+	assert.Equal(sunset.UTC().Format(http.TimeFormat), w.Header().Get("Sunset"))
+
+	// Removed handlers aren't called
+	handler.called = false
+	w = httptest.NewRecorder()
+	deprecationMiddleware(handler, time.Now().Add(-time.Hour)).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.False(handler.called)
+	assert.Equal(http.StatusGone, w.Code)
+}
@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lasthyphen/beacongo/api/metrics"
+	"github.com/lasthyphen/beacongo/chains/atomic"
+	"github.com/lasthyphen/beacongo/database/manager"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/snow/consensus/snowstorm"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/utils/formatting"
+	"github.com/lasthyphen/beacongo/utils/formatting/address"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/units"
+	"github.com/lasthyphen/beacongo/version"
+	"github.com/lasthyphen/beacongo/vms/avm"
+	"github.com/lasthyphen/beacongo/vms/avm/fxs"
+	"github.com/lasthyphen/beacongo/vms/avm/txbuilder"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+const (
+	avmNetworkID = constants.LocalID
+	avmTxFee     = units.MilliDjtx
+	// Amount of every UTXO that the benchmarked txs consume
+	avmUTXOAmount = units.Djtx
+	// Alias of the asset that the benchmarked txs transfer
+	avmAssetAlias = "DJTX"
+)
+
+var (
+	avmChainID = ids.ID{'b', 'e', 'n', 'c', 'h', 'm', 'a', 'r', 'k'}
+
+	errNoGenesisAsset = errors.New("genesis doesn't contain the benchmarked asset")
+)
+
+// benchmarkAVM transfers funds from UTXOs created in the AVM's genesis. Every
+// tx consumes distinct UTXOs, so the txs don't depend on each other.
+func benchmarkAVM(config Config) (*Result, error) {
+	factory := crypto.FactorySECP256K1R{}
+	keyIntf, err := factory.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	key := keyIntf.(*crypto.PrivateKeySECP256K1R)
+	addr := key.PublicKey().Address()
+
+	parser, err := txs.NewParser([]fxs.Fx{&secp256k1fx.Fx{}})
+	if err != nil {
+		return nil, err
+	}
+	genesisBytes, err := buildAVMGenesis(addr, config.Txs*config.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build genesis: %w", err)
+	}
+	assetTx, err := avmGenesisAsset(parser, genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+	assetID := assetTx.ID()
+
+	baseDBManager := manager.NewMemDB(version.DefaultVersion1_0_0)
+	memory := &atomic.Memory{}
+	if err := memory.Initialize(logging.NoLog{}, prefixdb.New([]byte{0}, baseDBManager.Current().Database)); err != nil {
+		return nil, err
+	}
+	ctx := &snow.Context{
+		NetworkID:    avmNetworkID,
+		SubnetID:     constants.PrimaryNetworkID,
+		ChainID:      avmChainID,
+		XChainID:     avmChainID,
+		DJTXAssetID:  assetID,
+		Log:          logging.NoLog{},
+		SharedMemory: memory.NewSharedMemory(avmChainID),
+		BCLookup:     ids.NewAliaser(),
+		Metrics:      metrics.NewOptionalGatherer(),
+	}
+
+	// The lock is held while the VM is used, as it would be by the engine
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	vm := &avm.VM{Factory: avm.Factory{
+		TxFee:            avmTxFee,
+		CreateAssetTxFee: avmTxFee,
+	}}
+	err = vm.Initialize(
+		ctx,
+		baseDBManager.NewPrefixDBManager([]byte{1}),
+		genesisBytes,
+		nil,
+		nil,
+		make(chan common.Message, 1),
+		[]*common.Fx{{
+			ID: secp256k1fx.ID,
+			Fx: &secp256k1fx.Fx{},
+		}},
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize VM: %w", err)
+	}
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			ctx.Log.Warn("failed to shut down the benchmarked VM: %s", err)
+		}
+	}()
+
+	if err := vm.SetState(snow.Bootstrapping); err != nil {
+		return nil, err
+	}
+	if err := vm.SetState(snow.NormalOp); err != nil {
+		return nil, err
+	}
+
+	txBytes, err := buildAVMTxs(config, parser, assetTx.UTXOs(), key)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build txs: %w", err)
+	}
+
+	result := &Result{Config: config}
+	parsedTxs := make([]snowstorm.Tx, len(txBytes))
+	start := time.Now()
+	for i, bytes := range txBytes {
+		parsedTxs[i], err = vm.ParseTx(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse tx: %w", err)
+		}
+	}
+	result.Parse = time.Since(start)
+
+	start = time.Now()
+	for _, tx := range parsedTxs {
+		if err := tx.Verify(); err != nil {
+			return nil, fmt.Errorf("couldn't verify tx %s: %w", tx.ID(), err)
+		}
+	}
+	result.Verify = time.Since(start)
+
+	start = time.Now()
+	for _, tx := range parsedTxs {
+		if err := tx.Accept(); err != nil {
+			return nil, fmt.Errorf("couldn't accept tx %s: %w", tx.ID(), err)
+		}
+	}
+	result.Accept = time.Since(start)
+	return result, nil
+}
+
+// buildAVMGenesis returns a genesis that gives [numUTXOs] UTXOs to [addr]
+func buildAVMGenesis(addr ids.ShortID, numUTXOs int) ([]byte, error) {
+	addrStr, err := address.FormatBech32(constants.GetHRP(avmNetworkID), addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	holders := make([]interface{}, numUTXOs)
+	for i := range holders {
+		holders[i] = avm.Holder{
+			Amount:  json.Uint64(avmUTXOAmount),
+			Address: addrStr,
+		}
+	}
+
+	reply := avm.BuildGenesisReply{}
+	err = avm.CreateStaticService().BuildGenesis(nil, &avm.BuildGenesisArgs{
+		NetworkID: json.Uint32(avmNetworkID),
+		GenesisData: map[string]avm.AssetDefinition{
+			avmAssetAlias: {
+				Name:         avmAssetAlias,
+				Symbol:       avmAssetAlias,
+				Denomination: 9,
+				InitialState: map[string][]interface{}{
+					"fixedCap": holders,
+				},
+			},
+		},
+		Encoding: formatting.Hex,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+	return formatting.Decode(reply.Encoding, reply.Bytes)
+}
+
+// avmGenesisAsset returns the tx in [genesisBytes] that creates the
+// benchmarked asset
+func avmGenesisAsset(parser txs.Parser, genesisBytes []byte) (*txs.Tx, error) {
+	genesis, err := avm.ParseGenesis(parser.GenesisCodec(), genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+	for _, genesisTx := range genesis.Txs {
+		if genesisTx.Alias != avmAssetAlias {
+			continue
+		}
+		tx := &txs.Tx{UnsignedTx: &genesisTx.CreateAssetTx}
+		return tx, parser.InitializeGenesisTx(tx)
+	}
+	return nil, errNoGenesisAsset
+}
+
+// buildAVMTxs returns the bytes of signed txs that each consume
+// [config.Inputs] of [utxos] and produce [config.Outputs] outputs.
+func buildAVMTxs(config Config, parser txs.Parser, utxos []*djtx.UTXO, key *crypto.PrivateKeySECP256K1R) ([][]byte, error) {
+	addr := key.PublicKey().Address()
+	assetID := utxos[0].AssetID()
+	builder := txbuilder.New(txbuilder.Context{
+		NetworkID:        avmNetworkID,
+		BlockchainID:     avmChainID,
+		FeeAssetID:       assetID,
+		TxFee:            avmTxFee,
+		CreateAssetTxFee: avmTxFee,
+	}, parser)
+	keys := secp256k1fx.NewKeychain(key)
+
+	// Every input is needed to pay for the outputs and the fee. Any remainder
+	// is returned as change.
+	outputAmount := (uint64(config.Inputs)*avmUTXOAmount - avmTxFee) / uint64(config.Outputs)
+	txBytes := make([][]byte, config.Txs)
+	for i := range txBytes {
+		outs := make([]*djtx.TransferableOutput, config.Outputs)
+		for j := range outs {
+			outs[j] = &djtx.TransferableOutput{
+				Asset: djtx.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: outputAmount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr},
+					},
+				},
+			}
+		}
+		tx, err := builder.NewBaseTx(txbuilder.Funds{
+			UTXOs:      utxos[i*config.Inputs : (i+1)*config.Inputs],
+			Keys:       keys,
+			ChangeAddr: addr,
+		}, outs, nil)
+		if err != nil {
+			return nil, err
+		}
+		txBytes[i] = tx.Bytes()
+	}
+	return txBytes, nil
+}
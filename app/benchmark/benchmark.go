@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package benchmark measures how quickly an in-memory instance of a VM parses,
+// verifies and accepts synthetic transactions. It backs the node's benchmark
+// subcommand, so that throughput can be compared between releases.
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Command is the name of the subcommand that runs the benchmarks
+const Command = "benchmark"
+
+const (
+	vmKey      = "vm"
+	txsKey     = "txs"
+	inputsKey  = "inputs"
+	outputsKey = "outputs"
+)
+
+var (
+	errNoTxs     = errors.New("at least one tx must be benchmarked")
+	errNoInputs  = errors.New("txs must have at least one input")
+	errNoOutputs = errors.New("txs must have at least one output")
+
+	// Key: name of the VM
+	// Value: runs a benchmark against an in-memory instance of the VM
+	benchmarks = map[string]func(Config) (*Result, error){
+		"avm": benchmarkAVM,
+	}
+)
+
+// Config is a benchmark configuration
+type Config struct {
+	// Name of the VM to benchmark
+	VM string
+	// Number of txs to parse, verify and accept
+	Txs int
+	// Number of inputs that each tx consumes
+	Inputs int
+	// Number of outputs that each tx produces, not including its change
+	Outputs int
+}
+
+func (c Config) String() string {
+	return fmt.Sprintf("vm=%s txs=%d inputs=%d outputs=%d", c.VM, c.Txs, c.Inputs, c.Outputs)
+}
+
+// Result is the time that it took to process the txs of a benchmark
+type Result struct {
+	Config Config
+	Parse  time.Duration
+	Verify time.Duration
+	Accept time.Duration
+}
+
+func (r *Result) String() string {
+	return fmt.Sprintf(
+		"%s parse=%.0f tx/s verify=%.0f tx/s accept=%.0f tx/s",
+		r.Config,
+		txsPerSecond(r.Config.Txs, r.Parse),
+		txsPerSecond(r.Config.Txs, r.Verify),
+		txsPerSecond(r.Config.Txs, r.Accept),
+	)
+}
+
+func txsPerSecond(txs int, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return float64(txs) / duration.Seconds()
+}
+
+// Run parses the benchmark configurations from [args], runs them in order and
+// writes their results to [w].
+func Run(args []string, w io.Writer) error {
+	configs, err := parseConfigs(args)
+	if err != nil {
+		return err
+	}
+	for _, config := range configs {
+		result, err := RunConfig(config)
+		if err != nil {
+			return fmt.Errorf("benchmark %s failed: %w", config, err)
+		}
+		if _, err := fmt.Fprintln(w, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunConfig runs the benchmark described by [config]
+func RunConfig(config Config) (*Result, error) {
+	switch {
+	case config.Txs <= 0:
+		return nil, errNoTxs
+	case config.Inputs <= 0:
+		return nil, errNoInputs
+	case config.Outputs <= 0:
+		return nil, errNoOutputs
+	}
+	benchmark, ok := benchmarks[config.VM]
+	if !ok {
+		return nil, fmt.Errorf("unknown VM %q, expected one of: %s", config.VM, strings.Join(vmNames(), ", "))
+	}
+	return benchmark(config)
+}
+
+// parseConfigs returns a configuration for every combination of the input and
+// output counts in [args]
+func parseConfigs(args []string) ([]Config, error) {
+	fs := pflag.NewFlagSet(Command, pflag.ContinueOnError)
+	vm := fs.String(vmKey, "avm", fmt.Sprintf("VM to benchmark. One of: %s", strings.Join(vmNames(), ", ")))
+	numTxs := fs.Int(txsKey, 10000, "Number of txs to process in each configuration")
+	inputs := fs.IntSlice(inputsKey, []int{1}, "Numbers of inputs per tx to benchmark")
+	outputs := fs.IntSlice(outputsKey, []int{1}, "Numbers of outputs per tx to benchmark")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	configs := make([]Config, 0, len(*inputs)*len(*outputs))
+	for _, numInputs := range *inputs {
+		for _, numOutputs := range *outputs {
+			configs = append(configs, Config{
+				VM:      *vm,
+				Txs:     *numTxs,
+				Inputs:  numInputs,
+				Outputs: numOutputs,
+			})
+		}
+	}
+	return configs, nil
+}
+
+func vmNames() []string {
+	names := make([]string, 0, len(benchmarks))
+	for name := range benchmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
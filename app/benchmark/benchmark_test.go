@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchmark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigs(t *testing.T) {
+	assert := assert.New(t)
+
+	configs, err := parseConfigs([]string{"--txs=5", "--inputs=1,2", "--outputs=3"})
+	assert.NoError(err)
+	assert.Equal([]Config{
+		{VM: "avm", Txs: 5, Inputs: 1, Outputs: 3},
+		{VM: "avm", Txs: 5, Inputs: 2, Outputs: 3},
+	}, configs)
+}
+
+func TestRunConfigAVM(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{VM: "avm", Txs: 10, Inputs: 2, Outputs: 2}
+	result, err := RunConfig(config)
+	assert.NoError(err)
+	assert.Equal(config, result.Config)
+	assert.Positive(result.Parse)
+	assert.Positive(result.Verify)
+	assert.Positive(result.Accept)
+
+	_, err = RunConfig(Config{VM: "unknown", Txs: 1, Inputs: 1, Outputs: 1})
+	assert.Error(err)
+	_, err = RunConfig(Config{VM: "avm", Inputs: 1, Outputs: 1})
+	assert.ErrorIs(err, errNoTxs)
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/perms"
+)
+
+const (
+	// Version of the chain bundle format
+	ChainBundleVersion = 0
+
+	// Names, without extensions, of the files a chain's config and upgrade
+	// bytes are read from in the chain config directory
+	chainConfigFileName  = "config"
+	chainUpgradeFileName = "upgrade"
+)
+
+var (
+	errUnsupportedBundleVersion = errors.New("unsupported chain bundle version")
+	errChainConfigExists        = errors.New("chain config files already exist")
+)
+
+// ChainBundle contains everything a node needs, other than the VM and fx
+// plugins themselves, to run a chain the same way another node runs it.
+type ChainBundle struct {
+	Version  uint16 `json:"version"`
+	ChainID  ids.ID `json:"chainID"`
+	SubnetID ids.ID `json:"subnetID"`
+	VMID     ids.ID `json:"vmID"`
+	// IDs of the fxs the chain runs, in the order they're passed to the VM
+	FxIDs []ids.ID `json:"fxIDs"`
+	// Genesis bytes of the chain
+	Genesis []byte `json:"genesis"`
+	// Chain-specific upgrade bytes. Empty if the chain isn't given any.
+	Upgrade []byte `json:"upgrade"`
+	// Chain-specific config bytes. Empty if the chain isn't given any.
+	Config []byte `json:"config"`
+}
+
+func (m *manager) ChainBundle(chainID ids.ID) (ChainBundle, error) {
+	m.chainsLock.Lock()
+	chainParams, exists := m.chainParams[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return ChainBundle{}, errUnknownChainID
+	}
+
+	vmID, err := m.VMManager.Lookup(chainParams.VMAlias)
+	if err != nil {
+		return ChainBundle{}, fmt.Errorf("couldn't look up VM: %w", err)
+	}
+	fxIDs := make([]ids.ID, len(chainParams.FxAliases))
+	for i, fxAlias := range chainParams.FxAliases {
+		fxIDs[i], err = m.VMManager.Lookup(fxAlias)
+		if err != nil {
+			return ChainBundle{}, fmt.Errorf("couldn't look up fx: %w", err)
+		}
+	}
+	chainConfig, err := m.getChainConfig(chainID)
+	if err != nil {
+		return ChainBundle{}, fmt.Errorf("couldn't get chain config: %w", err)
+	}
+
+	return ChainBundle{
+		Version:  ChainBundleVersion,
+		ChainID:  chainID,
+		SubnetID: chainParams.SubnetID,
+		VMID:     vmID,
+		FxIDs:    fxIDs,
+		Genesis:  chainParams.GenesisData,
+		Upgrade:  chainConfig.Upgrade,
+		Config:   chainConfig.Config,
+	}, nil
+}
+
+// WriteChainConfig writes the config and upgrade bytes of the bundled chain
+// into [chainConfigDir], where they're read from when the node starts. Fails
+// if config or upgrade files already exist for the chain.
+func (b *ChainBundle) WriteChainConfig(chainConfigDir string) error {
+	if b.Version != ChainBundleVersion {
+		return fmt.Errorf("%w: %d", errUnsupportedBundleVersion, b.Version)
+	}
+
+	chainDir := filepath.Join(chainConfigDir, b.ChainID.String())
+	for _, name := range []string{chainConfigFileName, chainUpgradeFileName} {
+		existing, err := filepath.Glob(filepath.Join(chainDir, name+".*"))
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("%w: %s", errChainConfigExists, existing[0])
+		}
+	}
+
+	if len(b.Config) == 0 && len(b.Upgrade) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(chainDir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	if len(b.Config) > 0 {
+		if err := perms.WriteFile(bundleFilePath(chainDir, chainConfigFileName, b.Config), b.Config, perms.ReadWrite); err != nil {
+			return err
+		}
+	}
+	if len(b.Upgrade) > 0 {
+		if err := perms.WriteFile(bundleFilePath(chainDir, chainUpgradeFileName, b.Upgrade), b.Upgrade, perms.ReadWrite); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundleFilePath returns the path that [contents] are written to. As only the
+// file's name, and not its extension, is used to find it, the extension is
+// only a hint of the format of [contents].
+func bundleFilePath(dir, name string, contents []byte) string {
+	if json.Valid(contents) {
+		return filepath.Join(dir, name+".json")
+	}
+	return filepath.Join(dir, name+".bin")
+}
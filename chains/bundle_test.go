@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/storage"
+)
+
+func TestChainBundleWriteChainConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	bundle := ChainBundle{
+		Version: ChainBundleVersion,
+		ChainID: ids.GenerateTestID(),
+		Genesis: []byte{1, 2, 3},
+		Upgrade: []byte{0xff},
+		Config:  []byte(`{"pruning-enabled":true}`),
+	}
+	assert.NoError(bundle.WriteChainConfig(dir))
+
+	chainDir := filepath.Join(dir, bundle.ChainID.String())
+	config, err := storage.ReadFileWithName(chainDir, chainConfigFileName)
+	assert.NoError(err)
+	assert.Equal(bundle.Config, config)
+	upgrade, err := storage.ReadFileWithName(chainDir, chainUpgradeFileName)
+	assert.NoError(err)
+	assert.Equal(bundle.Upgrade, upgrade)
+
+	// Existing configs aren't overwritten
+	assert.ErrorIs(bundle.WriteChainConfig(dir), errChainConfigExists)
+
+	bundle.Version++
+	assert.ErrorIs(bundle.WriteChainConfig(t.TempDir()), errUnsupportedBundleVersion)
+}
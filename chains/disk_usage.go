@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+)
+
+// Measurements of a chain's disk usage that are more recent than this are
+// returned instead of scanning the chain's database again, so that repeated
+// calls don't repeatedly walk large databases.
+const minDiskUsageInterval = time.Minute
+
+// Estimated number of bytes a key/value pair takes in the database, in
+// addition to the key and value themselves
+const diskUsageKVOverhead = 8
+
+// DiskUsage is an estimate of the space a chain's data takes in the database
+type DiskUsage struct {
+	ChainID ids.ID `json:"chainID"`
+	// Estimated number of bytes taken by the chain's keys and values
+	Bytes uint64 `json:"bytes"`
+	// Number of keys stored by the chain
+	Keys uint64 `json:"keys"`
+	// When the chain's database was scanned
+	MeasuredAt time.Time `json:"measuredAt"`
+	// Change in [Bytes] per hour since the previous measurement. Zero if the
+	// chain hasn't been measured before.
+	GrowthRate float64 `json:"growthRate"`
+}
+
+// diskUsageTracker stores the last disk usage measured for each chain, so the
+// rate at which chains grow can be reported.
+type diskUsageTracker struct {
+	// Held while a chain's database is scanned, so concurrent calls don't scan
+	// it more than once
+	lock sync.Mutex
+	// Key: Chain's ID
+	// Value: The last disk usage measured for the chain
+	last map[ids.ID]DiskUsage
+}
+
+func (m *manager) DiskUsage(chainID ids.ID) (DiskUsage, error) {
+	m.chainsLock.Lock()
+	_, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return DiskUsage{}, errUnknownChainID
+	}
+
+	m.diskUsage.lock.Lock()
+	defer m.diskUsage.lock.Unlock()
+
+	now := time.Now()
+	last, measured := m.diskUsage.last[chainID]
+	if measured && now.Sub(last.MeasuredAt) < minDiskUsageInterval {
+		return last, nil
+	}
+
+	usage, err := m.measureDiskUsage(chainID)
+	if err != nil {
+		return DiskUsage{}, err
+	}
+	usage.MeasuredAt = now
+	if measured {
+		elapsed := now.Sub(last.MeasuredAt).Hours()
+		usage.GrowthRate = (float64(usage.Bytes) - float64(last.Bytes)) / elapsed
+	}
+	m.diskUsage.last[chainID] = usage
+	return usage, nil
+}
+
+// measureDiskUsage scans the keys stored under the prefix of [chainID].
+func (m *manager) measureDiskUsage(chainID ids.ID) (DiskUsage, error) {
+	db := prefixdb.New(chainID[:], m.DBManager.Current().Database)
+	it := db.NewIterator()
+	defer it.Release()
+
+	usage := DiskUsage{ChainID: chainID}
+	for it.Next() {
+		usage.Keys++
+		usage.Bytes += uint64(len(it.Key()) + len(it.Value()) + diskUsageKVOverhead)
+	}
+	return usage, it.Error()
+}
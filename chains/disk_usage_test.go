@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/networking/handler"
+	"github.com/lasthyphen/beacongo/version"
+
+	dbManager "github.com/lasthyphen/beacongo/database/manager"
+)
+
+func TestDiskUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	chainID := ids.GenerateTestID()
+	otherChainID := ids.GenerateTestID()
+	m := New(&ManagerConfig{
+		DBManager: dbManager.NewMemDB(version.DefaultVersion1_0_0),
+	}).(*manager)
+
+	_, err := m.DiskUsage(chainID)
+	assert.ErrorIs(err, errUnknownChainID)
+
+	m.chains[chainID] = handler.Handler(nil)
+	db := m.DBManager.Current().Database
+	chainDB := prefixdb.New(chainID[:], db)
+	assert.NoError(chainDB.Put([]byte{1}, []byte{2, 3}))
+	otherChainDB := prefixdb.New(otherChainID[:], db)
+	assert.NoError(otherChainDB.Put([]byte{1}, []byte{2, 3}))
+
+	usage, err := m.DiskUsage(chainID)
+	assert.NoError(err)
+	assert.Equal(chainID, usage.ChainID)
+	assert.EqualValues(1, usage.Keys)
+	assert.EqualValues(3+diskUsageKVOverhead, usage.Bytes)
+	assert.Zero(usage.GrowthRate)
+
+	// Recent measurements are reused
+	assert.NoError(chainDB.Put([]byte{4}, []byte{5, 6, 7}))
+	cached, err := m.DiskUsage(chainID)
+	assert.NoError(err)
+	assert.Equal(usage, cached)
+
+	// The growth rate is relative to the previous measurement
+	usage.MeasuredAt = usage.MeasuredAt.Add(-time.Hour)
+	m.diskUsage.last[chainID] = usage
+	grown, err := m.DiskUsage(chainID)
+	assert.NoError(err)
+	assert.EqualValues(2, grown.Keys)
+	assert.EqualValues(3+4+2*diskUsageKVOverhead, grown.Bytes)
+	assert.InDelta(4+diskUsageKVOverhead, grown.GrowthRate, 0.1)
+}
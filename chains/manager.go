@@ -18,6 +18,7 @@ import (
 	"github.com/lasthyphen/beacongo/api/metrics"
 	"github.com/lasthyphen/beacongo/api/server"
 	"github.com/lasthyphen/beacongo/chains/atomic"
+	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/database/prefixdb"
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/message"
@@ -30,6 +31,7 @@ import (
 	"github.com/lasthyphen/beacongo/snow/engine/common/queue"
 	"github.com/lasthyphen/beacongo/snow/engine/common/tracker"
 	"github.com/lasthyphen/beacongo/snow/engine/snowman/block"
+	"github.com/lasthyphen/beacongo/snow/engine/snowman/replica"
 	"github.com/lasthyphen/beacongo/snow/engine/snowman/syncer"
 	"github.com/lasthyphen/beacongo/snow/networking/handler"
 	"github.com/lasthyphen/beacongo/snow/networking/router"
@@ -37,13 +39,16 @@ import (
 	"github.com/lasthyphen/beacongo/snow/networking/timeout"
 	"github.com/lasthyphen/beacongo/snow/validators"
 	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/utils/eventbus"
 	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/overload"
 	"github.com/lasthyphen/beacongo/version"
 	"github.com/lasthyphen/beacongo/vms"
 	"github.com/lasthyphen/beacongo/vms/metervm"
 	"github.com/lasthyphen/beacongo/vms/proposervm"
 
 	dbManager "github.com/lasthyphen/beacongo/database/manager"
+	replicationpb "github.com/lasthyphen/beacongo/proto/pb/replication"
 	timetracker "github.com/lasthyphen/beacongo/snow/networking/tracker"
 
 	avcon "github.com/lasthyphen/beacongo/snow/consensus/avalanche"
@@ -64,6 +69,8 @@ var (
 	errUnknownVMType    = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
 	errCreatePlatformVM = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped  = errors.New("chains not bootstrapped")
+	errNoStateDigest    = errors.New("chain's VM doesn't support state digests")
+	errCriticalChain    = errors.New("critical chains can't be put into maintenance mode")
 
 	_ Manager = &manager{}
 )
@@ -102,6 +109,26 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns a deterministic digest of the committed state of the chain with
+	// the given ID, if the chain's VM supports it
+	StateDigest(chainID ids.ID) (ids.ID, error)
+
+	// Puts the chain with the given ID into, or takes it out of, maintenance
+	// mode. While in maintenance mode, the chain doesn't participate in
+	// consensus and rejects API calls that write to it.
+	SetMaintenance(chainID ids.ID, enabled bool) error
+
+	// Returns true iff the chain with the given ID is in maintenance mode
+	InMaintenance(chainID ids.ID) (bool, error)
+
+	// Returns the genesis, config and VM of the chain with the given ID, so
+	// that another node can run the chain the same way
+	ChainBundle(chainID ids.ID) (ChainBundle, error)
+
+	// Returns an estimate of the space the data of the chain with the given ID
+	// takes in the database, and how fast it's growing
+	DiskUsage(chainID ids.ID) (DiskUsage, error)
+
 	Shutdown()
 }
 
@@ -126,6 +153,8 @@ type chain struct {
 	Engine  common.Engine
 	Handler handler.Handler
 	Beacons validators.Set
+	// The chain's VM, if it supports state digests
+	StateDigester common.StateDigester
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -182,6 +211,14 @@ type ManagerConfig struct {
 	// This node will only consider the first [AncestorsMaxContainersReceived]
 	// containers in an ancestors message it receives.
 	BootstrapAncestorsMaxContainersReceived int
+	// Max number of recently sent ancestors messages each chain caches.
+	BootstrapAncestorsCacheSize int
+	// Limits the upload bandwidth used to serve Get and GetAncestors requests
+	// across all chains.
+	BootstrapServingBandwidth tracker.Bandwidth
+	// Reports whether the node is overloaded, in which case periodic gossip is
+	// skipped. If nil, gossip is never skipped.
+	Overload overload.Controller
 
 	ApricotPhase4Time            time.Time
 	ApricotPhase4MinPChainHeight uint64
@@ -191,6 +228,18 @@ type ManagerConfig struct {
 
 	StateSyncBeacons         []ids.NodeID
 	StateSyncDisableRequests bool
+
+	// Receives the events of the chains, such as a chain finishing
+	// bootstrapping. If nil, events aren't published.
+	EventBus eventbus.Publisher
+
+	// Max number of chains that are built at the same time once the P-chain
+	// has bootstrapped. If <= 1, chains are built one at a time.
+	ChainCreationConcurrency int
+
+	// If non-nil, Snowman chains apply the blocks streamed by this client
+	// from the primary node instead of running consensus
+	ReplicationClient replicationpb.ReplicationClient
 }
 
 type manager struct {
@@ -213,6 +262,14 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The chain's VM, if it supports state digests
+	stateDigesters map[ids.ID]common.StateDigester
+	// Key: Chain's ID
+	// Value: The parameters the chain was created with
+	chainParams map[ids.ID]ChainParameters
+
+	diskUsage diskUsageTracker
 
 	// snowman++ related interface to allow validators retrival
 	validatorState validators.State
@@ -221,10 +278,15 @@ type manager struct {
 // New returns a new Manager
 func New(config *ManagerConfig) Manager {
 	return &manager{
-		Aliaser:       ids.NewAliaser(),
-		ManagerConfig: *config,
-		subnets:       make(map[ids.ID]Subnet),
-		chains:        make(map[ids.ID]handler.Handler),
+		Aliaser:        ids.NewAliaser(),
+		ManagerConfig:  *config,
+		subnets:        make(map[ids.ID]Subnet),
+		chains:         make(map[ids.ID]handler.Handler),
+		stateDigesters: make(map[ids.ID]common.StateDigester),
+		chainParams:    make(map[ids.ID]ChainParameters),
+		diskUsage: diskUsageTracker{
+			last: make(map[ids.ID]DiskUsage),
+		},
 	}
 }
 
@@ -243,6 +305,23 @@ func (m *manager) CreateChain(chain ChainParameters) {
 // Create a chain, this is only called from the P-chain thread, except for
 // creating the P-chain.
 func (m *manager) ForceCreateChain(chainParams ChainParameters) {
+	sb, ok := m.prepareChain(chainParams)
+	if !ok {
+		return
+	}
+
+	// Note: buildChain builds all chain's relevant objects (notably engine and handler)
+	// but does not start their operations. Starting of the handler (which could potentially
+	// issue some internal messages), is delayed until chain dispatching is started and
+	// the chain is registered in the manager. This ensures that no message generated by handler
+	// upon start is dropped.
+	chain, err := m.buildChain(chainParams, sb)
+	m.registerChain(chainParams, sb, chain, err)
+}
+
+// prepareChain returns the subnet that [chainParams] should be built in, after
+// adding the chain to it. Returns false if the chain shouldn't be created.
+func (m *manager) prepareChain(chainParams ChainParameters) (Subnet, bool) {
 	if m.StakingEnabled && chainParams.SubnetID != constants.PrimaryNetworkID && !m.WhitelistedSubnets.Contains(chainParams.SubnetID) {
 		m.Log.Debug("Skipped creating non-whitelisted chain:\n"+
 			"    ID: %s\n"+
@@ -250,7 +329,7 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 			chainParams.ID,
 			chainParams.VMAlias,
 		)
-		return
+		return nil, false
 	}
 	// Assert that there isn't already a chain with an alias in [chain].Aliases
 	// (Recall that the string representation of a chain's ID is also an alias
@@ -258,7 +337,7 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 	if alias, isRepeat := m.isChainWithAlias(chainParams.ID.String()); isRepeat {
 		m.Log.Debug("there is already a chain with alias '%s'. Chain not created.",
 			alias)
-		return
+		return nil, false
 	}
 	m.Log.Info("creating chain:\n"+
 		"    ID: %s\n"+
@@ -269,18 +348,17 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 
 	sb, exists := m.subnets[chainParams.SubnetID]
 	if !exists {
-		sb = newSubnet()
+		sb = newSubnet(m.chainBootstrapped)
 		m.subnets[chainParams.SubnetID] = sb
 	}
 
 	sb.addChain(chainParams.ID)
+	return sb, true
+}
 
-	// Note: buildChain builds all chain's relevant objects (notably engine and handler)
-	// but does not start their operations. Starting of the handler (which could potentially
-	// issue some internal messages), is delayed until chain dispatching is started and
-	// the chain is registered in the manager. This ensures that no message generated by handler
-	// upon start is dropped.
-	chain, err := m.buildChain(chainParams, sb)
+// registerChain tracks [chain], which was built by buildChain with the
+// returned error [err], and starts its handler.
+func (m *manager) registerChain(chainParams ChainParameters, sb Subnet, chain *chain, err error) {
 	if err != nil {
 		sb.removeChain(chainParams.ID)
 		if m.CriticalChains.Contains(chainParams.ID) {
@@ -295,6 +373,10 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	m.chainParams[chainParams.ID] = chainParams
+	if chain.StateDigester != nil {
+		m.stateDigesters[chainParams.ID] = chain.StateDigester
+	}
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -488,6 +570,12 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		return nil, errUnknownVMType
 	}
 
+	// The digest is taken from the VM itself rather than from the wrappers
+	// added around it, which don't forward optional interfaces.
+	if digester, ok := vm.(common.StateDigester); ok {
+		chain.StateDigester = digester
+	}
+
 	// Register the chain with the timeout manager
 	if err := m.TimeoutManager.RegisterChain(ctx); err != nil {
 		return nil, err
@@ -498,12 +586,63 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 
 func (m *manager) AddRegistrant(r Registrant) { m.registrants = append(m.registrants, r) }
 
+// unblockChains creates the chains that were blocked on the P-chain
+// bootstrapping. Up to [ChainCreationConcurrency] chains are built at the same
+// time, but they're registered, and their handlers started, in the order they
+// were blocked in.
 func (m *manager) unblockChains() {
 	m.unblocked = true
 	blocked := m.blockedChains
 	m.blockedChains = nil
+
+	type result struct {
+		params ChainParameters
+		sb     Subnet
+		chain  *chain
+		err    error
+	}
+	results := make([]*result, 0, len(blocked))
+	prepared := ids.Set{}
 	for _, chainParams := range blocked {
-		m.ForceCreateChain(chainParams)
+		// The chains aren't aliased until they're registered, so repeated
+		// chains are skipped here rather than by prepareChain.
+		if prepared.Contains(chainParams.ID) {
+			m.Log.Debug("there is already a chain with alias '%s'. Chain not created.",
+				chainParams.ID)
+			continue
+		}
+		sb, ok := m.prepareChain(chainParams)
+		if !ok {
+			continue
+		}
+		prepared.Add(chainParams.ID)
+		results = append(results, &result{
+			params: chainParams,
+			sb:     sb,
+		})
+	}
+
+	concurrency := m.ChainCreationConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for _, r := range results {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(r *result) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r.chain, r.err = m.buildChain(r.params, r.sb)
+		}(r)
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		m.registerChain(r.params, r.sb, r.chain, r.err)
 	}
 }
 
@@ -620,6 +759,9 @@ func (m *manager) createAvalancheChain(
 	if err != nil {
 		return nil, fmt.Errorf("error initializing network handler: %w", err)
 	}
+	if m.Overload != nil {
+		handler.SetSkipGossip(m.Overload.Overloaded)
+	}
 
 	connectedPeers := tracker.NewPeers()
 	startupTracker := tracker.NewStartup(connectedPeers, (3*bootstrapWeight+3)/4)
@@ -640,6 +782,8 @@ func (m *manager) createAvalancheChain(
 		MaxTimeGetAncestors:            m.BootstrapMaxTimeGetAncestors,
 		AncestorsMaxContainersSent:     m.BootstrapAncestorsMaxContainersSent,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
+		AncestorsCacheSize:             m.BootstrapAncestorsCacheSize,
+		ServingBandwidth:               m.BootstrapServingBandwidth,
 		SharedCfg:                      &common.SharedConfig{},
 	}
 
@@ -684,6 +828,7 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("error initializing avalanche engine: %w", err)
 	}
 	handler.SetConsensus(engine)
+	m.setIdleGossipPacing(handler, engineConfig.Consensus.NumProcessing)
 
 	// Register health check for this chain
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -819,6 +964,9 @@ func (m *manager) createSnowmanChain(
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize message handler: %w", err)
 	}
+	if m.Overload != nil {
+		handler.SetSkipGossip(m.Overload.Overloaded)
+	}
 
 	connectedPeers := tracker.NewPeers()
 	startupTracker := tracker.NewStartup(connectedPeers, (3*bootstrapWeight+3)/4)
@@ -839,6 +987,8 @@ func (m *manager) createSnowmanChain(
 		MaxTimeGetAncestors:            m.BootstrapMaxTimeGetAncestors,
 		AncestorsMaxContainersSent:     m.BootstrapAncestorsMaxContainersSent,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
+		AncestorsCacheSize:             m.BootstrapAncestorsCacheSize,
+		ServingBandwidth:               m.BootstrapServingBandwidth,
 		SharedCfg:                      &common.SharedConfig{},
 	}
 
@@ -847,6 +997,10 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("couldn't initialize snow base message handler: %w", err)
 	}
 
+	if m.ReplicationClient != nil {
+		return m.createSnowmanReplica(ctx, vm, sb, handler, snowGetHandler, db.Database)
+	}
+
 	// Create engine, bootstrapper and state-syncer in this order,
 	// to make sure start callbacks are duly initialized
 	engineConfig := smeng.Config{
@@ -863,6 +1017,7 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
 	handler.SetConsensus(engine)
+	m.setIdleGossipPacing(handler, engineConfig.Consensus.NumProcessing)
 
 	// create bootstrap gear
 	bootstrapCfg := smbootstrap.Config{
@@ -911,6 +1066,45 @@ func (m *manager) createSnowmanChain(
 	}, nil
 }
 
+// Create a linear chain that applies the blocks accepted by the primary node
+// rather than running consensus
+func (m *manager) createSnowmanReplica(
+	ctx *snow.ConsensusContext,
+	vm block.ChainVM,
+	sb Subnet,
+	handler handler.Handler,
+	snowGetHandler common.AllGetsServer,
+	db database.Database,
+) (*chain, error) {
+	engine, err := replica.New(replica.Config{
+		AllGetsServer: snowGetHandler,
+		Ctx:           ctx,
+		VM:            vm,
+		Subnet:        sb,
+		Client:        m.ReplicationClient,
+		DB:            prefixdb.New([]byte("replica"), db),
+		Bootstrapped:  m.unblockChains,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error initializing replica engine: %w", err)
+	}
+	// The replica starts in normal operation, so it's both the bootstrapper
+	// and the consensus engine
+	handler.SetBootstrapper(engine)
+	handler.SetConsensus(engine)
+
+	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
+	if err := m.Health.RegisterHealthCheck(chainAlias, handler); err != nil {
+		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
+	}
+
+	return &chain{
+		Name:    chainAlias,
+		Engine:  engine,
+		Handler: handler,
+	}, nil
+}
+
 func (m *manager) SubnetID(chainID ids.ID) (ids.ID, error) {
 	m.chainsLock.Lock()
 	defer m.chainsLock.Unlock()
@@ -969,15 +1163,110 @@ func (m *manager) registerBootstrappedHealthChecks() error {
 	return nil
 }
 
+func (m *manager) StateDigest(chainID ids.ID) (ids.ID, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	digester, supported := m.stateDigesters[chainID]
+	m.chainsLock.Unlock()
+
+	switch {
+	case !exists:
+		return ids.ID{}, errUnknownChainID
+	case !supported:
+		return ids.ID{}, errNoStateDigest
+	}
+
+	ctx := chain.Context()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	return digester.StateDigest()
+}
+
+func (m *manager) SetMaintenance(chainID ids.ID, enabled bool) error {
+	if enabled && m.CriticalChains.Contains(chainID) {
+		return errCriticalChain
+	}
+
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return errUnknownChainID
+	}
+
+	ctx := chain.Context()
+	if ctx.InMaintenance() != enabled {
+		if enabled {
+			m.Log.Info("putting chain %s into maintenance mode", m.PrimaryAliasOrDefault(chainID))
+		} else {
+			m.Log.Info("taking chain %s out of maintenance mode", m.PrimaryAliasOrDefault(chainID))
+		}
+	}
+	ctx.SetMaintenance(enabled)
+	return nil
+}
+
+func (m *manager) InMaintenance(chainID ids.ID) (bool, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return false, errUnknownChainID
+	}
+	return chain.Context().InMaintenance(), nil
+}
+
+// logStateDigests logs the state digest of every chain that supports them, so
+// that the final states of nodes can be compared after they shut down.
+func (m *manager) logStateDigests() {
+	m.chainsLock.Lock()
+	chainIDs := make([]ids.ID, 0, len(m.stateDigesters))
+	for chainID := range m.stateDigesters {
+		chainIDs = append(chainIDs, chainID)
+	}
+	m.chainsLock.Unlock()
+
+	for _, chainID := range chainIDs {
+		digest, err := m.StateDigest(chainID)
+		if err != nil {
+			m.Log.Warn("couldn't compute state digest of chain %s: %s", chainID, err)
+			continue
+		}
+		m.Log.Info("state digest of chain %s at shutdown: %s", m.PrimaryAliasOrDefault(chainID), digest)
+	}
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
+	m.logStateDigests()
 	m.ManagerConfig.Router.Shutdown()
 }
 
 // LookupVM returns the ID of the VM associated with an alias
 func (m *manager) LookupVM(alias string) (ids.ID, error) { return m.VMManager.Lookup(alias) }
 
+// chainBootstrapped publishes that the chain [chainID] finished bootstrapping
+func (m *manager) chainBootstrapped(chainID ids.ID) {
+	if m.EventBus != nil {
+		m.EventBus.Publish(eventbus.ChainBootstrapped{ChainID: chainID})
+	}
+}
+
+// setIdleGossipPacing slows down the periodic gossip of [h] while its chain
+// has no processing containers, if its subnet is configured to.
+func (m *manager) setIdleGossipPacing(h handler.Handler, numProcessing func() int) {
+	subnetID := h.Context().SubnetID
+	sbConfig, ok := m.SubnetConfigs[subnetID]
+	if !ok || subnetID == constants.PrimaryNetworkID || sbConfig.MaxIdleGossipFrequency <= m.ConsensusGossipFrequency {
+		return
+	}
+	h.SetIdleGossipPacing(sbConfig.MaxIdleGossipFrequency, func() bool {
+		return numProcessing() == 0
+	})
+}
+
 // Notify registrants [those who want to know about the creation of chains]
 // that the specified chain has been created
 func (m *manager) notifyRegistrants(name string, engine common.Engine) {
@@ -14,18 +14,23 @@ var _ Manager = MockManager{}
 // To be used only in tests
 type MockManager struct{}
 
-func (mm MockManager) Router() router.Router               { return nil }
-func (mm MockManager) CreateChain(ChainParameters)         {}
-func (mm MockManager) ForceCreateChain(ChainParameters)    {}
-func (mm MockManager) AddRegistrant(Registrant)            {}
-func (mm MockManager) Aliases(ids.ID) ([]string, error)    { return nil, nil }
-func (mm MockManager) PrimaryAlias(ids.ID) (string, error) { return "", nil }
-func (mm MockManager) PrimaryAliasOrDefault(ids.ID) string { return "" }
-func (mm MockManager) Alias(ids.ID, string) error          { return nil }
-func (mm MockManager) RemoveAliases(ids.ID)                {}
-func (mm MockManager) Shutdown()                           {}
-func (mm MockManager) SubnetID(ids.ID) (ids.ID, error)     { return ids.ID{}, nil }
-func (mm MockManager) IsBootstrapped(ids.ID) bool          { return false }
+func (mm MockManager) Router() router.Router                   { return nil }
+func (mm MockManager) CreateChain(ChainParameters)             {}
+func (mm MockManager) ForceCreateChain(ChainParameters)        {}
+func (mm MockManager) AddRegistrant(Registrant)                {}
+func (mm MockManager) Aliases(ids.ID) ([]string, error)        { return nil, nil }
+func (mm MockManager) PrimaryAlias(ids.ID) (string, error)     { return "", nil }
+func (mm MockManager) PrimaryAliasOrDefault(ids.ID) string     { return "" }
+func (mm MockManager) Alias(ids.ID, string) error              { return nil }
+func (mm MockManager) RemoveAliases(ids.ID)                    {}
+func (mm MockManager) Shutdown()                               {}
+func (mm MockManager) SubnetID(ids.ID) (ids.ID, error)         { return ids.ID{}, nil }
+func (mm MockManager) IsBootstrapped(ids.ID) bool              { return false }
+func (mm MockManager) StateDigest(ids.ID) (ids.ID, error)      { return ids.ID{}, nil }
+func (mm MockManager) SetMaintenance(ids.ID, bool) error       { return nil }
+func (mm MockManager) InMaintenance(ids.ID) (bool, error)      { return false, nil }
+func (mm MockManager) ChainBundle(ids.ID) (ChainBundle, error) { return ChainBundle{}, nil }
+func (mm MockManager) DiskUsage(ids.ID) (DiskUsage, error)     { return DiskUsage{}, nil }
 
 func (mm MockManager) Lookup(s string) (ids.ID, error) {
 	id, err := ids.FromString(s)
@@ -5,6 +5,7 @@ package chains
 
 import (
 	"sync"
+	"time"
 
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/snow/consensus/avalanche"
@@ -32,6 +33,12 @@ type SubnetConfig struct {
 	// ValidatorOnly indicates that this Subnet's Chains are available to only subnet validators.
 	ValidatorOnly       bool                 `json:"validatorOnly"`
 	ConsensusParameters avalanche.Parameters `json:"consensusParameters"`
+
+	// If greater than the consensus gossip frequency, the chains of this
+	// Subnet gossip less often while they have no processing containers, up to
+	// once every MaxIdleGossipFrequency. Useful for Subnets with sporadic
+	// traffic.
+	MaxIdleGossipFrequency time.Duration `json:"maxIdleGossipFrequency"`
 }
 
 type subnet struct {
@@ -39,11 +46,15 @@ type subnet struct {
 	bootstrapping    ids.Set
 	once             sync.Once
 	bootstrappedSema chan struct{}
+
+	// Called when a chain of the subnet finishes bootstrapping. May be nil.
+	onBootstrapped func(chainID ids.ID)
 }
 
-func newSubnet() Subnet {
+func newSubnet(onBootstrapped func(chainID ids.ID)) Subnet {
 	return &subnet{
 		bootstrappedSema: make(chan struct{}),
+		onBootstrapped:   onBootstrapped,
 	}
 }
 
@@ -55,17 +66,29 @@ func (s *subnet) IsBootstrapped() bool {
 }
 
 func (s *subnet) Bootstrapped(chainID ids.ID) {
+	// Bootstrapped may be called again if the chain restarts bootstrapping
+	// while the rest of the subnet catches up, so the chain is only reported
+	// the first time.
+	if s.markBootstrapped(chainID) && s.onBootstrapped != nil {
+		s.onBootstrapped(chainID)
+	}
+}
+
+// markBootstrapped returns true if [chainID] was bootstrapping
+func (s *subnet) markBootstrapped(chainID ids.ID) bool {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	wasBootstrapping := s.bootstrapping.Contains(chainID)
 	s.bootstrapping.Remove(chainID)
 	if s.bootstrapping.Len() > 0 {
-		return
+		return wasBootstrapping
 	}
 
 	s.once.Do(func() {
 		close(s.bootstrappedSema)
 	})
+	return wasBootstrapping
 }
 
 func (s *subnet) afterBootstrapped() chan struct{} {
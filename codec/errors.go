@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import "errors"
+
+// UnmarshalError is returned when bytes couldn't be unmarshalled. It records
+// where in the bytes unmarshalling stopped.
+type UnmarshalError struct {
+	// Index of the byte at which unmarshalling failed
+	Offset int
+	Err    error
+}
+
+func (e *UnmarshalError) Error() string { return e.Err.Error() }
+
+func (e *UnmarshalError) Unwrap() error { return e.Err }
+
+// ErrorOffset returns the index of the byte at which unmarshalling failed with
+// [err]. Returns false if [err] doesn't record where unmarshalling failed.
+func ErrorOffset(err error) (int, bool) {
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		return 0, false
+	}
+	return unmarshalErr.Offset, true
+}
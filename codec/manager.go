@@ -134,13 +134,20 @@ func (m *manager) Unmarshal(bytes []byte, dest interface{}) (uint16, error) {
 	version := p.UnpackShort()
 	if p.Errored() { // Make sure the codec version is correct
 		m.lock.RUnlock()
-		return 0, errCantUnpackVersion
+		return 0, &UnmarshalError{Err: errCantUnpackVersion}
 	}
 
 	c, exists := m.codecs[version]
 	m.lock.RUnlock()
 	if !exists {
-		return version, errUnknownVersion
+		return version, &UnmarshalError{Err: errUnknownVersion}
 	}
-	return version, c.Unmarshal(p.Bytes[p.Offset:], dest)
+	err := c.Unmarshal(p.Bytes[p.Offset:], dest)
+	// Report the offset of the failure from the start of [bytes], which
+	// includes the codec version
+	var unmarshalErr *UnmarshalError
+	if errors.As(err, &unmarshalErr) {
+		unmarshalErr.Offset += p.Offset
+	}
+	return version, err
 }
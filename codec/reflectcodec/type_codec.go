@@ -205,10 +205,16 @@ func (c *genericCodec) Unmarshal(bytes []byte, dest interface{}) error {
 		return errNeedPointer
 	}
 	if err := c.unmarshal(&p, destPtr.Elem(), c.maxSliceLen); err != nil {
-		return err
+		return &codec.UnmarshalError{
+			Offset: p.Offset,
+			Err:    err,
+		}
 	}
 	if p.Offset != len(bytes) {
-		return errExtraSpace
+		return &codec.UnmarshalError{
+			Offset: p.Offset,
+			Err:    errExtraSpace,
+		}
 	}
 	return nil
 }
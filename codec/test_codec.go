@@ -976,6 +976,9 @@ func TestExtraSpace(codec GeneralCodec, t testing.TB) {
 	if err == nil {
 		t.Fatalf("Should have errored due to too many bytes being passed in")
 	}
+	if offset, ok := ErrorOffset(err); !ok || offset != 3 {
+		t.Fatalf("Should have reported the extra data at offset 3 but got (%d, %t)", offset, ok)
+	}
 }
 
 // Ensure deserializing slices that have been length restricted errors correctly
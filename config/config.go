@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/lasthyphen/beacongo/api/apikeys"
 	"github.com/lasthyphen/beacongo/app/runner"
 	"github.com/lasthyphen/beacongo/chains"
 	"github.com/lasthyphen/beacongo/genesis"
@@ -36,9 +38,11 @@ import (
 	"github.com/lasthyphen/beacongo/snow/networking/tracker"
 	"github.com/lasthyphen/beacongo/staking"
 	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/utils/crash"
 	"github.com/lasthyphen/beacongo/utils/dynamicip"
 	"github.com/lasthyphen/beacongo/utils/ips"
 	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/overload"
 	"github.com/lasthyphen/beacongo/utils/password"
 	"github.com/lasthyphen/beacongo/utils/profiler"
 	"github.com/lasthyphen/beacongo/utils/storage"
@@ -67,6 +71,7 @@ var (
 	errStakeMaxConsumptionBelowMin   = errors.New("stake max consumption can't be less than min stake consumption")
 	errStakeMintingPeriodBelowMin    = errors.New("stake minting period can't be less than max stake duration")
 	errCannotWhitelistPrimaryNetwork = errors.New("cannot whitelist primary network")
+	errInvalidWebhookURL             = errors.New("webhook URL must use http or https")
 	errStakingKeyContentUnset        = fmt.Errorf("%s key not set but %s set", StakingKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset       = fmt.Errorf("%s key set but %s not set", StakingKeyContentKey, StakingCertContentKey)
 )
@@ -186,6 +191,18 @@ func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
 	return config, nil
 }
 
+func getAPIKeysConfig(v *viper.Viper) (apikeys.Config, error) {
+	config := apikeys.Config{
+		Enabled:   v.GetBool(APIKeysEnabledKey),
+		Required:  v.GetBool(APIKeysRequiredKey),
+		TxMethods: v.GetStringSlice(APIKeysTxMethodsKey),
+	}
+	if config.Required && !config.Enabled {
+		return apikeys.Config{}, fmt.Errorf("%q requires %q", APIKeysRequiredKey, APIKeysEnabledKey)
+	}
+	return config, nil
+}
+
 func getIPCConfig(v *viper.Viper) node.IPCConfig {
 	config := node.IPCConfig{
 		IPCAPIEnabled: v.GetBool(IpcAPIEnabledKey),
@@ -237,14 +254,18 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	config := node.HTTPConfig{
 		APIConfig: node.APIConfig{
 			APIIndexerConfig: node.APIIndexerConfig{
-				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
-				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexAPIEnabled:       v.GetBool(IndexEnabledKey),
+				IndexAllowIncomplete:  v.GetBool(IndexAllowIncompleteKey),
+				IndexReplicationToken: v.GetString(IndexReplicationTokenKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
+			InfoAPIGeoIPDBPath: GetExpandedArg(v, InfoAPIGeoIPDBKey),
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+
+			KeystoreDeletedUserRetention: v.GetDuration(KeystoreDeletedUserRetentionKey),
 		},
 		HTTPHost:          v.GetString(HTTPHostKey),
 		HTTPPort:          uint16(v.GetUint(HTTPPortKey)),
@@ -257,10 +278,22 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		ShutdownWait:    v.GetDuration(HTTPShutdownWaitKey),
 	}
 
+	if config.KeystoreDeletedUserRetention < 0 {
+		return node.HTTPConfig{}, fmt.Errorf("%q must be >= 0", KeystoreDeletedUserRetentionKey)
+	}
+	// The replication API is served over gRPC, which requires HTTP/2
+	if config.IndexReplicationToken != "" && (!config.IndexAPIEnabled || !config.HTTPSEnabled) {
+		return node.HTTPConfig{}, fmt.Errorf("%q requires %q and %q", IndexReplicationTokenKey, IndexEnabledKey, HTTPSEnabledKey)
+	}
+
 	config.APIAuthConfig, err = getAPIAuthConfig(v)
 	if err != nil {
 		return node.HTTPConfig{}, err
 	}
+	config.APIKeysConfig, err = getAPIKeysConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
 	config.IPCConfig = getIPCConfig(v)
 	return config, nil
 }
@@ -406,6 +439,7 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+		PeerLatencyEnabled:        v.GetBool(NetworkPeerLatencyEnabledKey),
 	}
 
 	switch {
@@ -505,6 +539,12 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		BootstrapMaxTimeGetAncestors:            v.GetDuration(BootstrapMaxTimeGetAncestorsKey),
 		BootstrapAncestorsMaxContainersSent:     int(v.GetUint(BootstrapAncestorsMaxContainersSentKey)),
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
+		BootstrapAncestorsCacheSize:             int(v.GetUint(BootstrapAncestorsCacheSizeKey)),
+		BootstrapServingMaxBandwidth:            v.GetUint64(BootstrapServingMaxBandwidthKey),
+		BootstrapServingMaxBurstSize:            v.GetUint64(BootstrapServingMaxBurstSizeKey),
+	}
+	if config.BootstrapServingMaxBurstSize < uint64(constants.MaxContainersLen) {
+		return node.BootstrapConfig{}, fmt.Errorf("%q must be at least %d", BootstrapServingMaxBurstSizeKey, constants.MaxContainersLen)
 	}
 
 	ipsSet := v.IsSet(BootstrapIPsKey)
@@ -598,6 +638,23 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 	return config, nil
 }
 
+func getCrashReportConfig(v *viper.Viper) (crash.Config, error) {
+	config := crash.Config{
+		Enabled:      v.GetBool(CrashReportEnabledKey),
+		Directory:    GetExpandedArg(v, CrashReportDirKey),
+		LogDirectory: GetExpandedArg(v, LogsDirKey),
+		MaxLogSize:   v.GetInt64(CrashReportMaxLogSizeKey),
+		MaxReports:   v.GetInt(CrashReportMaxFilesKey),
+	}
+	switch {
+	case config.MaxLogSize < 0:
+		return crash.Config{}, fmt.Errorf("%s must be >= 0", CrashReportMaxLogSizeKey)
+	case config.MaxReports < 0:
+		return crash.Config{}, fmt.Errorf("%s must be >= 0", CrashReportMaxFilesKey)
+	}
+	return config, nil
+}
+
 func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 	config := profiler.Config{
 		Dir:         GetExpandedArg(v, ProfileDirKey),
@@ -783,6 +840,24 @@ func getWhitelistedSubnets(v *viper.Viper) (ids.Set, error) {
 	return whitelistedSubnetIDs, nil
 }
 
+func getValidatorWebhookURLs(v *viper.Viper) ([]string, error) {
+	var urls []string
+	for _, rawURL := range strings.Split(v.GetString(ValidatorWebhookURLsKey), ",") {
+		if rawURL == "" {
+			continue
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse validator webhook URL %q: %w", rawURL, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("%w: %q", errInvalidWebhookURL, rawURL)
+		}
+		urls = append(urls, rawURL)
+	}
+	return urls, nil
+}
+
 func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, error) {
 	var (
 		configBytes []byte
@@ -1077,6 +1152,24 @@ func getCPUTargeterConfig(v *viper.Viper) (tracker.TargeterConfig, error) {
 	}
 }
 
+func getOverloadConfig(v *viper.Viper) (overload.Config, error) {
+	config := overload.Config{
+		CPUThreshold:   v.GetFloat64(OverloadCPUThresholdKey),
+		QueueThreshold: v.GetInt(OverloadQueueThresholdKey),
+		CheckFrequency: v.GetDuration(OverloadCheckFrequencyKey),
+	}
+	switch {
+	case config.CPUThreshold < 0:
+		return overload.Config{}, fmt.Errorf("%q (%f) < 0", OverloadCPUThresholdKey, config.CPUThreshold)
+	case config.QueueThreshold < 0:
+		return overload.Config{}, fmt.Errorf("%q (%d) < 0", OverloadQueueThresholdKey, config.QueueThreshold)
+	case config.CheckFrequency <= 0:
+		return overload.Config{}, fmt.Errorf("%q (%s) <= 0", OverloadCheckFrequencyKey, config.CheckFrequency)
+	default:
+		return config, nil
+	}
+}
+
 func getDiskSpaceConfig(v *viper.Viper) (requiredAvailableDiskSpace uint64, warningThresholdAvailableDiskSpace uint64, err error) {
 	requiredAvailableDiskSpace = v.GetUint64(SystemTrackerRequiredAvailableDiskSpaceKey)
 	warningThresholdAvailableDiskSpace = v.GetUint64(SystemTrackerWarningThresholdAvailableDiskSpaceKey)
@@ -1166,6 +1259,23 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	if err != nil {
 		return node.Config{}, err
 	}
+	nodeConfig.ValidatorWebhookURLs, err = getValidatorWebhookURLs(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+	nodeConfig.ChainCreationConcurrency = v.GetInt(ChainCreationConcurrencyKey)
+	if nodeConfig.ChainCreationConcurrency <= 0 {
+		return node.Config{}, fmt.Errorf("%s must be positive", ChainCreationConcurrencyKey)
+	}
+
+	// Read replicas
+	nodeConfig.ReplicaConfig = node.ReplicaConfig{
+		ReplicaPrimaryAddress: v.GetString(ReplicaPrimaryAddressKey),
+		ReplicaPrimaryToken:   v.GetString(ReplicaPrimaryTokenKey),
+	}
+	if nodeConfig.ReplicaPrimaryAddress != "" && nodeConfig.ReplicaPrimaryToken == "" {
+		return node.Config{}, fmt.Errorf("%s requires %s", ReplicaPrimaryAddressKey, ReplicaPrimaryTokenKey)
+	}
 
 	// HTTP APIs
 	nodeConfig.HTTPConfig, err = getHTTPConfig(v)
@@ -1256,6 +1366,9 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	if err != nil {
 		return node.Config{}, err
 	}
+	if !v.IsSet(ChainConfigContentKey) {
+		nodeConfig.ChainConfigDir = filepath.Clean(GetExpandedArg(v, ChainConfigDirKey))
+	}
 
 	// Profiler
 	nodeConfig.ProfilerConfig, err = getProfilerConfig(v)
@@ -1263,6 +1376,12 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Crash reports
+	nodeConfig.CrashReportConfig, err = getCrashReportConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// VM Aliases
 	nodeConfig.VMManager, err = getVMManager(v)
 	if err != nil {
@@ -1284,6 +1403,11 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.OverloadConfig, err = getOverloadConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.DiskTargeterConfig, err = getDiskTargeterConfig(v)
 	return nodeConfig, err
 }
@@ -10,12 +10,15 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/kardianos/osext"
 
 	"github.com/spf13/viper"
 
+	"github.com/lasthyphen/beacongo/api/apikeys"
+	"github.com/lasthyphen/beacongo/api/keystore"
 	"github.com/lasthyphen/beacongo/database/leveldb"
 	"github.com/lasthyphen/beacongo/database/memdb"
 	"github.com/lasthyphen/beacongo/database/rocksdb"
@@ -39,6 +42,7 @@ var (
 	defaultDBDir           = filepath.Join(defaultUnexpandedDataDir, "db")
 	defaultLogDir          = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir      = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultCrashReportDir  = filepath.Join(defaultUnexpandedDataDir, "crashes")
 	defaultStakingPath     = filepath.Join(defaultUnexpandedDataDir, "staking")
 	defaultStakingKeyPath  = filepath.Join(defaultStakingPath, "staker.key")
 	defaultStakingCertPath = filepath.Join(defaultStakingPath, "staker.crt")
@@ -171,6 +175,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(NetworkRequireValidatorToConnectKey, false, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, 8*units.KiB, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, 8*units.KiB, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Bool(NetworkPeerLatencyEnabledKey, false, "If true, the round trip time of pings to each peer is measured and reported by the Info API")
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, 10, "Number of consecutive failed queries before benchlisting a node")
@@ -221,11 +226,16 @@ func addNodeFlags(fs *flag.FlagSet) {
 		fmt.Sprintf("Password file used to initially create/validate API authorization tokens. Ignored if %s is specified. Leading and trailing whitespace is removed from the password. Can be changed via API call",
 			APIAuthPasswordKey))
 	fs.String(APIAuthPasswordKey, "", "Specifies password for API authorization tokens")
+	fs.Bool(APIKeysEnabledKey, false, fmt.Sprintf("If true, API requests are metered by the API key passed in the %q header. Keys are managed with the Admin API", apikeys.HeaderKey))
+	fs.Bool(APIKeysRequiredKey, false, fmt.Sprintf("If true, API requests without a valid API key are refused, except for requests to the Admin API. Requires %s", APIKeysEnabledKey))
+	fs.String(APIKeysTxMethodsKey, strings.Join(apikeys.DefaultTxMethods, " "), "Whitespace separated JSON-RPC methods, without the service prefix, whose successful calls are metered as issued transactions")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
 	fs.Bool(InfoAPIEnabledKey, true, "If true, this node exposes the Info API")
+	fs.String(InfoAPIGeoIPDBKey, "", "Path to a MaxMind DB (MMDB) file, such as GeoLite2 City, used by the Info API to locate peers. If empty, peers aren't located")
 	fs.Bool(KeystoreAPIEnabledKey, true, "If true, this node exposes the Keystore API")
+	fs.Duration(KeystoreDeletedUserRetentionKey, keystore.DefaultDeletedUserRetention, "Duration that deleted keystore users can be restored for before they are purged")
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
 	fs.Bool(IpcAPIEnabledKey, false, "If true, IPCs can be opened")
@@ -273,6 +283,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(StakeSupplyCapKey, genesis.LocalParams.RewardConfig.SupplyCap, "Supply cap of the staking function")
 	// Subnets
 	fs.String(WhitelistedSubnetsKey, "", "Whitelist of subnets to validate")
+	fs.String(ValidatorWebhookURLsKey, "", "Comma separated list of URLs that changes to the validator sets of the Primary Network and whitelisted subnets are posted to")
+	fs.Int(ChainCreationConcurrencyKey, 4, "Max number of chains to build at the same time once the P-chain has bootstrapped")
 
 	// State syncing
 	fs.String(StateSyncIPsKey, "", "Comma separated list of state sync peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
@@ -288,6 +300,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
 	fs.Uint(BootstrapAncestorsMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Ancestors message")
+	fs.Uint(BootstrapAncestorsCacheSizeKey, 8, "Max number of recently sent Ancestors messages each chain caches, so that they can be resent to other bootstrapping peers without reading the database. If 0, nothing is cached")
+	fs.Uint64(BootstrapServingMaxBandwidthKey, 0, "Max number of bytes per second this node uploads when responding to Get and GetAncestors requests. If 0, the bandwidth isn't limited")
+	fs.Uint64(BootstrapServingMaxBurstSizeKey, 4*units.MiB, "Max number of bytes this node uploads at once when responding to Get and GetAncestors requests. Must be at least the max container size")
 
 	// Consensus
 	fs.Int(SnowSampleSizeKey, 20, "Number of nodes to query for each network poll")
@@ -314,6 +329,11 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.String(IndexReplicationTokenKey, "", fmt.Sprintf("If non-empty, the accepted blocks of indexed chains are streamed to read replicas that present this token. Requires %s and %s", IndexEnabledKey, HTTPSEnabledKey))
+
+	// Read replicas
+	fs.String(ReplicaPrimaryAddressKey, "", "If non-empty, the node's Snowman chains apply the blocks accepted by the node at this host:port, whose HTTP API must use TLS, instead of running consensus")
+	fs.String(ReplicaPrimaryTokenKey, "", fmt.Sprintf("Token presented to the node at %s. Must match its %s", ReplicaPrimaryAddressKey, IndexReplicationTokenKey))
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -326,6 +346,13 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(ProfileContinuousEnabledKey, false, "Whether the app should continuously produce performance profiles")
 	fs.Duration(ProfileContinuousFreqKey, 15*time.Minute, "How frequently to rotate performance profiles")
 	fs.Int(ProfileContinuousMaxFilesKey, 5, "Maximum number of historical profiles to keep")
+
+	// Crash reports
+	fs.Bool(CrashReportEnabledKey, true, "Whether a crash report is written when the node hits a fatal error")
+	fs.String(CrashReportDirKey, defaultCrashReportDir, "Path to the directory that crash reports are written to")
+	fs.Int64(CrashReportMaxLogSizeKey, 4*units.MiB, "Maximum number of bytes, from the end, of each log file included in a crash report")
+	fs.Int(CrashReportMaxFilesKey, 5, "Maximum number of crash reports to keep")
+
 	fs.String(VMAliasesFileKey, defaultVMAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs with custom aliases. Ignored if %s is specified", VMAliasesContentKey))
 	fs.String(VMAliasesContentKey, "", "Specifies base64 encoded maps vmIDs with custom aliases")
 
@@ -341,6 +368,11 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(SystemTrackerRequiredAvailableDiskSpaceKey, units.GiB/2, "Minimum number of available bytes on disk, under which the node will shutdown.")
 	fs.Uint64(SystemTrackerWarningThresholdAvailableDiskSpaceKey, units.GiB, fmt.Sprintf("Warning threshold for the number of available bytes on disk, under which the node will be considered unhealthy.  Must be >= [%s]", SystemTrackerRequiredAvailableDiskSpaceKey))
 
+	// Load shedding
+	fs.Float64(OverloadCPUThresholdKey, 0, "CPU usage, in number of cores, at or above which the node sheds low priority API requests and gossip. If 0, CPU usage doesn't trigger load shedding")
+	fs.Int(OverloadQueueThresholdKey, 0, "Number of unprocessed consensus messages, across all chains, at or above which the node sheds low priority API requests and gossip. If 0, the queue depth doesn't trigger load shedding")
+	fs.Duration(OverloadCheckFrequencyKey, time.Second, "Frequency to check whether the node is overloaded")
+
 	// CPU management
 	fs.Float64(CPUVdrAllocKey, float64(runtime.NumCPU()), "Maximum number of CPUs to allocate for use by validators. Value should be in range [0, total core count]")
 	fs.Float64(CPUMaxNonVdrUsageKey, .8*float64(runtime.NumCPU()), "Number of CPUs that if fully utilized, will rate limit all non-validators. Value should be in range [0, total core count]")
@@ -54,6 +54,9 @@ const (
 	APIAuthRequiredKey                                 = "api-auth-required"
 	APIAuthPasswordKey                                 = "api-auth-password"
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
+	APIKeysEnabledKey                                  = "api-keys-enabled"
+	APIKeysRequiredKey                                 = "api-keys-required"
+	APIKeysTxMethodsKey                                = "api-keys-tx-methods"
 	StateSyncIPsKey                                    = "state-sync-ips"
 	StateSyncIDsKey                                    = "state-sync-ids"
 	StateSyncDisableRequests                           = "state-sync-disable-requests"
@@ -95,6 +98,7 @@ const (
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerLatencyEnabledKey                       = "network-peer-latency-enabled"
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
@@ -121,9 +125,13 @@ const (
 	SnowMixedQueryNumPushVdrKey                        = "snow-mixed-query-num-push-vdr"
 	SnowMixedQueryNumPushNonVdrKey                     = "snow-mixed-query-num-push-non-vdr"
 	WhitelistedSubnetsKey                              = "whitelisted-subnets"
+	ChainCreationConcurrencyKey                        = "chain-creation-concurrency"
+	ValidatorWebhookURLsKey                            = "validator-webhook-urls"
 	AdminAPIEnabledKey                                 = "api-admin-enabled"
 	InfoAPIEnabledKey                                  = "api-info-enabled"
+	InfoAPIGeoIPDBKey                                  = "api-info-geoip-db"
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
+	KeystoreDeletedUserRetentionKey                    = "keystore-deleted-user-retention"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
 	IpcAPIEnabledKey                                   = "api-ipcs-enabled"
@@ -144,6 +152,9 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexReplicationTokenKey                           = "index-replication-token"
+	ReplicaPrimaryAddressKey                           = "replica-primary-address"
+	ReplicaPrimaryTokenKey                             = "replica-primary-token"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
@@ -155,6 +166,9 @@ const (
 	BootstrapMaxTimeGetAncestorsKey                    = "boostrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
+	BootstrapAncestorsCacheSizeKey                     = "bootstrap-ancestors-cache-size"
+	BootstrapServingMaxBandwidthKey                    = "bootstrap-serving-max-bandwidth"
+	BootstrapServingMaxBurstSizeKey                    = "bootstrap-serving-max-burst-size"
 	ChainConfigDirKey                                  = "chain-config-dir"
 	ChainConfigContentKey                              = "chain-config-content"
 	SubnetConfigDirKey                                 = "subnet-config-dir"
@@ -163,6 +177,10 @@ const (
 	ProfileContinuousEnabledKey                        = "profile-continuous-enabled"
 	ProfileContinuousFreqKey                           = "profile-continuous-freq"
 	ProfileContinuousMaxFilesKey                       = "profile-continuous-max-files"
+	CrashReportEnabledKey                              = "crash-report-enabled"
+	CrashReportDirKey                                  = "crash-report-dir"
+	CrashReportMaxLogSizeKey                           = "crash-report-max-log-size"
+	CrashReportMaxFilesKey                             = "crash-report-max-files"
 	InboundThrottlerAtLargeAllocSizeKey                = "throttler-inbound-at-large-alloc-size"
 	InboundThrottlerVdrAllocSizeKey                    = "throttler-inbound-validator-alloc-size"
 	InboundThrottlerNodeMaxAtLargeBytesKey             = "throttler-inbound-node-max-at-large-bytes"
@@ -180,6 +198,9 @@ const (
 	SystemTrackerDiskHalflifeKey                       = "system-tracker-disk-halflife"
 	SystemTrackerRequiredAvailableDiskSpaceKey         = "system-tracker-disk-required-available-space"
 	SystemTrackerWarningThresholdAvailableDiskSpaceKey = "system-tracker-disk-warning-threshold-available-space"
+	OverloadCPUThresholdKey                            = "overload-cpu-threshold"
+	OverloadQueueThresholdKey                          = "overload-queue-threshold"
+	OverloadCheckFrequencyKey                          = "overload-check-frequency"
 	DiskVdrAllocKey                                    = "throttler-inbound-disk-validator-alloc"
 	DiskMaxNonVdrUsageKey                              = "throttler-inbound-disk-max-non-validator-usage"
 	DiskMaxNonVdrNodeUsageKey                          = "throttler-inbound-disk-max-non-validator-node-usage"
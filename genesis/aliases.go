@@ -8,6 +8,7 @@ import (
 
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/vms/managedassetfx"
 	"github.com/lasthyphen/beacongo/vms/nftfx"
 	"github.com/lasthyphen/beacongo/vms/platformvm"
 	"github.com/lasthyphen/beacongo/vms/propertyfx"
@@ -77,5 +78,6 @@ func GetVMAliases() map[ids.ID][]string {
 		secp256k1fx.ID:         {"secp256k1fx"},
 		nftfx.ID:               {"nftfx"},
 		propertyfx.ID:          {"propertyfx"},
+		managedassetfx.ID:      {"managedassetfx"},
 	}
 }
@@ -540,9 +540,8 @@ func DJTXAssetID(avmGenesisBytes []byte) (ids.ID, error) {
 		return ids.Empty, err
 	}
 
-	genesisCodec := parser.GenesisCodec()
-	genesis := avm.Genesis{}
-	if _, err := genesisCodec.Unmarshal(avmGenesisBytes, &genesis); err != nil {
+	genesis, err := avm.ParseGenesis(parser.GenesisCodec(), avmGenesisBytes)
+	if err != nil {
 		return ids.Empty, err
 	}
 
@@ -63,6 +63,9 @@ type Config struct {
 	ConsensusAcceptorGroup snow.AcceptorGroup
 	APIServer              server.PathAdder
 	ShutdownF              func()
+	// If non-empty, the accepted blocks of indexed chains are streamed to
+	// read replicas that present this token
+	ReplicationToken string
 }
 
 // Indexer causes accepted containers for a given chain
@@ -103,7 +106,15 @@ func NewIndexer(config Config) (Indexer, error) {
 		return nil, err
 	}
 	indexer.hasRunBefore = hasRun
-	return indexer, indexer.markHasRun()
+	if err := indexer.markHasRun(); err != nil {
+		return nil, err
+	}
+	if config.ReplicationToken != "" {
+		if err := indexer.registerReplication(config.ReplicationToken); err != nil {
+			return nil, fmt.Errorf("couldn't register replication API: %w", err)
+		}
+	}
+	return indexer, nil
 }
 
 type indexer struct {
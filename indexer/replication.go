@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+
+	replicationpb "github.com/lasthyphen/beacongo/proto/pb/replication"
+)
+
+const (
+	// replicationEndpoint is the endpoint, under "/ext/index", that serves the
+	// replication API. As gRPC requires HTTP/2, the API is only served when the
+	// HTTP API uses TLS.
+	replicationEndpoint = "/replication"
+
+	// Metadata key of the token that replicas authenticate with
+	replicationTokenKey    = "authorization"
+	replicationTokenPrefix = "Bearer "
+
+	// How often a stream checks for newly accepted containers once it has
+	// sent every accepted container
+	replicationPollFrequency = 100 * time.Millisecond
+)
+
+var (
+	_ replicationpb.ReplicationServer = &replicationServer{}
+	_ http.Handler                    = &replicationHandler{}
+)
+
+// replicationServer streams the blocks accepted by this node to read replicas.
+// Only chains whose blocks are indexed can be replicated.
+type replicationServer struct {
+	replicationpb.UnsafeReplicationServer
+	indexer *indexer
+	token   string
+}
+
+func (s *replicationServer) StreamAccepted(req *replicationpb.StreamAcceptedRequest, stream replicationpb.Replication_StreamAcceptedServer) error {
+	chainID, err := ids.ToID(req.ChainId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.indexer.lock.RLock()
+	index, ok := s.indexer.blockIndices[chainID]
+	s.indexer.lock.RUnlock()
+	if !ok {
+		return status.Errorf(codes.NotFound, "blocks of chain %s aren't indexed", chainID)
+	}
+
+	s.indexer.log.Debug("streaming accepted blocks of chain %s from index %d", chainID, req.StartIndex)
+
+	ticker := time.NewTicker(replicationPollFrequency)
+	defer ticker.Stop()
+
+	nextIndex := req.StartIndex
+	for {
+		lastAccepted, err := index.GetLastAccepted()
+		switch {
+		case err == errNoneAccepted:
+		case err != nil:
+			return err
+		default:
+			lastAcceptedIndex, err := index.GetIndex(lastAccepted.ID)
+			if err != nil {
+				return err
+			}
+			for nextIndex <= lastAcceptedIndex {
+				containers, err := index.GetContainerRange(nextIndex, MaxFetchedByRange)
+				if err != nil {
+					return err
+				}
+				for _, container := range containers {
+					err := stream.Send(&replicationpb.AcceptedContainer{
+						Index:     nextIndex,
+						Id:        container.ID[:],
+						Bytes:     container.Bytes,
+						Timestamp: container.Timestamp,
+					})
+					if err != nil {
+						return err
+					}
+					nextIndex++
+				}
+			}
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// registerReplication serves the replication API to replicas that present
+// [token].
+func (i *indexer) registerReplication(token string) error {
+	service := &replicationServer{
+		indexer: i,
+		token:   token,
+	}
+	grpcServer := grpc.NewServer(grpc.StreamInterceptor(service.authenticate))
+	replicationpb.RegisterReplicationServer(grpcServer, service)
+	handler := &common.HTTPHandler{
+		// Streams grab the index locks themselves, so that they don't hold
+		// them between messages
+		LockOptions: common.NoLock,
+		Handler:     &replicationHandler{server: grpcServer},
+	}
+	return i.pathAdder.AddRoute(handler, &sync.RWMutex{}, "index", replicationEndpoint+"/")
+}
+
+// authenticate refuses streams that don't present the replication token.
+func (s *replicationServer) authenticate(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	for _, value := range md.Get(replicationTokenKey) {
+		token := strings.TrimPrefix(value, replicationTokenPrefix)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			return handler(srv, stream)
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid replication token")
+}
+
+// replicationHandler serves gRPC requests sent to any path under the
+// replication endpoint, e.g.
+// "/ext/index/replication/replication.Replication/StreamAccepted".
+type replicationHandler struct {
+	server *grpc.Server
+}
+
+func (h *replicationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// gRPC routes requests by the service and method at the end of the path
+	if i := strings.LastIndex(r.URL.Path, replicationEndpoint+"/"); i >= 0 {
+		r.URL.Path = r.URL.Path[i+len(replicationEndpoint):]
+	}
+	h.server.ServeHTTP(w, r)
+}
+
+// NewReplicationClient returns a client of the replication API served by the
+// node that [conn] is connected to, which authenticates with [token]. [conn]
+// must use TLS, as the API is served over HTTP/2.
+func NewReplicationClient(conn *grpc.ClientConn, token string) replicationpb.ReplicationClient {
+	return replicationpb.NewReplicationClient(&replicationConn{
+		ClientConn: conn,
+		prefix:     "/ext/index" + replicationEndpoint,
+		token:      token,
+	})
+}
+
+// replicationConn sends calls to the replication endpoint rather than to the
+// root of the node's API server, with the replication token attached.
+type replicationConn struct {
+	*grpc.ClientConn
+	prefix string
+	token  string
+}
+
+func (c *replicationConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, replicationTokenKey, replicationTokenPrefix+c.token)
+	return c.ClientConn.Invoke(ctx, c.prefix+method, args, reply, opts...)
+}
+
+func (c *replicationConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, replicationTokenKey, replicationTokenPrefix+c.token)
+	return c.ClientConn.NewStream(ctx, desc, c.prefix+method, opts...)
+}
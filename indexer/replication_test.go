@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/lasthyphen/beacongo/codec"
+	"github.com/lasthyphen/beacongo/codec/linearcodec"
+	"github.com/lasthyphen/beacongo/database/memdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/utils"
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/timer/mockable"
+
+	replicationpb "github.com/lasthyphen/beacongo/proto/pb/replication"
+)
+
+// acceptedStream records the sent containers, and is cancelled once [limit]
+// containers have been sent
+type acceptedStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	cancel     context.CancelFunc
+	limit      int
+	containers []*replicationpb.AcceptedContainer
+}
+
+func (s *acceptedStream) Context() context.Context { return s.ctx }
+
+func (s *acceptedStream) Send(container *replicationpb.AcceptedContainer) error {
+	s.containers = append(s.containers, container)
+	if len(s.containers) == s.limit {
+		s.cancel()
+	}
+	return nil
+}
+
+func TestReplicationStreamAccepted(t *testing.T) {
+	assert := assert.New(t)
+
+	codec := codec.NewDefaultManager()
+	assert.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	index, err := newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{})
+	assert.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	containerIDs := make([]ids.ID, 3)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		assert.NoError(index.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+
+	chainID := ids.GenerateTestID()
+	s := &replicationServer{
+		indexer: &indexer{
+			log:          logging.NoLog{},
+			blockIndices: map[ids.ID]Index{chainID: index},
+		},
+		token: "token",
+	}
+
+	// Containers are streamed from the requested index
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stream := &acceptedStream{
+		ctx:    streamCtx,
+		cancel: cancel,
+		limit:  2,
+	}
+	assert.NoError(s.StreamAccepted(&replicationpb.StreamAcceptedRequest{
+		ChainId:    chainID[:],
+		StartIndex: 1,
+	}, stream))
+	assert.Len(stream.containers, 2)
+	for i, container := range stream.containers {
+		assert.EqualValues(i+1, container.Index)
+		assert.Equal(containerIDs[i+1][:], container.Id)
+	}
+
+	otherChainID := ids.GenerateTestID()
+	err = s.StreamAccepted(&replicationpb.StreamAcceptedRequest{ChainId: otherChainID[:]}, stream)
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	// Streams must present the token
+	handled := false
+	handler := func(interface{}, grpc.ServerStream) error {
+		handled = true
+		return nil
+	}
+	badCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(replicationTokenKey, replicationTokenPrefix+"wrong"))
+	err = s.authenticate(nil, &acceptedStream{ctx: badCtx}, nil, handler)
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+	assert.False(handled)
+
+	goodCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(replicationTokenKey, replicationTokenPrefix+"token"))
+	assert.NoError(s.authenticate(nil, &acceptedStream{ctx: goodCtx}, nil, handler))
+	assert.True(handled)
+}
@@ -8,7 +8,7 @@ import (
 	"path/filepath"
 
 	"github.com/lasthyphen/beacongo/ids"
-	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/utils/eventbus"
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/utils/wrappers"
 )
@@ -26,28 +26,26 @@ type context struct {
 	log       logging.Logger
 	networkID uint32
 	path      string
+	bus       eventbus.Bus
 }
 
 // ChainIPCs maintains IPCs for a set of chains
 type ChainIPCs struct {
 	context
-	chains                 map[ids.ID]*EventSockets
-	consensusAcceptorGroup snow.AcceptorGroup
-	decisionAcceptorGroup  snow.AcceptorGroup
+	chains map[ids.ID]*EventSockets
 }
 
-// NewChainIPCs creates a new *ChainIPCs that writes consensus and decision
-// events to IPC sockets
-func NewChainIPCs(log logging.Logger, path string, networkID uint32, consensusAcceptorGroup, decisionAcceptorGroup snow.AcceptorGroup, defaultChainIDs []ids.ID) (*ChainIPCs, error) {
+// NewChainIPCs creates a new *ChainIPCs that writes the consensus and decision
+// events published to [bus] to IPC sockets
+func NewChainIPCs(log logging.Logger, path string, networkID uint32, bus eventbus.Bus, defaultChainIDs []ids.ID) (*ChainIPCs, error) {
 	cipcs := &ChainIPCs{
 		context: context{
 			log:       log,
 			networkID: networkID,
 			path:      path,
+			bus:       bus,
 		},
-		chains:                 make(map[ids.ID]*EventSockets),
-		consensusAcceptorGroup: consensusAcceptorGroup,
-		decisionAcceptorGroup:  decisionAcceptorGroup,
+		chains: make(map[ids.ID]*EventSockets),
 	}
 	for _, chainID := range defaultChainIDs {
 		if _, err := cipcs.Publish(chainID); err != nil {
@@ -64,7 +62,7 @@ func (cipcs *ChainIPCs) Publish(chainID ids.ID) (*EventSockets, error) {
 		return es, nil
 	}
 
-	es, err := newEventSockets(cipcs.context, chainID, cipcs.consensusAcceptorGroup, cipcs.decisionAcceptorGroup)
+	es, err := newEventSockets(cipcs.context, chainID)
 	if err != nil {
 		cipcs.log.Error("can't create ipcs: %s", err)
 		return nil, err
@@ -5,12 +5,14 @@ package ipcs
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"syscall"
 
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/ipcs/socket"
 	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/utils/eventbus"
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/utils/wrappers"
 )
@@ -24,13 +26,13 @@ type EventSockets struct {
 }
 
 // newEventSockets creates a *ChainIPCs with both consensus and decisions IPCs
-func newEventSockets(ctx context, chainID ids.ID, consensusAcceptorGroup, decisionAcceptorGroup snow.AcceptorGroup) (*EventSockets, error) {
-	consensusIPC, err := newEventIPCSocket(ctx, chainID, ipcConsensusIdentifier, consensusAcceptorGroup)
+func newEventSockets(ctx context, chainID ids.ID) (*EventSockets, error) {
+	consensusIPC, err := newEventIPCSocket(ctx, chainID, ipcConsensusIdentifier, eventbus.KindConsensusAccepted)
 	if err != nil {
 		return nil, err
 	}
 
-	decisionsIPC, err := newEventIPCSocket(ctx, chainID, ipcDecisionsIdentifier, decisionAcceptorGroup)
+	decisionsIPC, err := newEventIPCSocket(ctx, chainID, ipcDecisionsIdentifier, eventbus.KindDecisionAccepted)
 	if err != nil {
 		return nil, err
 	}
@@ -91,12 +93,12 @@ type eventSocket struct {
 	unregisterFn func() error
 }
 
-// newEventIPCSocket creates a *eventSocket for the given chain and
-// EventDispatcher that writes to a local IPC socket
-func newEventIPCSocket(ctx context, chainID ids.ID, name string, acceptorGroup snow.AcceptorGroup) (*eventSocket, error) {
+// newEventIPCSocket creates a *eventSocket that writes the accepted events of
+// [kind] of the given chain to a local IPC socket
+func newEventIPCSocket(ctx context, chainID ids.ID, name string, kind eventbus.Kind) (*eventSocket, error) {
 	var (
 		url     = ipcURL(ctx, chainID, name)
-		ipcName = ipcIdentifierPrefix + "-" + name
+		ipcName = fmt.Sprintf("%s-%s-%s", ipcIdentifierPrefix, name, chainID)
 	)
 
 	err := os.Remove(url)
@@ -109,7 +111,7 @@ func newEventIPCSocket(ctx context, chainID ids.ID, name string, acceptorGroup s
 		url:    url,
 		socket: socket.NewSocket(url, ctx.log),
 		unregisterFn: func() error {
-			return acceptorGroup.DeregisterAcceptor(chainID, ipcName)
+			return ctx.bus.Unsubscribe(ipcName)
 		},
 	}
 
@@ -120,7 +122,21 @@ func newEventIPCSocket(ctx context, chainID ids.ID, name string, acceptorGroup s
 		return nil, err
 	}
 
-	if err := acceptorGroup.RegisterAcceptor(chainID, ipcName, eis, false); err != nil {
+	handler := func(event eventbus.Event) {
+		var accepted eventbus.Accepted
+		switch event := event.(type) {
+		case eventbus.ConsensusAccepted:
+			accepted = event.Accepted
+		case eventbus.DecisionAccepted:
+			accepted = event.Accepted
+		default:
+			return
+		}
+		if accepted.ChainID == chainID {
+			eis.socket.Send(accepted.Container)
+		}
+	}
+	if err := ctx.bus.Subscribe(ipcName, handler, kind); err != nil {
 		if err := eis.stop(); err != nil {
 			return nil, err
 		}
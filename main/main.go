@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/lasthyphen/beacongo/app/benchmark"
 	"github.com/lasthyphen/beacongo/app/runner"
 	"github.com/lasthyphen/beacongo/config"
 	"github.com/lasthyphen/beacongo/version"
@@ -15,6 +16,14 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == benchmark.Command {
+		if err := benchmark.Run(os.Args[2:], os.Stdout); err != nil && !errors.Is(err, pflag.ErrHelp) {
+			fmt.Printf("couldn't run benchmark: %s\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 
@@ -119,6 +119,10 @@ type Config struct {
 	// true.
 	CompressionEnabled bool `json:"compressionEnabled"`
 
+	// PeerLatencyEnabled will measure the round trip time of pings to each
+	// peer when set to true.
+	PeerLatencyEnabled bool `json:"peerLatencyEnabled"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 
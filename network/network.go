@@ -221,6 +221,7 @@ func NewNetwork(
 		PingFrequency:        config.PingFrequency,
 		PongTimeout:          config.PingPongTimeout,
 		MaxClockDifference:   config.MaxClockDifference,
+		MeasureLatency:       config.PeerLatencyEnabled,
 		ResourceTracker:      config.ResourceTracker,
 		PingMessage:          pingMessge,
 	}
@@ -37,6 +37,8 @@ type Config struct {
 	PingFrequency        time.Duration
 	PongTimeout          time.Duration
 	MaxClockDifference   time.Duration
+	// If true, the round trip time of pings is measured
+	MeasureLatency bool
 
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
@@ -19,4 +19,6 @@ type Info struct {
 	LastReceived   time.Time  `json:"lastReceived"`
 	ObservedUptime json.Uint8 `json:"observedUptime"`
 	TrackedSubnets []ids.ID   `json:"trackedSubnets"`
+	// Smoothed round trip time of pings, if latency is measured
+	RTT time.Duration `json:"rtt,omitempty"`
 }
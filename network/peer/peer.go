@@ -79,6 +79,10 @@ type Peer interface {
 	// returns true.
 	ObservedUptime() uint8
 
+	// RTT returns the smoothed round trip time of pings sent to the peer. It
+	// returns 0 if latency isn't measured or no pong has been received yet.
+	RTT() time.Duration
+
 	// Send attempts to send [msg] to the peer. The peer takes ownership of
 	// [msg] for reference counting. This returns false if the message is
 	// guaranteed not to be delivered to the peer.
@@ -126,6 +130,13 @@ type peer struct {
 	// [observedUptimeLock] must be held while accessing [observedUptime]
 	observedUptime uint8
 
+	rttLock sync.Mutex
+	// [rttLock] must be held while accessing [pingSent] and [rtt].
+	// [pingSent] is the time the oldest unanswered ping was written, or zero
+	// if every ping was answered.
+	pingSent time.Time
+	rtt      time.Duration
+
 	// True if this peer has sent us a valid Version message and
 	// is running a compatible version.
 	// Only modified on the connection's reader routine.
@@ -232,6 +243,7 @@ func (p *peer) Info() Info {
 		LastReceived:   time.Unix(atomic.LoadInt64(&p.lastReceived), 0),
 		ObservedUptime: json.Uint8(p.ObservedUptime()),
 		TrackedSubnets: p.trackedSubnets.List(),
+		RTT:            p.RTT(),
 	}
 }
 
@@ -248,6 +260,13 @@ func (p *peer) ObservedUptime() uint8 {
 	return uptime
 }
 
+func (p *peer) RTT() time.Duration {
+	p.rttLock.Lock()
+	rtt := p.rtt
+	p.rttLock.Unlock()
+	return rtt
+}
+
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
 	return p.messageQueue.Push(ctx, msg)
 }
@@ -480,10 +499,18 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 		return
 	}
 
-	now := p.Clock.Time().Unix()
-	atomic.StoreInt64(&p.Config.LastSent, now)
-	atomic.StoreInt64(&p.lastSent, now)
+	now := p.Clock.Time()
+	atomic.StoreInt64(&p.Config.LastSent, now.Unix())
+	atomic.StoreInt64(&p.lastSent, now.Unix())
 	p.Metrics.Sent(msg)
+
+	if p.MeasureLatency && msg.Op() == message.Ping {
+		p.rttLock.Lock()
+		if p.pingSent.IsZero() {
+			p.pingSent = now
+		}
+		p.rttLock.Unlock()
+	}
 }
 
 func (p *peer) sendPings() {
@@ -572,6 +599,30 @@ func (p *peer) handlePong(msg message.InboundMessage) {
 	p.observedUptimeLock.Lock()
 	p.observedUptime = uptime // [0, 100] percentage
 	p.observedUptimeLock.Unlock()
+	if p.MeasureLatency {
+		p.observeRTT()
+	}
+}
+
+// observeRTT updates the smoothed round trip time with the time since the
+// oldest unanswered ping was written. As with TCP, each sample is weighted by
+// 1/8.
+func (p *peer) observeRTT() {
+	now := p.Clock.Time()
+
+	p.rttLock.Lock()
+	defer p.rttLock.Unlock()
+
+	if p.pingSent.IsZero() {
+		return
+	}
+	sample := now.Sub(p.pingSent)
+	p.pingSent = time.Time{}
+	if p.rtt == 0 {
+		p.rtt = sample
+		return
+	}
+	p.rtt = (7*p.rtt + sample) / 8
 }
 
 func (p *peer) handleVersion(msg message.InboundMessage) {
@@ -285,3 +285,27 @@ func TestSend(t *testing.T) {
 	err = peer1.AwaitClosed(context.Background())
 	assert.NoError(err)
 }
+
+func TestObserveRTT(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &peer{Config: &Config{MeasureLatency: true}}
+	now := time.Unix(1000, 0)
+	p.Clock.Set(now)
+
+	// A pong without an outstanding ping is ignored
+	p.observeRTT()
+	assert.Zero(p.RTT())
+
+	p.pingSent = now
+	p.Clock.Set(now.Add(80 * time.Millisecond))
+	p.observeRTT()
+	assert.Equal(80*time.Millisecond, p.RTT())
+
+	// Later samples are smoothed
+	p.pingSent = now
+	p.Clock.Set(now.Add(160 * time.Millisecond))
+	p.observeRTT()
+	assert.Equal(90*time.Millisecond, p.RTT())
+	assert.True(p.pingSent.IsZero())
+}
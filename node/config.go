@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/lasthyphen/beacongo/api/apikeys"
 	"github.com/lasthyphen/beacongo/chains"
 	"github.com/lasthyphen/beacongo/genesis"
 	"github.com/lasthyphen/beacongo/ids"
@@ -17,9 +18,11 @@ import (
 	"github.com/lasthyphen/beacongo/snow/networking/router"
 	"github.com/lasthyphen/beacongo/snow/networking/sender"
 	"github.com/lasthyphen/beacongo/snow/networking/tracker"
+	"github.com/lasthyphen/beacongo/utils/crash"
 	"github.com/lasthyphen/beacongo/utils/dynamicip"
 	"github.com/lasthyphen/beacongo/utils/ips"
 	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/overload"
 	"github.com/lasthyphen/beacongo/utils/profiler"
 	"github.com/lasthyphen/beacongo/utils/timer"
 	"github.com/lasthyphen/beacongo/vms"
@@ -39,6 +42,16 @@ type APIAuthConfig struct {
 type APIIndexerConfig struct {
 	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
 	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	// If non-empty, read replicas that present this token are streamed the
+	// accepted blocks of indexed chains
+	IndexReplicationToken string `json:"-"`
+}
+
+type ReplicaConfig struct {
+	// If non-empty, Snowman chains apply the blocks accepted by the node at
+	// this address instead of running consensus
+	ReplicaPrimaryAddress string `json:"replicaPrimaryAddress"`
+	ReplicaPrimaryToken   string `json:"-"`
 }
 
 type HTTPConfig struct {
@@ -61,12 +74,21 @@ type APIConfig struct {
 	APIIndexerConfig `json:"indexerConfig"`
 	IPCConfig        `json:"ipcConfig"`
 
+	// Metering of API requests by API key
+	APIKeysConfig apikeys.Config `json:"apiKeysConfig"`
+
 	// Enable/Disable APIs
 	AdminAPIEnabled    bool `json:"adminAPIEnabled"`
 	InfoAPIEnabled     bool `json:"infoAPIEnabled"`
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+
+	// Path of the MMDB file used by the Info API to locate peers
+	InfoAPIGeoIPDBPath string `json:"infoAPIGeoIPDBPath"`
+
+	// How long deleted keystore users can be restored for
+	KeystoreDeletedUserRetention time.Duration `json:"keystoreDeletedUserRetention"`
 }
 
 type IPConfig struct {
@@ -113,6 +135,17 @@ type BootstrapConfig struct {
 	// containers in an ancestors message it receives.
 	BootstrapAncestorsMaxContainersReceived int `json:"bootstrapAncestorsMaxContainersReceived"`
 
+	// Max number of recently sent ancestors messages each chain caches.
+	BootstrapAncestorsCacheSize int `json:"bootstrapAncestorsCacheSize"`
+
+	// Max number of bytes per second this node uploads in response to Get and
+	// GetAncestors requests. 0 means unlimited.
+	BootstrapServingMaxBandwidth uint64 `json:"bootstrapServingMaxBandwidth"`
+
+	// Max number of bytes this node can upload at once in response to Get and
+	// GetAncestors requests.
+	BootstrapServingMaxBurstSize uint64 `json:"bootstrapServingMaxBurstSize"`
+
 	// Max time to spend fetching a container and its
 	// ancestors while responding to a GetAncestors message
 	BootstrapMaxTimeGetAncestors time.Duration `json:"bootstrapMaxTimeGetAncestors"`
@@ -174,6 +207,9 @@ type Config struct {
 	// Logging configuration
 	LoggingConfig logging.Config `json:"loggingConfig"`
 
+	// Crash report configuration
+	CrashReportConfig crash.Config `json:"crashReportConfig"`
+
 	// Plugin directory
 	PluginDir string `json:"pluginDir"`
 
@@ -196,12 +232,25 @@ type Config struct {
 	// Subnet Whitelist
 	WhitelistedSubnets ids.Set `json:"whitelistedSubnets"`
 
+	// Max number of chains built at the same time during startup
+	ChainCreationConcurrency int `json:"chainCreationConcurrency"`
+
+	ReplicaConfig `json:"replicaConfig"`
+
+	// URLs that the changes to the validator sets of the Primary Network and
+	// of the whitelisted subnets are posted to
+	ValidatorWebhookURLs []string `json:"validatorWebhookURLs"`
+
 	// SubnetConfigs
 	SubnetConfigs map[ids.ID]chains.SubnetConfig `json:"subnetConfigs"`
 
 	// ChainConfigs
 	ChainConfigs map[string]chains.ChainConfig `json:"-"`
 
+	// Directory that chain configs are read from. Empty if the chain configs
+	// are passed as content.
+	ChainConfigDir string `json:"chainConfigDir"`
+
 	// VM management
 	VMManager vms.Manager `json:"-"`
 
@@ -226,6 +275,9 @@ type Config struct {
 
 	DiskTargeterConfig tracker.TargeterConfig `json:"diskTargeterConfig"`
 
+	// Thresholds at which the node starts shedding load
+	OverloadConfig overload.Config `json:"overloadConfig"`
+
 	RequiredAvailableDiskSpace         uint64 `json:"requiredAvailableDiskSpace"`
 	WarningThresholdAvailableDiskSpace uint64 `json:"warningThresholdAvailableDiskSpace"`
 }
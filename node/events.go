@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"github.com/lasthyphen/beacongo/chains"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+	"github.com/lasthyphen/beacongo/snow/networking/router"
+	"github.com/lasthyphen/beacongo/snow/validators"
+	"github.com/lasthyphen/beacongo/utils/eventbus"
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/version"
+)
+
+const acceptedPublisherName = "eventBus"
+
+var (
+	_ router.Router     = &eventRouter{}
+	_ chains.Registrant = &acceptedPublisher{}
+	_ snow.Acceptor     = &acceptor{}
+
+	_ validators.SetCallbackListener = &validatorPublisher{}
+)
+
+// eventRouter publishes the peers that connect and disconnect to the event
+// bus
+type eventRouter struct {
+	router.Router
+	bus eventbus.Publisher
+}
+
+func (e *eventRouter) Connected(nodeID ids.NodeID, nodeVersion version.Application) {
+	e.Router.Connected(nodeID, nodeVersion)
+	e.bus.Publish(eventbus.PeerConnected{
+		NodeID:  nodeID,
+		Version: nodeVersion,
+	})
+}
+
+func (e *eventRouter) Disconnected(nodeID ids.NodeID) {
+	e.Router.Disconnected(nodeID)
+	e.bus.Publish(eventbus.PeerDisconnected{NodeID: nodeID})
+}
+
+// acceptedPublisher publishes the containers accepted by each chain to the
+// event bus
+type acceptedPublisher struct {
+	log                    logging.Logger
+	bus                    eventbus.Publisher
+	decisionAcceptorGroup  snow.AcceptorGroup
+	consensusAcceptorGroup snow.AcceptorGroup
+}
+
+func (a *acceptedPublisher) RegisterChain(name string, engine common.Engine) {
+	chainID := engine.Context().ChainID
+	err := a.decisionAcceptorGroup.RegisterAcceptor(chainID, acceptedPublisherName, &acceptor{
+		bus: a.bus,
+	}, false)
+	if err != nil {
+		a.log.Error("couldn't publish the decisions of chain %s: %s", name, err)
+	}
+	err = a.consensusAcceptorGroup.RegisterAcceptor(chainID, acceptedPublisherName, &acceptor{
+		bus:       a.bus,
+		consensus: true,
+	}, false)
+	if err != nil {
+		a.log.Error("couldn't publish the consensus events of chain %s: %s", name, err)
+	}
+}
+
+type acceptor struct {
+	bus eventbus.Publisher
+	// If true, events are published as ConsensusAccepted rather than
+	// DecisionAccepted
+	consensus bool
+}
+
+func (a *acceptor) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	accepted := eventbus.Accepted{
+		ChainID:     ctx.ChainID,
+		ContainerID: containerID,
+		Container:   container,
+	}
+	if a.consensus {
+		a.bus.Publish(eventbus.ConsensusAccepted{Accepted: accepted})
+	} else {
+		a.bus.Publish(eventbus.DecisionAccepted{Accepted: accepted})
+	}
+	return nil
+}
+
+// validatorPublisher publishes the changes to the validator set of a subnet to
+// the event bus
+type validatorPublisher struct {
+	subnetID ids.ID
+	bus      eventbus.Publisher
+}
+
+func (v *validatorPublisher) OnValidatorAdded(nodeID ids.NodeID, weight uint64) {
+	v.bus.Publish(eventbus.ValidatorSetChanged{
+		SubnetID:  v.subnetID,
+		NodeID:    nodeID,
+		Change:    eventbus.ValidatorAdded,
+		NewWeight: weight,
+	})
+}
+
+func (v *validatorPublisher) OnValidatorRemoved(nodeID ids.NodeID, weight uint64) {
+	v.bus.Publish(eventbus.ValidatorSetChanged{
+		SubnetID:  v.subnetID,
+		NodeID:    nodeID,
+		Change:    eventbus.ValidatorRemoved,
+		OldWeight: weight,
+	})
+}
+
+func (v *validatorPublisher) OnValidatorWeightChanged(nodeID ids.NodeID, oldWeight, newWeight uint64) {
+	v.bus.Publish(eventbus.ValidatorSetChanged{
+		SubnetID:  v.subnetID,
+		NodeID:    nodeID,
+		Change:    eventbus.ValidatorWeightChanged,
+		OldWeight: oldWeight,
+		NewWeight: newWeight,
+	})
+}
@@ -5,6 +5,7 @@ package node
 
 import (
 	"crypto"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,9 +21,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
 	coreth "github.com/lasthyphen/coreth/plugin/evm"
 
 	"github.com/lasthyphen/beacongo/api/admin"
+	"github.com/lasthyphen/beacongo/api/apikeys"
 	"github.com/lasthyphen/beacongo/api/auth"
 	"github.com/lasthyphen/beacongo/api/health"
 	"github.com/lasthyphen/beacongo/api/info"
@@ -56,18 +61,22 @@ import (
 	"github.com/lasthyphen/beacongo/snow/validators"
 	"github.com/lasthyphen/beacongo/utils"
 	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/utils/crash"
+	"github.com/lasthyphen/beacongo/utils/eventbus"
 	"github.com/lasthyphen/beacongo/utils/filesystem"
 	"github.com/lasthyphen/beacongo/utils/hashing"
 	"github.com/lasthyphen/beacongo/utils/ips"
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/utils/math"
 	"github.com/lasthyphen/beacongo/utils/math/meter"
+	"github.com/lasthyphen/beacongo/utils/overload"
 	"github.com/lasthyphen/beacongo/utils/profiler"
 	"github.com/lasthyphen/beacongo/utils/resource"
 	"github.com/lasthyphen/beacongo/utils/timer"
 	"github.com/lasthyphen/beacongo/utils/wrappers"
 	"github.com/lasthyphen/beacongo/version"
 	"github.com/lasthyphen/beacongo/vms/avm"
+	"github.com/lasthyphen/beacongo/vms/managedassetfx"
 	"github.com/lasthyphen/beacongo/vms/nftfx"
 	"github.com/lasthyphen/beacongo/vms/platformvm"
 	"github.com/lasthyphen/beacongo/vms/platformvm/config"
@@ -76,11 +85,15 @@ import (
 	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
 
 	ipcsapi "github.com/lasthyphen/beacongo/api/ipcs"
+	replicationpb "github.com/lasthyphen/beacongo/proto/pb/replication"
+	enginetracker "github.com/lasthyphen/beacongo/snow/engine/common/tracker"
 )
 
 var (
 	genesisHashKey  = []byte("genesisID")
 	indexerDBPrefix = []byte{0x00}
+	adminDBPrefix   = []byte("admin")
+	apiKeysDBPrefix = []byte("apikeys")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -129,6 +142,17 @@ type Node struct {
 	DecisionAcceptorGroup  snow.AcceptorGroup
 	ConsensusAcceptorGroup snow.AcceptorGroup
 
+	// Delivers the events of the node's subsystems, such as chains finishing
+	// bootstrapping and peers connecting, to the subsystems and sinks that
+	// subscribe to them
+	EventBus eventbus.Bus
+	// Webhooks that the validator set changes are posted to
+	validatorWebhooks []*eventbus.Webhook
+
+	// Connection to the primary node that blocks are replicated from. Nil if
+	// this node isn't a read replica.
+	replicationConn *grpc.ClientConn
+
 	IPCs *ipcs.ChainIPCs
 
 	// Net runs the networking stack
@@ -180,6 +204,13 @@ type Node struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	diskTargeter tracker.Targeter
+
+	// Sheds low priority API requests and gossip while the node is under
+	// resource pressure.
+	overload overload.Controller
+
+	// Meters API requests by API key
+	apiKeys apikeys.Manager
 }
 
 /*
@@ -225,7 +256,10 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
 
-	consensusRouter := n.Config.ConsensusRouter
+	var consensusRouter router.Router = &eventRouter{
+		Router: n.Config.ConsensusRouter,
+		bus:    n.EventBus,
+	}
 	if !n.Config.EnableStaking {
 		if err := primaryNetVdrs.AddWeight(n.ID, n.Config.DisabledStakingWeight); err != nil {
 			return err
@@ -485,6 +519,42 @@ func (n *Node) initBeacons() error {
 func (n *Node) initEventDispatchers() {
 	n.DecisionAcceptorGroup = snow.NewAcceptorGroup(n.Log)
 	n.ConsensusAcceptorGroup = snow.NewAcceptorGroup(n.Log)
+	n.EventBus = eventbus.New(n.Log)
+}
+
+// Publishes the changes to the validator sets of the Primary Network and of the
+// whitelisted subnets to [n.EventBus], and posts them to the configured
+// webhooks.
+//
+// Assumes [n.vdrs] and [n.EventBus] are initialized.
+func (n *Node) initValidatorEvents() error {
+	subnetIDs := append([]ids.ID{constants.PrimaryNetworkID}, n.Config.WhitelistedSubnets.List()...)
+	for _, subnetID := range subnetIDs {
+		vdrs, ok := n.vdrs.GetValidators(subnetID)
+		if !ok {
+			// The set is created now so that the changes made when the
+			// P-chain first sets the validators of the subnet are published.
+			vdrs = validators.NewSet()
+			if err := n.vdrs.Set(subnetID, vdrs); err != nil {
+				return fmt.Errorf("couldn't set validators of subnet %s: %w", subnetID, err)
+			}
+		}
+		vdrs.RegisterCallbackListener(&validatorPublisher{
+			subnetID: subnetID,
+			bus:      n.EventBus,
+		})
+	}
+
+	for i, url := range n.Config.ValidatorWebhookURLs {
+		webhook := eventbus.NewWebhook(n.Log, url)
+		name := fmt.Sprintf("validatorWebhook%d", i)
+		if err := n.EventBus.Subscribe(name, webhook.Handle, eventbus.KindValidatorSetChanged); err != nil {
+			webhook.Close()
+			return err
+		}
+		n.validatorWebhooks = append(n.validatorWebhooks, webhook)
+	}
+	return nil
 }
 
 func (n *Node) initIPCs() error {
@@ -498,7 +568,7 @@ func (n *Node) initIPCs() error {
 	}
 
 	var err error
-	n.IPCs, err = ipcs.NewChainIPCs(n.Log, n.Config.IPCPath, n.Config.NetworkID, n.ConsensusAcceptorGroup, n.DecisionAcceptorGroup, chainIDs)
+	n.IPCs, err = ipcs.NewChainIPCs(n.Log, n.Config.IPCPath, n.Config.NetworkID, n.EventBus, chainIDs)
 	return err
 }
 
@@ -518,6 +588,7 @@ func (n *Node) initIndexer() error {
 		ConsensusAcceptorGroup: n.ConsensusAcceptorGroup,
 		APIServer:              n.APIServer,
 		ShutdownF:              func() { n.Shutdown(0) }, // TODO put exit code here
+		ReplicationToken:       n.Config.IndexReplicationToken,
 	})
 	if err != nil {
 		return fmt.Errorf("couldn't create index for txs: %w", err)
@@ -548,6 +619,8 @@ func (n *Node) initChains(genesisBytes []byte) {
 func (n *Node) initAPIServer() error {
 	n.Log.Info("initializing API server")
 	n.APIServer = server.New()
+	n.overload = overload.NewController(n.Log)
+	n.apiKeys = apikeys.NewManager(n.Log)
 
 	if !n.Config.APIRequireAuthToken {
 		n.APIServer.Initialize(
@@ -558,6 +631,8 @@ func (n *Node) initAPIServer() error {
 			n.Config.APIAllowedOrigins,
 			n.Config.ShutdownTimeout,
 			n.ID,
+			n.apiKeys,
+			n.overload,
 		)
 		return nil
 	}
@@ -575,6 +650,8 @@ func (n *Node) initAPIServer() error {
 		n.Config.APIAllowedOrigins,
 		n.Config.ShutdownTimeout,
 		n.ID,
+		n.apiKeys,
+		n.overload,
 		a,
 	)
 
@@ -642,6 +719,21 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 	}
 	go n.Log.RecoverAndPanic(timeoutManager.Dispatch)
 
+	var replicationClient replicationpb.ReplicationClient
+	if n.Config.ReplicaPrimaryAddress != "" {
+		// The primary serves the replication API over HTTP/2, which requires
+		// TLS
+		n.replicationConn, err = grpc.Dial(
+			n.Config.ReplicaPrimaryAddress,
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})),
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't connect to the replication primary: %w", err)
+		}
+		replicationClient = indexer.NewReplicationClient(n.replicationConn, n.Config.ReplicaPrimaryToken)
+		n.Log.Info("replicating Snowman chains from %s", n.Config.ReplicaPrimaryAddress)
+	}
+
 	// Routes incoming messages from peers to the appropriate chain
 	err = n.Config.ConsensusRouter.Initialize(
 		n.ID,
@@ -659,6 +751,16 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		return fmt.Errorf("couldn't initialize chain router: %w", err)
 	}
 
+	servingBandwidth, err := enginetracker.NewBandwidth(
+		n.Config.BootstrapServingMaxBandwidth,
+		n.Config.BootstrapServingMaxBurstSize,
+		"bootstrap_serving",
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize bootstrap serving bandwidth limiter: %w", err)
+	}
+
 	n.chainManager = chains.New(&chains.ManagerConfig{
 		StakingEnabled:                          n.Config.EnableStaking,
 		StakingCert:                             n.Config.StakingTLSCert,
@@ -684,6 +786,7 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		TimeoutManager:                          timeoutManager,
 		Health:                                  n.health,
 		WhitelistedSubnets:                      n.Config.WhitelistedSubnets,
+		ChainCreationConcurrency:                n.Config.ChainCreationConcurrency,
 		RetryBootstrap:                          n.Config.RetryBootstrap,
 		RetryBootstrapWarnFrequency:             n.Config.RetryBootstrapWarnFrequency,
 		ShutdownNodeFunc:                        n.Shutdown,
@@ -696,15 +799,28 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 		BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 		BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
+		BootstrapAncestorsCacheSize:             n.Config.BootstrapAncestorsCacheSize,
+		BootstrapServingBandwidth:               servingBandwidth,
+		Overload:                                n.overload,
 		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
 		StateSyncDisableRequests:                n.Config.StateSyncDisableRequests,
+		EventBus:                                n.EventBus,
+		ReplicationClient:                       replicationClient,
 	})
 
 	// Notify the API server when new chains are created
 	n.chainManager.AddRegistrant(n.APIServer)
+
+	// Publish the containers accepted by new chains to the event bus
+	n.chainManager.AddRegistrant(&acceptedPublisher{
+		log:                    n.Log,
+		bus:                    n.EventBus,
+		decisionAcceptorGroup:  n.DecisionAcceptorGroup,
+		consensusAcceptorGroup: n.ConsensusAcceptorGroup,
+	})
 	return nil
 }
 
@@ -763,6 +879,7 @@ func (n *Node) initVMs() error {
 		n.Config.VMManager.RegisterFactory(secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.Config.VMManager.RegisterFactory(nftfx.ID, &nftfx.Factory{}),
 		n.Config.VMManager.RegisterFactory(propertyfx.ID, &propertyfx.Factory{}),
+		n.Config.VMManager.RegisterFactory(managedassetfx.ID, &managedassetfx.Factory{}),
 	)
 	if errs.Errored() {
 		return errs.Err
@@ -799,7 +916,7 @@ func (n *Node) initSharedMemory() error {
 func (n *Node) initKeystoreAPI() error {
 	n.Log.Info("initializing keystore")
 	keystoreDB := n.DBManager.NewPrefixDBManager([]byte("keystore"))
-	n.keystore = keystore.New(n.Log, keystoreDB)
+	n.keystore = keystore.NewWithRetention(n.Log, keystoreDB, n.Config.KeystoreDeletedUserRetention)
 	keystoreHandler, err := n.keystore.CreateHandler()
 	if err != nil {
 		return err
@@ -859,6 +976,24 @@ func (n *Node) initMetricsAPI() error {
 	)
 }
 
+// initCrashReporter registers a crash reporter, which writes a crash report
+// when any logger of the node recovers a panic.
+// Assumes n.MetricsGatherer is already set
+func (n *Node) initCrashReporter() {
+	if !n.Config.CrashReportConfig.Enabled {
+		n.Log.Info("skipping crash reporter initialization because it has been disabled")
+		return
+	}
+
+	n.Log.Info("initializing crash reporter")
+	summary := map[string]interface{}{
+		"nodeID": n.ID,
+		"config": n.Config,
+	}
+	reporter := crash.New(n.Log, n.Config.CrashReportConfig, summary, n.MetricsGatherer)
+	logging.SetPanicHandler(reporter.Report)
+}
+
 // initAdminAPI initializes the Admin API service
 // Assumes n.log, n.chainManager, and n.ValidatorAPI already initialized
 func (n *Node) initAdminAPI() error {
@@ -883,6 +1018,10 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.Config.VMManager,
 			VMRegistry:   n.VMRegistry,
+			DB:           prefixdb.New(adminDBPrefix, n.DB),
+			APIKeys:      n.apiKeys,
+
+			ChainConfigDir: n.Config.ChainConfigDir,
 		},
 	)
 	if err != nil {
@@ -932,6 +1071,8 @@ func (n *Node) initInfoAPI() error {
 			CreateSubnetTxFee:     n.Config.CreateSubnetTxFee,
 			CreateBlockchainTxFee: n.Config.CreateBlockchainTxFee,
 			VMManager:             n.Config.VMManager,
+			PeerLatencyEnabled:    n.Config.NetworkConfig.PeerLatencyEnabled,
+			GeoIPDBPath:           n.Config.InfoAPIGeoIPDBPath,
 		},
 		n.Log,
 		n.chainManager,
@@ -951,7 +1092,7 @@ func (n *Node) initInfoAPI() error {
 // initHealthAPI initializes the Health API service
 // Assumes n.Log, n.Net, n.APIServer, n.HTTPLog already initialized
 func (n *Node) initHealthAPI() error {
-	healthChecker, err := health.New(n.Log, n.MetricsRegisterer)
+	healthChecker, err := health.NewWithPublisher(n.Log, n.MetricsRegisterer, n.EventBus)
 	if err != nil {
 		return err
 	}
@@ -973,6 +1114,11 @@ func (n *Node) initHealthAPI() error {
 		return fmt.Errorf("couldn't register router health check: %w", err)
 	}
 
+	err = healthChecker.RegisterHealthCheck("overload", n.overload)
+	if err != nil {
+		return fmt.Errorf("couldn't register overload health check: %w", err)
+	}
+
 	diskSpaceCheck := health.CheckerFunc(func() (interface{}, error) {
 		// confirm that the node has enough disk space to continue operating
 		// if there is too little disk space remaining, first report unhealthy and then shutdown the node
@@ -1083,7 +1229,13 @@ func (n *Node) initChainAliases(genesisBytes []byte) error {
 			}
 		}
 	}
-	return nil
+
+	// Chain aliases registered through the admin API
+	return admin.RestoreChainMounts(
+		prefixdb.New(adminDBPrefix, n.DB),
+		n.chainManager,
+		n.APIServer,
+	)
 }
 
 // APIs aliases as specified by the genesis information
@@ -1127,6 +1279,41 @@ func (n *Node) initResourceManager(reg prometheus.Registerer) error {
 	return err
 }
 
+// Load the API keys that [n.apiKeys] meters requests by.
+// Assumes [n.DB] is already initialized.
+func (n *Node) initAPIKeys() error {
+	if !n.Config.APIKeysConfig.Enabled {
+		n.Log.Info("skipping API key metering because it has been disabled")
+	}
+	return n.apiKeys.Initialize(
+		n.Config.APIKeysConfig,
+		prefixdb.New(apiKeysDBPrefix, n.DB),
+		"api_keys",
+		n.MetricsRegisterer,
+	)
+}
+
+// Start [n.overload] sampling the node's CPU usage and consensus queue depth.
+// Assumes [n.resourceTracker] and [n.Config.ConsensusRouter] are already
+// initialized.
+func (n *Node) initOverloadController() error {
+	signals := []overload.Signal{
+		{
+			Name:      "cpu",
+			Threshold: n.Config.OverloadConfig.CPUThreshold,
+			Value:     n.resourceTracker.CPUTracker().TotalUsage,
+		},
+		{
+			Name:      "queue",
+			Threshold: float64(n.Config.OverloadConfig.QueueThreshold),
+			Value: func() float64 {
+				return float64(n.Config.ConsensusRouter.PendingMessages())
+			},
+		},
+	}
+	return n.overload.Start(n.Config.OverloadConfig, signals, "overload", n.MetricsRegisterer)
+}
+
 // Initialize [n.cpuTargeter].
 // Assumes [n.resourceTracker] is already initialized.
 func (n *Node) initCPUTargeter(
@@ -1181,10 +1368,16 @@ func (n *Node) Initialize(
 		return fmt.Errorf("couldn't initialize metrics API: %w", err)
 	}
 
+	n.initCrashReporter()
+
 	if err := n.initDatabase(); err != nil { // Set up the node's database
 		return fmt.Errorf("problem initializing database: %w", err)
 	}
 
+	if err := n.initAPIKeys(); err != nil { // Start metering API requests
+		return fmt.Errorf("couldn't initialize API keys: %w", err)
+	}
+
 	if err := n.initKeystoreAPI(); err != nil { // Start the Keystore API
 		return fmt.Errorf("couldn't initialize keystore API: %w", err)
 	}
@@ -1216,11 +1409,20 @@ func (n *Node) Initialize(
 	}
 	n.initCPUTargeter(&config.CPUTargeterConfig, primaryNetVdrs)
 	n.initDiskTargeter(&config.DiskTargeterConfig, primaryNetVdrs)
+
+	// [n.EventBus] must be set before the networking layer is initialized
+	n.initEventDispatchers()
+
+	if err := n.initValidatorEvents(); err != nil {
+		return fmt.Errorf("problem initializing validator events: %w", err)
+	}
+
 	if err = n.initNetworking(primaryNetVdrs); err != nil { // Set up networking layer.
 		return fmt.Errorf("problem initializing networking: %w", err)
 	}
-
-	n.initEventDispatchers()
+	if err := n.initOverloadController(); err != nil {
+		return fmt.Errorf("problem initializing overload controller: %w", err)
+	}
 
 	// Start the Health API
 	// Has to be initialized before chain manager
@@ -1296,9 +1498,15 @@ func (n *Node) shutdown() {
 		time.Sleep(n.Config.ShutdownWait)
 	}
 
+	if n.overload != nil {
+		n.overload.Stop()
+	}
 	if n.resourceManager != nil {
 		n.resourceManager.Shutdown()
 	}
+	for _, webhook := range n.validatorWebhooks {
+		webhook.Close()
+	}
 	if n.IPCs != nil {
 		if err := n.IPCs.Shutdown(); err != nil {
 			n.Log.Debug("error during IPC shutdown: %s", err)
@@ -1307,6 +1515,11 @@ func (n *Node) shutdown() {
 	if n.chainManager != nil {
 		n.chainManager.Shutdown()
 	}
+	if n.replicationConn != nil {
+		if err := n.replicationConn.Close(); err != nil {
+			n.Log.Debug("error closing connection to the replication primary: %s", err)
+		}
+	}
 	if n.profiler != nil {
 		n.profiler.Shutdown()
 	}
@@ -1316,6 +1529,11 @@ func (n *Node) shutdown() {
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown: %s", err)
 	}
+	if n.apiKeys != nil {
+		if err := n.apiKeys.Shutdown(); err != nil {
+			n.Log.Warn("error persisting API key usage: %s", err)
+		}
+	}
 	if err := n.indexer.Close(); err != nil {
 		n.Log.Debug("error closing tx indexer: %s", err)
 	}
@@ -0,0 +1,738 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: avm/avm.proto
+
+package avm
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IssueTxRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// signed tx bytes
+	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (x *IssueTxRequest) Reset() {
+	*x = IssueTxRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssueTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTxRequest) ProtoMessage() {}
+
+func (x *IssueTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTxRequest.ProtoReflect.Descriptor instead.
+func (*IssueTxRequest) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IssueTxRequest) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+type IssueTxResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxId []byte `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+}
+
+func (x *IssueTxResponse) Reset() {
+	*x = IssueTxResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssueTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTxResponse) ProtoMessage() {}
+
+func (x *IssueTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTxResponse.ProtoReflect.Descriptor instead.
+func (*IssueTxResponse) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IssueTxResponse) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+type GetTxRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxId []byte `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+}
+
+func (x *GetTxRequest) Reset() {
+	*x = GetTxRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxRequest) ProtoMessage() {}
+
+func (x *GetTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxRequest.ProtoReflect.Descriptor instead.
+func (*GetTxRequest) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTxRequest) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+type GetTxResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// signed tx bytes
+	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (x *GetTxResponse) Reset() {
+	*x = GetTxResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxResponse) ProtoMessage() {}
+
+func (x *GetTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxResponse.ProtoReflect.Descriptor instead.
+func (*GetTxResponse) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTxResponse) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+type GetUTXOsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// addresses, e.g. "X-avax1...", whose UTXOs are returned
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// alias or ID of the chain the UTXOs were exported from. If empty, the
+	// UTXOs of this chain are returned.
+	SourceChain string `protobuf:"bytes,2,opt,name=source_chain,json=sourceChain,proto3" json:"source_chain,omitempty"`
+	// max number of UTXOs in each page. If 0, the max page size is used.
+	PageSize uint32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *GetUTXOsRequest) Reset() {
+	*x = GetUTXOsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUTXOsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUTXOsRequest) ProtoMessage() {}
+
+func (x *GetUTXOsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUTXOsRequest.ProtoReflect.Descriptor instead.
+func (*GetUTXOsRequest) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUTXOsRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *GetUTXOsRequest) GetSourceChain() string {
+	if x != nil {
+		return x.SourceChain
+	}
+	return ""
+}
+
+func (x *GetUTXOsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetUTXOsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UTXO bytes
+	Utxos [][]byte `protobuf:"bytes,1,rep,name=utxos,proto3" json:"utxos,omitempty"`
+}
+
+func (x *GetUTXOsResponse) Reset() {
+	*x = GetUTXOsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUTXOsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUTXOsResponse) ProtoMessage() {}
+
+func (x *GetUTXOsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUTXOsResponse.ProtoReflect.Descriptor instead.
+func (*GetUTXOsResponse) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUTXOsResponse) GetUtxos() [][]byte {
+	if x != nil {
+		return x.Utxos
+	}
+	return nil
+}
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// alias or ID of the asset
+	AssetId string `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// if true, UTXOs owned by multiple addresses or locked until a future
+	// time are counted
+	IncludePartial bool `protobuf:"varint,3,opt,name=include_partial,json=includePartial,proto3" json:"include_partial,omitempty"`
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBalanceRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetBalanceRequest) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *GetBalanceRequest) GetIncludePartial() bool {
+	if x != nil {
+		return x.IncludePartial
+	}
+	return false
+}
+
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Balance uint64    `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	UtxoIds []*UTXOID `protobuf:"bytes,2,rep,name=utxo_ids,json=utxoIds,proto3" json:"utxo_ids,omitempty"`
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBalanceResponse) GetBalance() uint64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetUtxoIds() []*UTXOID {
+	if x != nil {
+		return x.UtxoIds
+	}
+	return nil
+}
+
+type UTXOID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxId        []byte `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	OutputIndex uint32 `protobuf:"varint,2,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+}
+
+func (x *UTXOID) Reset() {
+	*x = UTXOID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_avm_avm_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UTXOID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UTXOID) ProtoMessage() {}
+
+func (x *UTXOID) ProtoReflect() protoreflect.Message {
+	mi := &file_avm_avm_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UTXOID.ProtoReflect.Descriptor instead.
+func (*UTXOID) Descriptor() ([]byte, []int) {
+	return file_avm_avm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UTXOID) GetTxId() []byte {
+	if x != nil {
+		return x.TxId
+	}
+	return nil
+}
+
+func (x *UTXOID) GetOutputIndex() uint32 {
+	if x != nil {
+		return x.OutputIndex
+	}
+	return 0
+}
+
+var File_avm_avm_proto protoreflect.FileDescriptor
+
+var file_avm_avm_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x61, 0x76, 0x6d, 0x2f, 0x61, 0x76, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x03, 0x61, 0x76, 0x6d, 0x22, 0x20, 0x0a, 0x0e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x54, 0x78, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x74, 0x78, 0x22, 0x26, 0x0a, 0x0f, 0x49, 0x73, 0x73, 0x75, 0x65, 0x54,
+	0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x78, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x22, 0x23,
+	0x0a, 0x0c, 0x47, 0x65, 0x74, 0x54, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x13,
+	0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x74,
+	0x78, 0x49, 0x64, 0x22, 0x1f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x78, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x02, 0x74, 0x78, 0x22, 0x6f, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55, 0x54, 0x58, 0x4f, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x28, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x55, 0x54, 0x58, 0x4f,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x74, 0x78,
+	0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x75, 0x74, 0x78, 0x6f, 0x73, 0x22,
+	0x71, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69,
+	0x61, 0x6c, 0x22, 0x56, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x26, 0x0a, 0x08, 0x75, 0x74, 0x78, 0x6f, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x76, 0x6d, 0x2e, 0x55, 0x54, 0x58, 0x4f, 0x49,
+	0x44, 0x52, 0x07, 0x75, 0x74, 0x78, 0x6f, 0x49, 0x64, 0x73, 0x22, 0x40, 0x0a, 0x06, 0x55, 0x54,
+	0x58, 0x4f, 0x49, 0x44, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x32, 0xe5, 0x01, 0x0a,
+	0x03, 0x41, 0x56, 0x4d, 0x12, 0x34, 0x0a, 0x07, 0x49, 0x73, 0x73, 0x75, 0x65, 0x54, 0x78, 0x12,
+	0x13, 0x2e, 0x61, 0x76, 0x6d, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x54, 0x78, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x76, 0x6d, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65,
+	0x54, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x47, 0x65,
+	0x74, 0x54, 0x78, 0x12, 0x11, 0x2e, 0x61, 0x76, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x78, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x61, 0x76, 0x6d, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x55, 0x54, 0x58, 0x4f, 0x73, 0x12, 0x14, 0x2e, 0x61, 0x76, 0x6d, 0x2e, 0x47, 0x65, 0x74,
+	0x55, 0x54, 0x58, 0x4f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61,
+	0x76, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x54, 0x58, 0x4f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3d, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x16, 0x2e, 0x61, 0x76, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x61, 0x76,
+	0x6d, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6c, 0x61, 0x73, 0x74, 0x68, 0x79, 0x70, 0x68, 0x65, 0x6e, 0x2f, 0x62, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f,
+	0x61, 0x76, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_avm_avm_proto_rawDescOnce sync.Once
+	file_avm_avm_proto_rawDescData = file_avm_avm_proto_rawDesc
+)
+
+func file_avm_avm_proto_rawDescGZIP() []byte {
+	file_avm_avm_proto_rawDescOnce.Do(func() {
+		file_avm_avm_proto_rawDescData = protoimpl.X.CompressGZIP(file_avm_avm_proto_rawDescData)
+	})
+	return file_avm_avm_proto_rawDescData
+}
+
+var file_avm_avm_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_avm_avm_proto_goTypes = []interface{}{
+	(*IssueTxRequest)(nil),     // 0: avm.IssueTxRequest
+	(*IssueTxResponse)(nil),    // 1: avm.IssueTxResponse
+	(*GetTxRequest)(nil),       // 2: avm.GetTxRequest
+	(*GetTxResponse)(nil),      // 3: avm.GetTxResponse
+	(*GetUTXOsRequest)(nil),    // 4: avm.GetUTXOsRequest
+	(*GetUTXOsResponse)(nil),   // 5: avm.GetUTXOsResponse
+	(*GetBalanceRequest)(nil),  // 6: avm.GetBalanceRequest
+	(*GetBalanceResponse)(nil), // 7: avm.GetBalanceResponse
+	(*UTXOID)(nil),             // 8: avm.UTXOID
+}
+var file_avm_avm_proto_depIdxs = []int32{
+	8, // 0: avm.GetBalanceResponse.utxo_ids:type_name -> avm.UTXOID
+	0, // 1: avm.AVM.IssueTx:input_type -> avm.IssueTxRequest
+	2, // 2: avm.AVM.GetTx:input_type -> avm.GetTxRequest
+	4, // 3: avm.AVM.GetUTXOs:input_type -> avm.GetUTXOsRequest
+	6, // 4: avm.AVM.GetBalance:input_type -> avm.GetBalanceRequest
+	1, // 5: avm.AVM.IssueTx:output_type -> avm.IssueTxResponse
+	3, // 6: avm.AVM.GetTx:output_type -> avm.GetTxResponse
+	5, // 7: avm.AVM.GetUTXOs:output_type -> avm.GetUTXOsResponse
+	7, // 8: avm.AVM.GetBalance:output_type -> avm.GetBalanceResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_avm_avm_proto_init() }
+func file_avm_avm_proto_init() {
+	if File_avm_avm_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_avm_avm_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssueTxRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_avm_avm_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssueTxResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_avm_avm_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTxRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_avm_avm_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTxResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_avm_avm_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUTXOsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_avm_avm_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUTXOsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_avm_avm_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBalanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_avm_avm_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBalanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_avm_avm_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UTXOID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_avm_avm_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_avm_avm_proto_goTypes,
+		DependencyIndexes: file_avm_avm_proto_depIdxs,
+		MessageInfos:      file_avm_avm_proto_msgTypes,
+	}.Build()
+	File_avm_avm_proto = out.File
+	file_avm_avm_proto_rawDesc = nil
+	file_avm_avm_proto_goTypes = nil
+	file_avm_avm_proto_depIdxs = nil
+}
@@ -0,0 +1,245 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: avm/avm.proto
+
+package avm
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AVMClient is the client API for AVM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AVMClient interface {
+	IssueTx(ctx context.Context, in *IssueTxRequest, opts ...grpc.CallOption) (*IssueTxResponse, error)
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	// GetUTXOs streams the UTXOs of the addresses one page at a time, until
+	// every UTXO has been sent.
+	GetUTXOs(ctx context.Context, in *GetUTXOsRequest, opts ...grpc.CallOption) (AVM_GetUTXOsClient, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+}
+
+type aVMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAVMClient(cc grpc.ClientConnInterface) AVMClient {
+	return &aVMClient{cc}
+}
+
+func (c *aVMClient) IssueTx(ctx context.Context, in *IssueTxRequest, opts ...grpc.CallOption) (*IssueTxResponse, error) {
+	out := new(IssueTxResponse)
+	err := c.cc.Invoke(ctx, "/avm.AVM/IssueTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/avm.AVM/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetUTXOs(ctx context.Context, in *GetUTXOsRequest, opts ...grpc.CallOption) (AVM_GetUTXOsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AVM_ServiceDesc.Streams[0], "/avm.AVM/GetUTXOs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aVMGetUTXOsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AVM_GetUTXOsClient interface {
+	Recv() (*GetUTXOsResponse, error)
+	grpc.ClientStream
+}
+
+type aVMGetUTXOsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aVMGetUTXOsClient) Recv() (*GetUTXOsResponse, error) {
+	m := new(GetUTXOsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aVMClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, "/avm.AVM/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AVMServer is the server API for AVM service.
+// All implementations must embed UnimplementedAVMServer
+// for forward compatibility
+type AVMServer interface {
+	IssueTx(context.Context, *IssueTxRequest) (*IssueTxResponse, error)
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	// GetUTXOs streams the UTXOs of the addresses one page at a time, until
+	// every UTXO has been sent.
+	GetUTXOs(*GetUTXOsRequest, AVM_GetUTXOsServer) error
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	mustEmbedUnimplementedAVMServer()
+}
+
+// UnimplementedAVMServer must be embedded to have forward compatible implementations.
+type UnimplementedAVMServer struct {
+}
+
+func (UnimplementedAVMServer) IssueTx(context.Context, *IssueTxRequest) (*IssueTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueTx not implemented")
+}
+func (UnimplementedAVMServer) GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (UnimplementedAVMServer) GetUTXOs(*GetUTXOsRequest, AVM_GetUTXOsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetUTXOs not implemented")
+}
+func (UnimplementedAVMServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedAVMServer) mustEmbedUnimplementedAVMServer() {}
+
+// UnsafeAVMServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AVMServer will
+// result in compilation errors.
+type UnsafeAVMServer interface {
+	mustEmbedUnimplementedAVMServer()
+}
+
+func RegisterAVMServer(s grpc.ServiceRegistrar, srv AVMServer) {
+	s.RegisterService(&AVM_ServiceDesc, srv)
+}
+
+func _AVM_IssueTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).IssueTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avm.AVM/IssueTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).IssueTx(ctx, req.(*IssueTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avm.AVM/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetUTXOs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetUTXOsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AVMServer).GetUTXOs(m, &aVMGetUTXOsServer{stream})
+}
+
+type AVM_GetUTXOsServer interface {
+	Send(*GetUTXOsResponse) error
+	grpc.ServerStream
+}
+
+type aVMGetUTXOsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aVMGetUTXOsServer) Send(m *GetUTXOsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AVM_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avm.AVM/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AVM_ServiceDesc is the grpc.ServiceDesc for AVM service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AVM_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "avm.AVM",
+	HandlerType: (*AVMServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IssueTx",
+			Handler:    _AVM_IssueTx_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _AVM_GetTx_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _AVM_GetBalance_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetUTXOs",
+			Handler:       _AVM_GetUTXOs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "avm/avm.proto",
+}
@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: replication/replication.proto
+
+package replication
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ReplicationClient is the client API for Replication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReplicationClient interface {
+	// StreamAccepted sends the accepted containers of a chain in the order they
+	// were accepted, starting at [start_index], and then keeps sending newly
+	// accepted containers until the stream is closed.
+	StreamAccepted(ctx context.Context, in *StreamAcceptedRequest, opts ...grpc.CallOption) (Replication_StreamAcceptedClient, error)
+}
+
+type replicationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicationClient(cc grpc.ClientConnInterface) ReplicationClient {
+	return &replicationClient{cc}
+}
+
+func (c *replicationClient) StreamAccepted(ctx context.Context, in *StreamAcceptedRequest, opts ...grpc.CallOption) (Replication_StreamAcceptedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Replication_ServiceDesc.Streams[0], "/replication.Replication/StreamAccepted", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replicationStreamAcceptedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Replication_StreamAcceptedClient interface {
+	Recv() (*AcceptedContainer, error)
+	grpc.ClientStream
+}
+
+type replicationStreamAcceptedClient struct {
+	grpc.ClientStream
+}
+
+func (x *replicationStreamAcceptedClient) Recv() (*AcceptedContainer, error) {
+	m := new(AcceptedContainer)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReplicationServer is the server API for Replication service.
+// All implementations must embed UnimplementedReplicationServer
+// for forward compatibility
+type ReplicationServer interface {
+	// StreamAccepted sends the accepted containers of a chain in the order they
+	// were accepted, starting at [start_index], and then keeps sending newly
+	// accepted containers until the stream is closed.
+	StreamAccepted(*StreamAcceptedRequest, Replication_StreamAcceptedServer) error
+	mustEmbedUnimplementedReplicationServer()
+}
+
+// UnimplementedReplicationServer must be embedded to have forward compatible implementations.
+type UnimplementedReplicationServer struct {
+}
+
+func (UnimplementedReplicationServer) StreamAccepted(*StreamAcceptedRequest, Replication_StreamAcceptedServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAccepted not implemented")
+}
+func (UnimplementedReplicationServer) mustEmbedUnimplementedReplicationServer() {}
+
+// UnsafeReplicationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplicationServer will
+// result in compilation errors.
+type UnsafeReplicationServer interface {
+	mustEmbedUnimplementedReplicationServer()
+}
+
+func RegisterReplicationServer(s grpc.ServiceRegistrar, srv ReplicationServer) {
+	s.RegisterService(&Replication_ServiceDesc, srv)
+}
+
+func _Replication_StreamAccepted_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAcceptedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplicationServer).StreamAccepted(m, &replicationStreamAcceptedServer{stream})
+}
+
+type Replication_StreamAcceptedServer interface {
+	Send(*AcceptedContainer) error
+	grpc.ServerStream
+}
+
+type replicationStreamAcceptedServer struct {
+	grpc.ServerStream
+}
+
+func (x *replicationStreamAcceptedServer) Send(m *AcceptedContainer) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Replication_ServiceDesc is the grpc.ServiceDesc for Replication service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Replication_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "replication.Replication",
+	HandlerType: (*ReplicationServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAccepted",
+			Handler:       _Replication_StreamAccepted_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "replication/replication.proto",
+}
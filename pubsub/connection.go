@@ -39,6 +39,10 @@ type connection struct {
 
 	fp *FilterParam
 
+	// subscription that can be resumed after this connection drops, or nil.
+	// Protected by the server's lock.
+	subscription *subscription
+
 	active uint32
 }
 
@@ -165,6 +169,10 @@ func (c *connection) readMessage() error {
 		c.handleNewSet(cmd.NewSet)
 	case cmd.AddAddresses != nil:
 		err = c.handleAddAddresses(cmd.AddAddresses)
+	case cmd.Subscribe != nil:
+		err = c.handleSubscribe()
+	case cmd.Resume != nil:
+		err = c.s.resume(c, cmd.Resume.Token)
 	default:
 		err = ErrInvalidCommand
 	}
@@ -192,6 +200,15 @@ func (c *connection) handleNewSet(_ *NewSet) {
 	c.fp.NewSet()
 }
 
+func (c *connection) handleSubscribe() error {
+	token, err := c.s.subscribe(c)
+	if err != nil {
+		return err
+	}
+	c.Send(&SubscriptionReply{Token: token})
+	return nil
+}
+
 func (c *connection) handleAddAddresses(cmd *AddAddresses) error {
 	if err := cmd.parseAddresses(); err != nil {
 		return fmt.Errorf("address parse failed %w", err)
@@ -5,15 +5,18 @@ package pubsub
 
 import "sync"
 
+// connections is the set of filters that published messages are matched
+// against. Each filter is either a live connection or a detached
+// subscription.
 type connections struct {
 	lock      sync.RWMutex
-	conns     map[*connection]struct{}
+	conns     map[Filter]struct{}
 	connsList []Filter
 }
 
 func newConnections() *connections {
 	return &connections{
-		conns: make(map[*connection]struct{}),
+		conns: make(map[Filter]struct{}),
 	}
 }
 
@@ -24,7 +27,7 @@ func (c *connections) Conns() []Filter {
 	return append([]Filter{}, c.connsList...)
 }
 
-func (c *connections) Remove(conn *connection) {
+func (c *connections) Remove(conn Filter) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -32,7 +35,7 @@ func (c *connections) Remove(conn *connection) {
 	c.createConnsList()
 }
 
-func (c *connections) Add(conn *connection) {
+func (c *connections) Add(conn Filter) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -38,6 +38,31 @@ func TestAddAddressesParseAddresses(t *testing.T) {
 	assert.Equal(addrID[:], msg.addressIds[0])
 }
 
+func TestAddAddressesParseNodeAndSubnetIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+	msg := &AddAddresses{JSONAddresses: api.JSONAddresses{
+		Addresses: []string{
+			nodeID.String(),
+			subnetID.String(),
+		},
+	}}
+
+	err := msg.parseAddresses()
+	assert.NoError(err)
+
+	assert.Len(msg.addressIds, 2)
+	assert.Equal(nodeID[:], msg.addressIds[0])
+	assert.Equal(subnetID[:], msg.addressIds[1])
+
+	msg = &AddAddresses{JSONAddresses: api.JSONAddresses{
+		Addresses: []string{"not an address"},
+	}}
+	assert.Error(msg.parseAddresses())
+}
+
 func TestFilterParamUpdateMulti(t *testing.T) {
 	fp := NewFilterParam()
 
@@ -4,7 +4,10 @@
 package pubsub
 
 import (
+	"strings"
+
 	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/utils/formatting/address"
 	"github.com/lasthyphen/beacongo/utils/json"
 )
@@ -28,11 +31,33 @@ type AddAddresses struct {
 	addressIds [][]byte
 }
 
+// Subscribe command to create a subscription token for the connection's
+// filters
+type Subscribe struct{}
+
+// Resume command to restore the filters of a previous connection
+type Resume struct {
+	// Token returned when the previous connection subscribed
+	Token string `json:"token"`
+}
+
+// SubscriptionReply is sent in response to the Subscribe and Resume commands
+type SubscriptionReply struct {
+	Token string `json:"token"`
+	// Number of messages replayed after this reply
+	Replayed int `json:"replayed"`
+	// True if messages published while disconnected were dropped because the
+	// replay buffer was full
+	Truncated bool `json:"truncated"`
+}
+
 // Command execution command
 type Command struct {
 	NewBloom     *NewBloom     `json:"newBloom,omitempty"`
 	NewSet       *NewSet       `json:"newSet,omitempty"`
 	AddAddresses *AddAddresses `json:"addAddresses,omitempty"`
+	Subscribe    *Subscribe    `json:"subscribe,omitempty"`
+	Resume       *Resume       `json:"resume,omitempty"`
 }
 
 func (c *Command) String() string {
@@ -43,6 +68,10 @@ func (c *Command) String() string {
 		return "newSet"
 	case c.AddAddresses != nil:
 		return "addAddresses"
+	case c.Subscribe != nil:
+		return "subscribe"
+	case c.Resume != nil:
+		return "resume"
 	default:
 		return "unknown"
 	}
@@ -59,7 +88,7 @@ func (c *AddAddresses) parseAddresses() error {
 		c.addressIds = make([][]byte, len(c.Addresses))
 	}
 	for i, addrStr := range c.Addresses {
-		_, _, addrBytes, err := address.Parse(addrStr)
+		addrBytes, err := parseAddress(addrStr)
 		if err != nil {
 			return err
 		}
@@ -67,3 +96,26 @@ func (c *AddAddresses) parseAddresses() error {
 	}
 	return nil
 }
+
+// parseAddress converts a bech32 address to its byte format. Node IDs and cb58
+// encoded IDs, such as subnet IDs, are accepted too, so that events that are
+// about nodes or subnets rather than addresses can be subscribed to.
+func parseAddress(addrStr string) ([]byte, error) {
+	if strings.HasPrefix(addrStr, ids.NodeIDPrefix) {
+		nodeID, err := ids.NodeIDFromString(addrStr)
+		if err != nil {
+			return nil, err
+		}
+		return nodeID.Bytes(), nil
+	}
+	_, _, addrBytes, err := address.Parse(addrStr)
+	if err == nil {
+		return addrBytes, nil
+	}
+	id, idErr := ids.FromString(addrStr)
+	if idErr != nil {
+		// Report the address error, as addresses are the common case
+		return nil, err
+	}
+	return id[:], nil
+}
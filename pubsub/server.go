@@ -55,19 +55,31 @@ var upgrader = websocket.Upgrader{
 
 // Server maintains the set of active clients and sends messages to the clients.
 type Server struct {
-	log  logging.Logger
-	lock sync.RWMutex
+	log    logging.Logger
+	config Config
+	lock   sync.RWMutex
 	// conns a list of all our connections
 	conns map[*connection]struct{}
 	// subscribedConnections the connections that have activated subscriptions
+	// and the subscriptions that are waiting to be resumed
 	subscribedConnections *connections
+	// token -> subscription
+	subscriptions map[string]*subscription
 }
 
 func New(networkID uint32, log logging.Logger) *Server {
+	return NewWithConfig(networkID, log, Config{})
+}
+
+// NewWithConfig returns a server whose subscriptions can be resumed according
+// to [config].
+func NewWithConfig(networkID uint32, log logging.Logger, config Config) *Server {
 	return &Server{
 		log:                   log,
+		config:                config,
 		conns:                 make(map[*connection]struct{}),
 		subscribedConnections: newConnections(),
+		subscriptions:         make(map[string]*subscription),
 	}
 }
 
@@ -88,15 +100,25 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) Publish(parser Filterer) {
+	// Holding the lock prevents subscriptions from being resumed while the
+	// message is delivered, so a resumed subscription can't miss the message
+	// or receive it twice.
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
 	conns := s.subscribedConnections.Conns()
 	toNotify, msg := parser.Filter(conns)
 	for i, shouldNotify := range toNotify {
 		if !shouldNotify {
 			continue
 		}
-		conn := conns[i].(*connection)
-		if !conn.Send(msg) {
-			s.log.Verbo("dropping message to subscribed connection due to too many pending messages")
+		switch conn := conns[i].(type) {
+		case *connection:
+			if !conn.Send(msg) {
+				s.log.Verbo("dropping message to subscribed connection due to too many pending messages")
+			}
+		case *subscription:
+			conn.buffer(msg)
 		}
 	}
 }
@@ -112,10 +134,15 @@ func (s *Server) addConnection(conn *connection) {
 }
 
 func (s *Server) removeConnection(conn *connection) {
-	s.subscribedConnections.Remove(conn)
-
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	s.subscribedConnections.Remove(conn)
 	delete(s.conns, conn)
+
+	// removeConnection is called by both the readPump and the writePump, so
+	// the subscription may have already been detached.
+	if sub := conn.subscription; sub != nil && sub.conn == conn {
+		s.detach(sub)
+	}
 }
@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// number of random bytes in a subscription token
+const tokenByteLen = 20
+
+var (
+	ErrResumeDisabled    = errors.New("subscriptions can't be resumed")
+	ErrUnknownToken      = errors.New("unknown or expired subscription token")
+	ErrSubscriptionInUse = errors.New("subscription is attached to another connection")
+
+	_ Filter = &subscription{}
+)
+
+// Config describes how subscriptions survive disconnects.
+type Config struct {
+	// ResumeWindow is how long the filters of a subscribed connection are kept
+	// after it disconnects. If 0, subscriptions can't be resumed.
+	ResumeWindow time.Duration `json:"resume-window"`
+
+	// ReplayBufferSize is the max number of messages kept for a disconnected
+	// subscription. If the buffer is full, the oldest message is dropped. If 0
+	// or larger than the number of messages that can be pending on a
+	// connection, it is set to that number.
+	ReplayBufferSize int `json:"replay-buffer-size"`
+}
+
+// subscription is a set of filters that outlives the connection that created
+// it. While it isn't attached to a connection, messages that match the filters
+// are buffered until the subscription is resumed or expires.
+type subscription struct {
+	token string
+	fp    *FilterParam
+
+	// The following fields are protected by the server's lock.
+
+	// conn is the connection the subscription is attached to, or nil if it
+	// is detached.
+	conn *connection
+	// number of times the subscription was detached, used to ignore expiry
+	// timers of previous detachments
+	detachments uint64
+	expiry      *time.Timer
+
+	lock       sync.Mutex
+	maxPending int
+	pending    []interface{}
+	truncated  bool
+}
+
+func (s *subscription) Check(addr []byte) bool {
+	return s.fp.Check(addr)
+}
+
+// buffer [msg] until the subscription is resumed
+func (s *subscription) buffer(msg interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.pending) >= s.maxPending {
+		s.pending = s.pending[1:]
+		s.truncated = true
+	}
+	s.pending = append(s.pending, msg)
+}
+
+// flush returns the buffered messages and whether any were dropped
+func (s *subscription) flush() ([]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	pending, truncated := s.pending, s.truncated
+	s.pending = nil
+	s.truncated = false
+	return pending, truncated
+}
+
+// subscribe returns the token that [c] can be resumed with after it
+// disconnects.
+func (s *Server) subscribe(c *connection) (string, error) {
+	if s.config.ResumeWindow <= 0 {
+		return "", ErrResumeDisabled
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if c.subscription != nil {
+		return c.subscription.token, nil
+	}
+
+	tokenBytes := [tokenByteLen]byte{}
+	if _, err := rand.Read(tokenBytes[:]); err != nil {
+		return "", fmt.Errorf("failed to generate subscription token: %w", err)
+	}
+	sub := &subscription{
+		token:      base64.RawURLEncoding.EncodeToString(tokenBytes[:]),
+		fp:         c.fp,
+		conn:       c,
+		maxPending: s.replayBufferSize(),
+	}
+	s.subscriptions[sub.token] = sub
+	c.subscription = sub
+	return sub.token, nil
+}
+
+// resume attaches the subscription with [token] to [c] and sends [c] the
+// messages published since the subscription was detached.
+func (s *Server) resume(c *connection, token string) error {
+	if s.config.ResumeWindow <= 0 {
+		return ErrResumeDisabled
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sub, ok := s.subscriptions[token]
+	switch {
+	case !ok:
+		return ErrUnknownToken
+	case sub.conn != nil:
+		return ErrSubscriptionInUse
+	}
+
+	if old := c.subscription; old != nil {
+		// The connection's own subscription is replaced.
+		delete(s.subscriptions, old.token)
+	}
+	sub.expiry.Stop()
+	s.subscribedConnections.Remove(sub)
+
+	sub.conn = c
+	c.subscription = sub
+	c.fp = sub.fp
+
+	pending, truncated := sub.flush()
+	c.Send(&SubscriptionReply{
+		Token:     sub.token,
+		Replayed:  len(pending),
+		Truncated: truncated,
+	})
+	for _, msg := range pending {
+		if !c.Send(msg) {
+			s.log.Verbo("dropping replayed message due to too many pending messages")
+		}
+	}
+	s.subscribedConnections.Add(c)
+	return nil
+}
+
+// detach [sub] from its connection and start buffering its messages.
+//
+// Assumes [s.lock] is held.
+func (s *Server) detach(sub *subscription) {
+	sub.conn = nil
+	sub.detachments++
+	detachments := sub.detachments
+	s.subscribedConnections.Add(sub)
+	sub.expiry = time.AfterFunc(s.config.ResumeWindow, func() {
+		s.expire(sub, detachments)
+	})
+}
+
+// expire removes [sub] if it wasn't resumed since it was detached for the
+// [detachments]th time.
+func (s *Server) expire(sub *subscription, detachments uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if sub.conn != nil || sub.detachments != detachments {
+		return
+	}
+	delete(s.subscriptions, sub.token)
+	s.subscribedConnections.Remove(sub)
+}
+
+func (s *Server) replayBufferSize() int {
+	size := s.config.ReplayBufferSize
+	if size <= 0 || size > maxPendingMessages {
+		return maxPendingMessages
+	}
+	return size
+}
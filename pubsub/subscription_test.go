@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/utils/formatting/address"
+	"github.com/lasthyphen/beacongo/utils/logging"
+)
+
+type testFilterer struct {
+	addr []byte
+	msg  string
+}
+
+func (f *testFilterer) Filter(conns []Filter) ([]bool, interface{}) {
+	toNotify := make([]bool, len(conns))
+	for i, conn := range conns {
+		toNotify[i] = conn.Check(f.addr)
+	}
+	return toNotify, f.msg
+}
+
+func TestSubscriptionResume(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewWithConfig(constants.UnitTestID, logging.NoLog{}, Config{
+		ResumeWindow:     time.Minute,
+		ReplayBufferSize: 1,
+	})
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+
+	addrID := ids.ShortID{1}
+	addrStr, err := address.Format("X", constants.GetHRP(constants.UnitTestID), addrID[:])
+	assert.NoError(err)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(err)
+	assert.NoError(conn.WriteJSON(&Command{NewSet: &NewSet{}}))
+	assert.NoError(conn.WriteJSON(&Command{AddAddresses: &AddAddresses{
+		JSONAddresses: api.JSONAddresses{Addresses: []string{addrStr}},
+	}}))
+	assert.NoError(conn.WriteJSON(&Command{Subscribe: &Subscribe{}}))
+
+	reply := SubscriptionReply{}
+	assert.NoError(conn.ReadJSON(&reply))
+	assert.NotEmpty(reply.Token)
+	token := reply.Token
+
+	s.Publish(&testFilterer{addr: addrID[:], msg: "live"})
+	msg := ""
+	assert.NoError(conn.ReadJSON(&msg))
+	assert.Equal("live", msg)
+
+	// Drop the connection and wait for the subscription to be detached
+	assert.NoError(conn.Close())
+	assert.Eventually(func() bool {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+
+		sub, ok := s.subscriptions[token]
+		return ok && sub.conn == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Only the most recent message fits in the replay buffer
+	s.Publish(&testFilterer{addr: addrID[:], msg: "missed1"})
+	s.Publish(&testFilterer{addr: addrID[:], msg: "missed2"})
+	s.Publish(&testFilterer{addr: ids.ShortEmpty[:], msg: "unrelated"})
+
+	conn, _, err = websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(err)
+	defer conn.Close()
+	assert.NoError(conn.WriteJSON(&Command{Resume: &Resume{Token: token}}))
+
+	reply = SubscriptionReply{}
+	assert.NoError(conn.ReadJSON(&reply))
+	assert.Equal(token, reply.Token)
+	assert.Equal(1, reply.Replayed)
+	assert.True(reply.Truncated)
+
+	assert.NoError(conn.ReadJSON(&msg))
+	assert.Equal("missed2", msg)
+
+	// The resumed connection keeps receiving messages that match the filters
+	s.Publish(&testFilterer{addr: addrID[:], msg: "live"})
+	assert.NoError(conn.ReadJSON(&msg))
+	assert.Equal("live", msg)
+}
+
+func TestSubscriptionResumeErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	disabled := New(constants.UnitTestID, logging.NoLog{})
+	_, err := disabled.subscribe(&connection{fp: NewFilterParam()})
+	assert.ErrorIs(err, ErrResumeDisabled)
+
+	s := NewWithConfig(constants.UnitTestID, logging.NoLog{}, Config{
+		ResumeWindow: time.Minute,
+	})
+	c := &connection{
+		s:    s,
+		send: make(chan interface{}, maxPendingMessages),
+		fp:   NewFilterParam(),
+	}
+	assert.ErrorIs(s.resume(c, "unknown"), ErrUnknownToken)
+
+	token, err := s.subscribe(c)
+	assert.NoError(err)
+
+	// The subscription is still attached to [c]
+	other := &connection{
+		s:    s,
+		send: make(chan interface{}, maxPendingMessages),
+		fp:   NewFilterParam(),
+	}
+	assert.ErrorIs(s.resume(other, token), ErrSubscriptionInUse)
+}
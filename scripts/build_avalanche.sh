@@ -42,10 +42,19 @@ source "$AVALANCHE_PATH"/scripts/versions.sh
 source "$AVALANCHE_PATH"/scripts/constants.sh
 
 # Build with rocksdb allowed only if the environment variable ROCKSDBALLOWED is set
-if [ -z ${ROCKSDBALLOWED+x} ]; then
+build_tags=""
+if [ -n "${ROCKSDBALLOWED+x}" ]; then
+    build_tags="rocksdballowed"
+fi
+
+version_pkg="github.com/lasthyphen/beacongo/version"
+version_ld_flags="-X $version_pkg.GitCommit=$git_commit -X $version_pkg.BuildDate=$build_date -X $version_pkg.BuildTags=$build_tags"
+
+# -trimpath removes local file system paths from the binary so that builds of
+# the same commit are reproducible across machines.
+if [ -z "$build_tags" ]; then
     echo "Building Dijets Node..."
-    go build -ldflags "-X github.com/lasthyphen/beacongo/version.GitCommit=$git_commit $static_ld_flags" -o "$avalanchego_path" "$AVALANCHE_PATH/main/"*.go
 else
-    echo "Building Dijets Node with rocksdb enabled..."
-    go build -tags rocksdballowed -ldflags "-X github.com/lasthyphen/beacongo/version.GitCommit=$git_commit $static_ld_flags" -o "$avalanchego_path" "$AVALANCHE_PATH/main/"*.go
+    echo "Building Dijets Node with tags: $build_tags..."
 fi
+go build -trimpath -tags "$build_tags" -ldflags "$version_ld_flags $static_ld_flags" -o "$avalanchego_path" "$AVALANCHE_PATH/main/"*.go
@@ -28,6 +28,14 @@ current_branch=$(git symbolic-ref -q --short HEAD || git describe --tags --exact
 
 git_commit=${AVALANCHEGO_COMMIT:-$( git rev-list -1 HEAD )}
 
+# Use the commit date, rather than the current time, as the build date so that
+# builds are reproducible. SOURCE_DATE_EPOCH overrides it when set.
+if [ -n "${SOURCE_DATE_EPOCH:-}" ]; then
+    build_date=$(date -u -d "@$SOURCE_DATE_EPOCH" +%Y-%m-%dT%H:%M:%SZ)
+else
+    build_date=${AVALANCHEGO_BUILD_DATE:-$( git log -1 --format=%cI HEAD 2>/dev/null || true )}
+fi
+
 # Static compilation
 static_ld_flags=''
 if [ "${STATIC_COMPILATION:-}" = 1 ]
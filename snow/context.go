@@ -80,6 +80,9 @@ type ConsensusContext struct {
 
 	// Indicates this chain is available to only validators.
 	validatorOnly utils.AtomicBool
+
+	// Non-zero iff this chain was put into maintenance mode.
+	maintenance utils.AtomicBool
 }
 
 func (ctx *ConsensusContext) SetState(newState State) {
@@ -112,6 +115,18 @@ func (ctx *ConsensusContext) SetValidatorOnly() {
 	ctx.validatorOnly.SetValue(true)
 }
 
+// InMaintenance returns true iff this chain is in maintenance mode. While in
+// maintenance mode, the chain doesn't participate in consensus and rejects API
+// calls that write to it.
+func (ctx *ConsensusContext) InMaintenance() bool {
+	return ctx.maintenance.GetValue()
+}
+
+// SetMaintenance puts this chain into, or takes it out of, maintenance mode.
+func (ctx *ConsensusContext) SetMaintenance(b bool) {
+	ctx.maintenance.SetValue(b)
+}
+
 func DefaultContextTest() *Context {
 	return &Context{
 		NetworkID: 0,
@@ -35,6 +35,15 @@ func New(storage vertex.Storage, commonCfg common.Config) (common.AllGetsServer,
 		"vertices fetched in a call to GetAncestors",
 		commonCfg.Ctx.Registerer,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	gh.ancestorsCache, err = common.NewAncestorsCache(
+		commonCfg.AncestorsCacheSize,
+		"bs_ancestors_cache",
+		commonCfg.Ctx.Registerer,
+	)
 	return gh, err
 }
 
@@ -45,6 +54,7 @@ type getter struct {
 
 	log              logging.Logger
 	getAncestorsVtxs metric.Averager
+	ancestorsCache   common.AncestorsCache
 }
 
 func (gh *getter) GetStateSummaryFrontier(validatorID ids.NodeID, requestID uint32) error {
@@ -83,6 +93,14 @@ func (gh *getter) GetAncestors(nodeID ids.NodeID, requestID uint32, vtxID ids.ID
 		return nil // Don't have the requested vertex. Drop message.
 	}
 
+	if ancestorsBytes, ok := gh.ancestorsCache.Get(vtxID, gh.cfg.AncestorsMaxContainersSent); ok {
+		ancestorsBytesLen := 0
+		for _, vtxBytes := range ancestorsBytes {
+			ancestorsBytesLen += wrappers.IntLen + len(vtxBytes)
+		}
+		return gh.sendAncestors(nodeID, requestID, vtxID, ancestorsBytes, ancestorsBytesLen)
+	}
+
 	queue := make([]avalanche.Vertex, 1, gh.cfg.AncestorsMaxContainersSent) // for BFS
 	queue[0] = vertex
 	ancestorsBytesLen := 0                                                 // length, in bytes, of vertex and its ancestors
@@ -117,6 +135,20 @@ func (gh *getter) GetAncestors(nodeID ids.NodeID, requestID uint32, vtxID ids.ID
 		}
 	}
 
+	// The parents of an accepted vertex are all accepted, so the ancestors of
+	// the vertex that are sent never change
+	if vertex.Status() == choices.Accepted {
+		gh.ancestorsCache.Put(vtxID, gh.cfg.AncestorsMaxContainersSent, ancestorsBytes)
+	}
+	return gh.sendAncestors(nodeID, requestID, vtxID, ancestorsBytes, ancestorsBytesLen)
+}
+
+func (gh *getter) sendAncestors(nodeID ids.NodeID, requestID uint32, vtxID ids.ID, ancestorsBytes [][]byte, ancestorsBytesLen int) error {
+	if !gh.cfg.ServingBandwidth.TryConsume(ancestorsBytesLen) {
+		gh.log.Verbo("dropping GetAncestors(%s, %d, %s) due to the serving bandwidth limit", nodeID, requestID, vtxID)
+		return nil
+	}
+
 	gh.getAncestorsVtxs.Observe(float64(len(ancestorsBytes)))
 	gh.sender.SendAncestors(nodeID, requestID, ancestorsBytes)
 	return nil
@@ -125,7 +157,12 @@ func (gh *getter) GetAncestors(nodeID ids.NodeID, requestID uint32, vtxID ids.ID
 func (gh *getter) Get(nodeID ids.NodeID, requestID uint32, vtxID ids.ID) error {
 	// If this engine has access to the requested vertex, provide it
 	if vtx, err := gh.storage.GetVtx(vtxID); err == nil {
-		gh.sender.SendPut(nodeID, requestID, vtxID, vtx.Bytes())
+		vtxBytes := vtx.Bytes()
+		if !gh.cfg.ServingBandwidth.TryConsume(len(vtxBytes)) {
+			gh.log.Verbo("dropping Get(%s, %d, %s) due to the serving bandwidth limit", nodeID, requestID, vtxID)
+			return nil
+		}
+		gh.sender.SendPut(nodeID, requestID, vtxID, vtxBytes)
 	}
 	return nil
 }
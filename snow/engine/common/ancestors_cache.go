@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/cache"
+	"github.com/lasthyphen/beacongo/cache/metercacher"
+	"github.com/lasthyphen/beacongo/ids"
+)
+
+var (
+	_ AncestorsCache = &ancestorsCache{}
+	_ AncestorsCache = noAncestorsCache{}
+)
+
+// AncestorsCache holds the Ancestors responses that were recently sent, so that
+// peers that bootstrap at the same time don't each cause the same containers
+// to be read from the database.
+type AncestorsCache interface {
+	// Get returns the containers sent in response to a GetAncestors for
+	// [containerID], when at most [maxContainers] containers could be sent.
+	Get(containerID ids.ID, maxContainers int) ([][]byte, bool)
+
+	// Put caches the containers sent in response to a GetAncestors for
+	// [containerID], when at most [maxContainers] containers could be sent.
+	Put(containerID ids.ID, maxContainers int, containers [][]byte)
+}
+
+type ancestorsKey struct {
+	containerID   ids.ID
+	maxContainers int
+}
+
+type ancestorsCache struct {
+	cache cache.Cacher
+}
+
+// NewAncestorsCache returns a cache of the last [size] Ancestors responses.
+// Its hits and misses are reported under [namespace]. If [size] is 0, nothing
+// is cached.
+func NewAncestorsCache(size int, namespace string, registerer prometheus.Registerer) (AncestorsCache, error) {
+	if size <= 0 {
+		return noAncestorsCache{}, nil
+	}
+	meteredCache, err := metercacher.New(namespace, registerer, &cache.LRU{Size: size})
+	return &ancestorsCache{cache: meteredCache}, err
+}
+
+func (c *ancestorsCache) Get(containerID ids.ID, maxContainers int) ([][]byte, bool) {
+	containers, ok := c.cache.Get(ancestorsKey{
+		containerID:   containerID,
+		maxContainers: maxContainers,
+	})
+	if !ok {
+		return nil, false
+	}
+	return containers.([][]byte), true
+}
+
+func (c *ancestorsCache) Put(containerID ids.ID, maxContainers int, containers [][]byte) {
+	c.cache.Put(ancestorsKey{
+		containerID:   containerID,
+		maxContainers: maxContainers,
+	}, containers)
+}
+
+type noAncestorsCache struct{}
+
+func (noAncestorsCache) Get(ids.ID, int) ([][]byte, bool) { return nil, false }
+
+func (noAncestorsCache) Put(ids.ID, int, [][]byte) {}
@@ -43,6 +43,13 @@ type Config struct {
 	// containers in an ancestors message it receives.
 	AncestorsMaxContainersReceived int
 
+	// Max number of recently sent ancestors messages to cache, so that they
+	// can be resent without being read from the database again.
+	AncestorsCacheSize int
+
+	// Limits the upload bandwidth used to serve Get and GetAncestors requests.
+	ServingBandwidth tracker.Bandwidth
+
 	SharedCfg *SharedConfig
 }
 
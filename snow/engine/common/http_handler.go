@@ -5,6 +5,7 @@ package common
 
 import (
 	"net/http"
+	"time"
 )
 
 // LockOption allows the vm to specify their lock option based on their endpoint
@@ -20,4 +21,8 @@ const (
 type HTTPHandler struct {
 	LockOptions LockOption
 	Handler     http.Handler
+	// If non-zero, the handler is deprecated and will stop serving requests
+	// at this time. Until then, its responses tell clients when it's going
+	// away, so that they can move to a newer version of the API.
+	Sunset time.Time
 }
@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"github.com/lasthyphen/beacongo/ids"
+)
+
+// StateDigester is implemented by VMs that can summarize their committed
+// state, so that the state of a chain can be compared across nodes.
+type StateDigester interface {
+	// StateDigest returns a deterministic digest of the VM's committed state.
+	// Nodes that accepted the same operations return the same digest.
+	//
+	// The chain's context lock is held while StateDigest is called.
+	StateDigest() (ids.ID, error)
+}
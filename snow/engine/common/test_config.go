@@ -35,6 +35,7 @@ func DefaultConfigTest() Config {
 		Timer:                          &TimerTest{},
 		AncestorsMaxContainersSent:     2000,
 		AncestorsMaxContainersReceived: 2000,
+		ServingBandwidth:               tracker.NewNoBandwidth(),
 		SharedCfg:                      &SharedConfig{},
 	}
 }
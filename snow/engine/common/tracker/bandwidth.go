@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+)
+
+var (
+	_ Bandwidth = &bandwidth{}
+	_ Bandwidth = noBandwidth{}
+)
+
+// Bandwidth limits the number of bytes this node uploads while serving
+// containers to peers that are bootstrapping. Requests that would exceed the
+// limit are dropped, so that the requester times out and asks another peer.
+type Bandwidth interface {
+	// TryConsume returns true and records [numBytes] as being sent if the
+	// bandwidth allocation currently permits sending [numBytes]. Otherwise,
+	// false is returned and nothing is recorded.
+	//
+	// It's safe for multiple goroutines to concurrently call TryConsume.
+	TryConsume(numBytes int) bool
+}
+
+type bandwidth struct {
+	limiter *rate.Limiter
+
+	servedBytes prometheus.Counter
+	dropped     prometheus.Counter
+}
+
+// NewBandwidth returns a token bucket, where each token is a byte, that
+// refills at [bytesPerSec] and can accumulate up to [maxBurstSize] bytes.
+//
+// If [bytesPerSec] is 0, the upload bandwidth isn't limited, but is still
+// reported.
+func NewBandwidth(
+	bytesPerSec uint64,
+	maxBurstSize uint64,
+	namespace string,
+	registerer prometheus.Registerer,
+) (Bandwidth, error) {
+	limit := rate.Inf
+	if bytesPerSec != 0 {
+		limit = rate.Limit(bytesPerSec)
+	}
+	b := &bandwidth{
+		limiter: rate.NewLimiter(limit, int(maxBurstSize)),
+		servedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "served_bytes",
+			Help:      "Number of bytes sent in response to Get and GetAncestors requests",
+		}),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "throttled_responses",
+			Help:      "Number of responses to Get and GetAncestors requests dropped due to the upload bandwidth limit",
+		}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(b.servedBytes),
+		registerer.Register(b.dropped),
+	)
+	return b, errs.Err
+}
+
+func (b *bandwidth) TryConsume(numBytes int) bool {
+	if !b.limiter.AllowN(time.Now(), numBytes) {
+		b.dropped.Inc()
+		return false
+	}
+	b.servedBytes.Add(float64(numBytes))
+	return true
+}
+
+type noBandwidth struct{}
+
+// NewNoBandwidth returns a Bandwidth that never limits the upload bandwidth.
+func NewNoBandwidth() Bandwidth { return noBandwidth{} }
+
+func (noBandwidth) TryConsume(int) bool { return true }
@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidth(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewBandwidth(1, 10, "", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	assert.True(b.TryConsume(6))
+	assert.False(b.TryConsume(6))
+	assert.True(b.TryConsume(4))
+}
+
+func TestBandwidthUnlimited(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewBandwidth(0, 0, "", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	assert.True(b.TryConsume(1024))
+	assert.True(b.TryConsume(1024))
+}
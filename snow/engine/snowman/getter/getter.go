@@ -38,6 +38,15 @@ func New(
 		"blocks fetched in a call to GetAncestors",
 		commonCfg.Ctx.Registerer,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	gh.ancestorsCache, err = common.NewAncestorsCache(
+		commonCfg.AncestorsCacheSize,
+		"bs_ancestors_cache",
+		commonCfg.Ctx.Registerer,
+	)
 	return gh, err
 }
 
@@ -50,6 +59,7 @@ type getter struct {
 
 	log              logging.Logger
 	getAncestorsBlks metric.Averager
+	ancestorsCache   common.AncestorsCache
 }
 
 func (gh *getter) GetStateSummaryFrontier(nodeID ids.NodeID, requestID uint32) error {
@@ -140,16 +150,36 @@ func (gh *getter) GetAccepted(nodeID ids.NodeID, requestID uint32, containerIDs
 }
 
 func (gh *getter) GetAncestors(nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
-	ancestorsBytes, err := block.GetAncestors(
-		gh.vm,
-		blkID,
-		gh.cfg.AncestorsMaxContainersSent,
-		constants.MaxContainersLen,
-		gh.cfg.MaxTimeGetAncestors,
-	)
-	if err != nil {
-		gh.log.Verbo("couldn't get ancestors with %s. Dropping GetAncestors(%s, %d, %s)",
-			err, nodeID, requestID, blkID)
+	ancestorsBytes, cached := gh.ancestorsCache.Get(blkID, gh.cfg.AncestorsMaxContainersSent)
+	if !cached {
+		var err error
+		ancestorsBytes, err = block.GetAncestors(
+			gh.vm,
+			blkID,
+			gh.cfg.AncestorsMaxContainersSent,
+			constants.MaxContainersLen,
+			gh.cfg.MaxTimeGetAncestors,
+		)
+		if err != nil {
+			gh.log.Verbo("couldn't get ancestors with %s. Dropping GetAncestors(%s, %d, %s)",
+				err, nodeID, requestID, blkID)
+			return nil
+		}
+		// A block's ancestors never change, so the response can be resent to
+		// the next peer that asks for them. Empty responses aren't cached, as
+		// the block may not have been fetched yet.
+		if len(ancestorsBytes) > 0 {
+			gh.ancestorsCache.Put(blkID, gh.cfg.AncestorsMaxContainersSent, ancestorsBytes)
+		}
+	}
+
+	numBytes := 0
+	for _, blkBytes := range ancestorsBytes {
+		numBytes += len(blkBytes)
+	}
+	if !gh.cfg.ServingBandwidth.TryConsume(numBytes) {
+		gh.log.Verbo("dropping GetAncestors(%s, %d, %s) due to the serving bandwidth limit",
+			nodeID, requestID, blkID)
 		return nil
 	}
 
@@ -168,7 +198,13 @@ func (gh *getter) Get(nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
 		return nil
 	}
 
+	blkBytes := blk.Bytes()
+	if !gh.cfg.ServingBandwidth.TryConsume(len(blkBytes)) {
+		gh.log.Verbo("dropping Get(%s, %d, %s) due to the serving bandwidth limit", nodeID, requestID, blkID)
+		return nil
+	}
+
 	// Respond to the validator with the fetched block and the same requestID.
-	gh.sender.SendPut(nodeID, requestID, blkID, blk.Bytes())
+	gh.sender.SendPut(nodeID, requestID, blkID, blkBytes)
 	return nil
 }
@@ -6,6 +6,7 @@ package getter
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/lasthyphen/beacongo/snow/choices"
 	"github.com/lasthyphen/beacongo/snow/consensus/snowman"
 	"github.com/lasthyphen/beacongo/snow/engine/common"
+	"github.com/lasthyphen/beacongo/snow/engine/common/tracker"
 	"github.com/lasthyphen/beacongo/snow/engine/snowman/block"
 	"github.com/lasthyphen/beacongo/snow/engine/snowman/block/mocks"
 	"github.com/lasthyphen/beacongo/snow/validators"
@@ -274,3 +276,59 @@ func TestDisabledGetAcceptedStateSummary(t *testing.T) {
 	assert.NoError(getter.GetAcceptedStateSummary(nodeID, reqID, heights))
 	assert.False(summaryVoteSent)
 }
+
+func TestGetAncestorsCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vm, sender, config := testSetup(t, ctrl)
+	config.AncestorsCacheSize = 1
+	config.MaxTimeGetAncestors = time.Minute
+	config.ServingBandwidth = tracker.NewNoBandwidth()
+
+	parent := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: []byte{1},
+	}
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		ParentV: parent.ID(),
+		BytesV:  []byte{2},
+	}
+	getBlockCalls := 0
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		getBlockCalls++
+		switch blkID {
+		case blk.ID():
+			return blk, nil
+		case parent.ID():
+			return parent, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	bs, err := New(vm, config, false /*StateSyncDisableRequests*/)
+	assert.NoError(t, err)
+
+	var sent [][][]byte
+	sender.SendAncestorsF = func(_ ids.NodeID, _ uint32, containers [][]byte) {
+		sent = append(sent, containers)
+	}
+
+	assert.NoError(t, bs.GetAncestors(ids.GenerateTestNodeID(), 0, blk.ID()))
+	calls := getBlockCalls
+
+	// The second peer is sent the same blocks without them being fetched again
+	assert.NoError(t, bs.GetAncestors(ids.GenerateTestNodeID(), 0, blk.ID()))
+	assert.Equal(t, calls, getBlockCalls)
+	assert.Len(t, sent, 2)
+	assert.Equal(t, [][]byte{{2}, {1}}, sent[0])
+	assert.Equal(t, sent[0], sent[1])
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replica
+
+import (
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+	"github.com/lasthyphen/beacongo/snow/engine/snowman/block"
+
+	replicationpb "github.com/lasthyphen/beacongo/proto/pb/replication"
+)
+
+// Config wraps all the parameters needed for a replica engine
+type Config struct {
+	common.AllGetsServer
+
+	Ctx    *snow.ConsensusContext
+	VM     block.ChainVM
+	Subnet common.Subnet
+	// Streams the blocks accepted by the primary node
+	Client replicationpb.ReplicationClient
+	// Stores the replication progress, so that it's resumed after a restart
+	DB database.Database
+	// Called once the engine has started, in place of the end of
+	// bootstrapping. May be nil.
+	Bootstrapped func()
+}
@@ -0,0 +1,288 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replica
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+	"github.com/lasthyphen/beacongo/snow/engine/snowman"
+	"github.com/lasthyphen/beacongo/version"
+
+	replicationpb "github.com/lasthyphen/beacongo/proto/pb/replication"
+	smcon "github.com/lasthyphen/beacongo/snow/consensus/snowman"
+)
+
+// How long to wait before reopening a failed stream from the primary
+const retryFrequency = 5 * time.Second
+
+var (
+	nextIndexKey = []byte("nextIndex")
+
+	errUnexpectedIndex = errors.New("unexpected container index")
+	errWrongBlock      = errors.New("parsed block has a different ID")
+
+	_ snowman.Engine             = &engine{}
+	_ common.BootstrapableEngine = &engine{}
+)
+
+// engine follows a chain by applying the blocks accepted by a primary node,
+// rather than by running consensus. The primary streams the blocks in the
+// order it accepted them, so the engine never has more than one block
+// processing and never needs to query validators.
+//
+// The engine is used both as the bootstrapper and as the consensus engine of
+// the chain's handler, so that messages from peers are dropped in every state.
+type engine struct {
+	Config
+
+	// list of NoOpsHandler for messages dropped by the replica
+	common.StateSummaryFrontierHandler
+	common.AcceptedStateSummaryHandler
+	common.AcceptedFrontierHandler
+	common.AcceptedHandler
+	common.AncestorsHandler
+	common.PutHandler
+	common.QueryHandler
+	common.ChitsHandler
+	common.AppHandler
+
+	// Index, in the primary's order of acceptance, of the next block to apply
+	nextIndex uint64
+
+	// cancels the stream from the primary
+	cancel context.CancelFunc
+
+	healthLock sync.Mutex
+	// Unix time, in nanoseconds, at which the primary accepted the last
+	// applied block
+	lastAcceptedByPrimary int64
+	// Error that closed the last stream, if it hasn't been reopened since
+	streamErr error
+}
+
+func New(config Config) (common.BootstrapableEngine, error) {
+	nextIndex, err := database.GetUInt64(config.DB, nextIndexKey)
+	if err == database.ErrNotFound {
+		nextIndex = 0
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't get replication progress: %w", err)
+	}
+	return &engine{
+		Config:                      config,
+		StateSummaryFrontierHandler: common.NewNoOpStateSummaryFrontierHandler(config.Ctx.Log),
+		AcceptedStateSummaryHandler: common.NewNoOpAcceptedStateSummaryHandler(config.Ctx.Log),
+		AcceptedFrontierHandler:     common.NewNoOpAcceptedFrontierHandler(config.Ctx.Log),
+		AcceptedHandler:             common.NewNoOpAcceptedHandler(config.Ctx.Log),
+		AncestorsHandler:            common.NewNoOpAncestorsHandler(config.Ctx.Log),
+		PutHandler:                  common.NewNoOpPutHandler(config.Ctx.Log),
+		QueryHandler:                common.NewNoOpQueryHandler(config.Ctx.Log),
+		ChitsHandler:                common.NewNoOpChitsHandler(config.Ctx.Log),
+		AppHandler:                  common.NewNoOpAppHandler(config.Ctx.Log),
+		nextIndex:                   nextIndex,
+	}, nil
+}
+
+func (e *engine) Start(startReqID uint32) error {
+	e.Ctx.Log.Info("replicating blocks accepted by the primary from index %d", e.nextIndex)
+
+	e.Ctx.SetState(snow.NormalOp)
+	if err := e.VM.SetState(snow.NormalOp); err != nil {
+		return fmt.Errorf("failed to notify VM that it's replicating: %w", err)
+	}
+	if e.Bootstrapped != nil {
+		e.Bootstrapped()
+	}
+	e.Subnet.Bootstrapped(e.Ctx.ChainID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	go e.Ctx.Log.RecoverAndPanic(func() { e.replicate(ctx) })
+	return nil
+}
+
+// replicate streams blocks from the primary until [ctx] is cancelled,
+// reopening the stream whenever it fails.
+func (e *engine) replicate(ctx context.Context) {
+	for {
+		err := e.stream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		e.Ctx.Log.Warn("replication stream from the primary failed, retrying in %s: %s", retryFrequency, err)
+
+		e.healthLock.Lock()
+		e.streamErr = err
+		e.healthLock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryFrequency):
+		}
+	}
+}
+
+func (e *engine) stream(ctx context.Context) error {
+	e.Ctx.Lock.Lock()
+	nextIndex := e.nextIndex
+	e.Ctx.Lock.Unlock()
+
+	stream, err := e.Client.StreamAccepted(ctx, &replicationpb.StreamAcceptedRequest{
+		ChainId:    e.Ctx.ChainID[:],
+		StartIndex: nextIndex,
+	})
+	if err != nil {
+		return err
+	}
+
+	e.healthLock.Lock()
+	e.streamErr = nil
+	e.healthLock.Unlock()
+
+	for {
+		container, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := e.apply(ctx, container); err != nil {
+			return err
+		}
+	}
+}
+
+// apply accepts [container], which must be the next block accepted by the
+// primary, and records that it's been applied.
+func (e *engine) apply(ctx context.Context, container *replicationpb.AcceptedContainer) error {
+	e.Ctx.Lock.Lock()
+	defer e.Ctx.Lock.Unlock()
+
+	// The VM may have been shut down while waiting for the lock
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if container.Index != e.nextIndex {
+		return fmt.Errorf("%w: expected %d but got %d", errUnexpectedIndex, e.nextIndex, container.Index)
+	}
+
+	blk, err := e.VM.ParseBlock(container.Bytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse block at index %d: %w", container.Index, err)
+	}
+	blkID := blk.ID()
+	if expectedID, err := ids.ToID(container.Id); err != nil || blkID != expectedID {
+		return fmt.Errorf("%w: %s at index %d", errWrongBlock, blkID, container.Index)
+	}
+
+	// The block was already accepted if the node stopped after accepting it
+	// but before recording that it had been applied.
+	if blk.Status() != choices.Accepted {
+		if err := e.accept(blk); err != nil {
+			return fmt.Errorf("couldn't accept block %s at index %d: %w", blkID, container.Index, err)
+		}
+	}
+
+	if err := database.PutUInt64(e.DB, nextIndexKey, container.Index+1); err != nil {
+		return err
+	}
+	e.nextIndex = container.Index + 1
+
+	e.healthLock.Lock()
+	e.lastAcceptedByPrimary = container.Timestamp
+	e.healthLock.Unlock()
+	return nil
+}
+
+// accept verifies and accepts [blk] as consensus would have.
+//
+// Assumes [e.Ctx.Lock] is held.
+func (e *engine) accept(blk smcon.Block) error {
+	if err := blk.Verify(); err != nil {
+		return err
+	}
+	blkID := blk.ID()
+	bytes := blk.Bytes()
+	// Note that DecisionAcceptor.Accept / ConsensusAcceptor.Accept must be
+	// called before blk.Accept to honor Acceptor.Accept's invariant.
+	if err := e.Ctx.DecisionAcceptor.Accept(e.Ctx, blkID, bytes); err != nil {
+		return err
+	}
+	if err := e.Ctx.ConsensusAcceptor.Accept(e.Ctx, blkID, bytes); err != nil {
+		return err
+	}
+	if err := blk.Accept(); err != nil {
+		return err
+	}
+	return e.VM.SetPreference(blkID)
+}
+
+func (e *engine) Connected(nodeID ids.NodeID, nodeVersion version.Application) error {
+	return e.VM.Connected(nodeID, nodeVersion)
+}
+
+func (e *engine) Disconnected(nodeID ids.NodeID) error {
+	return e.VM.Disconnected(nodeID)
+}
+
+func (e *engine) Timeout() error { return nil }
+
+func (e *engine) Gossip() error { return nil }
+
+func (e *engine) Halt() {}
+
+func (e *engine) Shutdown() error {
+	e.Ctx.Log.Info("shutting down replica engine")
+	if e.cancel != nil {
+		// The stream may be waiting for the context lock, which is held by
+		// the caller, so it isn't waited for
+		e.cancel()
+	}
+	return e.VM.Shutdown()
+}
+
+func (e *engine) Notify(msg common.Message) error {
+	// Replicas don't build blocks
+	e.Ctx.Log.Debug("dropping message from the VM: %s", msg)
+	return nil
+}
+
+func (e *engine) Context() *snow.ConsensusContext { return e.Ctx }
+
+func (e *engine) HealthCheck() (interface{}, error) {
+	e.healthLock.Lock()
+	lastAccepted := e.lastAcceptedByPrimary
+	streamErr := e.streamErr
+	e.healthLock.Unlock()
+
+	vmIntf, vmErr := e.VM.HealthCheck()
+	intf := map[string]interface{}{
+		"vm": vmIntf,
+	}
+	if lastAccepted != 0 {
+		intf["lastAcceptedByPrimary"] = time.Unix(0, lastAccepted)
+	}
+	if streamErr != nil {
+		return intf, fmt.Errorf("replication stream failed: %w", streamErr)
+	}
+	return intf, vmErr
+}
+
+func (e *engine) GetVM() common.VM { return e.VM }
+
+func (e *engine) GetBlock(blkID ids.ID) (smcon.Block, error) { return e.VM.GetBlock(blkID) }
+
+// ForceAccepted is never called, as the engine doesn't fetch the accepted
+// frontier from beacons.
+func (e *engine) ForceAccepted([]ids.ID) error { return nil }
+
+func (e *engine) Clear() error { return nil }
@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replica
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+
+	"github.com/lasthyphen/beacongo/database/memdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/snow/engine/snowman/block"
+
+	replicationpb "github.com/lasthyphen/beacongo/proto/pb/replication"
+	smcon "github.com/lasthyphen/beacongo/snow/consensus/snowman"
+)
+
+// testClient streams [containers] from the requested index, and then fails
+// with io.EOF
+type testClient struct {
+	containers []*replicationpb.AcceptedContainer
+	startIndex uint64
+}
+
+func (c *testClient) StreamAccepted(_ context.Context, req *replicationpb.StreamAcceptedRequest, _ ...grpc.CallOption) (replicationpb.Replication_StreamAcceptedClient, error) {
+	c.startIndex = req.StartIndex
+	return &testStream{containers: c.containers[req.StartIndex:]}, nil
+}
+
+type testStream struct {
+	grpc.ClientStream
+	containers []*replicationpb.AcceptedContainer
+}
+
+func (s *testStream) Recv() (*replicationpb.AcceptedContainer, error) {
+	if len(s.containers) == 0 {
+		return nil, io.EOF
+	}
+	container := s.containers[0]
+	s.containers = s.containers[1:]
+	return container, nil
+}
+
+func TestReplicaAppliesAcceptedBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	blks := make([]*smcon.TestBlock, 3)
+	containers := make([]*replicationpb.AcceptedContainer, len(blks))
+	for i := range blks {
+		blks[i] = &smcon.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			HeightV: uint64(i + 1),
+			BytesV:  []byte{byte(i)},
+		}
+		containers[i] = &replicationpb.AcceptedContainer{
+			Index: uint64(i),
+			Id:    blks[i].IDV[:],
+			Bytes: blks[i].BytesV,
+		}
+	}
+
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.ParseBlockF = func(b []byte) (smcon.Block, error) {
+		return blks[b[0]], nil
+	}
+	preferred := ids.Empty
+	vm.SetPreferenceF = func(blkID ids.ID) error {
+		preferred = blkID
+		return nil
+	}
+
+	client := &testClient{containers: containers[:2]}
+	db := memdb.New()
+	config := Config{
+		Ctx:    snow.DefaultConsensusContextTest(),
+		VM:     vm,
+		Client: client,
+		DB:     db,
+	}
+	engineIntf, err := New(config)
+	assert.NoError(err)
+	e := engineIntf.(*engine)
+
+	// The stream ends once the primary's blocks have been applied
+	assert.ErrorIs(e.stream(context.Background()), io.EOF)
+	assert.Equal(choices.Accepted, blks[0].Status())
+	assert.Equal(choices.Accepted, blks[1].Status())
+	assert.Equal(blks[1].ID(), preferred)
+	assert.EqualValues(2, e.nextIndex)
+
+	// Out of order blocks are refused
+	err = e.apply(context.Background(), containers[0])
+	assert.ErrorIs(err, errUnexpectedIndex)
+
+	// Replication resumes where it stopped
+	client.containers = containers
+	engineIntf, err = New(config)
+	assert.NoError(err)
+	e = engineIntf.(*engine)
+	assert.ErrorIs(e.stream(context.Background()), io.EOF)
+	assert.EqualValues(2, client.startIndex)
+	assert.Equal(choices.Accepted, blks[2].Status())
+	assert.EqualValues(3, e.nextIndex)
+}
@@ -42,6 +42,17 @@ type Handler interface {
 	Consensus() common.Engine
 
 	SetOnStopped(onStopped func())
+	// SetSkipGossip registers a function that, when it returns true, causes
+	// periodic gossip to be skipped.
+	SetSkipGossip(skipGossip func() bool)
+	// SetIdleGossipPacing causes periodic gossip to slow down while
+	// [isIdle] returns true. Each gossip while idle doubles the time until the
+	// next one, up to [maxFrequency]. The gossip frequency is restored as soon
+	// as the chain isn't idle, or the VM notifies the engine. [isIdle] is
+	// called with the context lock held.
+	SetIdleGossipPacing(maxFrequency time.Duration, isIdle func() bool)
+	// Len returns the number of messages that haven't been processed yet.
+	Len() int
 	Start(recoverPanic bool)
 	Push(msg message.InboundMessage)
 	Stop()
@@ -72,6 +83,13 @@ type handler struct {
 	// onStopped is called in a goroutine when this handler finishes shutting
 	// down. If it is nil then it is skipped.
 	onStopped func()
+	// skipGossip is checked before every periodic gossip. If it is nil, gossip
+	// is never skipped.
+	skipGossip func() bool
+	// While [isIdle] returns true, the time between periodic gossips grows up
+	// to [maxIdleGossipFrequency]. If [isIdle] is nil, gossip isn't paced.
+	isIdle                 func() bool
+	maxIdleGossipFrequency time.Duration
 
 	// Tracks cpu/disk usage caused by each peer.
 	resourceTracker tracker.ResourceTracker
@@ -155,6 +173,17 @@ func (h *handler) Consensus() common.Engine          { return h.engine }
 
 func (h *handler) SetOnStopped(onStopped func()) { h.onStopped = onStopped }
 
+func (h *handler) SetSkipGossip(skipGossip func() bool) { h.skipGossip = skipGossip }
+
+func (h *handler) SetIdleGossipPacing(maxFrequency time.Duration, isIdle func() bool) {
+	h.maxIdleGossipFrequency = maxFrequency
+	h.isIdle = isIdle
+}
+
+func (h *handler) Len() int {
+	return h.syncMessageQueue.Len() + h.asyncMessageQueue.Len()
+}
+
 func (h *handler) selectStartingGear() (common.Engine, error) {
 	if h.stateSyncer == nil {
 		return h.bootstrapper, nil
@@ -321,7 +350,8 @@ func (h *handler) dispatchAsync() {
 }
 
 func (h *handler) dispatchChans() {
-	gossiper := time.NewTicker(h.gossipFrequency)
+	gossipFrequency := h.gossipFrequency
+	gossiper := time.NewTimer(gossipFrequency)
 	defer func() {
 		gossiper.Stop()
 		h.closeDispatcher()
@@ -336,8 +366,22 @@ func (h *handler) dispatchChans() {
 
 		case vmMSG := <-h.msgFromVMChan:
 			msg = h.mc.InternalVMMessage(h.ctx.NodeID, uint32(vmMSG))
+			if gossipFrequency != h.gossipFrequency {
+				// The chain is no longer idle, so gossip is no longer paced
+				gossipFrequency = h.gossipFrequency
+				if !gossiper.Stop() {
+					<-gossiper.C
+				}
+				gossiper.Reset(gossipFrequency)
+			}
 
 		case <-gossiper.C:
+			gossipFrequency = h.nextGossipFrequency(gossipFrequency)
+			gossiper.Reset(gossipFrequency)
+			if h.skipGossip != nil && h.skipGossip() {
+				h.ctx.Log.Verbo("skipping gossip because the node is overloaded")
+				continue
+			}
 			msg = h.mc.InternalGossipRequest(h.ctx.NodeID)
 
 		case <-h.timeouts:
@@ -355,6 +399,27 @@ func (h *handler) dispatchChans() {
 	}
 }
 
+// nextGossipFrequency returns the time until the next periodic gossip, given
+// that the last one was [current] after the one before it.
+func (h *handler) nextGossipFrequency(current time.Duration) time.Duration {
+	if h.isIdle == nil || h.maxIdleGossipFrequency <= h.gossipFrequency {
+		return h.gossipFrequency
+	}
+
+	h.ctx.Lock.Lock()
+	idle := h.ctx.GetState() == snow.NormalOp && h.isIdle()
+	h.ctx.Lock.Unlock()
+	if !idle {
+		return h.gossipFrequency
+	}
+
+	next := 2 * current
+	if next > h.maxIdleGossipFrequency {
+		next = h.maxIdleGossipFrequency
+	}
+	return next
+}
+
 func (h *handler) handleSyncMsg(msg message.InboundMessage) error {
 	h.ctx.Log.Debug("Forwarding sync message to consensus: %s", msg)
 
@@ -293,3 +293,43 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	case <-calledNotify:
 	}
 }
+
+func TestHandlerIdleGossipPacing(t *testing.T) {
+	assert := assert.New(t)
+
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	assert.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	assert.NoError(err)
+	handlerIntf, err := New(
+		mc,
+		ctx,
+		validators.NewSet(),
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+	)
+	assert.NoError(err)
+	handler := handlerIntf.(*handler)
+
+	// Gossip isn't paced by default
+	ctx.SetState(snow.NormalOp)
+	assert.Equal(time.Second, handler.nextGossipFrequency(time.Second))
+
+	idle := true
+	handler.SetIdleGossipPacing(3*time.Second, func() bool { return idle })
+	assert.Equal(2*time.Second, handler.nextGossipFrequency(time.Second))
+	assert.Equal(3*time.Second, handler.nextGossipFrequency(2*time.Second))
+	assert.Equal(3*time.Second, handler.nextGossipFrequency(3*time.Second))
+
+	// Gossip isn't paced while bootstrapping or while containers are processing
+	ctx.SetState(snow.Bootstrapping)
+	assert.Equal(time.Second, handler.nextGossipFrequency(3*time.Second))
+	ctx.SetState(snow.NormalOp)
+	idle = false
+	assert.Equal(time.Second, handler.nextGossipFrequency(3*time.Second))
+}
@@ -191,6 +191,17 @@ func (cr *ChainRouter) HandleInbound(msg message.InboundMessage) {
 
 	ctx := chain.Context()
 
+	// Failed requests are still delivered so that the chain doesn't wait for
+	// responses it will never receive. The responses to outstanding requests
+	// are dropped, so their requests will time out.
+	if _, isFailed := message.FailedToResponseOps[op]; !isFailed && ctx.InMaintenance() {
+		cr.log.Debug("dropping %s from %s since chain %s is in maintenance mode", op, nodeID, chainID)
+		cr.metrics.droppedRequests.Inc()
+
+		msg.OnFinishedHandling()
+		return
+	}
+
 	if _, notRequested := message.UnrequestedOps[op]; notRequested ||
 		(op == message.Put && requestID == constants.GossipMsgRequestID) {
 		if ctx.IsExecuting() {
@@ -381,6 +392,17 @@ func (cr *ChainRouter) Unbenched(chainID ids.ID, nodeID ids.NodeID) {
 	}
 }
 
+func (cr *ChainRouter) PendingMessages() int {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	numMsgs := 0
+	for _, chain := range cr.chains {
+		numMsgs += chain.Len()
+	}
+	return numMsgs
+}
+
 // HealthCheck returns results of router health checks. Returns:
 // 1) Information about health check results
 // 2) An error if the health check reports unhealthy
@@ -594,3 +594,108 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 	// the GetFailed message is sent
 	assert.Equal(t, 1, chainRouter.timedRequests.Len())
 }
+
+func TestMaintenanceMessageDrops(t *testing.T) {
+	// Create a timeout manager
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     10 * time.Millisecond,
+			MinimumTimeout:     10 * time.Millisecond,
+			MaximumTimeout:     25 * time.Millisecond,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go tm.Dispatch()
+
+	// Create a router
+	chainRouter := ChainRouter{}
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	assert.NoError(t, err)
+
+	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	// Create bootstrapper, engine and handler
+	calledF := false
+	wg := sync.WaitGroup{}
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	vID := ids.GenerateTestNodeID()
+	err = vdrs.AddWeight(vID, 1)
+	assert.NoError(t, err)
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	assert.NoError(t, err)
+	handler, err := handler.New(
+		mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+	)
+	assert.NoError(t, err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.ContextF = func() *snow.ConsensusContext { return ctx }
+	bootstrapper.PullQueryF = func(nodeID ids.NodeID, requestID uint32, containerID ids.ID) error {
+		defer wg.Done()
+		calledF = true
+		return nil
+	}
+	handler.SetBootstrapper(bootstrapper)
+	ctx.SetState(snow.Bootstrapping) // assumed bootstrapping is ongoing
+
+	engine := &common.EngineTest{T: t}
+	engine.ContextF = func() *snow.ConsensusContext { return ctx }
+	engine.Default(false)
+	handler.SetConsensus(engine)
+
+	chainRouter.AddChain(handler)
+
+	bootstrapper.StartF = func(startReqID uint32) error { return nil }
+	handler.Start(false)
+
+	dummyContainerID := ids.GenerateTestID()
+	reqID := uint32(0)
+
+	// Messages are dropped while the chain is in maintenance mode
+	ctx.SetMaintenance(true)
+	inMsg := mc.InboundPullQuery(ctx.ChainID, reqID, time.Hour, dummyContainerID, vID)
+	chainRouter.HandleInbound(inMsg)
+	assert.False(t, calledF)
+
+	// Responses are dropped, but their requests are still failed
+	reqID++
+	chainRouter.RegisterRequest(vID, ctx.ChainID, reqID, message.Get)
+	inMsg = mc.InboundPut(ctx.ChainID, reqID, ids.GenerateTestID(), nil, vID)
+	chainRouter.HandleInbound(inMsg)
+	assert.Equal(t, 1, chainRouter.timedRequests.Len())
+
+	// Messages are delivered again once maintenance is over
+	ctx.SetMaintenance(false)
+	reqID++
+	inMsg = mc.InboundPullQuery(ctx.ChainID, reqID, time.Hour, dummyContainerID, vID)
+	wg.Add(1)
+	chainRouter.HandleInbound(inMsg)
+
+	wg.Wait()
+	assert.True(t, calledF)
+}
@@ -35,6 +35,9 @@ type Router interface {
 		metricsNamespace string,
 		metricsRegisterer prometheus.Registerer,
 	) error
+	// PendingMessages returns the number of messages that have been routed to
+	// chains but haven't been processed yet.
+	PendingMessages() int
 	Shutdown()
 	AddChain(chain handler.Handler)
 	health.Checker
@@ -28,6 +28,11 @@ type Tracker interface {
 	Connect(nodeID ids.NodeID) error
 	IsConnected(nodeID ids.NodeID) bool
 	Disconnect(nodeID ids.NodeID) error
+
+	// LastSeen returns the last time that [nodeID] was connected, which is now
+	// if it's connected. False is returned if [nodeID] hasn't been connected
+	// since this node started.
+	LastSeen(nodeID ids.NodeID) (time.Time, bool)
 }
 
 type Calculator interface {
@@ -45,15 +50,18 @@ type manager struct {
 	// Used to get time. Useful for faking time during tests.
 	clock mockable.Clock
 
-	state           State
-	connections     map[ids.NodeID]time.Time
+	state       State
+	connections map[ids.NodeID]time.Time
+	// nodeID -> time it disconnected
+	disconnections  map[ids.NodeID]time.Time
 	startedTracking bool
 }
 
 func NewManager(state State) Manager {
 	return &manager{
-		state:       state,
-		connections: make(map[ids.NodeID]time.Time),
+		state:          state,
+		connections:    make(map[ids.NodeID]time.Time),
+		disconnections: make(map[ids.NodeID]time.Time),
 	}
 }
 
@@ -120,6 +128,7 @@ func (m *manager) IsConnected(nodeID ids.NodeID) bool {
 }
 
 func (m *manager) Disconnect(nodeID ids.NodeID) error {
+	m.disconnections[nodeID] = m.clock.Time()
 	if !m.startedTracking {
 		delete(m.connections, nodeID)
 		return nil
@@ -137,6 +146,14 @@ func (m *manager) Disconnect(nodeID ids.NodeID) error {
 	return m.state.SetUptime(nodeID, newDuration, newLastUpdated)
 }
 
+func (m *manager) LastSeen(nodeID ids.NodeID) (time.Time, bool) {
+	if _, connected := m.connections[nodeID]; connected {
+		return m.clock.Time(), true
+	}
+	lastSeen, seen := m.disconnections[nodeID]
+	return lastSeen, seen
+}
+
 func (m *manager) CalculateUptime(nodeID ids.NodeID) (time.Duration, time.Time, error) {
 	upDuration, lastUpdated, err := m.state.GetUptime(nodeID)
 	if err != nil {
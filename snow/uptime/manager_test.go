@@ -518,3 +518,41 @@ func TestCalculateUptimePercentage(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(float64(0), uptime)
 }
+
+func TestLastSeen(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	startTime := time.Now()
+
+	s := NewTestState()
+	s.AddNode(nodeID0, startTime)
+
+	up := NewManager(s).(*manager)
+	up.clock.Set(startTime)
+
+	err := up.StartTracking([]ids.NodeID{nodeID0})
+	assert.NoError(err)
+
+	_, seen := up.LastSeen(nodeID0)
+	assert.False(seen)
+
+	err = up.Connect(nodeID0)
+	assert.NoError(err)
+
+	currentTime := startTime.Add(time.Second)
+	up.clock.Set(currentTime)
+
+	lastSeen, seen := up.LastSeen(nodeID0)
+	assert.True(seen)
+	assert.Equal(currentTime, lastSeen)
+
+	err = up.Disconnect(nodeID0)
+	assert.NoError(err)
+
+	up.clock.Set(currentTime.Add(time.Second))
+
+	lastSeen, seen = up.LastSeen(nodeID0)
+	assert.True(seen)
+	assert.Equal(currentTime, lastSeen)
+}
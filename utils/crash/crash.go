@@ -0,0 +1,253 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crash
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/perms"
+	"github.com/lasthyphen/beacongo/version"
+)
+
+const (
+	// Prefix of the name of each report directory
+	reportPrefix = "crash-"
+	// Format of the time that a report directory is named after
+	reportTimeFormat = "20060102T150405.000000000Z"
+
+	reasonFile  = "reason.txt"
+	stacksFile  = "stacks.txt"
+	configFile  = "config.json"
+	metricsFile = "metrics.json"
+	logsDir     = "logs"
+
+	logFileExt = ".log"
+)
+
+// Config defines where crash reports are written and how much they contain
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Directory that the reports are written to
+	Directory string `json:"directory"`
+	// Directory that the logs included in a report are read from
+	LogDirectory string `json:"logDirectory"`
+	// Max number of bytes, from the end, of each log file that is included in
+	// a report
+	MaxLogSize int64 `json:"maxLogSize"`
+	// Max number of reports kept in [Directory]. Once exceeded, the oldest
+	// reports are removed.
+	MaxReports int `json:"maxReports"`
+}
+
+// Reporter writes a crash report when the node hits a fatal error, so that
+// the state of the node can be sent for support. Each report is a directory
+// holding the reason of the crash, the stack traces of every goroutine, the
+// tail of the logs, a summary of the config and a snapshot of the metrics.
+type Reporter struct {
+	log      logging.Logger
+	config   Config
+	summary  interface{}
+	gatherer prometheus.Gatherer
+
+	// Only the first fatal error is reported, as the ones that follow are
+	// usually caused by it
+	once sync.Once
+}
+
+// New returns a reporter that includes [summary], marshalled to JSON, as the
+// config summary and the metrics gathered by [gatherer] in its reports.
+// [summary] must not include any secrets.
+func New(log logging.Logger, config Config, summary interface{}, gatherer prometheus.Gatherer) *Reporter {
+	return &Reporter{
+		log:      log,
+		config:   config,
+		summary:  summary,
+		gatherer: gatherer,
+	}
+}
+
+// Report writes a crash report for the fatal error [reason], unless one was
+// already written by this reporter. It's meant to be registered with
+// logging.SetPanicHandler.
+func (r *Reporter) Report(reason interface{}) {
+	r.once.Do(func() {
+		dir, err := r.Write(reason)
+		if err != nil {
+			r.log.Error("couldn't write crash report: %s", err)
+			return
+		}
+		r.log.Fatal("wrote crash report to %s", dir)
+	})
+}
+
+// Write writes a crash report for [reason] and returns the directory it was
+// written to.
+func (r *Reporter) Write(reason interface{}) (string, error) {
+	now := time.Now().UTC()
+	dir := filepath.Join(r.config.Directory, reportPrefix+now.Format(reportTimeFormat))
+	if err := os.MkdirAll(dir, perms.ReadWriteExecute); err != nil {
+		return "", err
+	}
+
+	reasonStr := fmt.Sprintf(
+		"time: %s\nversion: %s\ngo: %s %s/%s\n\n%v\n",
+		now.Format(time.RFC3339Nano),
+		version.CurrentApp,
+		runtime.Version(),
+		runtime.GOOS,
+		runtime.GOARCH,
+		reason,
+	)
+	if err := perms.WriteFile(filepath.Join(dir, reasonFile), []byte(reasonStr), perms.ReadWrite); err != nil {
+		return "", err
+	}
+	if err := perms.WriteFile(filepath.Join(dir, stacksFile), stacks(), perms.ReadWrite); err != nil {
+		return "", err
+	}
+
+	// The remaining sections are written on a best effort basis, so that a
+	// failure to write one of them doesn't lose the others
+	var errs []string
+	if err := r.writeJSON(filepath.Join(dir, configFile), r.summary); err != nil {
+		errs = append(errs, fmt.Sprintf("config: %s", err))
+	}
+	if err := r.writeMetrics(filepath.Join(dir, metricsFile)); err != nil {
+		errs = append(errs, fmt.Sprintf("metrics: %s", err))
+	}
+	if err := r.writeLogs(filepath.Join(dir, logsDir)); err != nil {
+		errs = append(errs, fmt.Sprintf("logs: %s", err))
+	}
+	if err := r.prune(); err != nil {
+		errs = append(errs, fmt.Sprintf("pruning old reports: %s", err))
+	}
+	if len(errs) > 0 {
+		return dir, fmt.Errorf("incomplete crash report %s: %s", dir, strings.Join(errs, "; "))
+	}
+	return dir, nil
+}
+
+func (r *Reporter) writeJSON(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return err
+	}
+	return perms.WriteFile(path, b, perms.ReadWrite)
+}
+
+func (r *Reporter) writeMetrics(path string) error {
+	if r.gatherer == nil {
+		return nil
+	}
+	families, err := r.gatherer.Gather()
+	if err != nil {
+		return err
+	}
+	return r.writeJSON(path, families)
+}
+
+// writeLogs copies the tail of each log file into [dir].
+func (r *Reporter) writeLogs(dir string) error {
+	if r.config.LogDirectory == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(r.config.LogDirectory)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != logFileExt {
+			continue
+		}
+		src := filepath.Join(r.config.LogDirectory, entry.Name())
+		if err := copyTail(src, filepath.Join(dir, entry.Name()), r.config.MaxLogSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prune removes the oldest reports once there are more than [MaxReports].
+func (r *Reporter) prune() error {
+	if r.config.MaxReports <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(r.config.Directory)
+	if err != nil {
+		return err
+	}
+	reports := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), reportPrefix) {
+			reports = append(reports, entry.Name())
+		}
+	}
+	if len(reports) <= r.config.MaxReports {
+		return nil
+	}
+	// The names sort in the order the reports were written
+	sort.Strings(reports)
+	for _, report := range reports[:len(reports)-r.config.MaxReports] {
+		if err := os.RemoveAll(filepath.Join(r.config.Directory, report)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTail copies at most the last [maxSize] bytes of [src] to [dst]. If
+// [maxSize] isn't positive, all of [src] is copied.
+func copyTail(src, dst string, maxSize int64) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if maxSize > 0 && info.Size() > maxSize {
+		if _, err := in.Seek(info.Size()-maxSize, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	out, err := perms.Create(dst, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close() // Return the original error
+		return err
+	}
+	return out.Close()
+}
+
+// stacks returns the stack traces of every goroutine.
+func stacks() []byte {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
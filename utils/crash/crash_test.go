@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/utils/logging"
+)
+
+func TestReporterWrite(t *testing.T) {
+	assert := assert.New(t)
+
+	logDir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(logDir, "main.log"), []byte("first line\nlast line\n"), 0o600))
+	assert.NoError(os.WriteFile(filepath.Join(logDir, "notes.txt"), []byte("not a log"), 0o600))
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "test_counter",
+		Help: "a counter",
+	})
+	assert.NoError(registry.Register(counter))
+
+	config := Config{
+		Enabled:      true,
+		Directory:    t.TempDir(),
+		LogDirectory: logDir,
+		MaxLogSize:   int64(len("last line\n")),
+		MaxReports:   1,
+	}
+	r := New(logging.NoLog{}, config, map[string]string{"networkID": "local"}, registry)
+
+	oldDir, err := r.Write("first crash")
+	assert.NoError(err)
+	dir, err := r.Write("second crash")
+	assert.NoError(err)
+
+	// Only the latest report is kept
+	_, err = os.Stat(oldDir)
+	assert.True(os.IsNotExist(err))
+
+	reason, err := os.ReadFile(filepath.Join(dir, reasonFile))
+	assert.NoError(err)
+	assert.Contains(string(reason), "second crash")
+
+	stacks, err := os.ReadFile(filepath.Join(dir, stacksFile))
+	assert.NoError(err)
+	assert.Contains(string(stacks), "TestReporterWrite")
+
+	summary, err := os.ReadFile(filepath.Join(dir, configFile))
+	assert.NoError(err)
+	assert.Contains(string(summary), `"networkID": "local"`)
+
+	metrics, err := os.ReadFile(filepath.Join(dir, metricsFile))
+	assert.NoError(err)
+	assert.Contains(string(metrics), "test_counter")
+
+	log, err := os.ReadFile(filepath.Join(dir, logsDir, "main.log"))
+	assert.NoError(err)
+	assert.Equal("last line\n", string(log))
+
+	_, err = os.Stat(filepath.Join(dir, logsDir, "notes.txt"))
+	assert.True(os.IsNotExist(err))
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lasthyphen/beacongo/utils/logging"
+)
+
+var (
+	_ Bus = &bus{}
+
+	errDuplicateSubscriber = errors.New("duplicated subscriber")
+	errUnknownSubscriber   = errors.New("unknown subscriber")
+	errNoKinds             = errors.New("no kinds of events to subscribe to")
+)
+
+// Handler is called with each event a subscriber is subscribed to. Handlers
+// are called synchronously by the publisher, so they must not block.
+type Handler func(Event)
+
+// Publisher publishes events to the subscribers of their kind
+type Publisher interface {
+	Publish(event Event)
+}
+
+// Bus delivers the events published by the subsystems of the node to the
+// subsystems and external sinks that subscribe to them.
+type Bus interface {
+	Publisher
+
+	// Subscribe causes [handler] to be called with every event of one of
+	// [kinds]. Events are delivered in the order they are published.
+	Subscribe(name string, handler Handler, kinds ...Kind) error
+
+	// Unsubscribe removes the subscriber named [name].
+	Unsubscribe(name string) error
+}
+
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+type bus struct {
+	log logging.Logger
+
+	lock sync.RWMutex
+	// Subscriber name --> Kinds the subscriber is subscribed to
+	names map[string][]Kind
+	// Kind --> Subscribers of the kind, in order of subscription
+	subscribers map[Kind][]subscriber
+}
+
+func New(log logging.Logger) Bus {
+	return &bus{
+		log:         log,
+		names:       make(map[string][]Kind),
+		subscribers: make(map[Kind][]subscriber),
+	}
+}
+
+func (b *bus) Publish(event Event) {
+	kind := event.Kind()
+
+	b.lock.RLock()
+	// Handlers are called without holding the lock so that they may
+	// (un)subscribe.
+	subscribers := b.subscribers[kind]
+	b.lock.RUnlock()
+
+	for _, s := range subscribers {
+		b.log.Verbo("delivering %s event to %s", kind, s.name)
+		s.handler(event)
+	}
+}
+
+func (b *bus) Subscribe(name string, handler Handler, kinds ...Kind) error {
+	if len(kinds) == 0 {
+		return fmt.Errorf("%w: %q", errNoKinds, name)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.names[name]; ok {
+		return fmt.Errorf("%w: %q", errDuplicateSubscriber, name)
+	}
+	b.names[name] = kinds
+
+	s := subscriber{
+		name:    name,
+		handler: handler,
+	}
+	for _, kind := range kinds {
+		// Copy on write, as the old slice may be used by Publish
+		subscribers := b.subscribers[kind]
+		newSubscribers := make([]subscriber, len(subscribers), len(subscribers)+1)
+		copy(newSubscribers, subscribers)
+		b.subscribers[kind] = append(newSubscribers, s)
+	}
+	return nil
+}
+
+func (b *bus) Unsubscribe(name string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	kinds, ok := b.names[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownSubscriber, name)
+	}
+	delete(b.names, name)
+
+	for _, kind := range kinds {
+		subscribers := b.subscribers[kind]
+		newSubscribers := make([]subscriber, 0, len(subscribers))
+		for _, s := range subscribers {
+			if s.name != name {
+				newSubscribers = append(newSubscribers, s)
+			}
+		}
+		if len(newSubscribers) == 0 {
+			delete(b.subscribers, kind)
+		} else {
+			b.subscribers[kind] = newSubscribers
+		}
+	}
+	return nil
+}
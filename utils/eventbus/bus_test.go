@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/logging"
+)
+
+func TestBus(t *testing.T) {
+	assert := assert.New(t)
+
+	b := New(logging.NoLog{})
+
+	var (
+		peerEvents  []Event
+		chainEvents []Event
+	)
+	err := b.Subscribe("peers", func(e Event) { peerEvents = append(peerEvents, e) }, KindPeerConnected, KindPeerDisconnected)
+	assert.NoError(err)
+	err = b.Subscribe("chains", func(e Event) { chainEvents = append(chainEvents, e) }, KindChainBootstrapped)
+	assert.NoError(err)
+
+	err = b.Subscribe("peers", func(Event) {}, KindHealthChanged)
+	assert.ErrorIs(err, errDuplicateSubscriber)
+	err = b.Subscribe("nothing", func(Event) {})
+	assert.ErrorIs(err, errNoKinds)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	b.Publish(PeerConnected{NodeID: nodeID})
+	b.Publish(ChainBootstrapped{ChainID: chainID})
+	b.Publish(PeerDisconnected{NodeID: nodeID})
+	b.Publish(HealthChanged{Checks: "health", Healthy: true})
+
+	assert.Equal([]Event{PeerConnected{NodeID: nodeID}, PeerDisconnected{NodeID: nodeID}}, peerEvents)
+	assert.Equal([]Event{ChainBootstrapped{ChainID: chainID}}, chainEvents)
+
+	assert.NoError(b.Unsubscribe("peers"))
+	assert.ErrorIs(b.Unsubscribe("peers"), errUnknownSubscriber)
+
+	b.Publish(PeerConnected{NodeID: nodeID})
+	assert.Len(peerEvents, 2)
+}
+
+func TestBusUnsubscribeDuringPublish(t *testing.T) {
+	assert := assert.New(t)
+
+	b := New(logging.NoLog{})
+
+	calls := 0
+	err := b.Subscribe("once", func(Event) {
+		calls++
+		assert.NoError(b.Unsubscribe("once"))
+	}, KindChainBootstrapped)
+	assert.NoError(err)
+
+	b.Publish(ChainBootstrapped{})
+	b.Publish(ChainBootstrapped{})
+	assert.Equal(1, calls)
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/version"
+)
+
+var (
+	_ Event = ChainBootstrapped{}
+	_ Event = PeerConnected{}
+	_ Event = PeerDisconnected{}
+	_ Event = ConsensusAccepted{}
+	_ Event = DecisionAccepted{}
+	_ Event = HealthChanged{}
+	_ Event = ValidatorSetChanged{}
+)
+
+// Kind identifies the type of an event
+type Kind string
+
+const (
+	KindChainBootstrapped Kind = "chainBootstrapped"
+	KindPeerConnected     Kind = "peerConnected"
+	KindPeerDisconnected  Kind = "peerDisconnected"
+	KindConsensusAccepted Kind = "consensusAccepted"
+	KindDecisionAccepted  Kind = "decisionAccepted"
+	KindHealthChanged     Kind = "healthChanged"
+
+	KindValidatorSetChanged Kind = "validatorSetChanged"
+)
+
+// Event is something that happened in a subsystem of the node
+type Event interface {
+	Kind() Kind
+}
+
+// ChainBootstrapped is published when a chain finishes bootstrapping
+type ChainBootstrapped struct {
+	ChainID ids.ID
+}
+
+func (ChainBootstrapped) Kind() Kind { return KindChainBootstrapped }
+
+// PeerConnected is published when a connection to a peer is established
+type PeerConnected struct {
+	NodeID  ids.NodeID
+	Version version.Application
+}
+
+func (PeerConnected) Kind() Kind { return KindPeerConnected }
+
+// PeerDisconnected is published when the connection to a peer is closed
+type PeerDisconnected struct {
+	NodeID ids.NodeID
+}
+
+func (PeerDisconnected) Kind() Kind { return KindPeerDisconnected }
+
+// Accepted describes a container that was accepted by a chain
+type Accepted struct {
+	ChainID     ids.ID
+	ContainerID ids.ID
+	Container   []byte
+}
+
+// ConsensusAccepted is published when a vertex or block is accepted. It's
+// published before the container is committed to the chain's database.
+type ConsensusAccepted struct {
+	Accepted
+}
+
+func (ConsensusAccepted) Kind() Kind { return KindConsensusAccepted }
+
+// DecisionAccepted is published when a tx or block is accepted. It's published
+// before the container is committed to the chain's database.
+type DecisionAccepted struct {
+	Accepted
+}
+
+func (DecisionAccepted) Kind() Kind { return KindDecisionAccepted }
+
+// HealthChanged is published when a set of health checks starts or stops
+// passing, and the first time the set is run.
+type HealthChanged struct {
+	// Set of checks whose state changed. One of "readiness", "health" or
+	// "liveness".
+	Checks  string
+	Healthy bool
+	// Names of the checks that are failing
+	Failing []string
+}
+
+func (HealthChanged) Kind() Kind { return KindHealthChanged }
+
+// ValidatorChange is how the validator set of a subnet changed
+type ValidatorChange string
+
+const (
+	ValidatorAdded         ValidatorChange = "added"
+	ValidatorRemoved       ValidatorChange = "removed"
+	ValidatorWeightChanged ValidatorChange = "weightChanged"
+)
+
+// ValidatorSetChanged is published when a validator is added to, or removed
+// from, the validator set of a tracked subnet, or when its weight changes.
+type ValidatorSetChanged struct {
+	SubnetID ids.ID          `json:"subnetID"`
+	NodeID   ids.NodeID      `json:"nodeID"`
+	Change   ValidatorChange `json:"change"`
+	// Weight of the validator before the change. Zero if it was added.
+	OldWeight uint64 `json:"oldWeight"`
+	// Weight of the validator after the change. Zero if it was removed.
+	NewWeight uint64 `json:"newWeight"`
+}
+
+func (ValidatorSetChanged) Kind() Kind { return KindValidatorSetChanged }
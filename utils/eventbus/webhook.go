@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/beacongo/utils/logging"
+)
+
+const (
+	// Max number of events waiting to be posted to a webhook. Events
+	// published while the queue is full are dropped.
+	webhookQueueSize = 1024
+
+	webhookTimeout = 10 * time.Second
+)
+
+// webhookPayload is the body of the requests sent to a webhook
+type webhookPayload struct {
+	Kind  Kind  `json:"kind"`
+	Event Event `json:"event"`
+}
+
+// Webhook posts the events it's subscribed to, as JSON, to a URL. Events are
+// posted in the order they were published by a single goroutine, so that
+// publishers aren't blocked by the webhook.
+type Webhook struct {
+	log    logging.Logger
+	url    string
+	client *http.Client
+
+	events  chan Event
+	closer  sync.Once
+	closing chan struct{}
+	done    chan struct{}
+}
+
+// NewWebhook returns a webhook that posts events to [url] until it's closed.
+func NewWebhook(log logging.Logger, url string) *Webhook {
+	w := &Webhook{
+		log:     log,
+		url:     url,
+		client:  &http.Client{Timeout: webhookTimeout},
+		events:  make(chan Event, webhookQueueSize),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.dispatch()
+	return w
+}
+
+// Handle queues [event] to be posted. It can be subscribed to a Bus.
+func (w *Webhook) Handle(event Event) {
+	select {
+	case w.events <- event:
+	default:
+		w.log.Warn("dropping %s event as the webhook %s is falling behind", event.Kind(), w.url)
+	}
+}
+
+// Close stops posting events. Queued events that weren't posted yet are
+// dropped.
+func (w *Webhook) Close() {
+	w.closer.Do(func() {
+		close(w.closing)
+	})
+	<-w.done
+}
+
+func (w *Webhook) dispatch() {
+	defer close(w.done)
+
+	for {
+		select {
+		case <-w.closing:
+			return
+		case event := <-w.events:
+			if err := w.post(event); err != nil {
+				w.log.Debug("couldn't post %s event to webhook %s: %s", event.Kind(), w.url, err)
+			}
+		}
+	}
+}
+
+func (w *Webhook) post(event Event) error {
+	body, err := json.Marshal(webhookPayload{
+		Kind:  event.Kind(),
+		Event: event,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-w.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package eventbus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/logging"
+)
+
+func TestWebhook(t *testing.T) {
+	assert := assert.New(t)
+
+	type payload struct {
+		Kind  Kind                `json:"kind"`
+		Event ValidatorSetChanged `json:"event"`
+	}
+	received := make(chan payload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := payload{}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&p))
+		received <- p
+	}))
+	defer server.Close()
+
+	b := New(logging.NoLog{})
+	w := NewWebhook(logging.NoLog{}, server.URL)
+	defer w.Close()
+	assert.NoError(b.Subscribe("webhook", w.Handle, KindValidatorSetChanged))
+
+	event := ValidatorSetChanged{
+		SubnetID:  ids.GenerateTestID(),
+		NodeID:    ids.GenerateTestNodeID(),
+		Change:    ValidatorWeightChanged,
+		OldWeight: 1,
+		NewWeight: 2,
+	}
+	b.Publish(event)
+	assert.Equal(payload{
+		Kind:  KindValidatorSetChanged,
+		Event: event,
+	}, <-received)
+}
@@ -0,0 +1,387 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// Only this many bytes at the end of the file are searched for the metadata
+const maxMetadataSize = 128 * 1024
+
+// Types of the fields in the data section
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBool
+	typeFloat
+)
+
+var (
+	metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+	ErrNotFound = errors.New("address not found")
+
+	errNoMetadata         = errors.New("couldn't find database metadata")
+	errInvalidMetadata    = errors.New("invalid database metadata")
+	errInvalidRecordSize  = errors.New("unsupported record size")
+	errInvalidDataSection = errors.New("invalid data section")
+	errUnexpectedType     = errors.New("unexpected data type")
+	errInvalidPointer     = errors.New("invalid pointer")
+)
+
+// Location is the location of an IP address
+type Location struct {
+	// ISO 3166-1 code of the country
+	Country string `json:"country,omitempty"`
+	// English name of the city
+	City      string  `json:"city,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+}
+
+// Reader looks up IP addresses in a MaxMind DB (MMDB) file, such as the
+// GeoLite2 City and Country databases.
+type Reader struct {
+	tree       []byte
+	data       []byte
+	nodeCount  uint32
+	recordSize uint32
+	ipVersion  uint32
+	// Node to start from when looking up an IPv4 address
+	ipv4Start uint32
+}
+
+// Open reads the database at [path].
+func Open(path string) (*Reader, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return New(b)
+}
+
+// New returns a reader of the database [b].
+func New(b []byte) (*Reader, error) {
+	searchStart := len(b) - maxMetadataSize
+	if searchStart < 0 {
+		searchStart = 0
+	}
+	markerIndex := bytes.LastIndex(b[searchStart:], metadataMarker)
+	if markerIndex < 0 {
+		return nil, errNoMetadata
+	}
+	metadataStart := searchStart + markerIndex + len(metadataMarker)
+
+	metadataIntf, _, err := decoder{data: b[metadataStart:]}.decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode metadata: %w", err)
+	}
+	metadata, ok := metadataIntf.(map[string]interface{})
+	if !ok {
+		return nil, errInvalidMetadata
+	}
+
+	r := &Reader{}
+	for key, dst := range map[string]*uint32{
+		"node_count":  &r.nodeCount,
+		"record_size": &r.recordSize,
+		"ip_version":  &r.ipVersion,
+	} {
+		value, ok := metadata[key].(uint64)
+		if !ok || value > math.MaxUint32 {
+			return nil, fmt.Errorf("%w: %s", errInvalidMetadata, key)
+		}
+		*dst = uint32(value)
+	}
+	switch r.recordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: %d", errInvalidRecordSize, r.recordSize)
+	}
+
+	treeSize := uint64(r.nodeCount) * uint64(r.recordSize) / 4
+	// The search tree is followed by 16 bytes of zeros
+	dataStart := treeSize + 16
+	if dataStart > uint64(metadataStart-len(metadataMarker)) {
+		return nil, errInvalidDataSection
+	}
+	r.tree = b[:treeSize]
+	r.data = b[dataStart : metadataStart-len(metadataMarker)]
+
+	// In IPv6 databases, IPv4 addresses are found in the ::/96 subnet
+	if r.ipVersion == 6 {
+		for i := 0; i < 96 && r.ipv4Start < r.nodeCount; i++ {
+			r.ipv4Start = r.readNode(r.ipv4Start, 0)
+		}
+	}
+	return r, nil
+}
+
+// Lookup returns the record of [ip], or ErrNotFound if the database has no
+// record of it.
+func (r *Reader) Lookup(ip net.IP) (map[string]interface{}, error) {
+	node := uint32(0)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		node = r.ipv4Start
+	} else if r.ipVersion == 4 {
+		return nil, ErrNotFound
+	}
+
+	numBits := len(ip) * 8
+	for i := 0; i < numBits && node < r.nodeCount; i++ {
+		bit := (ip[i/8] >> (7 - uint(i%8))) & 1
+		node = r.readNode(node, bit)
+	}
+	if node <= r.nodeCount {
+		return nil, ErrNotFound
+	}
+
+	offset := uint64(node) - uint64(r.nodeCount) - 16
+	recordIntf, _, err := decoder{data: r.data}.decode(offset, 0)
+	if err != nil {
+		return nil, err
+	}
+	record, ok := recordIntf.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: record isn't a map", errUnexpectedType)
+	}
+	return record, nil
+}
+
+// Locate returns the location of [ip] according to the GeoIP2 and GeoLite2
+// database schemas.
+func (r *Reader) Locate(ip net.IP) (Location, error) {
+	record, err := r.Lookup(ip)
+	if err != nil {
+		return Location{}, err
+	}
+	location := Location{}
+	location.Country, _ = lookupPath(record, "country", "iso_code").(string)
+	location.City, _ = lookupPath(record, "city", "names", "en").(string)
+	location.Latitude, _ = lookupPath(record, "location", "latitude").(float64)
+	location.Longitude, _ = lookupPath(record, "location", "longitude").(float64)
+	return location, nil
+}
+
+// readNode returns the [bit] record of [node].
+func (r *Reader) readNode(node uint32, bit byte) uint32 {
+	nodeSize := r.recordSize / 4
+	b := r.tree[node*nodeSize : (node+1)*nodeSize]
+	switch r.recordSize {
+	case 24:
+		b = b[3*bit:]
+		return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+	case 28:
+		if bit == 0 {
+			return uint32(b[3]&0xF0)<<20 | uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+		}
+		return uint32(b[3]&0x0F)<<24 | uint32(b[4])<<16 | uint32(b[5])<<8 | uint32(b[6])
+	default:
+		return binary.BigEndian.Uint32(b[4*bit:])
+	}
+}
+
+func lookupPath(value interface{}, path ...string) interface{} {
+	for _, key := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+// decoder decodes fields of the MMDB data section format.
+type decoder struct {
+	data []byte
+}
+
+// decode the field at [offset]. Returns the field and the offset of the next
+// field. [depth] is the number of containers the field is nested in.
+func (d decoder) decode(offset uint64, depth int) (interface{}, uint64, error) {
+	if depth > 64 {
+		return nil, 0, fmt.Errorf("%w: too deeply nested", errInvalidDataSection)
+	}
+	ctrl, offset, err := d.readBytes(offset, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	fieldType := int(ctrl[0] >> 5)
+	if fieldType == typePointer {
+		pointer, next, err := d.decodePointer(ctrl[0], offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		value, _, err := d.decode(pointer, depth+1)
+		return value, next, err
+	}
+	if fieldType == typeExtended {
+		extended, next, err := d.readBytes(offset, 1)
+		if err != nil {
+			return nil, 0, err
+		}
+		fieldType = 7 + int(extended[0])
+		offset = next
+	}
+
+	size, offset, err := d.decodeSize(ctrl[0], offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch fieldType {
+	case typeMap:
+		m := make(map[string]interface{}, size)
+		for i := uint64(0); i < size; i++ {
+			var key, value interface{}
+			key, offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			keyStr, ok := key.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("%w: map key isn't a string", errUnexpectedType)
+			}
+			value, offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[keyStr] = value
+		}
+		return m, offset, nil
+	case typeArray:
+		a := make([]interface{}, 0, size)
+		for i := uint64(0); i < size; i++ {
+			var value interface{}
+			value, offset, err = d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			a = append(a, value)
+		}
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	b, next, err := d.readBytes(offset, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch fieldType {
+	case typeString:
+		return string(b), next, nil
+	case typeBytes, typeUint128:
+		return b, next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: double of size %d", errInvalidDataSection, size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: float of size %d", errInvalidDataSection, size)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("%w: integer of size %d", errInvalidDataSection, size)
+		}
+		value := uint64(0)
+		for _, byt := range b {
+			value = value<<8 | uint64(byt)
+		}
+		return value, next, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%w: integer of size %d", errInvalidDataSection, size)
+		}
+		value := uint32(0)
+		for _, byt := range b {
+			value = value<<8 | uint32(byt)
+		}
+		return int64(int32(value)), next, nil
+	default:
+		return nil, 0, fmt.Errorf("%w: %d", errUnexpectedType, fieldType)
+	}
+}
+
+func (d decoder) decodePointer(ctrl byte, offset uint64) (uint64, uint64, error) {
+	pointerSize := uint64((ctrl>>3)&0x3) + 1
+	b, next, err := d.readBytes(offset, pointerSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	prefix := uint64(ctrl & 0x7)
+	var pointer uint64
+	switch pointerSize {
+	case 1:
+		pointer = prefix<<8 | uint64(b[0])
+	case 2:
+		pointer = (prefix<<16 | uint64(b[0])<<8 | uint64(b[1])) + 2048
+	case 3:
+		pointer = (prefix<<24 | uint64(b[0])<<16 | uint64(b[1])<<8 | uint64(b[2])) + 526336
+	default:
+		pointer = uint64(binary.BigEndian.Uint32(b))
+	}
+	if pointer >= uint64(len(d.data)) {
+		return 0, 0, errInvalidPointer
+	}
+	return pointer, next, nil
+}
+
+func (d decoder) decodeSize(ctrl byte, offset uint64) (uint64, uint64, error) {
+	size := uint64(ctrl & 0x1f)
+	if size < 29 {
+		return size, offset, nil
+	}
+
+	numBytes := size - 28
+	b, next, err := d.readBytes(offset, numBytes)
+	if err != nil {
+		return 0, 0, err
+	}
+	extra := uint64(0)
+	for _, byt := range b {
+		extra = extra<<8 | uint64(byt)
+	}
+	switch size {
+	case 29:
+		return 29 + extra, next, nil
+	case 30:
+		return 285 + extra, next, nil
+	default:
+		return 65821 + extra, next, nil
+	}
+}
+
+func (d decoder) readBytes(offset, size uint64) ([]byte, uint64, error) {
+	end := offset + size
+	if end < offset || end > uint64(len(d.data)) {
+		return nil, 0, fmt.Errorf("%w: field exceeds the data section", errInvalidDataSection)
+	}
+	return d.data[offset:end], end, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package geoip
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeString(s string) []byte {
+	return append([]byte{typeString<<5 | byte(len(s))}, s...)
+}
+
+func encodeMap(numPairs int) []byte {
+	return []byte{typeMap<<5 | byte(numPairs)}
+}
+
+func encodeDouble(f float64) []byte {
+	b := make([]byte, 9)
+	b[0] = typeDouble<<5 | 8
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(f))
+	return b
+}
+
+func encodeUint16(v uint16) []byte {
+	return []byte{typeUint16<<5 | 2, byte(v >> 8), byte(v)}
+}
+
+func concat(parts ...[]byte) []byte {
+	result := []byte(nil)
+	for _, part := range parts {
+		result = append(result, part...)
+	}
+	return result
+}
+
+// newTestDB returns an IPv4 database with a single node. Addresses in
+// 0.0.0.0/1 are located in Bern, and addresses in 128.0.0.0/1 aren't found.
+func newTestDB() []byte {
+	const nodeCount = 1
+	tree := []byte{
+		0, 0, nodeCount + 16, // data at offset 0
+		0, 0, nodeCount, // no data
+	}
+	data := concat(
+		encodeMap(3),
+		encodeString("country"),
+		encodeMap(1),
+		encodeString("iso_code"),
+		encodeString("CH"),
+		encodeString("city"),
+		encodeMap(1),
+		encodeString("names"),
+		// Pointer to the value of "country", at offset 9
+		[]byte{typePointer << 5, 9},
+		encodeString("location"),
+		encodeMap(2),
+		encodeString("latitude"),
+		encodeDouble(46.9),
+		encodeString("longitude"),
+		encodeDouble(7.4),
+	)
+	metadata := concat(
+		encodeMap(3),
+		encodeString("node_count"),
+		encodeUint16(nodeCount),
+		encodeString("record_size"),
+		encodeUint16(24),
+		encodeString("ip_version"),
+		encodeUint16(4),
+	)
+	return concat(tree, make([]byte, 16), data, metadataMarker, metadata)
+}
+
+func TestReaderLocate(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := New(newTestDB())
+	assert.NoError(err)
+
+	location, err := r.Locate(net.ParseIP("10.0.0.1"))
+	assert.NoError(err)
+	assert.Equal("CH", location.Country)
+	assert.Equal(46.9, location.Latitude)
+	assert.Equal(7.4, location.Longitude)
+
+	record, err := r.Lookup(net.ParseIP("10.0.0.1"))
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"iso_code": "CH"}, lookupPath(record, "city", "names"))
+
+	_, err = r.Locate(net.ParseIP("200.0.0.1"))
+	assert.ErrorIs(err, ErrNotFound)
+
+	_, err = r.Locate(net.ParseIP("2001:db8::1"))
+	assert.ErrorIs(err, ErrNotFound)
+}
+
+func TestReaderInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := New([]byte("not a database"))
+	assert.ErrorIs(err, errNoMetadata)
+
+	db := newTestDB()
+	// Truncate the metadata
+	_, err = New(db[:len(db)-1])
+	assert.Error(err)
+}
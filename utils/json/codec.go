@@ -26,9 +26,10 @@ var (
 )
 
 // NewCodec returns a new json codec that will convert the first character of
-// the method to uppercase
+// the method to uppercase. Errors that implement CodedError are reported with
+// their code and data.
 func NewCodec() rpc.Codec {
-	return lowercase{json2.NewCodec()}
+	return lowercase{json2.NewCustomCodecWithErrorMapper(rpc.DefaultEncoderSelector, mapError)}
 }
 
 type lowercase struct{ *json2.Codec }
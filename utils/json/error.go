@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"errors"
+
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+var _ CodedError = &codedError{}
+
+// CodedError is an error that is reported to JSON-RPC clients with a code and
+// structured data, in addition to its message. Errors that wrap a CodedError
+// are reported with its code and data, and their own message.
+type CodedError interface {
+	error
+	// ErrorCode is the code of the JSON-RPC error object
+	ErrorCode() int
+	// ErrorData is the data of the JSON-RPC error object. May be nil.
+	ErrorData() interface{}
+}
+
+type codedError struct {
+	code    int
+	message string
+}
+
+// NewError returns an error that is reported to JSON-RPC clients with [code]
+// and no data.
+func NewError(code int, message string) error {
+	return &codedError{
+		code:    code,
+		message: message,
+	}
+}
+
+func (e *codedError) Error() string          { return e.message }
+func (e *codedError) ErrorCode() int         { return e.code }
+func (e *codedError) ErrorData() interface{} { return nil }
+
+// mapError converts errors that carry a code into JSON-RPC error objects.
+// Other errors are reported with the generic server error code.
+func mapError(err error) error {
+	var coded CodedError
+	if !errors.As(err, &coded) {
+		return err
+	}
+	return &json2.Error{
+		Code:    json2.ErrorCode(coded.ErrorCode()),
+		Message: err.Error(),
+		Data:    coded.ErrorData(),
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDataError struct{}
+
+func (testDataError) Error() string          { return "test" }
+func (testDataError) ErrorCode() int         { return -32050 }
+func (testDataError) ErrorData() interface{} { return []int{1, 2} }
+
+func TestMapError(t *testing.T) {
+	assert := assert.New(t)
+
+	// Errors without a code are left to the codec
+	plainErr := errors.New("plain")
+	assert.Equal(plainErr, mapError(plainErr))
+
+	codedErr := NewError(-32010, "coded")
+	mapped := mapError(fmt.Errorf("wrapped: %w", codedErr))
+	jsonErr, ok := mapped.(*json2.Error)
+	assert.True(ok)
+	assert.Equal(json2.ErrorCode(-32010), jsonErr.Code)
+	assert.Equal("wrapped: coded", jsonErr.Message)
+	assert.Nil(jsonErr.Data)
+
+	mapped = mapError(testDataError{})
+	jsonErr, ok = mapped.(*json2.Error)
+	assert.True(ok)
+	assert.Equal(json2.ErrorCode(-32050), jsonErr.Code)
+	assert.Equal([]int{1, 2}, jsonErr.Data)
+}
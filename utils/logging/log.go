@@ -158,6 +158,7 @@ func (l *log) AssertDeferredNoError(f func() error) {
 func (l *log) StopOnPanic() {
 	if r := recover(); r != nil {
 		l.Fatal("Panicking due to:\n%s\nFrom:\n%s", r, Stacktrace{})
+		handlePanic(r)
 		l.Stop()
 		panic(r)
 	}
@@ -168,6 +169,7 @@ func (l *log) RecoverAndPanic(f func()) { defer l.StopOnPanic(); f() }
 func (l *log) stopAndExit(exit func()) {
 	if r := recover(); r != nil {
 		l.Fatal("Panicking due to:\n%s\nFrom:\n%s", r, Stacktrace{})
+		handlePanic(r)
 		l.Stop()
 		exit()
 	}
@@ -21,3 +21,21 @@ func TestLog(t *testing.T) {
 		t.Fatalf("Exit function was never called")
 	}
 }
+
+func TestLogPanicHandler(t *testing.T) {
+	log := NewLogger(false, "", NewWrappedCore(Info, Discard, Plain.ConsoleEncoder()))
+
+	var handled interface{}
+	SetPanicHandler(func(reason interface{}) {
+		handled = reason
+	})
+	defer SetPanicHandler(nil)
+
+	log.RecoverAndExit(func() {
+		panic("DON'T PANIC!")
+	}, func() {})
+
+	if handled != "DON'T PANIC!" {
+		t.Fatalf("Panic handler was called with %v", handled)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"sync"
+)
+
+var (
+	panicHandlerLock sync.RWMutex
+	panicHandler     func(reason interface{})
+)
+
+// SetPanicHandler registers [handler] to be called with the reason of every
+// panic that is recovered by a logger, after the panic is logged and before
+// it's rethrown or the exit function is called. Passing nil removes the
+// handler.
+func SetPanicHandler(handler func(reason interface{})) {
+	panicHandlerLock.Lock()
+	defer panicHandlerLock.Unlock()
+
+	panicHandler = handler
+}
+
+func handlePanic(reason interface{}) {
+	panicHandlerLock.RLock()
+	handler := panicHandler
+	panicHandlerLock.RUnlock()
+
+	if handler != nil {
+		handler(reason)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metric
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/database"
+)
+
+var _ PersistentCounter = &persistentCounter{}
+
+// PersistentCounter is a counter whose value is written to a database, so that
+// it resumes from its prior value after a restart rather than from zero.
+type PersistentCounter interface {
+	// Add adds [delta] to the counter and writes the new value to the
+	// database. Callers should commit the database together with the state
+	// the counter describes, so that the two don't diverge.
+	Add(delta uint64) error
+}
+
+type persistentCounter struct {
+	// May be nil
+	db    database.KeyValueWriter
+	key   []byte
+	value uint64
+
+	counter prometheus.Counter
+}
+
+// NewPersistentCounter registers a counter described by [opts] to [reg]. The
+// counter resumes from the value stored in [db]. If [db] is nil, the counter
+// isn't persisted and starts from zero.
+func NewPersistentCounter(opts prometheus.CounterOpts, db database.KeyValueReaderWriter, reg prometheus.Registerer) (PersistentCounter, error) {
+	c := &persistentCounter{
+		key:     []byte(prometheus.BuildFQName(opts.Namespace, opts.Subsystem, opts.Name)),
+		counter: prometheus.NewCounter(opts),
+	}
+	if db != nil {
+		value, err := database.GetUInt64(db, c.key)
+		if err != nil && err != database.ErrNotFound {
+			return nil, err
+		}
+		c.db = db
+		c.value = value
+		c.counter.Add(float64(value))
+	}
+	return c, reg.Register(c.counter)
+}
+
+func (c *persistentCounter) Add(delta uint64) error {
+	c.value += delta
+	c.counter.Add(float64(delta))
+	if c.db == nil {
+		return nil
+	}
+	return database.PutUInt64(c.db, c.key, c.value)
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metric
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database/memdb"
+)
+
+func TestPersistentCounter(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := prometheus.CounterOpts{
+		Namespace: "test",
+		Name:      "count",
+	}
+	db := memdb.New()
+
+	c, err := NewPersistentCounter(opts, db, prometheus.NewRegistry())
+	assert.NoError(err)
+	assert.NoError(c.Add(1))
+	assert.NoError(c.Add(2))
+	assert.Equal(3.0, testutil.ToFloat64(c.(*persistentCounter).counter))
+
+	// After a restart, the counter resumes from its prior value
+	c, err = NewPersistentCounter(opts, db, prometheus.NewRegistry())
+	assert.NoError(err)
+	assert.Equal(3.0, testutil.ToFloat64(c.(*persistentCounter).counter))
+	assert.NoError(c.Add(1))
+	assert.Equal(4.0, testutil.ToFloat64(c.(*persistentCounter).counter))
+
+	// Without a database, the counter starts from zero
+	c, err = NewPersistentCounter(opts, nil, prometheus.NewRegistry())
+	assert.NoError(err)
+	assert.NoError(c.Add(1))
+	assert.Equal(1.0, testutil.ToFloat64(c.(*persistentCounter).counter))
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package overload
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/api/health"
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+)
+
+const (
+	// Once overloaded, every signal must drop below [recoveryRatio] of its
+	// threshold before the node leaves overload mode. This prevents the mode
+	// from flapping when a signal hovers around its threshold.
+	recoveryRatio = 0.9
+
+	retryAfter = 5 * time.Second
+)
+
+var (
+	_ Controller = &controller{}
+
+	errOverloaded = errors.New("node is overloaded")
+
+	// highPriorityPaths are always served, even while overloaded, so that
+	// operators can observe and manage an overloaded node.
+	highPriorityPaths = []string{
+		"/ext/health",
+		"/ext/metrics",
+		"/ext/admin",
+		"/ext/info",
+	}
+)
+
+// Config describes when the node should be considered overloaded.
+type Config struct {
+	// CPUThreshold is the CPU usage, in number of cores, at or above which the
+	// node is overloaded. If 0, CPU usage is ignored.
+	CPUThreshold float64 `json:"cpuThreshold"`
+
+	// QueueThreshold is the number of unprocessed consensus messages, summed
+	// across all chains, at or above which the node is overloaded. If 0, the
+	// queue depth is ignored.
+	QueueThreshold int `json:"queueThreshold"`
+
+	// CheckFrequency is how often the signals are sampled.
+	CheckFrequency time.Duration `json:"checkFrequency"`
+}
+
+// Signal is a measurement of resource pressure.
+type Signal struct {
+	Name string
+	// Threshold at or above which the node is overloaded. If 0, the signal
+	// is ignored.
+	Threshold float64
+	Value     func() float64
+}
+
+// Controller tracks whether the node is under resource pressure. While
+// overloaded, low-priority API requests are rejected and gossip is reduced.
+// Consensus messages are never dropped by the controller.
+type Controller interface {
+	health.Checker
+
+	// WrapHandler rejects low-priority requests with a 429 while the node is
+	// overloaded.
+	WrapHandler(h http.Handler) http.Handler
+
+	// Overloaded returns true if the node is currently overloaded.
+	//
+	// It's safe to call Overloaded from multiple goroutines.
+	Overloaded() bool
+
+	// Start periodically samples [signals] according to [config].
+	Start(
+		config Config,
+		signals []Signal,
+		namespace string,
+		registerer prometheus.Registerer,
+	) error
+
+	// Stop sampling the signals.
+	Stop()
+}
+
+type controller struct {
+	log logging.Logger
+
+	lock       sync.RWMutex
+	overloaded bool
+	// reasons describes which signals were over their thresholds the last
+	// time the node entered overload mode.
+	reasons []string
+	signals []Signal
+
+	overloadedGauge prometheus.Gauge
+	transitions     prometheus.Counter
+	rejected        prometheus.Counter
+
+	closer    sync.Once
+	closeChan chan struct{}
+}
+
+// NewController returns a controller that reports the node as not overloaded
+// until it is started.
+func NewController(log logging.Logger) Controller {
+	return &controller{
+		log:       log,
+		closeChan: make(chan struct{}),
+	}
+}
+
+func (c *controller) Start(
+	config Config,
+	signals []Signal,
+	namespace string,
+	registerer prometheus.Registerer,
+) error {
+	c.overloadedGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "overloaded",
+		Help:      "1 if the node is currently shedding load, 0 otherwise",
+	})
+	c.transitions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transitions",
+		Help:      "Number of times the node entered or left overload mode",
+	})
+	c.rejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rejected_api_requests",
+		Help:      "Number of API requests rejected because the node was overloaded",
+	})
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(c.overloadedGauge),
+		registerer.Register(c.transitions),
+		registerer.Register(c.rejected),
+	)
+	if errs.Errored() {
+		return errs.Err
+	}
+
+	c.lock.Lock()
+	for _, signal := range signals {
+		if signal.Threshold > 0 {
+			c.signals = append(c.signals, signal)
+		}
+	}
+	numSignals := len(c.signals)
+	c.lock.Unlock()
+
+	if numSignals == 0 || config.CheckFrequency <= 0 {
+		c.log.Info("load shedding is disabled")
+		return nil
+	}
+	go c.run(config.CheckFrequency)
+	return nil
+}
+
+func (c *controller) run(frequency time.Duration) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.update()
+		case <-c.closeChan:
+			return
+		}
+	}
+}
+
+// update samples the signals and transitions in or out of overload mode.
+func (c *controller) update() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var (
+		over       []string
+		recovering = true
+	)
+	for _, signal := range c.signals {
+		value := signal.Value()
+		if value >= signal.Threshold {
+			over = append(over, fmt.Sprintf("%s (%.2f) >= %.2f", signal.Name, value, signal.Threshold))
+		}
+		if value >= signal.Threshold*recoveryRatio {
+			recovering = false
+		}
+	}
+
+	switch {
+	case !c.overloaded && len(over) > 0:
+		c.overloaded = true
+		c.reasons = over
+		c.overloadedGauge.Set(1)
+		c.transitions.Inc()
+		c.log.Warn("entering overload mode: %s", strings.Join(over, ", "))
+	case c.overloaded && recovering:
+		c.overloaded = false
+		c.reasons = nil
+		c.overloadedGauge.Set(0)
+		c.transitions.Inc()
+		c.log.Info("leaving overload mode")
+	}
+}
+
+func (c *controller) Overloaded() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.overloaded
+}
+
+func (c *controller) HealthCheck() (interface{}, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	details := map[string]interface{}{
+		"overloaded": c.overloaded,
+	}
+	if !c.overloaded {
+		return details, nil
+	}
+	details["reasons"] = c.reasons
+	return details, errOverloaded
+}
+
+func (c *controller) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.Overloaded() || isHighPriority(r.URL.Path) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if c.rejected != nil {
+			c.rejected.Inc()
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+		http.Error(w, errOverloaded.Error(), http.StatusTooManyRequests)
+	})
+}
+
+func (c *controller) Stop() {
+	c.closer.Do(func() {
+		close(c.closeChan)
+	})
+}
+
+func isHighPriority(path string) bool {
+	for _, prefix := range highPriorityPaths {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package overload
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/utils/logging"
+)
+
+func TestControllerHysteresis(t *testing.T) {
+	assert := assert.New(t)
+
+	value := 0.
+	c := NewController(logging.NoLog{}).(*controller)
+	err := c.Start(
+		Config{},
+		[]Signal{
+			{
+				Name:      "test",
+				Threshold: 10,
+				Value:     func() float64 { return value },
+			},
+			{
+				Name:  "ignored",
+				Value: func() float64 { return 100 },
+			},
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	assert.NoError(err)
+	defer c.Stop()
+
+	c.update()
+	assert.False(c.Overloaded())
+	_, err = c.HealthCheck()
+	assert.NoError(err)
+
+	value = 10
+	c.update()
+	assert.True(c.Overloaded())
+	_, err = c.HealthCheck()
+	assert.ErrorIs(err, errOverloaded)
+
+	// Below the threshold, but not far enough below it to recover.
+	value = 9.5
+	c.update()
+	assert.True(c.Overloaded())
+
+	value = 8
+	c.update()
+	assert.False(c.Overloaded())
+}
+
+func TestControllerWrapHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	value := 0.
+	c := NewController(logging.NoLog{}).(*controller)
+	err := c.Start(
+		Config{},
+		[]Signal{{
+			Name:      "test",
+			Threshold: 1,
+			Value:     func() float64 { return value },
+		}},
+		"",
+		prometheus.NewRegistry(),
+	)
+	assert.NoError(err)
+	defer c.Stop()
+
+	h := c.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w
+	}
+
+	assert.Equal(http.StatusOK, serve("/ext/bc/X").Code)
+
+	value = 2
+	c.update()
+
+	w := serve("/ext/bc/X")
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(w.Header().Get("Retry-After"))
+
+	assert.Equal(http.StatusOK, serve("/ext/health").Code)
+	assert.Equal(http.StatusOK, serve("/ext/info").Code)
+	assert.Equal(http.StatusTooManyRequests, serve("/ext/healthy").Code)
+}
@@ -6,9 +6,11 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	rpc "github.com/gorilla/rpc/v2/json2"
 )
@@ -29,35 +31,106 @@ func SendJSONRequest(
 	ops := NewOptions(options)
 	uri.RawQuery = ops.queryParams.Encode()
 
+	backoff := ops.retryBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := sendRequest(ctx, uri, requestBodyBytes, ops.headers)
+		if err == nil {
+			if err := rpc.DecodeClientResponse(resp.Body, reply); err != nil {
+				// Drop any error during close to report the original error
+				_ = resp.Body.Close()
+				return fmt.Errorf("failed to decode client response: %w", err)
+			}
+			return resp.Body.Close()
+		}
+		if attempt >= ops.maxAttempts || !isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// sendRequest posts [body] to [uri]. Returns an error if the request failed or
+// its response has a non successful status code.
+func sendRequest(
+	ctx context.Context,
+	uri *url.URL,
+	body []byte,
+	headers http.Header,
+) (*http.Response, error) {
 	request, err := http.NewRequestWithContext(
 		ctx,
 		"POST",
 		uri.String(),
-		bytes.NewBuffer(requestBodyBytes),
+		bytes.NewBuffer(body),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, &requestError{err: fmt.Errorf("failed to create request: %w", err)}
 	}
 
-	request.Header = ops.headers
+	request.Header = headers.Clone()
 	request.Header.Set("Content-Type", "application/json")
 
 	resp, err := http.DefaultClient.Do(request)
 	if err != nil {
-		return fmt.Errorf("failed to issue request: %w", err)
+		return nil, fmt.Errorf("failed to issue request: %w", err)
 	}
 
 	// Return an error for any non successful status code
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		// Drop any error during close to report the original error
 		_ = resp.Body.Close()
-		return fmt.Errorf("received status code: %d", resp.StatusCode)
+		return nil, &statusError{code: resp.StatusCode}
 	}
+	return resp, nil
+}
 
-	if err := rpc.DecodeClientResponse(resp.Body, reply); err != nil {
-		// Drop any error during close to report the original error
-		_ = resp.Body.Close()
-		return fmt.Errorf("failed to decode client response: %w", err)
+// requestError is returned when a request couldn't be built, so resending it
+// would fail again.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+
+func (e *requestError) Unwrap() error { return e.err }
+
+// statusError is returned when the response to a request has a non successful
+// status code.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("received status code: %d", e.code)
+}
+
+// isRetryable returns true if resending a request that failed with [err] may
+// succeed.
+func isRetryable(err error) bool {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return false
+	}
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) {
+		// The node couldn't be reached
+		return true
+	}
+	switch statusErr.code {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
 	}
-	return resp.Body.Close()
 }
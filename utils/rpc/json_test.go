@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer returns a server that fails the first [numFailures] requests
+// with [failureCode] and then responds to the "test.echo" method.
+func newTestServer(numFailures int, failureCode int) (*httptest.Server, *int) {
+	numRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		if numRequests <= numFailures {
+			w.WriteHeader(failureCode)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","result":{"authorization":%q},"id":0}`, r.Header.Get("Authorization"))
+	}))
+	return server, &numRequests
+}
+
+type testReply struct {
+	Authorization string `json:"authorization"`
+}
+
+func TestSendRequestRetries(t *testing.T) {
+	assert := assert.New(t)
+
+	server, numRequests := newTestServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	requester := NewEndpointRequester(server.URL, "test", WithAuthToken("token"))
+	reply := &testReply{}
+	err := requester.SendRequest(context.Background(), "echo", struct{}{}, reply, WithRetries(3, time.Millisecond))
+	assert.NoError(err)
+	assert.Equal(3, *numRequests)
+	assert.Equal("Bearer token", reply.Authorization)
+
+	// Without retries, the first failure is returned
+	server, numRequests = newTestServer(1, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	requester = NewEndpointRequester(server.URL, "test")
+	err = requester.SendRequest(context.Background(), "echo", struct{}{}, reply)
+	assert.Error(err)
+	assert.Equal(1, *numRequests)
+}
+
+func TestSendRequestNotRetryable(t *testing.T) {
+	assert := assert.New(t)
+
+	server, numRequests := newTestServer(1, http.StatusUnauthorized)
+	defer server.Close()
+
+	requester := NewEndpointRequester(server.URL, "test", WithRetries(3, time.Millisecond))
+	err := requester.SendRequest(context.Background(), "echo", struct{}{}, &testReply{})
+	assert.Error(err)
+	assert.Equal(1, *numRequests)
+}
@@ -6,6 +6,7 @@ package rpc
 import (
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type Option func(*Options)
@@ -13,12 +14,18 @@ type Option func(*Options)
 type Options struct {
 	headers     http.Header
 	queryParams url.Values
+
+	// Number of times a request is sent before its failure is returned
+	maxAttempts int
+	// Delay before the first retry of a request
+	retryBackoff time.Duration
 }
 
 func NewOptions(ops []Option) *Options {
 	o := &Options{
 		headers:     http.Header{},
 		queryParams: url.Values{},
+		maxAttempts: 1,
 	}
 	o.applyOptions(ops)
 	return o
@@ -49,3 +56,23 @@ func WithQueryParam(key, val string) Option {
 		o.queryParams.Set(key, val)
 	}
 }
+
+// WithAuthToken authenticates requests with [token], as returned by the auth
+// API.
+func WithAuthToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithRetries resends requests that fail to reach the node, or that the node
+// reports as temporarily unavailable, until they've been sent [maxAttempts]
+// times. The delay before the first retry is [backoff] and doubles after each
+// retry.
+//
+// A request that reached the node before failing may be executed more than
+// once, so requests that aren't idempotent shouldn't be retried.
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(o *Options) {
+		o.maxAttempts = maxAttempts
+		o.retryBackoff = backoff
+	}
+}
@@ -17,12 +17,17 @@ type EndpointRequester interface {
 
 type avalancheEndpointRequester struct {
 	uri, base string
+	// Applied to every request, before the options of the request
+	options []Option
 }
 
-func NewEndpointRequester(uri, base string) EndpointRequester {
+// NewEndpointRequester returns a requester of the [base] service at [uri].
+// [options] are applied to every request sent by the requester.
+func NewEndpointRequester(uri, base string, options ...Option) EndpointRequester {
 	return &avalancheEndpointRequester{
-		uri:  uri,
-		base: base,
+		uri:     uri,
+		base:    base,
+		options: options,
 	}
 }
 
@@ -43,6 +48,6 @@ func (e *avalancheEndpointRequester) SendRequest(
 		fmt.Sprintf("%s.%s", e.base, method),
 		params,
 		reply,
-		options...,
+		append(e.options[:len(e.options):len(e.options)], options...)...,
 	)
 }
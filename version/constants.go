@@ -4,6 +4,7 @@
 package version
 
 import (
+	"math"
 	"time"
 
 	"github.com/lasthyphen/beacongo/utils/constants"
@@ -71,6 +72,15 @@ var (
 		constants.FujiID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 	}
 	XChainMigrationDefaultTime = time.Date(2022, time.January, 1, 1, 0, 0, 0, time.UTC)
+
+	// AVMStrictEncodingHeights are the numbers of accepted X-chain txs after
+	// which txs must be serialized exactly as the codec would serialize them.
+	// FIXME: schedule this before release
+	AVMStrictEncodingHeights = map[uint32]uint64{
+		constants.MainnetID: math.MaxUint64,
+		constants.FujiID:    math.MaxUint64,
+	}
+	AVMStrictEncodingDefaultHeight uint64
 )
 
 func GetApricotPhase0Time(networkID uint32) time.Time {
@@ -129,6 +139,13 @@ func GetXChainMigrationTime(networkID uint32) time.Time {
 	return XChainMigrationDefaultTime
 }
 
+func GetAVMStrictEncodingHeight(networkID uint32) uint64 {
+	if height, exists := AVMStrictEncodingHeights[networkID]; exists {
+		return height
+	}
+	return AVMStrictEncodingDefaultHeight
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
@@ -3,7 +3,11 @@
 
 package version
 
-import "fmt"
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
 
 var (
 	// String is displayed when CLI arg --version is used
@@ -11,6 +15,18 @@ var (
 
 	// GitCommit is set in the build script at compile time
 	GitCommit string
+
+	// BuildDate is set in the build script at compile time. It is the
+	// RFC 3339 commit date of [GitCommit], rather than the wall clock time of
+	// the build, so that rebuilding the same commit produces the same binary.
+	BuildDate string
+
+	// BuildTags is set in the build script at compile time to the comma
+	// separated list of build tags the binary was compiled with
+	BuildTags string
+
+	// GoVersion is the version of Go the binary was compiled with
+	GoVersion = runtime.Version()
 )
 
 func init() {
@@ -26,3 +42,18 @@ func init() {
 	format += "]\n"
 	String = fmt.Sprintf(format, args...)
 }
+
+// BuildTagList returns the build tags the binary was compiled with
+func BuildTagList() []string {
+	return parseBuildTags(BuildTags)
+}
+
+func parseBuildTags(tags string) []string {
+	tagList := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tagList = append(tagList, tag)
+		}
+	}
+	return tagList
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBuildTags(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(parseBuildTags(""))
+	assert.Equal([]string{"rocksdballowed"}, parseBuildTags("rocksdballowed"))
+	assert.Equal([]string{"a", "b"}, parseBuildTags(" a,,b, "))
+}
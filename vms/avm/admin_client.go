@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/utils/formatting"
+	"github.com/lasthyphen/beacongo/utils/rpc"
+
+	cjson "github.com/lasthyphen/beacongo/utils/json"
+)
+
+var _ AdminClient = &adminClient{}
+
+// AdminClient for interacting with the operator facing API of an AVM on [chain]
+type AdminClient interface {
+	// StartIndexBackfill starts indexing the historical transactions
+	StartIndexBackfill(ctx context.Context, options ...rpc.Option) (bool, error)
+	// GetIndexBackfillStatus returns the progress of the index backfill
+	GetIndexBackfillStatus(ctx context.Context, options ...rpc.Option) (*IndexBackfillStatus, error)
+	// TraceTx reports each step of the verification of [txBytes] without
+	// issuing the transaction
+	TraceTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*TraceTxReply, error)
+	// ExportGenesis returns the bytes of a genesis for [networkID] whose assets
+	// hold the UTXOs that reference any of [addrs], or every UTXO if [addrs] is
+	// empty, and the ID on this chain of each asset keyed by its alias
+	ExportGenesis(ctx context.Context, networkID uint32, addrs []string, options ...rpc.Option) ([]byte, map[string]ids.ID, error)
+}
+
+type adminClient struct {
+	requester rpc.EndpointRequester
+}
+
+// NewAdminClient returns an AVM admin client for interacting with the AVM
+// running on [chain]
+func NewAdminClient(uri, chain string, options ...rpc.Option) AdminClient {
+	path := fmt.Sprintf(
+		"%s/ext/%s/%s/admin",
+		uri,
+		constants.ChainAliasPrefix,
+		chain,
+	)
+	return &adminClient{
+		requester: rpc.NewEndpointRequester(path, "avmAdmin", options...),
+	}
+}
+
+func (c *adminClient) StartIndexBackfill(ctx context.Context, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest(ctx, "startIndexBackfill", struct{}{}, res, options...)
+	return res.Success, err
+}
+
+func (c *adminClient) GetIndexBackfillStatus(ctx context.Context, options ...rpc.Option) (*IndexBackfillStatus, error) {
+	res := &IndexBackfillStatus{}
+	err := c.requester.SendRequest(ctx, "getIndexBackfillStatus", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *adminClient) TraceTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*TraceTxReply, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &TraceTxReply{}
+	err = c.requester.SendRequest(ctx, "traceTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *adminClient) ExportGenesis(ctx context.Context, networkID uint32, addrs []string, options ...rpc.Option) ([]byte, map[string]ids.ID, error) {
+	res := &ExportGenesisReply{}
+	err := c.requester.SendRequest(ctx, "exportGenesis", &ExportGenesisArgs{
+		NetworkID: cjson.Uint32(networkID),
+		Addresses: addrs,
+		Encoding:  formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	genesisBytes, err := formatting.Decode(res.Encoding, res.Bytes)
+	return genesisBytes, res.AssetIDs, err
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/formatting"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+// AdminService defines the operator facing API methods of the AVM. It's served
+// under the /admin endpoint of the chain, so that access to it can be
+// restricted separately from the public API.
+type AdminService struct {
+	vm *VM
+}
+
+// StartIndexBackfill starts indexing the transactions that were accepted
+// before the address transaction index was enabled.
+func (service *AdminService) StartIndexBackfill(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+	service.vm.ctx.Log.Debug("AVM Admin: StartIndexBackfill called")
+
+	if err := service.vm.indexBackfill.start(); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetIndexBackfillStatus returns the progress of the index backfill.
+func (service *AdminService) GetIndexBackfillStatus(_ *http.Request, _ *struct{}, reply *IndexBackfillStatus) error {
+	service.vm.ctx.Log.Debug("AVM Admin: GetIndexBackfillStatus called")
+
+	status, err := service.vm.indexBackfill.status()
+	*reply = status
+	return err
+}
+
+// TraceTxReply defines the TraceTx replies returned from the API
+type TraceTxReply struct {
+	// ID of the transaction, if it could be parsed
+	TxID ids.ID `json:"txID"`
+	// True if the transaction passed every traced check
+	Valid bool `json:"valid"`
+	TxTrace
+	// Why the verification failed, if it did
+	Error string `json:"error,omitempty"`
+}
+
+// TraceTx verifies a signed transaction against the current state and reports
+// each step of the verification: which UTXOs each input consumed, which fx
+// verified it and which check failed. The transaction isn't issued or stored.
+func (service *AdminService) TraceTx(_ *http.Request, args *api.FormattedTx, reply *TraceTxReply) error {
+	service.vm.ctx.Log.Debug("AVM Admin: TraceTx called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	vm := service.vm
+	if !vm.bootstrapped {
+		return errBootstrapping
+	}
+
+	reply.Steps = []*TraceStep{}
+	txStep := reply.TxTrace.step(traceKindTx, 0)
+	tx, err := vm.parser.Parse(txBytes)
+	if err := txStep.check("parse", err); err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+	reply.TxID = tx.ID()
+
+	err = tx.SyntacticVerify(
+		vm.ctx,
+		vm.parser.Codec(),
+		vm.feeAssetID,
+		vm.TxFee,
+		vm.CreateAssetTxFee,
+		len(vm.fxs),
+	)
+	if err := txStep.check("syntax", err); err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	err = tx.Visit(&txSemanticVerify{
+		tx:    tx,
+		vm:    vm,
+		trace: &reply.TxTrace,
+	})
+	if err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+	reply.Valid = true
+	return nil
+}
+
+// ExportGenesisArgs are arguments for passing into ExportGenesis requests
+type ExportGenesisArgs struct {
+	// ID of the network the genesis is built for
+	NetworkID json.Uint32 `json:"networkID"`
+	// If non-empty, only the UTXOs that reference any of these addresses are
+	// exported. Otherwise, the whole UTXO set is exported.
+	Addresses []string            `json:"addresses"`
+	Encoding  formatting.Encoding `json:"encoding"`
+}
+
+// ExportGenesisReply defines the ExportGenesis replies returned from the API
+type ExportGenesisReply struct {
+	BuildGenesisReply
+	// Maps the alias of each asset in the genesis to the ID of the asset on
+	// this chain
+	AssetIDs map[string]ids.ID `json:"assetIDs"`
+}
+
+// ExportGenesis returns a genesis, in the format returned by BuildGenesis,
+// whose assets hold the current UTXOs of this chain. It can be used to start
+// a new network seeded with the balances of this chain. Each asset is
+// re-created in the genesis, so its ID in the new network differs.
+func (service *AdminService) ExportGenesis(_ *http.Request, args *ExportGenesisArgs, reply *ExportGenesisReply) error {
+	service.vm.ctx.Log.Debug("AVM Admin: ExportGenesis called for %d addresses", len(args.Addresses))
+
+	addrs, err := djtx.ParseServiceAddresses(service.vm, args.Addresses)
+	if err != nil {
+		return err
+	}
+	g, assetIDs, err := service.vm.exportGenesis(uint32(args.NetworkID), addrs)
+	if err != nil {
+		return fmt.Errorf("couldn't export genesis: %w", err)
+	}
+	genesisBytes, err := MarshalGenesis(service.vm.parser.GenesisCodec(), g)
+	if err != nil {
+		return fmt.Errorf("problem marshaling genesis: %w", err)
+	}
+
+	reply.Bytes, err = formatting.EncodeWithChecksum(args.Encoding, genesisBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode genesis as string: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	reply.AssetIDs = assetIDs
+	return nil
+}
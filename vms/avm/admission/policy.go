@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admission
+
+import (
+	"errors"
+	"fmt"
+	"plugin"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/components/verify"
+)
+
+// PluginSymbol is the name of the function a policy plugin must export. It
+// must have the type func() (Policy, error).
+const PluginSymbol = "NewPolicy"
+
+var (
+	_ Policy = Policies{}
+	_ Policy = maxOutputs(0)
+	_ Policy = deniedAddresses{}
+
+	ErrTooManyOutputs = errors.New("tx has too many outputs")
+	ErrDeniedAddress  = errors.New("tx references a denied address")
+
+	errWrongPluginType = fmt.Errorf("plugin symbol %q must have type func() (Policy, error)", PluginSymbol)
+)
+
+// Policy decides whether a transaction may enter this node's mempool.
+//
+// Policies are local to a node and are only applied to transactions before
+// they are issued into consensus. They are never applied when verifying
+// transactions issued by other nodes, so a policy can't cause the node to
+// disagree with the rest of the network.
+type Policy interface {
+	// Admit returns nil if [tx] may be issued. [consumed] are the UTXOs
+	// spent by [tx] that are known to this chain.
+	Admit(tx *txs.Tx, consumed []*djtx.UTXO) error
+}
+
+// Config describes the admission policies of a chain.
+type Config struct {
+	// Transactions that consume or produce an output owned by any of these
+	// addresses are refused.
+	DeniedAddresses []string `json:"denied-addresses"`
+	// If non-zero, transactions that produce more outputs than this are
+	// refused.
+	MaxOutputs int `json:"max-outputs"`
+	// Paths of Go plugins that each export [PluginSymbol].
+	Plugins []string `json:"plugins"`
+}
+
+// New returns the policies described by [config]. Denied addresses are parsed
+// with [parseAddr].
+func New(config Config, parseAddr func(string) (ids.ShortID, error)) (Policy, error) {
+	policies := Policies{}
+	if config.MaxOutputs > 0 {
+		policies = append(policies, maxOutputs(config.MaxOutputs))
+	}
+	if len(config.DeniedAddresses) > 0 {
+		addrs := ids.NewShortSet(len(config.DeniedAddresses))
+		for _, addrStr := range config.DeniedAddresses {
+			addr, err := parseAddr(addrStr)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse denied address %q: %w", addrStr, err)
+			}
+			addrs.Add(addr)
+		}
+		policies = append(policies, NewDeniedAddresses(addrs))
+	}
+	for _, path := range config.Plugins {
+		policy, err := LoadPlugin(path)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// LoadPlugin returns the policy created by the Go plugin at [path].
+func LoadPlugin(path string) (Policy, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open admission plugin %q: %w", path, err)
+	}
+	symbol, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load admission plugin %q: %w", path, err)
+	}
+	newPolicy, ok := symbol.(func() (Policy, error))
+	if !ok {
+		return nil, fmt.Errorf("couldn't load admission plugin %q: %w", path, errWrongPluginType)
+	}
+	return newPolicy()
+}
+
+// Policies admits a transaction only if every policy admits it.
+type Policies []Policy
+
+func (p Policies) Admit(tx *txs.Tx, consumed []*djtx.UTXO) error {
+	for _, policy := range p {
+		if err := policy.Admit(tx, consumed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type maxOutputs int
+
+// NewMaxOutputs returns a policy that refuses transactions that produce more
+// than [max] outputs, including exported outputs.
+func NewMaxOutputs(max int) Policy { return maxOutputs(max) }
+
+func (m maxOutputs) Admit(tx *txs.Tx, _ []*djtx.UTXO) error {
+	numOutputs := len(producedOutputs(tx))
+	if numOutputs > int(m) {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyOutputs, numOutputs, m)
+	}
+	return nil
+}
+
+type deniedAddresses struct {
+	addrs ids.ShortSet
+}
+
+// NewDeniedAddresses returns a policy that refuses transactions that consume
+// or produce outputs owned by any of [addrs].
+func NewDeniedAddresses(addrs ids.ShortSet) Policy {
+	return deniedAddresses{addrs: addrs}
+}
+
+func (d deniedAddresses) Admit(tx *txs.Tx, consumed []*djtx.UTXO) error {
+	outs := producedOutputs(tx)
+	for _, utxo := range consumed {
+		outs = append(outs, utxo.Out)
+	}
+	for _, out := range outs {
+		addressable, ok := out.(djtx.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				continue
+			}
+			if d.addrs.Contains(addr) {
+				return fmt.Errorf("%w: %s", ErrDeniedAddress, addr)
+			}
+		}
+	}
+	return nil
+}
+
+// producedOutputs returns the outputs of [tx], including outputs exported to
+// other chains.
+func producedOutputs(tx *txs.Tx) []verify.State {
+	utxos := tx.UTXOs()
+	outs := make([]verify.State, 0, len(utxos))
+	for _, utxo := range utxos {
+		outs = append(outs, utxo.Out)
+	}
+	if exportTx, ok := tx.UnsignedTx.(*txs.ExportTx); ok {
+		for _, out := range exportTx.ExportedOuts {
+			outs = append(outs, out.Out)
+		}
+	}
+	return outs
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admission
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+func newTestTx(owners ...ids.ShortID) *txs.Tx {
+	outs := make([]*djtx.TransferableOutput, len(owners))
+	for i, owner := range owners {
+		outs[i] = &djtx.TransferableOutput{
+			Asset: djtx.Asset{ID: ids.GenerateTestID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{owner},
+				},
+			},
+		}
+	}
+	return &txs.Tx{UnsignedTx: &txs.BaseTx{BaseTx: djtx.BaseTx{
+		Outs: outs,
+	}}}
+}
+
+func TestMaxOutputs(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := NewMaxOutputs(2)
+	assert.NoError(policy.Admit(newTestTx(ids.GenerateTestShortID(), ids.GenerateTestShortID()), nil))
+
+	err := policy.Admit(newTestTx(ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID()), nil)
+	assert.ErrorIs(err, ErrTooManyOutputs)
+}
+
+func TestDeniedAddresses(t *testing.T) {
+	assert := assert.New(t)
+
+	denied := ids.GenerateTestShortID()
+	allowed := ids.GenerateTestShortID()
+	policy := NewDeniedAddresses(ids.ShortSet{denied: struct{}{}})
+
+	assert.NoError(policy.Admit(newTestTx(allowed), nil))
+
+	err := policy.Admit(newTestTx(allowed, denied), nil)
+	assert.ErrorIs(err, ErrDeniedAddress)
+
+	// Spending an output owned by a denied address is also refused
+	consumed := newTestTx(denied).UTXOs()
+	err = policy.Admit(newTestTx(allowed), consumed)
+	assert.ErrorIs(err, ErrDeniedAddress)
+}
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+
+	denied := ids.GenerateTestShortID()
+	errParse := errors.New("unexpected address")
+	parseAddr := func(addrStr string) (ids.ShortID, error) {
+		if addrStr != "denied" {
+			return ids.ShortID{}, errParse
+		}
+		return denied, nil
+	}
+
+	policy, err := New(Config{}, parseAddr)
+	assert.NoError(err)
+	assert.NoError(policy.Admit(newTestTx(denied, denied), nil))
+
+	policy, err = New(Config{
+		DeniedAddresses: []string{"denied"},
+		MaxOutputs:      1,
+	}, parseAddr)
+	assert.NoError(err)
+	assert.ErrorIs(policy.Admit(newTestTx(denied, denied), nil), ErrTooManyOutputs)
+	assert.ErrorIs(policy.Admit(newTestTx(denied), nil), ErrDeniedAddress)
+	assert.NoError(policy.Admit(newTestTx(ids.GenerateTestShortID()), nil))
+
+	_, err = New(Config{DeniedAddresses: []string{"invalid"}}, parseAddr)
+	assert.ErrorIs(err, errParse)
+}
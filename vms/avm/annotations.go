@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+)
+
+// Max number of bytes in the annotation of a tx
+const maxAnnotationSize = 256
+
+var txAnnotationPrefix = []byte("txAnnotation")
+
+// verifyAnnotation returns an error if [annotation] can't be stored.
+func verifyAnnotation(annotation string) error {
+	if l := len(annotation); l > maxAnnotationSize {
+		return fmt.Errorf("max annotation length is %d but provided annotation is length %d", maxAnnotationSize, l)
+	}
+	return nil
+}
+
+// annotateTx stores [annotation] for [txID], so that it's returned alongside
+// the tx by the history queries of this node. Annotations are local to this
+// node and are never included in the tx. An empty annotation isn't stored.
+func (vm *VM) annotateTx(txID ids.ID, annotation string) error {
+	if annotation == "" {
+		return nil
+	}
+	if err := vm.annotationDB.Put(txID[:], []byte(annotation)); err != nil {
+		return fmt.Errorf("couldn't store annotation of tx %s: %w", txID, err)
+	}
+	return nil
+}
+
+// getTxAnnotation returns the annotation of [txID], or "" if it has none.
+func (vm *VM) getTxAnnotation(txID ids.ID) (string, error) {
+	annotation, err := vm.annotationDB.Get(txID[:])
+	if err == database.ErrNotFound {
+		return "", nil
+	}
+	return string(annotation), err
+}
+
+// getTxAnnotations returns the annotations of [txIDs] that have one.
+func (vm *VM) getTxAnnotations(txIDs []ids.ID) (map[ids.ID]string, error) {
+	annotations := make(map[ids.ID]string)
+	for _, txID := range txIDs {
+		annotation, err := vm.getTxAnnotation(txID)
+		if err != nil {
+			return nil, err
+		}
+		if annotation != "" {
+			annotations[txID] = annotation
+		}
+	}
+	return annotations, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/vms/avm/states"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+// Number of transactions read at a time while building the asset supplies
+const assetSupplyBuildBatchSize = 1024
+
+// assetSupplyChanges returns the amount of each asset that was minted and
+// burned by [utx], whose ID is [txID].
+//
+// Funds are minted by the initial states of a new asset and by mint
+// operations. Funds are burned when the inputs of a transaction consume more
+// of an asset than its outputs produce, such as when fees are paid. Exported
+// funds aren't burned.
+func assetSupplyChanges(txID ids.ID, utx txs.UnsignedTx) map[ids.ID]*states.AssetSupply {
+	var (
+		ins    []*djtx.TransferableInput
+		outs   []*djtx.TransferableOutput
+		minted = make(map[ids.ID]uint64)
+	)
+	switch utx := utx.(type) {
+	case *txs.BaseTx:
+		ins, outs = utx.Ins, utx.Outs
+	case *txs.CreateAssetTx:
+		ins, outs = utx.Ins, utx.Outs
+		for _, state := range utx.States {
+			for _, out := range state.Outs {
+				if out, ok := out.(djtx.Amounter); ok {
+					minted[txID] = saturatingAdd(minted[txID], out.Amount())
+				}
+			}
+		}
+	case *txs.OperationTx:
+		ins, outs = utx.Ins, utx.Outs
+		for _, op := range utx.Ops {
+			assetID := op.AssetID()
+			for _, out := range op.Op.Outs() {
+				if out, ok := out.(djtx.Amounter); ok {
+					minted[assetID] = saturatingAdd(minted[assetID], out.Amount())
+				}
+			}
+		}
+	case *txs.ImportTx:
+		ins = make([]*djtx.TransferableInput, 0, len(utx.Ins)+len(utx.ImportedIns))
+		ins = append(ins, utx.Ins...)
+		ins = append(ins, utx.ImportedIns...)
+		outs = utx.Outs
+	case *txs.ExportTx:
+		ins = utx.Ins
+		outs = make([]*djtx.TransferableOutput, 0, len(utx.Outs)+len(utx.ExportedOuts))
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.ExportedOuts...)
+	}
+
+	consumed := make(map[ids.ID]uint64)
+	for _, in := range ins {
+		assetID := in.AssetID()
+		consumed[assetID] = saturatingAdd(consumed[assetID], in.In.Amount())
+	}
+	produced := make(map[ids.ID]uint64)
+	for _, out := range outs {
+		assetID := out.AssetID()
+		produced[assetID] = saturatingAdd(produced[assetID], out.Out.Amount())
+	}
+
+	changes := make(map[ids.ID]*states.AssetSupply)
+	for assetID, amount := range minted {
+		changes[assetID] = &states.AssetSupply{Minted: amount}
+	}
+	for assetID, amount := range consumed {
+		if amount <= produced[assetID] {
+			continue
+		}
+		change, ok := changes[assetID]
+		if !ok {
+			change = &states.AssetSupply{}
+			changes[assetID] = change
+		}
+		change.Burned = amount - produced[assetID]
+	}
+	return changes
+}
+
+// updateAssetSupply accounts for the funds minted and burned by the accepted
+// tx [txID].
+func (vm *VM) updateAssetSupply(txID ids.ID, utx txs.UnsignedTx) error {
+	for assetID, change := range assetSupplyChanges(txID, utx) {
+		supply, err := vm.state.GetAssetSupply(assetID)
+		if err != nil {
+			return err
+		}
+		supply.Minted = saturatingAdd(supply.Minted, change.Minted)
+		supply.Burned = saturatingAdd(supply.Burned, change.Burned)
+		if err := vm.state.PutAssetSupply(assetID, supply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildAssetSupply accounts for every accepted tx in the asset supplies, if
+// they haven't been built yet. The supplies of a chain that was accepting txs
+// before they were maintained are built once, the first time the chain is
+// started.
+func (vm *VM) buildAssetSupply() error {
+	built, err := vm.state.IsAssetSupplyBuilt()
+	if err != nil || built {
+		return err
+	}
+	vm.ctx.Log.Info("building the supply of each asset from the accepted txs")
+
+	cursor := ids.Empty
+	for {
+		txIDs, err := vm.state.TxIDs(cursor, assetSupplyBuildBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, txID := range txIDs {
+			if txID == cursor && cursor != ids.Empty {
+				// Accounted for in the previous batch
+				continue
+			}
+			cursor = txID
+
+			status, err := vm.state.GetStatus(txID)
+			if err == database.ErrNotFound || (err == nil && status != choices.Accepted) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			tx, err := vm.state.GetTx(txID)
+			if err != nil {
+				return err
+			}
+			if err := vm.updateAssetSupply(txID, tx.UnsignedTx); err != nil {
+				return fmt.Errorf("couldn't account for tx %s: %w", txID, err)
+			}
+		}
+		if len(txIDs) < assetSupplyBuildBatchSize {
+			return vm.state.SetAssetSupplyBuilt()
+		}
+	}
+}
+
+// saturatingAdd returns [a] + [b], saturating at MaxUint64.
+func saturatingAdd(a, b uint64) uint64 {
+	sum, err := safemath.Add64(a, b)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return sum
+}
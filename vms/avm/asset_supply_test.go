@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/vms/avm/states"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+func TestAssetSupply(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	createTx := GetCreateTxFromGenesisTest(t, genesisBytes, "DJTX")
+	assetID := createTx.ID()
+
+	// The genesis UTXOs were minted
+	minted := uint64(0)
+	for _, utxo := range createTx.UTXOs() {
+		if out, ok := utxo.Out.(djtx.Amounter); ok {
+			minted += out.Amount()
+		}
+	}
+	supply, err := vm.state.GetAssetSupply(assetID)
+	assert.NoError(err)
+	assert.Equal(states.AssetSupply{Minted: minted}, supply)
+
+	// The tx consumes a genesis UTXO without producing any outputs, which
+	// burns its funds
+	newTx := NewTx(t, genesisBytes, vm)
+	tx, err := vm.ParseTx(newTx.Bytes())
+	assert.NoError(err)
+	assert.NoError(tx.Verify())
+	assert.NoError(tx.Accept())
+
+	supply, err = vm.state.GetAssetSupply(assetID)
+	assert.NoError(err)
+	assert.Equal(states.AssetSupply{
+		Minted: minted,
+		Burned: startBalance,
+	}, supply)
+	assert.Equal(minted-startBalance, supply.Circulating())
+}
@@ -27,12 +27,41 @@ type Client interface {
 	WalletClient
 	// GetTxStatus returns the status of [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error)
+	// GetPendingTxs returns the transactions issued to the node that haven't
+	// been decided yet
+	GetPendingTxs(ctx context.Context, options ...rpc.Option) (*GetPendingTxsReply, error)
 	// ConfirmTx attempts to confirm [txID] by repeatedly checking its status.
 	// Note: ConfirmTx will block until either the context is done or the client
 	//       returns a decided status.
 	ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error)
 	// GetTx returns the byte representation of [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
+	// VerifyTx runs the verification performed by IssueTx on [txBytes] without
+	// issuing the transaction
+	VerifyTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*VerifyTxReply, error)
+	// EstimateFee returns the fee, and the asset it is paid in, that [txBytes]
+	// must pay
+	EstimateFee(ctx context.Context, txBytes []byte, options ...rpc.Option) (uint64, ids.ID, error)
+	// GetFeeAssetID returns the asset that fees are paid in
+	GetFeeAssetID(ctx context.Context, options ...rpc.Option) (ids.ID, error)
+	// BuildUnsignedTx builds a transaction spending the UTXOs of [from] that
+	// must be signed externally. Returns the unsigned transaction and, for each
+	// credential, the addresses that must sign it.
+	BuildUnsignedTx(
+		ctx context.Context,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		outputs []ClientSendMultisigOutput,
+		memo string,
+		options ...rpc.Option,
+	) ([]byte, [][]string, error)
+	// GetTxSigningHashes returns the hash that must be signed by each of the
+	// returned addresses to create the credentials of [unsignedTx]
+	GetTxSigningHashes(ctx context.Context, unsignedTx []byte, options ...rpc.Option) ([]byte, [][]string, error)
+	// AttachSignatures adds [signatures] as the credentials of [unsignedTx]
+	// and returns the signed transaction. If [issue], the transaction is also
+	// issued.
+	AttachSignatures(ctx context.Context, unsignedTx []byte, signatures [][][]byte, issue bool, options ...rpc.Option) (ids.ID, []byte, error)
 	// IssueStopVertex issues a stop vertex.
 	IssueStopVertex(ctx context.Context, options ...rpc.Option) error
 	// GetUTXOs returns the byte representation of the UTXOs controlled by [addrs]
@@ -44,6 +73,30 @@ type Client interface {
 		startUTXOID ids.ID,
 		options ...rpc.Option,
 	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetMultisigUTXOs returns the byte representation of the UTXOs owned by
+	// [addrs] along with other addresses that need at least [minThreshold]
+	// signatures to be spent
+	GetMultisigUTXOs(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		minThreshold uint32,
+		limit uint32,
+		startAddress ids.ShortID,
+		startUTXOID ids.ID,
+		options ...rpc.Option,
+	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetNFTs returns up to [limit] NFTs controlled by [addrs], starting after
+	// [startAddress] and [startUTXOID]. If [assetID] is non-empty, only NFTs of
+	// that asset are returned.
+	GetNFTs(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		assetID string,
+		limit uint32,
+		startAddress ids.ShortID,
+		startUTXOID ids.ID,
+		options ...rpc.Option,
+	) ([]NFT, ids.ShortID, ids.ID, error)
 	// GetAtomicUTXOs returns the byte representation of the atomic UTXOs controlled by [addrs]
 	// from [sourceChain]
 	GetAtomicUTXOs(
@@ -57,9 +110,28 @@ type Client interface {
 	) ([][]byte, ids.ShortID, ids.ID, error)
 	// GetAssetDescription returns a description of [assetID]
 	GetAssetDescription(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetDescriptionReply, error)
+	// GetAssetMetadata returns a description of each of [assetIDs]
+	GetAssetMetadata(ctx context.Context, assetIDs []string, options ...rpc.Option) ([]AssetMetadata, error)
+	// GetAssetStats returns the accepted transfer statistics of [assetID]
+	GetAssetStats(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetStatsReply, error)
+	// GetAssetSupply returns the amounts of [assetID] that were minted and
+	// burned by accepted transactions
+	GetAssetSupply(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetSupplyReply, error)
+	// GetUTXOStats returns the statistics of the UTXO set, including those of
+	// each of [assetIDs]. If [assetIDs] is empty, every asset is included.
+	GetUTXOStats(ctx context.Context, assetIDs []string, options ...rpc.Option) (*GetUTXOStatsReply, error)
 	// GetBalance returns the balance of [assetID] held by [addr].
 	// If [includePartial], balance includes partial owned (i.e. in a multisig) funds.
 	GetBalance(ctx context.Context, addr ids.ShortID, assetID string, includePartial bool, options ...rpc.Option) (*GetBalanceReply, error)
+	// GetTxsByMemo returns the accepted txs whose memo equals [memo], or
+	// starts with [memo] if [prefix], along with the cursor of the next page
+	GetTxsByMemo(ctx context.Context, memo string, prefix bool, cursor string, pageSize uint64, options ...rpc.Option) ([]MemoTx, string, error)
+	// GetUTXOsAtHeight returns the byte representation of the UTXOs that
+	// referenced any of [addrs] once [height] txs had been accepted
+	GetUTXOsAtHeight(ctx context.Context, addrs []ids.ShortID, height uint64, options ...rpc.Option) ([][]byte, error)
+	// GetBalanceAtHeight returns the balance of [assetID] held by [addr] once
+	// [height] txs had been accepted
+	GetBalanceAtHeight(ctx context.Context, addr ids.ShortID, assetID string, includePartial bool, height uint64, options ...rpc.Option) (*GetBalanceReply, error)
 	// GetAllBalances returns all asset balances for [addr]
 	GetAllBalances(ctx context.Context, addr ids.ShortID, includePartial bool, options ...rpc.Option) ([]Balance, error)
 	// CreateAsset creates a new asset and returns its assetID
@@ -114,6 +186,13 @@ type Client interface {
 	CreateAddress(ctx context.Context, user api.UserPass, options ...rpc.Option) (ids.ShortID, error)
 	// ListAddresses returns all addresses on this chain controlled by [user]
 	ListAddresses(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]ids.ShortID, error)
+	// ListAddressesWithToken returns all addresses on this chain controlled by
+	// the user that the read-only [token] was created for
+	ListAddressesWithToken(ctx context.Context, token string, options ...rpc.Option) ([]ids.ShortID, error)
+	// GetUserBalances returns the balances of all addresses on this chain
+	// controlled by [user]. If [token] is non-empty, it's used instead of
+	// [user]'s password.
+	GetUserBalances(ctx context.Context, user api.UserPass, token string, includePartial bool, options ...rpc.Option) ([]Balance, error)
 	// ExportKey returns the private key corresponding to [addr] controlled by [user]
 	ExportKey(ctx context.Context, user api.UserPass, addr ids.ShortID, options ...rpc.Option) (*crypto.PrivateKeySECP256K1R, error)
 	// ImportKey imports [privateKey] to [user]
@@ -172,10 +251,13 @@ type Client interface {
 // implementation for an AVM client for interacting with avm [chain]
 type client struct {
 	requester rpc.EndpointRequester
+	// Only the wallet service tracks the transactions it issued, so
+	// replacing them must be requested from the wallet endpoint.
+	walletRequester rpc.EndpointRequester
 }
 
 // NewClient returns an AVM client for interacting with avm [chain]
-func NewClient(uri, chain string) Client {
+func NewClient(uri, chain string, options ...rpc.Option) Client {
 	path := fmt.Sprintf(
 		"%s/ext/%s/%s",
 		uri,
@@ -183,7 +265,8 @@ func NewClient(uri, chain string) Client {
 		chain,
 	)
 	return &client{
-		requester: rpc.NewEndpointRequester(path, "avm"),
+		requester:       rpc.NewEndpointRequester(path, "avm", options...),
+		walletRequester: newWalletRequester(uri, chain, options),
 	}
 }
 
@@ -200,10 +283,154 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) VerifyTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*VerifyTxReply, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &VerifyTxReply{}
+	err = c.requester.SendRequest(ctx, "verifyTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) EstimateFee(ctx context.Context, txBytes []byte, options ...rpc.Option) (uint64, ids.ID, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+	if err != nil {
+		return 0, ids.Empty, err
+	}
+	res := &EstimateFeeReply{}
+	err = c.requester.SendRequest(ctx, "estimateFee", &EstimateFeeArgs{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return uint64(res.Fee), res.FeeAssetID, err
+}
+
+func (c *client) GetFeeAssetID(ctx context.Context, options ...rpc.Option) (ids.ID, error) {
+	res := &GetFeeAssetIDReply{}
+	err := c.requester.SendRequest(ctx, "getFeeAssetID", struct{}{}, res, options...)
+	return res.AssetID, err
+}
+
+func (c *client) BuildUnsignedTx(
+	ctx context.Context,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	clientOutputs []ClientSendMultisigOutput,
+	memo string,
+	options ...rpc.Option,
+) ([]byte, [][]string, error) {
+	outputs := make([]SendMultisigOutput, len(clientOutputs))
+	for i, clientOutput := range clientOutputs {
+		outputs[i] = SendMultisigOutput{
+			Amount:    cjson.Uint64(clientOutput.Amount),
+			AssetID:   clientOutput.AssetID,
+			To:        ids.ShortIDsToStrings(clientOutput.To),
+			Threshold: cjson.Uint32(clientOutput.Threshold),
+			Locktime:  cjson.Uint64(clientOutput.Locktime),
+		}
+	}
+	res := &BuildUnsignedTxReply{}
+	err := c.requester.SendRequest(ctx, "buildUnsignedTx", &BuildUnsignedTxArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		Outputs:        outputs,
+		Memo:           memo,
+		Encoding:       formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	return txBytes, res.Signers, err
+}
+
+func (c *client) GetTxSigningHashes(ctx context.Context, unsignedTx []byte, options ...rpc.Option) ([]byte, [][]string, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, unsignedTx)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := &GetTxSigningHashesReply{}
+	err = c.requester.SendRequest(ctx, "getTxSigningHashes", &GetTxSigningHashesArgs{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	hash, err := formatting.Decode(res.Encoding, res.Hash)
+	return hash, res.Signers, err
+}
+
+func (c *client) AttachSignatures(
+	ctx context.Context,
+	unsignedTx []byte,
+	signatures [][][]byte,
+	issue bool,
+	options ...rpc.Option,
+) (ids.ID, []byte, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, unsignedTx)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	sigStrs := make([][]string, len(signatures))
+	for i, credSigs := range signatures {
+		sigStrs[i] = make([]string, len(credSigs))
+		for j, sig := range credSigs {
+			sigStrs[i][j], err = formatting.EncodeWithChecksum(formatting.Hex, sig)
+			if err != nil {
+				return ids.Empty, nil, err
+			}
+		}
+	}
+	res := &AttachSignaturesReply{}
+	err = c.requester.SendRequest(ctx, "attachSignatures", &AttachSignaturesArgs{
+		Tx:         txStr,
+		Signatures: sigStrs,
+		Encoding:   formatting.Hex,
+		Issue:      issue,
+	}, res, options...)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	return res.TxID, txBytes, err
+}
+
 func (c *client) IssueStopVertex(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "issueStopVertex", &struct{}{}, &struct{}{}, options...)
 }
 
+func (c *client) ReplaceTx(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	txID ids.ID,
+	fee uint64,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	return replaceTx(ctx, c.walletRequester, user, from, changeAddr, txID, fee, options...)
+}
+
+func (c *client) CancelTx(ctx context.Context, txID ids.ID, options ...rpc.Option) (bool, error) {
+	return cancelTx(ctx, c.walletRequester, txID, options...)
+}
+
+func (c *client) Consolidate(
+	ctx context.Context,
+	user api.UserPass,
+	addr ids.ShortID,
+	assetID string,
+	maxUTXOs uint32,
+	options ...rpc.Option,
+) (*ConsolidateReply, error) {
+	return consolidate(ctx, c.walletRequester, user, addr, assetID, maxUTXOs, options...)
+}
+
 func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error) {
 	res := &GetTxStatusReply{}
 	err := c.requester.SendRequest(ctx, "getTxStatus", &api.JSONTxID{
@@ -212,6 +439,12 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res.Status, err
 }
 
+func (c *client) GetPendingTxs(ctx context.Context, options ...rpc.Option) (*GetPendingTxsReply, error) {
+	res := &GetPendingTxsReply{}
+	err := c.requester.SendRequest(ctx, "getPendingTxs", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error) {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
@@ -300,6 +533,78 @@ func (c *client) GetAtomicUTXOs(
 	return utxos, endAddr, endUTXOID, err
 }
 
+func (c *client) GetMultisigUTXOs(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	minThreshold uint32,
+	limit uint32,
+	startAddress ids.ShortID,
+	startUTXOID ids.ID,
+	options ...rpc.Option,
+) ([][]byte, ids.ShortID, ids.ID, error) {
+	res := &api.GetUTXOsReply{}
+	err := c.requester.SendRequest(ctx, "getMultisigUTXOs", &GetMultisigUTXOsArgs{
+		Addresses:    ids.ShortIDsToStrings(addrs),
+		MinThreshold: cjson.Uint32(minThreshold),
+		Limit:        cjson.Uint32(limit),
+		StartIndex: api.Index{
+			Address: startAddress.String(),
+			UTXO:    startUTXOID.String(),
+		},
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, ids.ShortID{}, ids.Empty, err
+	}
+
+	utxos := make([][]byte, len(res.UTXOs))
+	for i, utxo := range res.UTXOs {
+		utxoBytes, err := formatting.Decode(res.Encoding, utxo)
+		if err != nil {
+			return nil, ids.ShortID{}, ids.Empty, err
+		}
+		utxos[i] = utxoBytes
+	}
+	endAddr, err := address.ParseToID(res.EndIndex.Address)
+	if err != nil {
+		return nil, ids.ShortID{}, ids.Empty, err
+	}
+	endUTXOID, err := ids.FromString(res.EndIndex.UTXO)
+	return utxos, endAddr, endUTXOID, err
+}
+
+func (c *client) GetNFTs(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	assetID string,
+	limit uint32,
+	startAddress ids.ShortID,
+	startUTXOID ids.ID,
+	options ...rpc.Option,
+) ([]NFT, ids.ShortID, ids.ID, error) {
+	res := &GetNFTsReply{}
+	err := c.requester.SendRequest(ctx, "getNFTs", &GetNFTsArgs{
+		Addresses: ids.ShortIDsToStrings(addrs),
+		AssetID:   assetID,
+		Limit:     cjson.Uint32(limit),
+		StartIndex: api.Index{
+			Address: startAddress.String(),
+			UTXO:    startUTXOID.String(),
+		},
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, ids.ShortID{}, ids.Empty, err
+	}
+
+	endAddr, err := address.ParseToID(res.EndIndex.Address)
+	if err != nil {
+		return nil, ids.ShortID{}, ids.Empty, err
+	}
+	endUTXOID, err := ids.FromString(res.EndIndex.UTXO)
+	return res.NFTs, endAddr, endUTXOID, err
+}
+
 func (c *client) GetAssetDescription(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetDescriptionReply, error) {
 	res := &GetAssetDescriptionReply{}
 	err := c.requester.SendRequest(ctx, "getAssetDescription", &GetAssetDescriptionArgs{
@@ -308,6 +613,38 @@ func (c *client) GetAssetDescription(ctx context.Context, assetID string, option
 	return res, err
 }
 
+func (c *client) GetAssetMetadata(ctx context.Context, assetIDs []string, options ...rpc.Option) ([]AssetMetadata, error) {
+	res := &GetAssetMetadataReply{}
+	err := c.requester.SendRequest(ctx, "getAssetMetadata", &GetAssetMetadataArgs{
+		AssetIDs: assetIDs,
+	}, res, options...)
+	return res.Assets, err
+}
+
+func (c *client) GetAssetStats(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetStatsReply, error) {
+	res := &GetAssetStatsReply{}
+	err := c.requester.SendRequest(ctx, "getAssetStats", &GetAssetStatsArgs{
+		AssetID: assetID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetAssetSupply(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetSupplyReply, error) {
+	res := &GetAssetSupplyReply{}
+	err := c.requester.SendRequest(ctx, "getAssetSupply", &GetAssetSupplyArgs{
+		AssetID: assetID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetUTXOStats(ctx context.Context, assetIDs []string, options ...rpc.Option) (*GetUTXOStatsReply, error) {
+	res := &GetUTXOStatsReply{}
+	err := c.requester.SendRequest(ctx, "getUTXOStats", &GetUTXOStatsArgs{
+		AssetIDs: assetIDs,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetBalance(
 	ctx context.Context,
 	addr ids.ShortID,
@@ -324,6 +661,71 @@ func (c *client) GetBalance(
 	return res, err
 }
 
+func (c *client) GetTxsByMemo(
+	ctx context.Context,
+	memo string,
+	prefix bool,
+	cursor string,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]MemoTx, string, error) {
+	res := &GetTxsByMemoReply{}
+	err := c.requester.SendRequest(ctx, "getTxsByMemo", &GetTxsByMemoArgs{
+		Memo:     memo,
+		Prefix:   prefix,
+		Cursor:   cursor,
+		PageSize: cjson.Uint64(pageSize),
+	}, res, options...)
+	return res.Txs, res.Cursor, err
+}
+
+func (c *client) GetUTXOsAtHeight(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	height uint64,
+	options ...rpc.Option,
+) ([][]byte, error) {
+	res := &GetUTXOsAtHeightReply{}
+	err := c.requester.SendRequest(ctx, "getUTXOsAtHeight", &GetUTXOsAtHeightArgs{
+		Addresses: ids.ShortIDsToStrings(addrs),
+		Height:    cjson.Uint64(height),
+		Encoding:  formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([][]byte, len(res.UTXOs))
+	for i, utxo := range res.UTXOs {
+		utxoBytes, err := formatting.Decode(res.Encoding, utxo)
+		if err != nil {
+			return nil, err
+		}
+		utxos[i] = utxoBytes
+	}
+	return utxos, nil
+}
+
+func (c *client) GetBalanceAtHeight(
+	ctx context.Context,
+	addr ids.ShortID,
+	assetID string,
+	includePartial bool,
+	height uint64,
+	options ...rpc.Option,
+) (*GetBalanceReply, error) {
+	res := &GetBalanceReply{}
+	err := c.requester.SendRequest(ctx, "getBalanceAtHeight", &GetBalanceAtHeightArgs{
+		GetBalanceArgs: GetBalanceArgs{
+			Address:        addr.String(),
+			AssetID:        assetID,
+			IncludePartial: includePartial,
+		},
+		Height: cjson.Uint64(height),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetAllBalances(
 	ctx context.Context,
 	addr ids.ShortID,
@@ -507,6 +909,29 @@ func (c *client) ListAddresses(ctx context.Context, user api.UserPass, options .
 	return address.ParseToIDs(res.Addresses)
 }
 
+func (c *client) ListAddressesWithToken(ctx context.Context, token string, options ...rpc.Option) ([]ids.ShortID, error) {
+	res := &api.JSONAddresses{}
+	err := c.requester.SendRequest(ctx, "listAddresses", &ReadUserArgs{
+		Token: token,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+	return address.ParseToIDs(res.Addresses)
+}
+
+func (c *client) GetUserBalances(ctx context.Context, user api.UserPass, token string, includePartial bool, options ...rpc.Option) ([]Balance, error) {
+	res := &GetAllBalancesReply{}
+	err := c.requester.SendRequest(ctx, "getUserBalances", &GetUserBalancesArgs{
+		ReadUserArgs: ReadUserArgs{
+			UserPass: user,
+			Token:    token,
+		},
+		IncludePartial: includePartial,
+	}, res, options...)
+	return res.Balances, err
+}
+
 func (c *client) ExportKey(ctx context.Context, user api.UserPass, addr ids.ShortID, options ...rpc.Option) (*crypto.PrivateKeySECP256K1R, error) {
 	res := &ExportKeyReply{}
 	err := c.requester.SendRequest(ctx, "exportKey", &ExportKeyArgs{
@@ -586,6 +1011,40 @@ func (c *client) SendMultiple(
 	return res.TxID, err
 }
 
+func (c *client) SendMultisig(
+	ctx context.Context,
+	user api.UserPass,
+	additionalSigners []api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	clientOutputs []ClientSendMultisigOutput,
+	memo string,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	outputs := make([]SendMultisigOutput, len(clientOutputs))
+	for i, clientOutput := range clientOutputs {
+		outputs[i] = SendMultisigOutput{
+			Amount:    cjson.Uint64(clientOutput.Amount),
+			AssetID:   clientOutput.AssetID,
+			To:        ids.ShortIDsToStrings(clientOutput.To),
+			Threshold: cjson.Uint32(clientOutput.Threshold),
+			Locktime:  cjson.Uint64(clientOutput.Locktime),
+		}
+	}
+	err := c.requester.SendRequest(ctx, "sendMultisig", &SendMultisigArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		AdditionalSigners: additionalSigners,
+		Outputs:           outputs,
+		Memo:              memo,
+	}, res, options...)
+	return res.TxID, err
+}
+
 func (c *client) Mint(
 	ctx context.Context,
 	user api.UserPass,
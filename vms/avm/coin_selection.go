@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+// CoinSelection is a strategy for choosing which UTXOs fund a transaction.
+type CoinSelection string
+
+const (
+	// CoinSelectionInOrder spends UTXOs in the order they were loaded. This is
+	// the default.
+	CoinSelectionInOrder CoinSelection = ""
+	// CoinSelectionLargestFirst spends the largest UTXOs first, which
+	// minimizes the number of inputs and therefore the size of the tx.
+	CoinSelectionLargestFirst CoinSelection = "largestFirst"
+	// CoinSelectionSmallestFirst spends the smallest UTXOs first, which
+	// reduces the number of UTXOs held by the spender.
+	CoinSelectionSmallestFirst CoinSelection = "smallestFirst"
+	// CoinSelectionBranchAndBound searches for a set of UTXOs that exactly
+	// matches the amount being spent, so that no change output is needed. If
+	// there is no such set, it falls back to CoinSelectionLargestFirst.
+	CoinSelectionBranchAndBound CoinSelection = "branchAndBound"
+
+	// maxBranchAndBoundTries bounds the number of branches explored while
+	// searching for an exact match.
+	maxBranchAndBoundTries = 100_000
+)
+
+var errUnknownCoinSelection = json.NewError(ErrorCodeInvalidArgument, "unknown coin selection strategy")
+
+// Verify returns nil if [c] is a known strategy.
+func (c CoinSelection) Verify() error {
+	switch c {
+	case CoinSelectionInOrder,
+		CoinSelectionLargestFirst,
+		CoinSelectionSmallestFirst,
+		CoinSelectionBranchAndBound:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnknownCoinSelection, c)
+	}
+}
+
+// spendCandidate is a UTXO that can be consumed by [in]
+type spendCandidate struct {
+	utxo    *djtx.UTXO
+	in      djtx.TransferableIn
+	signers []*crypto.PrivateKeySECP256K1R
+}
+
+// selectUTXOs returns the candidates, all of a single asset, that should be
+// spent to cover [target] according to [strategy], along with the amount they
+// consume. If the candidates can't cover [target], all of them are returned.
+func selectUTXOs(
+	strategy CoinSelection,
+	candidates []spendCandidate,
+	target uint64,
+) ([]spendCandidate, uint64, error) {
+	if target == 0 {
+		return nil, 0, nil
+	}
+
+	switch strategy {
+	case CoinSelectionInOrder:
+		return selectGreedily(candidates, target)
+	case CoinSelectionLargestFirst:
+		return selectGreedily(sortCandidates(candidates, false), target)
+	case CoinSelectionSmallestFirst:
+		return selectGreedily(sortCandidates(candidates, true), target)
+	case CoinSelectionBranchAndBound:
+		sorted := sortCandidates(candidates, false)
+		if selected, ok := selectExactly(sorted, target); ok {
+			return selected, target, nil
+		}
+		return selectGreedily(sorted, target)
+	default:
+		return nil, 0, fmt.Errorf("%w: %q", errUnknownCoinSelection, strategy)
+	}
+}
+
+// sortCandidates returns a copy of [candidates] sorted by amount. The relative
+// order of candidates with equal amounts is preserved.
+func sortCandidates(candidates []spendCandidate, ascending bool) []spendCandidate {
+	sorted := make([]spendCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ascending {
+			return sorted[i].in.Amount() < sorted[j].in.Amount()
+		}
+		return sorted[i].in.Amount() > sorted[j].in.Amount()
+	})
+	return sorted
+}
+
+// selectGreedily takes candidates in order until [target] is covered.
+func selectGreedily(candidates []spendCandidate, target uint64) ([]spendCandidate, uint64, error) {
+	spent := uint64(0)
+	for i, candidate := range candidates {
+		if spent >= target {
+			return candidates[:i], spent, nil
+		}
+		newSpent, err := safemath.Add64(spent, candidate.in.Amount())
+		if err != nil {
+			return nil, 0, errSpendOverflow
+		}
+		spent = newSpent
+	}
+	return candidates, spent, nil
+}
+
+// selectExactly searches for a subset of [candidates], which must be sorted
+// largest first, whose amounts sum to exactly [target]. Returns false if no
+// such subset was found within [maxBranchAndBoundTries] branches.
+func selectExactly(candidates []spendCandidate, target uint64) ([]spendCandidate, bool) {
+	// remaining[i] is the sum of the amounts of candidates[i:], saturated at
+	// the max uint64.
+	remaining := make([]uint64, len(candidates)+1)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		sum, err := safemath.Add64(remaining[i+1], candidates[i].in.Amount())
+		if err != nil {
+			sum = math.MaxUint64
+		}
+		remaining[i] = sum
+	}
+
+	var (
+		selected []spendCandidate
+		tries    int
+		search   func(i int, sum uint64) bool
+	)
+	// [sum] never exceeds [target], so it can't overflow.
+	search = func(i int, sum uint64) bool {
+		if sum == target {
+			return true
+		}
+		tries++
+		if i == len(candidates) || tries > maxBranchAndBoundTries || remaining[i] < target-sum {
+			return false
+		}
+		if amount := candidates[i].in.Amount(); amount <= target-sum {
+			selected = append(selected, candidates[i])
+			if search(i+1, sum+amount) {
+				return true
+			}
+			selected = selected[:len(selected)-1]
+		}
+		return search(i+1, sum)
+	}
+	if !search(0, 0) {
+		return nil, false
+	}
+	return selected, true
+}
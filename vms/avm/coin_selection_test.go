@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+func newTestCandidates(amounts ...uint64) []spendCandidate {
+	candidates := make([]spendCandidate, len(amounts))
+	for i, amount := range amounts {
+		candidates[i] = spendCandidate{
+			in: &secp256k1fx.TransferInput{Amt: amount},
+		}
+	}
+	return candidates
+}
+
+func candidateAmounts(candidates []spendCandidate) []uint64 {
+	amounts := make([]uint64, len(candidates))
+	for i, candidate := range candidates {
+		amounts[i] = candidate.in.Amount()
+	}
+	return amounts
+}
+
+func TestSelectUTXOs(t *testing.T) {
+	tests := []struct {
+		name             string
+		strategy         CoinSelection
+		amounts          []uint64
+		target           uint64
+		expectedSelected []uint64
+		expectedSpent    uint64
+	}{
+		{
+			name:             "in order",
+			strategy:         CoinSelectionInOrder,
+			amounts:          []uint64{1, 2, 10, 3},
+			target:           5,
+			expectedSelected: []uint64{1, 2, 10},
+			expectedSpent:    13,
+		},
+		{
+			name:             "largest first",
+			strategy:         CoinSelectionLargestFirst,
+			amounts:          []uint64{1, 2, 10, 3},
+			target:           5,
+			expectedSelected: []uint64{10},
+			expectedSpent:    10,
+		},
+		{
+			name:             "smallest first",
+			strategy:         CoinSelectionSmallestFirst,
+			amounts:          []uint64{1, 2, 10, 3},
+			target:           5,
+			expectedSelected: []uint64{1, 2, 3},
+			expectedSpent:    6,
+		},
+		{
+			name:             "branch and bound exact match",
+			strategy:         CoinSelectionBranchAndBound,
+			amounts:          []uint64{1, 2, 10, 3},
+			target:           5,
+			expectedSelected: []uint64{3, 2},
+			expectedSpent:    5,
+		},
+		{
+			name:             "branch and bound falls back to largest first",
+			strategy:         CoinSelectionBranchAndBound,
+			amounts:          []uint64{4, 4, 10},
+			target:           5,
+			expectedSelected: []uint64{10},
+			expectedSpent:    10,
+		},
+		{
+			name:             "insufficient funds",
+			strategy:         CoinSelectionLargestFirst,
+			amounts:          []uint64{1, 2},
+			target:           5,
+			expectedSelected: []uint64{2, 1},
+			expectedSpent:    3,
+		},
+		{
+			name:             "nothing to spend",
+			strategy:         CoinSelectionSmallestFirst,
+			amounts:          []uint64{1, 2},
+			target:           0,
+			expectedSelected: []uint64{},
+			expectedSpent:    0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			selected, spent, err := selectUTXOs(test.strategy, newTestCandidates(test.amounts...), test.target)
+			assert.NoError(err)
+			assert.Equal(test.expectedSelected, candidateAmounts(selected))
+			assert.Equal(test.expectedSpent, spent)
+		})
+	}
+}
+
+func TestSelectUTXOsErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, err := selectUTXOs("random", newTestCandidates(1), 1)
+	assert.ErrorIs(err, errUnknownCoinSelection)
+
+	_, _, err = selectUTXOs(CoinSelectionInOrder, newTestCandidates(1, math.MaxUint64), math.MaxUint64)
+	assert.ErrorIs(err, errSpendOverflow)
+}
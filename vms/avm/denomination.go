@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lasthyphen/beacongo/ids"
+)
+
+// formatDenominatedAmount returns [amount], in an asset's smallest unit, in
+// the asset's whole units given its [denomination]. For example, 1250000000
+// nDJTX, with DJTX's denomination of 9, is "1.25".
+func formatDenominatedAmount(amount uint64, denomination byte) string {
+	amountStr := strconv.FormatUint(amount, 10)
+	if denomination == 0 {
+		return amountStr
+	}
+
+	digits := int(denomination)
+	if len(amountStr) <= digits {
+		// Pad with zeros so that there is a whole part
+		amountStr = strings.Repeat("0", digits-len(amountStr)+1) + amountStr
+	}
+	whole := amountStr[:len(amountStr)-digits]
+	fraction := strings.TrimRight(amountStr[len(amountStr)-digits:], "0")
+	if fraction == "" {
+		return whole
+	}
+	return whole + "." + fraction
+}
+
+// formatAmount returns [amount] of [assetID] in the asset's whole units.
+func (vm *VM) formatAmount(assetID ids.ID, amount uint64) (string, error) {
+	metadata, err := vm.getAssetMetadata(assetID)
+	if err != nil {
+		return "", err
+	}
+	return formatDenominatedAmount(amount, metadata.Denomination), nil
+}
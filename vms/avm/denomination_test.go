@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDenominatedAmount(t *testing.T) {
+	tests := []struct {
+		amount       uint64
+		denomination byte
+		expected     string
+	}{
+		{amount: 0, denomination: 0, expected: "0"},
+		{amount: 1337, denomination: 0, expected: "1337"},
+		{amount: 0, denomination: 9, expected: "0"},
+		{amount: 1250000000, denomination: 9, expected: "1.25"},
+		{amount: 1000000000, denomination: 9, expected: "1"},
+		{amount: 1, denomination: 9, expected: "0.000000001"},
+		{amount: 123456789, denomination: 1, expected: "12345678.9"},
+		{amount: 18446744073709551615, denomination: 32, expected: "0.00000000000018446744073709551615"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, formatDenominatedAmount(test.amount, test.denomination))
+	}
+}
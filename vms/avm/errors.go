@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/json"
+)
+
+// Codes of the JSON-RPC error objects returned by the AVM APIs. Errors without
+// a specific code are reported with the generic server error code -32000.
+const (
+	// The arguments of the call are invalid
+	ErrorCodeInvalidArgument = -32602
+
+	// The spending addresses don't hold enough of an asset. The error data is
+	// an InsufficientFundsData if the shortfall is known.
+	ErrorCodeInsufficientFunds = -32010
+	// The asset doesn't exist
+	ErrorCodeUnknownAsset = -32011
+	// The chain hasn't finished bootstrapping
+	ErrorCodeBootstrapping = -32012
+	// The addresses aren't allowed to perform the operation
+	ErrorCodeUnauthorized = -32013
+	// Transfers of the asset are frozen
+	ErrorCodeFrozenAsset = -32014
+	// The transaction is unknown, or isn't in the required state
+	ErrorCodeInvalidTxState = -32015
+	// The fee of the transaction is too low
+	ErrorCodeInsufficientFee = -32016
+	// The request exceeded the rate limit of its method
+	ErrorCodeRateLimited = -32017
+)
+
+var _ json.CodedError = &InsufficientFundsError{}
+
+// InsufficientFundsError is returned when the spending addresses hold less of
+// an asset than needs to be spent.
+type InsufficientFundsError struct {
+	AssetID   ids.ID
+	Needed    uint64
+	Available uint64
+}
+
+// InsufficientFundsData is the data of the JSON-RPC error object of an
+// InsufficientFundsError
+type InsufficientFundsData struct {
+	AssetID   ids.ID      `json:"assetID"`
+	Needed    json.Uint64 `json:"needed"`
+	Available json.Uint64 `json:"available"`
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("want to spend %d of asset %s but only have %d",
+		e.Needed,
+		e.AssetID,
+		e.Available,
+	)
+}
+
+func (e *InsufficientFundsError) ErrorCode() int { return ErrorCodeInsufficientFunds }
+
+func (e *InsufficientFundsError) ErrorData() interface{} {
+	return &InsufficientFundsData{
+		AssetID:   e.AssetID,
+		Needed:    json.Uint64(e.Needed),
+		Available: json.Uint64(e.Available),
+	}
+}
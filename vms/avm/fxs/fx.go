@@ -8,6 +8,7 @@ import (
 	"github.com/lasthyphen/beacongo/snow"
 	"github.com/lasthyphen/beacongo/vms/components/djtx"
 	"github.com/lasthyphen/beacongo/vms/components/verify"
+	"github.com/lasthyphen/beacongo/vms/managedassetfx"
 	"github.com/lasthyphen/beacongo/vms/nftfx"
 	"github.com/lasthyphen/beacongo/vms/propertyfx"
 	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
@@ -17,6 +18,9 @@ var (
 	_ Fx = &secp256k1fx.Fx{}
 	_ Fx = &nftfx.Fx{}
 	_ Fx = &propertyfx.Fx{}
+	_ Fx = &managedassetfx.Fx{}
+
+	_ FreezableOutput = &managedassetfx.ManagerOutput{}
 )
 
 type ParsedFx struct {
@@ -49,6 +53,14 @@ type Fx interface {
 	VerifyOperation(tx, op, cred interface{}, utxos []interface{}) error
 }
 
+// FreezableOutput is implemented by outputs that control whether transfers of
+// their asset are frozen. When such an output is accepted, it overrides the
+// frozen status of its asset. Outputs of frozen assets can't be spent by
+// transfers, but can still be consumed by operations.
+type FreezableOutput interface {
+	IsFrozen() bool
+}
+
 type FxOperation interface {
 	verify.Verifiable
 	snow.ContextInitializable
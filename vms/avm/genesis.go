@@ -7,12 +7,25 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/lasthyphen/beacongo/codec"
 	"github.com/lasthyphen/beacongo/utils"
 	"github.com/lasthyphen/beacongo/vms/avm/txs"
 )
 
 type Genesis struct {
 	Txs []*GenesisAsset `serialize:"true"`
+
+	// FeeAsset, if set, is the alias of the genesis asset that fees are paid
+	// in. A genesis that sets it is serialized with the directive appended, so
+	// genesis bytes that don't set it are unchanged.
+	FeeAsset string `serialize:"false"`
+}
+
+// genesisWithFeeAsset is the serialized format of a Genesis that sets the fee
+// asset.
+type genesisWithFeeAsset struct {
+	Txs      []*GenesisAsset `serialize:"true"`
+	FeeAsset string          `serialize:"true"`
 }
 
 func (g *Genesis) Less(i, j int) bool { return strings.Compare(g.Txs[i].Alias, g.Txs[j].Alias) == -1 }
@@ -25,6 +38,36 @@ func (g *Genesis) Sort() { sort.Sort(g) }
 
 func (g *Genesis) IsSortedAndUnique() bool { return utils.IsSortedAndUnique(g) }
 
+// MarshalGenesis serializes [g] with [c], including the fee asset directive if
+// it's set.
+func MarshalGenesis(c codec.Manager, g *Genesis) ([]byte, error) {
+	if g.FeeAsset == "" {
+		return c.Marshal(txs.CodecVersion, g)
+	}
+	return c.Marshal(txs.CodecVersion, &genesisWithFeeAsset{
+		Txs:      g.Txs,
+		FeeAsset: g.FeeAsset,
+	})
+}
+
+// ParseGenesis deserializes a genesis, with or without the fee asset
+// directive, with [c].
+func ParseGenesis(c codec.Manager, genesisBytes []byte) (*Genesis, error) {
+	withFeeAsset := genesisWithFeeAsset{}
+	if _, err := c.Unmarshal(genesisBytes, &withFeeAsset); err == nil {
+		return &Genesis{
+			Txs:      withFeeAsset.Txs,
+			FeeAsset: withFeeAsset.FeeAsset,
+		}, nil
+	}
+
+	g := &Genesis{}
+	if _, err := c.Unmarshal(genesisBytes, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
 type GenesisAsset struct {
 	Alias             string `serialize:"true"`
 	txs.CreateAssetTx `serialize:"true"`
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/components/verify"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+// genesisExport accumulates the UTXOs of each asset into the initial states of
+// the asset's genesis transaction.
+type genesisExport struct {
+	vm *VM
+	// assetID -> fxIndex -> initial state of the asset
+	assets map[ids.ID]map[int]*exportedInitialState
+}
+
+type exportedInitialState struct {
+	outs []verify.State
+	// Transfer outputs with the same owners are merged, so that the exported
+	// states are unique.
+	// owners bytes -> output
+	transfers map[string]*secp256k1fx.TransferOutput
+	// bytes of every other exported output
+	others map[string]struct{}
+}
+
+// exportGenesis returns a genesis, for the network [networkID], whose assets
+// hold the UTXOs that reference any of [addrs], or every UTXO if [addrs] is
+// empty. Each asset is re-created with the name, symbol and denomination it
+// has on this chain, so its ID in the new network will differ. If the fee asset
+// is exported, the genesis names it as the fee asset. Returns the
+// genesis and the ID of each exported asset on this chain, keyed by its alias
+// in the genesis.
+//
+// Assumes the context lock is held.
+func (vm *VM) exportGenesis(networkID uint32, addrs ids.ShortSet) (*Genesis, map[string]ids.ID, error) {
+	e := &genesisExport{
+		vm:     vm,
+		assets: make(map[ids.ID]map[int]*exportedInitialState),
+	}
+	if addrs.Len() == 0 {
+		if err := vm.state.ForEachUTXO(e.add); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		utxos, err := djtx.GetAllUTXOs(vm.state, addrs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
+		}
+		for _, utxo := range utxos {
+			if err := e.add(utxo); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	genesisCodec := vm.parser.GenesisCodec()
+	g := &Genesis{}
+	assetIDs := make(map[string]ids.ID, len(e.assets))
+	for assetID, states := range e.assets {
+		metadata, err := vm.getAssetMetadata(assetID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't get metadata of asset %s: %w", assetID, err)
+		}
+		alias := vm.PrimaryAliasOrDefault(assetID)
+		asset := &GenesisAsset{
+			Alias: alias,
+			CreateAssetTx: txs.CreateAssetTx{
+				BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+					NetworkID:    networkID,
+					BlockchainID: ids.Empty,
+				}},
+				Name:         metadata.Name,
+				Symbol:       metadata.Symbol,
+				Denomination: metadata.Denomination,
+			},
+		}
+		for fxIndex, state := range states {
+			initialState := &txs.InitialState{
+				FxIndex: uint32(fxIndex),
+				Outs:    state.outs,
+			}
+			for _, out := range state.transfers {
+				initialState.Outs = append(initialState.Outs, out)
+			}
+			initialState.Sort(genesisCodec)
+			asset.States = append(asset.States, initialState)
+		}
+		asset.Sort()
+		g.Txs = append(g.Txs, asset)
+		assetIDs[alias] = assetID
+	}
+	g.Sort()
+	if _, ok := e.assets[vm.feeAssetID]; ok {
+		// Fees keep being paid in the same asset
+		g.FeeAsset = vm.PrimaryAliasOrDefault(vm.feeAssetID)
+	}
+	return g, assetIDs, nil
+}
+
+// add exports [utxo] into the initial state of its asset.
+func (e *genesisExport) add(utxo *djtx.UTXO) error {
+	fxIndex, err := e.vm.getFx(utxo.Out)
+	if err != nil {
+		return fmt.Errorf("couldn't export UTXO %s: %w", utxo.InputID(), err)
+	}
+
+	assetID := utxo.AssetID()
+	states, ok := e.assets[assetID]
+	if !ok {
+		states = make(map[int]*exportedInitialState)
+		e.assets[assetID] = states
+	}
+	state, ok := states[fxIndex]
+	if !ok {
+		state = &exportedInitialState{
+			transfers: make(map[string]*secp256k1fx.TransferOutput),
+			others:    make(map[string]struct{}),
+		}
+		states[fxIndex] = state
+	}
+
+	genesisCodec := e.vm.parser.GenesisCodec()
+	if out, ok := utxo.Out.(*secp256k1fx.TransferOutput); ok {
+		ownersBytes, err := genesisCodec.Marshal(txs.CodecVersion, &out.OutputOwners)
+		if err != nil {
+			return err
+		}
+		key := string(ownersBytes)
+		merged, ok := state.transfers[key]
+		if !ok {
+			state.transfers[key] = &secp256k1fx.TransferOutput{
+				Amt:          out.Amt,
+				OutputOwners: out.OutputOwners,
+			}
+			return nil
+		}
+		merged.Amt, err = safemath.Add64(merged.Amt, out.Amt)
+		return err
+	}
+
+	outBytes, err := genesisCodec.Marshal(txs.CodecVersion, &utxo.Out)
+	if err != nil {
+		return err
+	}
+	key := string(outBytes)
+	if _, ok := state.others[key]; ok {
+		// An identical output was already exported
+		return nil
+	}
+	state.others[key] = struct{}{}
+	state.outs = append(state.outs, utxo.Out)
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+func TestExportGenesis(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	// The amount of each asset held by the UTXOs of the chain
+	expected := map[ids.ID]uint64{}
+	assert.NoError(vm.state.ForEachUTXO(func(utxo *djtx.UTXO) error {
+		if out, ok := utxo.Out.(*secp256k1fx.TransferOutput); ok {
+			expected[utxo.AssetID()] += out.Amt
+		}
+		return nil
+	}))
+
+	g, assetIDs, err := vm.exportGenesis(10, ids.ShortSet{})
+	assert.NoError(err)
+	assert.True(g.IsSortedAndUnique())
+	assert.Len(assetIDs, len(g.Txs))
+
+	genesisCodec := vm.parser.GenesisCodec()
+	genesisBytes, err := genesisCodec.Marshal(txs.CodecVersion, g)
+	assert.NoError(err)
+	parsed := &Genesis{}
+	_, err = genesisCodec.Unmarshal(genesisBytes, parsed)
+	assert.NoError(err)
+
+	exported := map[ids.ID]uint64{}
+	for _, asset := range parsed.Txs {
+		assetID, ok := assetIDs[asset.Alias]
+		assert.True(ok)
+		assert.Equal(uint32(10), asset.NetworkID)
+		assert.True(txs.IsSortedAndUniqueInitialStates(asset.States))
+		for _, state := range asset.States {
+			assert.NoError(state.Verify(genesisCodec, len(vm.fxs)))
+			for _, out := range state.Outs {
+				if out, ok := out.(*secp256k1fx.TransferOutput); ok {
+					exported[assetID] += out.Amt
+				}
+			}
+		}
+	}
+	assert.Equal(expected, exported)
+
+	// Only the UTXOs of the given addresses are exported
+	addrSet := ids.ShortSet{}
+	addrSet.Add(addrs[0])
+	g, _, err = vm.exportGenesis(10, addrSet)
+	assert.NoError(err)
+	for _, asset := range g.Txs {
+		for _, state := range asset.States {
+			for _, out := range state.Outs {
+				addressable, ok := out.(djtx.Addressable)
+				assert.True(ok)
+				assert.Contains(addressable.Addresses(), addrs[0].Bytes())
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/lasthyphen/beacongo/utils/constants"
+
+	avmpb "github.com/lasthyphen/beacongo/proto/pb/avm"
+)
+
+// NewGRPCClient returns a client of the gRPC API of the chain [chain], e.g.
+// "X", served by the node that [conn] is connected to. [conn] must use TLS, as
+// the API is served over HTTP/2.
+func NewGRPCClient(conn *grpc.ClientConn, chain string) avmpb.AVMClient {
+	return avmpb.NewAVMClient(&grpcChainConn{
+		ClientConn: conn,
+		prefix:     fmt.Sprintf("/ext/%s/%s%s", constants.ChainAliasPrefix, chain, grpcEndpoint),
+	})
+}
+
+// grpcChainConn sends calls to the gRPC endpoint of a chain rather than to the
+// root of the node's API server.
+type grpcChainConn struct {
+	*grpc.ClientConn
+	prefix string
+}
+
+func (c *grpcChainConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	return c.ClientConn.Invoke(ctx, c.prefix+method, args, reply, opts...)
+}
+
+func (c *grpcChainConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.ClientConn.NewStream(ctx, desc, c.prefix+method, opts...)
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/formatting"
+	"github.com/lasthyphen/beacongo/utils/json"
+
+	avmpb "github.com/lasthyphen/beacongo/proto/pb/avm"
+)
+
+// grpcEndpoint is the endpoint of the chain that serves the gRPC API. As gRPC
+// requires HTTP/2, the API is only served when the HTTP API uses TLS.
+const grpcEndpoint = "/grpc"
+
+var (
+	_ avmpb.AVMServer = &grpcService{}
+	_ http.Handler    = &grpcHandler{}
+)
+
+// grpcService serves the core AVM API over gRPC. It's implemented by the
+// JSON-RPC Service, so the two APIs behave the same.
+type grpcService struct {
+	avmpb.UnsafeAVMServer
+	vm      *VM
+	service *Service
+}
+
+func newGRPCService(vm *VM) *grpcService {
+	return &grpcService{
+		vm:      vm,
+		service: &Service{vm: vm},
+	}
+}
+
+func (s *grpcService) IssueTx(_ context.Context, req *avmpb.IssueTxRequest) (*avmpb.IssueTxResponse, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, req.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply := api.JSONTxID{}
+	err = s.service.IssueTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+	return &avmpb.IssueTxResponse{TxId: reply.TxID[:]}, nil
+}
+
+func (s *grpcService) GetTx(_ context.Context, req *avmpb.GetTxRequest) (*avmpb.GetTxResponse, error) {
+	txID, err := ids.ToID(req.TxId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply := api.GetTxReply{}
+	err = s.service.GetTx(nil, &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := formatting.Decode(formatting.Hex, reply.Tx.(string))
+	if err != nil {
+		return nil, err
+	}
+	return &avmpb.GetTxResponse{Tx: txBytes}, nil
+}
+
+func (s *grpcService) GetUTXOs(req *avmpb.GetUTXOsRequest, stream avmpb.AVM_GetUTXOsServer) error {
+	pageSize := uint64(req.PageSize)
+	if pageSize == 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	args := &api.GetUTXOsArgs{
+		Addresses:   req.Addresses,
+		SourceChain: req.SourceChain,
+		Limit:       json.Uint32(pageSize),
+		Encoding:    formatting.Hex,
+	}
+	for {
+		page, reply, err := s.getUTXOsPage(args)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		if err := stream.Send(&avmpb.GetUTXOsResponse{Utxos: page}); err != nil {
+			return err
+		}
+		if uint64(reply.NumFetched) < pageSize {
+			return nil
+		}
+		args.StartIndex = reply.EndIndex
+	}
+}
+
+// getUTXOsPage returns the UTXOs of the page described by [args]. The lock is
+// only held while the page is read, so that slow clients don't block the
+// chain.
+func (s *grpcService) getUTXOsPage(args *api.GetUTXOsArgs) ([][]byte, *api.GetUTXOsReply, error) {
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply := &api.GetUTXOsReply{}
+	if err := s.service.GetUTXOs(nil, args, reply); err != nil {
+		return nil, nil, err
+	}
+	page := make([][]byte, len(reply.UTXOs))
+	for i, utxoStr := range reply.UTXOs {
+		utxoBytes, err := formatting.Decode(formatting.Hex, utxoStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		page[i] = utxoBytes
+	}
+	return page, reply, nil
+}
+
+func (s *grpcService) GetBalance(_ context.Context, req *avmpb.GetBalanceRequest) (*avmpb.GetBalanceResponse, error) {
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply := GetBalanceReply{}
+	err := s.service.GetBalance(nil, &GetBalanceArgs{
+		Address:        req.Address,
+		AssetID:        req.AssetId,
+		IncludePartial: req.IncludePartial,
+	}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	utxoIDs := make([]*avmpb.UTXOID, len(reply.UTXOIDs))
+	for i, utxoID := range reply.UTXOIDs {
+		utxoIDs[i] = &avmpb.UTXOID{
+			TxId:        utxoID.TxID[:],
+			OutputIndex: utxoID.OutputIndex,
+		}
+	}
+	return &avmpb.GetBalanceResponse{
+		Balance: uint64(reply.Balance),
+		UtxoIds: utxoIDs,
+	}, nil
+}
+
+// limitUnary and limitStream refuse gRPC calls that exceed the API rate
+// limits. The limits of JSON-RPC methods apply to the gRPC methods of the
+// same name, e.g. the limit of "avm.issueTx" applies to "/avm.AVM/IssueTx".
+func (vm *VM) limitUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := vm.limitGRPC(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (vm *VM) limitStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := vm.limitGRPC(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+func (vm *VM) limitGRPC(ctx context.Context, fullMethod string) error {
+	method := "avm." + fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+	if !vm.apiLimiter.AllowRemoteAddr(method, remoteAddr) {
+		return status.Error(codes.ResourceExhausted, errRateLimited.Error())
+	}
+	return nil
+}
+
+// grpcHandler serves gRPC requests sent to any path under the gRPC endpoint
+// of the chain, e.g. "/ext/bc/X/grpc/avm.AVM/GetTx".
+type grpcHandler struct {
+	server *grpc.Server
+}
+
+func (h *grpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// gRPC routes requests by the service and method at the end of the path
+	if i := strings.LastIndex(r.URL.Path, grpcEndpoint+"/"); i >= 0 {
+		r.URL.Path = r.URL.Path[i+len(grpcEndpoint):]
+	}
+	h.server.ServeHTTP(w, r)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+
+	avmpb "github.com/lasthyphen/beacongo/proto/pb/avm"
+)
+
+type utxosStream struct {
+	grpc.ServerStream
+	responses []*avmpb.GetUTXOsResponse
+}
+
+func (s *utxosStream) Send(response *avmpb.GetUTXOsResponse) error {
+	s.responses = append(s.responses, response)
+	return nil
+}
+
+func TestGRPCService(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, _, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	addrStr, err := vm.FormatLocalAddress(addr)
+	assert.NoError(err)
+	for i := 0; i < 3; i++ {
+		utxo := &djtx.UTXO{
+			UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 10,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+		assert.NoError(vm.state.PutUTXO(utxo.InputID(), utxo))
+	}
+
+	// The gRPC methods grab the lock themselves
+	vm.ctx.Lock.Unlock()
+	defer vm.ctx.Lock.Lock()
+
+	s := newGRPCService(vm)
+	ctx := context.Background()
+
+	txID := genesisTx.ID()
+	txReply, err := s.GetTx(ctx, &avmpb.GetTxRequest{TxId: txID[:]})
+	assert.NoError(err)
+	assert.Equal(genesisTx.Bytes(), txReply.Tx)
+
+	_, err = s.GetTx(ctx, &avmpb.GetTxRequest{TxId: []byte{1}})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	balanceReply, err := s.GetBalance(ctx, &avmpb.GetBalanceRequest{
+		Address: addrStr,
+		AssetId: assetID.String(),
+	})
+	assert.NoError(err)
+	assert.Equal(uint64(30), balanceReply.Balance)
+	assert.Len(balanceReply.UtxoIds, 3)
+
+	// The UTXOs are streamed in pages of 2
+	stream := &utxosStream{}
+	err = s.GetUTXOs(&avmpb.GetUTXOsRequest{
+		Addresses: []string{addrStr},
+		PageSize:  2,
+	}, stream)
+	assert.NoError(err)
+	assert.Len(stream.responses, 2)
+	assert.Len(stream.responses[0].Utxos, 2)
+	assert.Len(stream.responses[1].Utxos, 1)
+}
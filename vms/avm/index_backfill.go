@@ -0,0 +1,298 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+// Number of transactions scanned while holding the context lock
+const indexBackfillBatchSize = 1024
+
+var (
+	indexBackfillPrefix   = []byte("indexBackfill")
+	backfillCheckpointKey = []byte("checkpoint")
+	backfillDoneKey       = []byte("done")
+
+	errIndexingDisabled  = errors.New("address transaction indexing is disabled")
+	errBackfillRunning   = errors.New("index backfill is already running")
+	errBackfillUnstarted = errors.New("index backfill can't start before the chain is bootstrapped")
+)
+
+// indexBackfill indexes the transactions that were accepted before the address
+// transaction index was enabled. Progress is checkpointed after every batch, so
+// that an interrupted backfill resumes where it stopped.
+//
+// Transactions are indexed in order of their IDs, rather than the order they
+// were accepted in.
+//
+// All fields are protected by the context lock.
+type indexBackfill struct {
+	vm *VM
+	// Stores the checkpoint and whether the backfill finished
+	db database.Database
+
+	running bool
+	// Closed when the VM shuts down
+	closed chan struct{}
+
+	// Progress of the current, or last, run
+	numScanned, numIndexed uint64
+	lastErr                error
+
+	txsScanned prometheus.Counter
+	txsIndexed prometheus.Counter
+}
+
+func (b *indexBackfill) initialize(vm *VM, db database.Database, registerer prometheus.Registerer) error {
+	b.vm = vm
+	b.db = prefixdb.New(indexBackfillPrefix, db)
+	b.closed = make(chan struct{})
+	b.txsScanned = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "index_backfill_txs_scanned",
+		Help: "Number of stored transactions checked by the index backfill",
+	})
+	b.txsIndexed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "index_backfill_txs_indexed",
+		Help: "Number of historical transactions indexed by the index backfill",
+	})
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(b.txsScanned),
+		registerer.Register(b.txsIndexed),
+	)
+	return errs.Err
+}
+
+// start the backfill in the background. If a previous backfill finished, all
+// stored transactions are re-scanned.
+//
+// Assumes the context lock is held.
+func (b *indexBackfill) start() error {
+	switch {
+	case !b.vm.config.IndexTransactions:
+		return errIndexingDisabled
+	case !b.vm.bootstrapped:
+		return errBackfillUnstarted
+	case b.running:
+		return errBackfillRunning
+	}
+
+	done, err := database.GetBool(b.db, backfillDoneKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	if done {
+		if err := b.db.Delete(backfillCheckpointKey); err != nil {
+			return err
+		}
+		if err := b.db.Delete(backfillDoneKey); err != nil {
+			return err
+		}
+		if err := b.vm.db.Commit(); err != nil {
+			return err
+		}
+	}
+
+	b.running = true
+	b.numScanned = 0
+	b.numIndexed = 0
+	b.lastErr = nil
+	go b.run()
+	return nil
+}
+
+func (b *indexBackfill) run() {
+	for {
+		b.vm.ctx.Lock.Lock()
+		done, err := b.step()
+		if err != nil {
+			b.vm.ctx.Log.Error("index backfill failed: %s", err)
+			b.lastErr = err
+		}
+		if done || err != nil {
+			b.running = false
+			b.vm.ctx.Lock.Unlock()
+			return
+		}
+		b.vm.ctx.Lock.Unlock()
+	}
+}
+
+// step indexes the next batch of transactions and returns true if there are
+// no more transactions to index.
+//
+// Assumes the context lock is held.
+func (b *indexBackfill) step() (bool, error) {
+	select {
+	case <-b.closed:
+		return true, nil
+	default:
+	}
+	defer b.vm.db.Abort()
+
+	checkpoint, err := database.GetID(b.db, backfillCheckpointKey)
+	hasCheckpoint := err == nil
+	if err != nil && err != database.ErrNotFound {
+		return false, err
+	}
+
+	txIDs, err := b.vm.state.TxIDs(checkpoint, indexBackfillBatchSize)
+	if err != nil {
+		return false, err
+	}
+	done := len(txIDs) < indexBackfillBatchSize
+	for _, txID := range txIDs {
+		if hasCheckpoint && txID == checkpoint {
+			// The checkpoint was indexed in the previous batch
+			continue
+		}
+		if err := b.backfillTx(txID); err != nil {
+			return false, fmt.Errorf("couldn't index tx %s: %w", txID, err)
+		}
+		checkpoint = txID
+	}
+
+	if err := database.PutID(b.db, backfillCheckpointKey, checkpoint); err != nil {
+		return false, err
+	}
+	if done {
+		if err := b.vm.addressTxsIndexer.MarkComplete(); err != nil {
+			return false, err
+		}
+		if err := database.PutBool(b.db, backfillDoneKey, true); err != nil {
+			return false, err
+		}
+		b.vm.ctx.Log.Info("index backfill finished after indexing %d of %d transactions", b.numIndexed, b.numScanned)
+	}
+	return done, b.vm.db.Commit()
+}
+
+// backfillTx indexes [txID] if it was accepted and hasn't been indexed yet.
+func (b *indexBackfill) backfillTx(txID ids.ID) error {
+	b.numScanned++
+	b.txsScanned.Inc()
+
+	status, err := b.vm.state.GetStatus(txID)
+	if err == database.ErrNotFound || (err == nil && status != choices.Accepted) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	indexed, err := b.vm.addressTxsIndexer.IsIndexed(txID)
+	if err != nil || indexed {
+		return err
+	}
+
+	tx, err := b.vm.state.GetTx(txID)
+	if err != nil {
+		return err
+	}
+
+	// The consumed UTXOs were removed from state when the tx was accepted, so
+	// they are recreated from the txs that produced them.
+	inputUTXOIDs := tx.InputUTXOs()
+	inputUTXOs := make([]*djtx.UTXO, 0, len(inputUTXOIDs))
+	for _, utxoID := range inputUTXOIDs {
+		if utxoID.Symbolic() {
+			continue
+		}
+		utxo, err := b.producedUTXO(utxoID)
+		if err == database.ErrNotFound {
+			// The UTXO was imported from another chain
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		inputUTXOs = append(inputUTXOs, utxo)
+	}
+
+	// Txs accepted before acceptance times were recorded are indexed as if
+	// they were accepted at time 0.
+	acceptedTime := uint64(0)
+	acceptance, err := b.vm.state.GetAcceptance(txID)
+	switch {
+	case err == nil && acceptance.Timestamp > 0:
+		acceptedTime = uint64(acceptance.Timestamp)
+	case err != nil && err != database.ErrNotFound:
+		return err
+	}
+
+	if err := b.vm.addressTxsIndexer.Accept(txID, inputUTXOs, tx.UTXOs(), acceptedTime); err != nil {
+		return err
+	}
+	b.numIndexed++
+	b.txsIndexed.Inc()
+	return nil
+}
+
+// producedUTXO returns the UTXO [utxoID] as it was produced by its tx.
+func (b *indexBackfill) producedUTXO(utxoID *djtx.UTXOID) (*djtx.UTXO, error) {
+	producer, err := b.vm.state.GetTx(utxoID.TxID)
+	if err != nil {
+		return nil, err
+	}
+	for _, utxo := range producer.UTXOs() {
+		if utxo.OutputIndex == utxoID.OutputIndex {
+			return utxo, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+// IndexBackfillStatus describes the progress of the index backfill
+type IndexBackfillStatus struct {
+	// True if the backfill is currently running
+	Running bool `json:"running"`
+	// True if every stored transaction has been scanned
+	Done bool `json:"done"`
+	// Last transaction that was scanned
+	Checkpoint ids.ID `json:"checkpoint"`
+	// Number of transactions scanned and indexed by the current, or last, run
+	NumScanned uint64 `json:"numScanned"`
+	NumIndexed uint64 `json:"numIndexed"`
+	// Error that stopped the last run, if any
+	Error string `json:"error,omitempty"`
+}
+
+// status returns the progress of the backfill.
+//
+// Assumes the context lock is held.
+func (b *indexBackfill) status() (IndexBackfillStatus, error) {
+	status := IndexBackfillStatus{
+		Running:    b.running,
+		NumScanned: b.numScanned,
+		NumIndexed: b.numIndexed,
+	}
+	if b.lastErr != nil {
+		status.Error = b.lastErr.Error()
+	}
+
+	var err error
+	status.Done, err = database.GetBool(b.db, backfillDoneKey)
+	if err != nil && err != database.ErrNotFound {
+		return status, err
+	}
+	status.Checkpoint, err = database.GetID(b.db, backfillCheckpointKey)
+	if err != nil && err != database.ErrNotFound {
+		return status, err
+	}
+	return status, nil
+}
+
+func (b *indexBackfill) shutdown() {
+	close(b.closed)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -115,7 +116,7 @@ func TestIndexTransaction_Ordered(t *testing.T) {
 		}
 
 		// index the transaction
-		err := vm.addressTxsIndexer.Accept(uniqueParsedTX.ID(), inputUTXOs, uniqueParsedTX.UTXOs())
+		err := vm.addressTxsIndexer.Accept(uniqueParsedTX.ID(), inputUTXOs, uniqueParsedTX.UTXOs(), 0)
 		assert.NoError(t, err)
 	}
 
@@ -208,7 +209,7 @@ func TestIndexTransaction_MultipleTransactions(t *testing.T) {
 		}
 
 		// index the transaction
-		err := vm.addressTxsIndexer.Accept(uniqueParsedTX.ID(), inputUTXOs, uniqueParsedTX.UTXOs())
+		err := vm.addressTxsIndexer.Accept(uniqueParsedTX.ID(), inputUTXOs, uniqueParsedTX.UTXOs(), 0)
 		assert.NoError(t, err)
 	}
 
@@ -281,7 +282,7 @@ func TestIndexTransaction_MultipleAddresses(t *testing.T) {
 	}
 
 	// index the transaction
-	err := vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, tx.UTXOs())
+	err := vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, tx.UTXOs(), 0)
 	assert.NoError(t, err)
 	assert.NoError(t, err)
 
@@ -367,7 +368,7 @@ func TestIndexTransaction_UnorderedWrites(t *testing.T) {
 		}
 
 		// index the transaction, NOT calling Accept(ids.ID) method
-		err := vm.addressTxsIndexer.Accept(uniqueParsedTX.ID(), inputUTXOs, uniqueParsedTX.UTXOs())
+		err := vm.addressTxsIndexer.Accept(uniqueParsedTX.ID(), inputUTXOs, uniqueParsedTX.UTXOs(), 0)
 		assert.NoError(t, err)
 	}
 
@@ -413,6 +414,155 @@ func TestIndexer_Read(t *testing.T) {
 	}
 }
 
+func TestIndexer_ReadAssetStats(t *testing.T) {
+	ctx := NewContext(t)
+	db := memdb.New()
+	indexer, err := index.NewIndexer(db, ctx.Log, "", prometheus.NewRegistry(), false)
+	assert.NoError(t, err)
+
+	assetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+
+	stats, err := indexer.ReadAssetStats(assetID)
+	assert.NoError(t, err)
+	assert.Equal(t, index.AssetStats{}, stats)
+
+	for i := 0; i < 3; i++ {
+		inputUTXO := buildPlatformUTXO(djtx.UTXOID{TxID: ids.GenerateTestID()}, djtx.Asset{ID: assetID}, addr)
+		outputUTXOs := []*djtx.UTXO{
+			buildPlatformUTXO(djtx.UTXOID{TxID: ids.GenerateTestID()}, djtx.Asset{ID: assetID}, addr),
+			buildPlatformUTXO(djtx.UTXOID{TxID: ids.GenerateTestID()}, djtx.Asset{ID: otherAssetID}, addr),
+		}
+		err := indexer.Accept(ids.GenerateTestID(), []*djtx.UTXO{inputUTXO}, outputUTXOs, 0)
+		assert.NoError(t, err)
+	}
+
+	stats, err = indexer.ReadAssetStats(assetID)
+	assert.NoError(t, err)
+	assert.Equal(t, index.AssetStats{
+		NumTxs:     3,
+		NumOutputs: 3,
+		Volume:     3000,
+	}, stats)
+
+	stats, err = indexer.ReadAssetStats(otherAssetID)
+	assert.NoError(t, err)
+	assert.Equal(t, index.AssetStats{
+		NumTxs:     3,
+		NumOutputs: 3,
+		Volume:     3000,
+	}, stats)
+
+	noIndexer, err := index.NewNoIndexer(memdb.New(), false)
+	assert.NoError(t, err)
+	_, err = noIndexer.ReadAssetStats(assetID)
+	assert.Error(t, err)
+}
+
+func TestIndexer_ReadActivity(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := NewContext(t)
+	indexer, err := index.NewIndexer(memdb.New(), ctx.Log, "", prometheus.NewRegistry(), false)
+	assert.NoError(err)
+
+	assetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	otherAddr := ids.GenerateTestShortID()
+
+	// Txs accepted at times 10, 20 and 30. [addr] sends in the tx at time 20
+	// and receives in the others.
+	txIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	for i, txID := range txIDs {
+		from, to := otherAddr, addr
+		if i == 1 {
+			from, to = addr, otherAddr
+		}
+		inputUTXO := buildPlatformUTXO(djtx.UTXOID{TxID: ids.GenerateTestID()}, djtx.Asset{ID: assetID}, from)
+		outputUTXO := buildPlatformUTXO(djtx.UTXOID{TxID: txID}, djtx.Asset{ID: assetID}, to)
+		assert.NoError(indexer.Accept(txID, []*djtx.UTXO{inputUTXO}, []*djtx.UTXO{outputUTXO}, uint64(10*(i+1))))
+	}
+
+	activities, next, err := indexer.ReadActivity(addr[:], assetID, index.ActivityQuery{
+		StartTime: 15,
+		EndTime:   30,
+		PageSize:  10,
+	})
+	assert.NoError(err)
+	assert.Nil(next)
+	assert.Equal([]index.AddressActivity{
+		{TxID: txIDs[1], Timestamp: 20, Direction: index.Sent},
+		{TxID: txIDs[2], Timestamp: 30, Direction: index.Received},
+	}, activities)
+
+	activities, _, err = indexer.ReadActivity(addr[:], assetID, index.ActivityQuery{
+		Direction: index.Received,
+		PageSize:  10,
+	})
+	assert.NoError(err)
+	assert.Len(activities, 2)
+	assert.Equal(txIDs[0], activities[0].TxID)
+	assert.Equal(txIDs[2], activities[1].TxID)
+
+	// Pages resume from the returned cursor
+	activities, next, err = indexer.ReadActivity(addr[:], assetID, index.ActivityQuery{PageSize: 2})
+	assert.NoError(err)
+	assert.Len(activities, 2)
+	assert.NotNil(next)
+	activities, next, err = indexer.ReadActivity(addr[:], assetID, index.ActivityQuery{
+		Cursor:   next,
+		PageSize: 2,
+	})
+	assert.NoError(err)
+	assert.Nil(next)
+	assert.Equal([]index.AddressActivity{
+		{TxID: txIDs[2], Timestamp: 30, Direction: index.Received},
+	}, activities)
+}
+
+func TestIndexBackfill(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	genesisTx := GetDJTXTxFromGenesisTest(genesisBytes, t)
+
+	indexed, err := vm.addressTxsIndexer.IsIndexed(genesisTx.ID())
+	assert.NoError(t, err)
+	assert.False(t, indexed)
+
+	assert.NoError(t, vm.indexBackfill.start())
+	assert.ErrorIs(t, vm.indexBackfill.start(), errBackfillRunning)
+
+	var status IndexBackfillStatus
+	for {
+		vm.ctx.Lock.Unlock()
+		time.Sleep(time.Millisecond)
+		vm.ctx.Lock.Lock()
+
+		status, err = vm.indexBackfill.status()
+		assert.NoError(t, err)
+		if !status.Running {
+			break
+		}
+	}
+	assert.True(t, status.Done)
+	assert.Empty(t, status.Error)
+	assert.Greater(t, status.NumIndexed, uint64(0))
+
+	indexed, err = vm.addressTxsIndexer.IsIndexed(genesisTx.ID())
+	assert.NoError(t, err)
+	assert.True(t, indexed)
+
+	stats, err := vm.addressTxsIndexer.ReadAssetStats(genesisTx.ID())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, stats.NumTxs)
+}
+
 func TestIndexingNewInitWithIndexingEnabled(t *testing.T) {
 	baseDBManager := manager.NewMemDB(version.DefaultVersion1_0_0)
 	ctx := NewContext(t)
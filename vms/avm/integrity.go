@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+var errCorruptState = errors.New("corrupt state")
+
+// verifyIntegrity checks that the last [depth] accepted txs are consistent
+// with their stored statuses and acceptance records, and with the UTXO set and
+// its address index. Only the heights retained for the historical UTXO window
+// can be checked. Returns an error wrapping errCorruptState if an inconsistency
+// is found.
+func (vm *VM) verifyIntegrity(depth uint64) error {
+	start := time.Now()
+	numAccepted, err := vm.state.NumAccepted()
+	if err != nil {
+		return err
+	}
+	if depth > numAccepted {
+		depth = numAccepted
+	}
+	if window := vm.config.HistoricalUTXOWindow; depth > window {
+		vm.ctx.Log.Warn("only verifying the last %d accepted txs, as older heights aren't retained", window)
+		depth = window
+	}
+
+	numVerified := uint64(0)
+	for ; numVerified < depth; numVerified++ {
+		height := numAccepted - numVerified - 1
+		txID, err := vm.state.GetAcceptedTxID(height)
+		if err == database.ErrNotFound {
+			// The retention window was increased recently, so older heights
+			// aren't recorded yet
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := vm.verifyAcceptedTx(height, txID); err != nil {
+			return fmt.Errorf("%w: tx %s at height %d: %s", errCorruptState, txID, height, err)
+		}
+	}
+	vm.ctx.Log.Info("verified the last %d accepted txs in %s", numVerified, time.Since(start))
+	return nil
+}
+
+// verifyAcceptedTx checks that [txID], which was accepted at [height], is
+// stored and that its effects on the UTXO set are in place.
+func (vm *VM) verifyAcceptedTx(height uint64, txID ids.ID) error {
+	status, err := vm.state.GetStatus(txID)
+	if err != nil {
+		return fmt.Errorf("couldn't get status: %w", err)
+	}
+	if status != choices.Accepted {
+		return fmt.Errorf("unexpected status %s", status)
+	}
+
+	tx, err := vm.state.GetTx(txID)
+	if err != nil {
+		return fmt.Errorf("couldn't get tx: %w", err)
+	}
+	if storedID := tx.ID(); storedID != txID {
+		return fmt.Errorf("stored tx has ID %s", storedID)
+	}
+
+	acceptance, err := vm.state.GetAcceptance(txID)
+	if err != nil {
+		return fmt.Errorf("couldn't get acceptance record: %w", err)
+	}
+	if acceptance.Height != height {
+		return fmt.Errorf("acceptance record has height %d", acceptance.Height)
+	}
+
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		inputID := utxoID.InputID()
+		_, err := vm.state.GetUTXO(inputID)
+		if err == nil {
+			return fmt.Errorf("consumed UTXO %s is in the UTXO set", inputID)
+		}
+		if err != database.ErrNotFound {
+			return fmt.Errorf("couldn't get consumed UTXO %s: %w", inputID, err)
+		}
+	}
+
+	for _, utxo := range tx.UTXOs() {
+		if err := vm.verifyProducedUTXO(utxo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyProducedUTXO checks that [utxo] is stored as it was produced, and is
+// indexed under its addresses, unless it was spent since.
+func (vm *VM) verifyProducedUTXO(utxo *djtx.UTXO) error {
+	utxoID := utxo.InputID()
+	stored, err := vm.state.GetUTXO(utxoID)
+	if err == database.ErrNotFound {
+		// The UTXO was consumed by a later tx
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get produced UTXO %s: %w", utxoID, err)
+	}
+
+	c := vm.parser.Codec()
+	producedBytes, err := c.Marshal(txs.CodecVersion, utxo)
+	if err != nil {
+		return err
+	}
+	storedBytes, err := c.Marshal(txs.CodecVersion, stored)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(producedBytes, storedBytes) {
+		return fmt.Errorf("stored UTXO %s differs from the produced UTXO", utxoID)
+	}
+
+	addressable, ok := utxo.Out.(djtx.Addressable)
+	if !ok {
+		return nil
+	}
+	for _, addr := range addressable.Addresses() {
+		indexed, err := vm.state.HasUTXOID(addr, utxoID)
+		if err != nil {
+			return fmt.Errorf("couldn't read the address index: %w", err)
+		}
+		if !indexed {
+			return fmt.Errorf("UTXO %s isn't indexed under its address 0x%x", utxoID, addr)
+		}
+	}
+	return nil
+}
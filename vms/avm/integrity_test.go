@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+func TestVerifyIntegrity(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVMWithConfig(t, nil, nil, Config{
+		HistoricalUTXOWindow: 2,
+	})
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	addrSet := ids.ShortSet{}
+	addrSet.Add(addrs[0])
+	genesisUTXOs, err := djtx.GetAllUTXOs(vm.state, addrSet)
+	assert.NoError(err)
+
+	newTx := NewTx(t, genesisBytes, vm)
+	tx, err := vm.ParseTx(newTx.Bytes())
+	assert.NoError(err)
+	assert.NoError(tx.Verify())
+	assert.NoError(tx.Accept())
+
+	// Depths beyond the accepted txs are capped
+	assert.NoError(vm.verifyIntegrity(5))
+
+	// A decided tx whose status was lost is caught
+	assert.NoError(vm.state.PutStatus(tx.ID(), choices.Processing))
+	assert.ErrorIs(vm.verifyIntegrity(1), errCorruptState)
+	assert.NoError(vm.state.PutStatus(tx.ID(), choices.Accepted))
+	assert.NoError(vm.verifyIntegrity(1))
+
+	// A consumed UTXO that reappeared in the UTXO set is caught
+	spentUTXOID := newTx.InputUTXOs()[0].InputID()
+	for _, utxo := range genesisUTXOs {
+		if utxo.InputID() == spentUTXOID {
+			assert.NoError(vm.state.PutUTXO(spentUTXOID, utxo))
+		}
+	}
+	assert.ErrorIs(vm.verifyIntegrity(1), errCorruptState)
+}
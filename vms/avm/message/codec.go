@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"github.com/lasthyphen/beacongo/codec"
+	"github.com/lasthyphen/beacongo/codec/linearcodec"
+	"github.com/lasthyphen/beacongo/utils/units"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+)
+
+const (
+	codecVersion uint16 = 0
+	// Txs are gossiped before they are issued into consensus, so large txs are
+	// left to be propagated by the vertices that include them.
+	maxMessageSize = 128 * units.KiB
+	maxSliceLen    = maxMessageSize
+
+	// Codec version, type ID and length of the tx
+	txMessageOverhead = wrappers.ShortLen + wrappers.IntLen + wrappers.IntLen
+
+	// MaxTxSize is the size of the largest tx that fits in a Tx message
+	MaxTxSize = maxMessageSize - txMessageOverhead
+)
+
+// Codec does serialization and deserialization
+var c codec.Manager
+
+func init() {
+	c = codec.NewManager(maxMessageSize)
+	lc := linearcodec.NewCustomMaxLength(maxSliceLen)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		lc.RegisterType(&Tx{}),
+		c.RegisterCodec(codecVersion, lc),
+	)
+	if errs.Errored() {
+		panic(errs.Err)
+	}
+}
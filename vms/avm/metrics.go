@@ -4,14 +4,33 @@
 package avm
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/utils/metric"
 	"github.com/lasthyphen/beacongo/utils/wrappers"
+	"github.com/lasthyphen/beacongo/vms/avm/states"
 )
 
+var metricsPrefix = []byte("metrics")
+
 type metrics struct {
 	numTxRefreshes, numTxRefreshHits, numTxRefreshMisses prometheus.Counter
+	numTxsNotAdmitted                                    prometheus.Counter
+
+	// Resume from their prior values after a restart if the chain is
+	// configured to persist metrics
+	numTxsAccepted, numTxsRejected metric.PersistentCounter
+
+	numUTXOs  prometheus.Gauge
+	utxoSizes *prometheus.GaugeVec
+
+	// Batching of the txs issued to consensus
+	numPendingTxs            prometheus.Gauge
+	batchSize, batchLatency  prometheus.Histogram
+	numDroppedPendingTxsMsgs prometheus.Counter
 
 	apiRequestMetric metric.APIInterceptor
 }
@@ -35,6 +54,45 @@ func (m *metrics) Initialize(
 		Name:      "tx_refresh_misses",
 		Help:      "Number of times unique txs have not been unique and weren't cached",
 	})
+	m.numTxsNotAdmitted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "txs_not_admitted",
+		Help:      "Number of txs refused by the admission policy",
+	})
+
+	m.numUTXOs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "utxos",
+		Help:      "Number of UTXOs in the UTXO set",
+	})
+	m.utxoSizes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "utxo_sizes",
+		Help:      "Number of UTXOs in the UTXO set, by the upper bound of their size in bytes",
+	}, []string{"max_size"})
+
+	m.numPendingTxs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_txs",
+		Help:      "Number of issued txs waiting to be flushed into consensus",
+	})
+	m.batchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "batch_size",
+		Help:      "Number of txs in a batch when it's flushed into consensus",
+		Buckets:   []float64{1, 2, 5, 10, 20, batchSize},
+	})
+	m.batchLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "batch_latency",
+		Help:      "Time (in seconds) from the first tx of a batch being issued to the batch being flushed into consensus",
+		Buckets:   prometheus.DefBuckets,
+	})
+	m.numDroppedPendingTxsMsgs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dropped_pending_txs_msgs",
+		Help:      "Number of times the engine wasn't notified of pending txs because it was busy",
+	})
 
 	apiRequestMetric, err := metric.NewAPIInterceptor(namespace, registerer)
 	m.apiRequestMetric = apiRequestMetric
@@ -44,6 +102,49 @@ func (m *metrics) Initialize(
 		registerer.Register(m.numTxRefreshes),
 		registerer.Register(m.numTxRefreshHits),
 		registerer.Register(m.numTxRefreshMisses),
+		registerer.Register(m.numTxsNotAdmitted),
+		registerer.Register(m.numUTXOs),
+		registerer.Register(m.utxoSizes),
+		registerer.Register(m.numPendingTxs),
+		registerer.Register(m.batchSize),
+		registerer.Register(m.batchLatency),
+		registerer.Register(m.numDroppedPendingTxsMsgs),
 	)
 	return errs.Err
 }
+
+// initializePersistent registers the counters that are persisted in [db]. If
+// [db] is nil, the counters start from zero.
+func (m *metrics) initializePersistent(
+	namespace string,
+	registerer prometheus.Registerer,
+	db database.KeyValueReaderWriter,
+) error {
+	var err error
+	m.numTxsAccepted, err = metric.NewPersistentCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "txs_accepted",
+		Help:      "Number of txs accepted",
+	}, db, registerer)
+	if err != nil {
+		return err
+	}
+	m.numTxsRejected, err = metric.NewPersistentCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "txs_rejected",
+		Help:      "Number of txs rejected",
+	}, db, registerer)
+	return err
+}
+
+// observeUTXOStats reports the current statistics of the UTXO set.
+func (m *metrics) observeUTXOStats(stats *states.UTXOStats) {
+	m.numUTXOs.Set(float64(stats.NumUTXOs))
+	for i, count := range stats.SizeHistogram {
+		maxSize := "+Inf"
+		if i < len(states.UTXOSizeBuckets) {
+			maxSize = strconv.FormatUint(states.UTXOSizeBuckets[i], 10)
+		}
+		m.utxoSizes.WithLabelValues(maxSize).Set(float64(count))
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lasthyphen/beacongo/cache"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+	"github.com/lasthyphen/beacongo/vms/avm/message"
+)
+
+const (
+	// We allow [recentTxsCacheSize] to be fairly large because we only store
+	// hashes in the cache, not entire transactions.
+	recentTxsCacheSize = 512
+)
+
+// network gossips the txs issued to this node to its peers, so that they can
+// be issued into consensus by whichever node builds the next vertex.
+type network struct {
+	vm        *VM
+	appSender common.AppSender
+	// IDs of the txs that were recently gossiped or received through gossip
+	recentTxs *cache.LRU
+}
+
+// newNetwork returns the network of [vm]. If [appSender] is nil, txs aren't
+// gossiped.
+func newNetwork(vm *VM, appSender common.AppSender) *network {
+	return &network{
+		vm:        vm,
+		appSender: appSender,
+		recentTxs: &cache.LRU{Size: recentTxsCacheSize},
+	}
+}
+
+func (n *network) AppRequest(nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+	// This VM currently only supports gossiping of txs, so there are no
+	// requests.
+	return nil
+}
+
+func (n *network) AppResponse(nodeID ids.NodeID, requestID uint32, response []byte) error {
+	// This VM currently only supports gossiping of txs, so there are no
+	// requests.
+	return nil
+}
+
+func (n *network) AppRequestFailed(nodeID ids.NodeID, requestID uint32) error {
+	// This VM currently only supports gossiping of txs, so there are no
+	// requests.
+	return nil
+}
+
+// AppGossip issues the tx gossiped by [nodeID] into consensus, and gossips it
+// further, unless it was already seen. Invalid messages are dropped.
+func (n *network) AppGossip(nodeID ids.NodeID, msgBytes []byte) error {
+	log := n.vm.ctx.Log
+	log.Debug("AppGossip message handler called from %s with %d bytes", nodeID, len(msgBytes))
+
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		log.Debug("dropping AppGossip message from %s due to failing to parse message: %s", nodeID, err)
+		return nil
+	}
+	msg, ok := msgIntf.(*message.Tx)
+	if !ok {
+		log.Debug("dropping unexpected message from %s", nodeID)
+		return nil
+	}
+
+	// The tx is parsed without writing it to the state, so that txs that have
+	// already been seen are dropped cheaply.
+	rawTx, err := n.vm.parser.Parse(msg.Tx)
+	if err != nil {
+		log.Verbo("AppGossip provided invalid tx: %s", err)
+		return nil
+	}
+	txID := rawTx.ID()
+
+	// The context lock is grabbed to avoid racing with the engine when
+	// verifying and issuing the tx.
+	n.vm.ctx.Lock.Lock()
+	defer n.vm.ctx.Lock.Unlock()
+
+	if !n.vm.bootstrapped {
+		return nil
+	}
+	if _, ok := n.recentTxs.Get(txID); ok {
+		return nil
+	}
+	n.recentTxs.Put(txID, nil)
+
+	if status, err := n.vm.state.GetStatus(txID); err == nil && status != choices.Unknown {
+		// The tx is already processing or decided
+		return nil
+	}
+
+	tx, err := n.vm.parseTx(msg.Tx)
+	if err != nil {
+		log.Debug("dropping tx %s gossiped by %s: %s", txID, nodeID, err)
+		return nil
+	}
+	if err := tx.verifyWithoutCacheWrites(); err != nil {
+		log.Debug("dropping tx %s gossiped by %s: %s", txID, nodeID, err)
+		return nil
+	}
+	if err := n.vm.admit(tx); err != nil {
+		log.Debug("dropping tx %s gossiped by %s: %s", txID, nodeID, err)
+		return nil
+	}
+	n.vm.issueTx(tx)
+	return n.send(txID, msgBytes)
+}
+
+// GossipTx gossips [tx] to a sample of this node's peers, unless it was
+// recently gossiped. Txs that don't fit in a gossip message aren't gossiped.
+//
+// Assumes the context lock is held.
+func (n *network) GossipTx(tx *UniqueTx) error {
+	txID := tx.ID()
+	if _, ok := n.recentTxs.Get(txID); ok {
+		return nil
+	}
+	n.recentTxs.Put(txID, nil)
+
+	txBytes := tx.Bytes()
+	if len(txBytes) > message.MaxTxSize {
+		n.vm.ctx.Log.Debug("not gossiping tx %s of %d bytes as it's larger than %d bytes", txID, len(txBytes), message.MaxTxSize)
+		return nil
+	}
+	msgBytes, err := message.Build(&message.Tx{
+		Tx: txBytes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build Tx message: %w", err)
+	}
+	return n.send(txID, msgBytes)
+}
+
+func (n *network) send(txID ids.ID, msgBytes []byte) error {
+	if n.appSender == nil {
+		return nil
+	}
+	n.vm.ctx.Log.Debug("gossiping tx %s", txID)
+	return n.appSender.SendAppGossip(msgBytes)
+}
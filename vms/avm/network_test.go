@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+	"github.com/lasthyphen/beacongo/vms/avm/message"
+)
+
+func TestNetworkGossipIssuedTx(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	gossiped := [][]byte(nil)
+	vm.network.appSender = &common.SenderTest{
+		T: t,
+		SendAppGossipF: func(msgBytes []byte) error {
+			gossiped = append(gossiped, msgBytes)
+			return nil
+		},
+	}
+
+	tx := NewTx(t, genesisBytes, vm)
+	txID, err := vm.IssueTx(tx.Bytes())
+	assert.NoError(err)
+	assert.Equal(tx.ID(), txID)
+	assert.Len(gossiped, 1)
+
+	msgIntf, err := message.Parse(gossiped[0])
+	assert.NoError(err)
+	msg, ok := msgIntf.(*message.Tx)
+	assert.True(ok)
+	assert.Equal(tx.Bytes(), msg.Tx)
+
+	// Recently gossiped txs aren't gossiped again
+	assert.Len(vm.txs, 1)
+	uniqueTx, ok := vm.txs[0].(*UniqueTx)
+	assert.True(ok)
+	assert.NoError(vm.network.GossipTx(uniqueTx))
+	assert.Len(gossiped, 1)
+}
+
+func TestNetworkAppGossip(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		ctx.Lock.Lock()
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	gossiped := [][]byte(nil)
+	vm.network.appSender = &common.SenderTest{
+		T: t,
+		SendAppGossipF: func(msgBytes []byte) error {
+			gossiped = append(gossiped, msgBytes)
+			return nil
+		},
+	}
+
+	tx := NewTx(t, genesisBytes, vm)
+	msgBytes, err := message.Build(&message.Tx{
+		Tx: tx.Bytes(),
+	})
+	assert.NoError(err)
+	ctx.Lock.Unlock()
+
+	nodeID := ids.GenerateTestNodeID()
+	assert.NoError(vm.AppGossip(nodeID, msgBytes))
+	// The tx was issued and gossiped further
+	assert.Len(gossiped, 1)
+	assert.Equal(msgBytes, gossiped[0])
+
+	ctx.Lock.Lock()
+	assert.Len(vm.txs, 1)
+	issuedTx, err := vm.GetTx(tx.ID())
+	assert.NoError(err)
+	assert.Equal(choices.Processing, issuedTx.Status())
+	ctx.Lock.Unlock()
+
+	// Txs that were already seen are dropped
+	assert.NoError(vm.AppGossip(nodeID, msgBytes))
+	assert.Len(gossiped, 1)
+
+	// Invalid messages are dropped
+	assert.NoError(vm.AppGossip(nodeID, []byte{1, 2, 3}))
+	assert.Len(gossiped, 1)
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+)
+
+const (
+	defaultProcessingTxTimeout = time.Hour
+	// How often the processing txs are checked once the chain is
+	// bootstrapped
+	processingPruneFrequency = 10 * time.Minute
+)
+
+var (
+	processingPrunerPrefix = []byte("processingPruner")
+	reconciledKey          = []byte("reconciled")
+)
+
+// processingPruner removes the txs that were written to the database as
+// processing, when they were parsed, but were never decided. Such txs are
+// left behind when a tx is gossiped or issued but never makes it into an
+// accepted vertex, or when the node restarts while the tx is processing.
+//
+// All fields, other than [closed], are protected by the context lock.
+type processingPruner struct {
+	vm *VM
+	// Stores whether the txs that were processing before the start times of
+	// processing txs were recorded have been reconciled
+	db database.Database
+	// Processing txs older than this are pruned
+	timeout time.Duration
+
+	running bool
+	// Closed when the VM shuts down
+	closed chan struct{}
+
+	numProcessing prometheus.Gauge
+	numPruned     prometheus.Counter
+}
+
+func (p *processingPruner) initialize(
+	vm *VM,
+	db database.Database,
+	timeout time.Duration,
+	registerer prometheus.Registerer,
+) error {
+	if timeout <= 0 {
+		timeout = defaultProcessingTxTimeout
+	}
+	p.vm = vm
+	p.db = prefixdb.New(processingPrunerPrefix, db)
+	p.timeout = timeout
+	p.closed = make(chan struct{})
+	p.numProcessing = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "processing_txs_stored",
+		Help: "Number of processing txs stored in the database",
+	})
+	p.numPruned = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "processing_txs_pruned",
+		Help: "Number of processing txs removed from the database because they weren't decided in time",
+	})
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(p.numProcessing),
+		registerer.Register(p.numPruned),
+	)
+	return errs.Err
+}
+
+// start checking the processing txs in the background.
+//
+// Assumes the context lock is held.
+func (p *processingPruner) start() {
+	if p.running {
+		return
+	}
+	p.running = true
+	go p.vm.ctx.Log.RecoverAndPanic(p.run)
+}
+
+func (p *processingPruner) run() {
+	ticker := time.NewTicker(processingPruneFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.closed:
+			return
+		}
+
+		p.vm.ctx.Lock.Lock()
+		select {
+		case <-p.closed:
+			// The VM shut down while the lock was being grabbed
+			p.vm.ctx.Lock.Unlock()
+			return
+		default:
+		}
+		if err := p.prune(); err != nil {
+			p.vm.ctx.Log.Error("failed to prune processing txs: %s", err)
+		}
+		p.vm.ctx.Lock.Unlock()
+	}
+}
+
+// prune removes the txs that have been processing for longer than [timeout].
+// Txs that this node issued are kept until they're decided.
+//
+// Assumes the context lock is held.
+func (p *processingPruner) prune() error {
+	defer p.vm.db.Abort()
+
+	if err := p.reconcile(); err != nil {
+		return err
+	}
+
+	now := p.vm.clock.Time()
+	numProcessing := 0
+	stale := []ids.ID(nil)
+	err := p.vm.state.ForEachProcessing(func(txID ids.ID, timestamp time.Time) error {
+		numProcessing++
+		if now.Sub(timestamp) < p.timeout {
+			return nil
+		}
+		if _, issued := p.vm.txIssueTimes[txID]; issued {
+			return nil
+		}
+		stale = append(stale, txID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, txID := range stale {
+		if err := p.vm.state.DeleteTx(txID); err != nil {
+			return err
+		}
+		if err := p.vm.state.DeleteStatus(txID); err != nil {
+			return err
+		}
+		if err := p.vm.state.DeleteProcessing(txID); err != nil {
+			return err
+		}
+	}
+	if err := p.vm.db.Commit(); err != nil {
+		return err
+	}
+
+	if len(stale) > 0 {
+		p.vm.ctx.Log.Info("pruned %d txs that were processing for longer than %s", len(stale), p.timeout)
+	}
+	p.numProcessing.Set(float64(numProcessing - len(stale)))
+	p.numPruned.Add(float64(len(stale)))
+	return nil
+}
+
+// reconcile records the txs that were written as processing before the times
+// that txs start processing were recorded. As their age is unknown, they're
+// recorded as starting to process now. This only runs once.
+//
+// Assumes the context lock is held.
+func (p *processingPruner) reconcile() error {
+	reconciled, err := database.GetBool(p.db, reconciledKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	if reconciled {
+		return nil
+	}
+
+	processing := []ids.ID(nil)
+	err = p.vm.state.ForEachStatus(func(txID ids.ID, status choices.Status) error {
+		if status == choices.Processing {
+			processing = append(processing, txID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	now := p.vm.clock.Time()
+	for _, txID := range processing {
+		if err := p.vm.state.PutProcessing(txID, now); err != nil {
+			return err
+		}
+	}
+	if len(processing) > 0 {
+		p.vm.ctx.Log.Info("recorded %d processing txs that were stored before their start times were tracked", len(processing))
+	}
+	return database.PutBool(p.db, reconciledKey, true)
+}
+
+func (p *processingPruner) shutdown() {
+	close(p.closed)
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/snow/choices"
+)
+
+func TestProcessingPrunerPrunesStaleTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	newTx := NewTx(t, genesisBytes, vm)
+	tx, err := vm.ParseTx(newTx.Bytes())
+	assert.NoError(err)
+	txID := tx.ID()
+
+	// The tx isn't pruned before it times out
+	now := time.Now()
+	vm.clock.Set(now.Add(defaultProcessingTxTimeout - time.Second))
+	assert.NoError(vm.processingPruner.prune())
+	status, err := vm.state.GetStatus(txID)
+	assert.NoError(err)
+	assert.Equal(choices.Processing, status)
+
+	vm.clock.Set(now.Add(defaultProcessingTxTimeout))
+	assert.NoError(vm.processingPruner.prune())
+	_, err = vm.state.GetStatus(txID)
+	assert.ErrorIs(err, database.ErrNotFound)
+	_, err = vm.state.GetTx(txID)
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	// A pruned tx that is still in consensus is restored once it's decided
+	assert.NoError(tx.Verify())
+	assert.NoError(tx.Accept())
+	status, err = vm.state.GetStatus(txID)
+	assert.NoError(err)
+	assert.Equal(choices.Accepted, status)
+	storedTx, err := vm.state.GetTx(txID)
+	assert.NoError(err)
+	assert.Equal(newTx.Bytes(), storedTx.Bytes())
+}
+
+func TestProcessingPrunerKeepsIssuedTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	newTx := NewTx(t, genesisBytes, vm)
+	txID, err := vm.IssueTx(newTx.Bytes())
+	assert.NoError(err)
+
+	vm.clock.Set(time.Now().Add(2 * defaultProcessingTxTimeout))
+	assert.NoError(vm.processingPruner.prune())
+	status, err := vm.state.GetStatus(txID)
+	assert.NoError(err)
+	assert.Equal(choices.Processing, status)
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bytes"
+	"math"
+	"sort"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/pubsub"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+var _ pubsub.Filterer = &balanceFilterer{}
+
+// BalanceChange is the amount of an asset an address received and spent in a
+// single accepted transaction.
+//
+// Outputs owned by multiple addresses are attributed in full to each owner.
+type BalanceChange struct {
+	Address  string      `json:"address"`
+	AssetID  ids.ID      `json:"assetID"`
+	Received json.Uint64 `json:"received"`
+	Spent    json.Uint64 `json:"spent"`
+}
+
+// BalanceChangesEvent is published to the balance subscribers when a
+// transaction that modifies the balance of a subscribed address is accepted.
+type BalanceChangesEvent struct {
+	TxID    ids.ID          `json:"txID"`
+	Changes []BalanceChange `json:"changes"`
+}
+
+type balanceKey struct {
+	addr    ids.ShortID
+	assetID ids.ID
+}
+
+type balanceDelta struct {
+	received, spent uint64
+}
+
+type balanceFilterer struct {
+	txID   ids.ID
+	deltas map[balanceKey]*balanceDelta
+	format func(ids.ShortID) (string, error)
+}
+
+// NewPubSubBalanceFilterer returns a filterer that notifies the subscribers of
+// any address whose balance was modified by consuming [inputUTXOs] and
+// producing [outputUTXOs] in the transaction [txID].
+func NewPubSubBalanceFilterer(
+	txID ids.ID,
+	inputUTXOs []*djtx.UTXO,
+	outputUTXOs []*djtx.UTXO,
+	format func(ids.ShortID) (string, error),
+) pubsub.Filterer {
+	f := &balanceFilterer{
+		txID:   txID,
+		deltas: make(map[balanceKey]*balanceDelta),
+		format: format,
+	}
+	for _, utxo := range inputUTXOs {
+		f.add(utxo, false)
+	}
+	for _, utxo := range outputUTXOs {
+		f.add(utxo, true)
+	}
+	return f
+}
+
+func (f *balanceFilterer) add(utxo *djtx.UTXO, received bool) {
+	addressable, ok := utxo.Out.(djtx.Addressable)
+	if !ok {
+		return
+	}
+	amounter, ok := utxo.Out.(djtx.Amounter)
+	if !ok {
+		return
+	}
+	amount := amounter.Amount()
+	if amount == 0 {
+		return
+	}
+
+	assetID := utxo.AssetID()
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			continue
+		}
+		key := balanceKey{
+			addr:    addr,
+			assetID: assetID,
+		}
+		delta, exists := f.deltas[key]
+		if !exists {
+			delta = &balanceDelta{}
+			f.deltas[key] = delta
+		}
+		// Saturate rather than erroring, as the transaction was already
+		// accepted.
+		if received {
+			delta.received, err = safemath.Add64(delta.received, amount)
+			if err != nil {
+				delta.received = math.MaxUint64
+			}
+		} else {
+			delta.spent, err = safemath.Add64(delta.spent, amount)
+			if err != nil {
+				delta.spent = math.MaxUint64
+			}
+		}
+	}
+}
+
+// Filter returns which of the filters are subscribed to an address whose
+// balance was modified along with the changes to every modified balance.
+func (f *balanceFilterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	keys := make([]balanceKey, 0, len(f.deltas))
+	for key := range f.deltas {
+		keys = append(keys, key)
+		for i, c := range filters {
+			if resp[i] {
+				continue
+			}
+			resp[i] = c.Check(key.addr[:])
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if cmp := bytes.Compare(keys[i].addr[:], keys[j].addr[:]); cmp != 0 {
+			return cmp < 0
+		}
+		return bytes.Compare(keys[i].assetID[:], keys[j].assetID[:]) < 0
+	})
+
+	event := &BalanceChangesEvent{
+		TxID:    f.txID,
+		Changes: make([]BalanceChange, 0, len(keys)),
+	}
+	for _, key := range keys {
+		addr, err := f.format(key.addr)
+		if err != nil {
+			continue
+		}
+		delta := f.deltas[key]
+		event.Changes = append(event.Changes, BalanceChange{
+			Address:  addr,
+			AssetID:  key.assetID,
+			Received: json.Uint64(delta.received),
+			Spent:    json.Uint64(delta.spent),
+		})
+	}
+	return resp, event
+}
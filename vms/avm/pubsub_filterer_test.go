@@ -49,3 +49,70 @@ func TestFilter(t *testing.T) {
 	fr, _ := parser.Filter([]pubsub.Filter{&mockFilter{addr: addrBytes}})
 	assert.Equal([]bool{true}, fr)
 }
+
+func TestBalanceFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	sender := ids.ShortID{1}
+	receiver := ids.ShortID{2}
+	assetID := ids.ID{3}
+	txID := ids.ID{4}
+
+	inputUTXOs := []*djtx.UTXO{{
+		Asset: djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 10,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{sender},
+			},
+		},
+	}}
+	outputUTXOs := []*djtx.UTXO{
+		{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 7,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{receiver},
+				},
+			},
+		},
+		{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 2,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{sender},
+				},
+			},
+		},
+	}
+
+	filterer := NewPubSubBalanceFilterer(txID, inputUTXOs, outputUTXOs, func(addr ids.ShortID) (string, error) {
+		return addr.String(), nil
+	})
+	fr, msg := filterer.Filter([]pubsub.Filter{
+		&mockFilter{addr: receiver[:]},
+		&mockFilter{addr: ids.ShortEmpty[:]},
+	})
+	assert.Equal([]bool{true, false}, fr)
+	assert.Equal(&BalanceChangesEvent{
+		TxID: txID,
+		Changes: []BalanceChange{
+			{
+				Address:  sender.String(),
+				AssetID:  assetID,
+				Received: 2,
+				Spent:    10,
+			},
+			{
+				Address:  receiver.String(),
+				AssetID:  assetID,
+				Received: 7,
+			},
+		},
+	}, msg)
+}
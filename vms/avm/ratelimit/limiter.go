@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/cache"
+)
+
+const (
+	// AnyMethod is the key of the limit that applies to the methods that don't
+	// have a limit of their own
+	AnyMethod = "*"
+
+	// Max number of (method, client) buckets that are tracked when limits are
+	// applied per client. The least recently used buckets are dropped first.
+	maxBuckets = 8192
+)
+
+var errInvalidLimit = errors.New("rate must be positive and burst must be at least 1")
+
+// Limit is a token bucket. Tokens are added at [Rate] per second, up to
+// [Burst] tokens, and every request takes one token.
+type Limit struct {
+	// Requests per second
+	Rate float64 `json:"rate"`
+	// Max number of requests served at once
+	Burst int `json:"burst"`
+}
+
+// Config describes how often API methods may be called.
+type Config struct {
+	// Limits keyed by the full name of the method, e.g. "avm.getTx" or
+	// "wallet.send". The limit keyed by "*" applies to every other method.
+	// Each method has its own bucket. Methods without a limit are unlimited.
+	Methods map[string]Limit `json:"methods"`
+	// If true, the limits apply to each client IP separately. Otherwise, they
+	// apply to all clients together.
+	PerClient bool `json:"per-client"`
+}
+
+// Limiter throttles API requests according to a Config.
+type Limiter struct {
+	// Keyed by lowercase method names, since method names are matched
+	// regardless of case
+	limits    map[string]Limit
+	perClient bool
+
+	// Guards [buckets] so that a bucket is only created once
+	lock sync.Mutex
+	// bucketKey -> *rate.Limiter
+	buckets cache.LRU
+
+	throttled *prometheus.CounterVec
+}
+
+type bucketKey struct {
+	method, client string
+}
+
+// New returns a Limiter that applies [config]. The number of throttled
+// requests is reported to [registerer].
+func New(config Config, namespace string, registerer prometheus.Registerer) (*Limiter, error) {
+	limits := make(map[string]Limit, len(config.Methods))
+	for method, limit := range config.Methods {
+		if limit.Rate <= 0 || limit.Burst < 1 {
+			return nil, fmt.Errorf("invalid limit of %q: %w", method, errInvalidLimit)
+		}
+		limits[strings.ToLower(method)] = limit
+	}
+
+	l := &Limiter{
+		limits:    limits,
+		perClient: config.PerClient,
+		buckets:   cache.LRU{Size: maxBuckets},
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "api_requests_throttled",
+			Help:      "Number of API requests refused because they exceeded a rate limit",
+		}, []string{"method"}),
+	}
+	return l, registerer.Register(l.throttled)
+}
+
+// Allow returns true if the request [r] to [method] may be served. Each call
+// that returns true takes a token from the bucket of the request.
+func (l *Limiter) Allow(method string, r *http.Request) bool {
+	return l.AllowRemoteAddr(method, r.RemoteAddr)
+}
+
+// AllowRemoteAddr returns true if a request to [method] sent from
+// [remoteAddr], an "IP:port" address, may be served. It's used by APIs that
+// aren't served over HTTP/1.
+func (l *Limiter) AllowRemoteAddr(method string, remoteAddr string) bool {
+	method = strings.ToLower(method)
+	limit, ok := l.limits[method]
+	if !ok {
+		limit, ok = l.limits[AnyMethod]
+		if !ok {
+			return true
+		}
+	}
+
+	key := bucketKey{method: method}
+	if l.perClient {
+		key.client = clientIP(remoteAddr)
+	}
+
+	l.lock.Lock()
+	bucketIntf, ok := l.buckets.Get(key)
+	if !ok {
+		bucketIntf = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+		l.buckets.Put(key, bucketIntf)
+	}
+	l.lock.Unlock()
+
+	if bucketIntf.(*rate.Limiter).Allow() {
+		return true
+	}
+	l.throttled.WithLabelValues(method).Inc()
+	return false
+}
+
+// clientIP returns the IP of [remoteAddr]. Forwarding headers are ignored, as
+// they're set by the client.
+func clientIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
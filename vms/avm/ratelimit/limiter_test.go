@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := New(Config{
+		Methods: map[string]Limit{
+			"avm.getTx": {Rate: 0.001, Burst: 2},
+			AnyMethod:   {Rate: 0.001, Burst: 1},
+		},
+	}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	r := &http.Request{RemoteAddr: "1.2.3.4:5678"}
+	assert.True(l.Allow("avm.getTx", r))
+	// Method names are matched regardless of case
+	assert.True(l.Allow("avm.GetTx", r))
+	assert.False(l.Allow("avm.getTx", r))
+
+	// Each method has its own bucket
+	assert.True(l.Allow("avm.getBalance", r))
+	assert.False(l.Allow("avm.getBalance", r))
+	assert.True(l.Allow("wallet.send", r))
+
+	// The limits apply to all clients together
+	other := &http.Request{RemoteAddr: "5.6.7.8:5678"}
+	assert.False(l.Allow("avm.getTx", other))
+
+	assert.Equal(3.0, testutil.ToFloat64(l.throttled))
+}
+
+func TestLimiterPerClient(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := New(Config{
+		Methods: map[string]Limit{
+			AnyMethod: {Rate: 0.001, Burst: 1},
+		},
+		PerClient: true,
+	}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	r := &http.Request{RemoteAddr: "1.2.3.4:5678"}
+	assert.True(l.Allow("avm.getTx", r))
+	assert.False(l.Allow("avm.getTx", r))
+	// Requests from other ports of the same IP share the bucket
+	assert.False(l.Allow("avm.getTx", &http.Request{RemoteAddr: "1.2.3.4:1"}))
+	assert.True(l.Allow("avm.getTx", &http.Request{RemoteAddr: "5.6.7.8:5678"}))
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := New(Config{}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	for i := 0; i < 100; i++ {
+		assert.True(l.Allow("avm.getTx", &http.Request{}))
+	}
+
+	_, err = New(Config{
+		Methods: map[string]Limit{
+			"avm.getTx": {Rate: 1},
+		},
+	}, "", prometheus.NewRegistry())
+	assert.ErrorIs(err, errInvalidLimit)
+}
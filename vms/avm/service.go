@@ -10,13 +10,18 @@ import (
 	"net/http"
 
 	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/snow/choices"
 	"github.com/lasthyphen/beacongo/utils/crypto"
 	"github.com/lasthyphen/beacongo/utils/formatting"
+	"github.com/lasthyphen/beacongo/utils/hashing"
 	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/avm/fxs"
+	"github.com/lasthyphen/beacongo/vms/avm/states"
 	"github.com/lasthyphen/beacongo/vms/avm/txs"
 	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/components/index"
 	"github.com/lasthyphen/beacongo/vms/components/keystore"
 	"github.com/lasthyphen/beacongo/vms/components/verify"
 	"github.com/lasthyphen/beacongo/vms/nftfx"
@@ -34,20 +39,27 @@ const (
 )
 
 var (
-	errUnknownAssetID         = errors.New("unknown asset ID")
-	errTxNotCreateAsset       = errors.New("transaction doesn't create an asset")
-	errNoMinters              = errors.New("no minters provided")
-	errNoHoldersOrMinters     = errors.New("no minters or initialHolders provided")
-	errZeroAmount             = errors.New("amount must be positive")
-	errNoOutputs              = errors.New("no outputs to send")
-	errSpendOverflow          = errors.New("spent amount overflows uint64")
-	errInvalidMintAmount      = errors.New("amount minted must be positive")
-	errAddressesCantMintAsset = errors.New("provided addresses don't have the authority to mint the provided asset")
+	errUnknownAssetID         = json.NewError(ErrorCodeUnknownAsset, "unknown asset ID")
+	errTxNotCreateAsset       = json.NewError(ErrorCodeUnknownAsset, "transaction doesn't create an asset")
+	errNoMinters              = json.NewError(ErrorCodeInvalidArgument, "no minters provided")
+	errNoHoldersOrMinters     = json.NewError(ErrorCodeInvalidArgument, "no minters or initialHolders provided")
+	errZeroAmount             = json.NewError(ErrorCodeInvalidArgument, "amount must be positive")
+	errNoOutputs              = json.NewError(ErrorCodeInvalidArgument, "no outputs to send")
+	errNoOwners               = json.NewError(ErrorCodeInvalidArgument, "output must have at least one owner")
+	errSpendOverflow          = json.NewError(ErrorCodeInvalidArgument, "spent amount overflows uint64")
+	errInvalidMintAmount      = json.NewError(ErrorCodeInvalidArgument, "amount minted must be positive")
+	errAddressesCantMintAsset = json.NewError(ErrorCodeUnauthorized, "provided addresses don't have the authority to mint the provided asset")
 	errInvalidUTXO            = errors.New("invalid utxo")
-	errNilTxID                = errors.New("nil transaction ID")
-	errNoAddresses            = errors.New("no addresses provided")
-	errNoKeys                 = errors.New("from addresses have no keys or funds")
-	errMissingPrivateKey      = errors.New("argument 'privateKey' not given")
+	errNilTxID                = json.NewError(ErrorCodeInvalidArgument, "nil transaction ID")
+	errNoAddresses            = json.NewError(ErrorCodeInvalidArgument, "no addresses provided")
+	errInvalidDirection       = json.NewError(ErrorCodeInvalidArgument, "direction must be \"sent\", \"received\" or empty")
+	errInvalidTimeRange       = json.NewError(ErrorCodeInvalidArgument, "endTime is before startTime")
+	errInvalidActivityCursor  = json.NewError(ErrorCodeInvalidArgument, "invalid activityCursor")
+	errEmptyMemo              = json.NewError(ErrorCodeInvalidArgument, "memo must be non-empty")
+	errInvalidMemoCursor      = json.NewError(ErrorCodeInvalidArgument, "invalid cursor")
+	errNoKeys                 = json.NewError(ErrorCodeInsufficientFunds, "from addresses have no keys or funds")
+	errMissingPrivateKey      = json.NewError(ErrorCodeInvalidArgument, "argument 'privateKey' not given")
+	errNoTxOrTxType           = json.NewError(ErrorCodeInvalidArgument, "argument 'tx' or 'txType' must be given")
 )
 
 // Service defines the base service for the asset vm
@@ -75,6 +87,110 @@ func (service *Service) IssueTx(r *http.Request, args *api.FormattedTx, reply *a
 	return nil
 }
 
+// Checks of VerifyTx, in the order they are performed
+const (
+	verifyCheckParse     = "parse"
+	verifyCheckStatus    = "status"
+	verifyCheckSemantic  = "semantic"
+	verifyCheckAdmission = "admission"
+)
+
+// VerifyTxReply defines the VerifyTx replies returned from the API
+type VerifyTxReply struct {
+	// ID of the transaction, if it could be parsed
+	TxID ids.ID `json:"txID"`
+	// Status of the transaction on this chain
+	Status choices.Status `json:"status"`
+	// True if the transaction would be issued by IssueTx
+	Valid bool `json:"valid"`
+	// The check that failed, if the transaction is invalid. One of "parse",
+	// "status", "semantic" or "admission".
+	FailedCheck string `json:"failedCheck,omitempty"`
+	// Why the check failed
+	Reason string `json:"reason,omitempty"`
+	// IDs of the consumed UTXOs that don't exist or were already spent
+	MissingUTXOs []ids.ID `json:"missingUTXOs,omitempty"`
+}
+
+// VerifyTx runs the verification IssueTx performs on a signed transaction,
+// without issuing it or storing it.
+func (service *Service) VerifyTx(_ *http.Request, args *api.FormattedTx, reply *VerifyTxReply) error {
+	service.vm.ctx.Log.Debug("AVM: VerifyTx called with %s", args.Tx)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	if !service.vm.bootstrapped {
+		return errBootstrapping
+	}
+
+	vm := service.vm
+	fail := func(check string, err error) error {
+		reply.FailedCheck = check
+		reply.Reason = err.Error()
+		return nil
+	}
+
+	// Parse and syntactically verify the tx
+	tx, err := vm.parser.Parse(txBytes)
+	if err != nil {
+		return fail(verifyCheckParse, err)
+	}
+	reply.TxID = tx.ID()
+	err = tx.SyntacticVerify(
+		vm.ctx,
+		vm.parser.Codec(),
+		vm.feeAssetID,
+		vm.TxFee,
+		vm.CreateAssetTxFee,
+		len(vm.fxs),
+	)
+	if err != nil {
+		return fail(verifyCheckParse, err)
+	}
+
+	status, err := vm.state.GetStatus(reply.TxID)
+	switch {
+	case err == database.ErrNotFound:
+		status = choices.Unknown
+	case err != nil:
+		return err
+	}
+	reply.Status = status
+	switch status {
+	case choices.Accepted:
+		reply.Valid = true
+		return nil
+	case choices.Rejected:
+		return fail(verifyCheckStatus, errRejectedTx)
+	}
+
+	// Semantically verify the tx without caching the result
+	inputUTXOs := tx.InputUTXOs()
+	for _, utxoID := range inputUTXOs {
+		if utxoID.Symbolic() {
+			continue
+		}
+		if _, err := vm.getUTXO(utxoID); err != nil {
+			reply.MissingUTXOs = append(reply.MissingUTXOs, utxoID.InputID())
+		}
+	}
+	err = tx.Visit(&txSemanticVerify{
+		tx: tx,
+		vm: vm,
+	})
+	if err != nil {
+		return fail(verifyCheckSemantic, err)
+	}
+
+	if err := vm.admissionPolicy.Admit(tx, vm.consumedUTXOs(inputUTXOs)); err != nil {
+		return fail(verifyCheckAdmission, err)
+	}
+	reply.Valid = true
+	return nil
+}
+
 func (service *Service) IssueStopVertex(_ *http.Request, _ *struct{}, _ *struct{}) error {
 	return service.vm.issueStopVertex()
 }
@@ -92,12 +208,48 @@ type GetAddressTxsArgs struct {
 	PageSize json.Uint64 `json:"pageSize"`
 	// AssetID defaulted to DJTX if omitted or left blank
 	AssetID string `json:"assetID"`
+
+	// If any of the fields below is set, only the transactions accepted in
+	// the time range, and that changed the balance in the direction, are
+	// returned in order of acceptance time. [Cursor] is then ignored in favor
+	// of [ActivityCursor].
+
+	// Inclusive bounds, in Unix seconds, on the acceptance time
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// One of "sent", "received" or "" for both
+	Direction string `json:"direction"`
+	// Position to resume reading from, as returned by a previous call
+	ActivityCursor string `json:"activityCursor"`
+}
+
+// AddressTx is a transaction that changed the balance of an address.
+type AddressTx struct {
+	TxID ids.ID `json:"txID"`
+	// Unix time, in seconds, of when the transaction was accepted. 0 if it
+	// was accepted before acceptance times were recorded.
+	Timestamp json.Uint64 `json:"timestamp"`
+	// True if the address owned a UTXO that the transaction consumed
+	Sent bool `json:"sent"`
+	// True if the address owns a UTXO that the transaction produced
+	Received bool `json:"received"`
 }
 
 type GetAddressTxsReply struct {
 	TxIDs []ids.ID `json:"txIDs"`
 	// Cursor used as a page index / offset
 	Cursor json.Uint64 `json:"cursor"`
+
+	// Set instead of [Cursor] when the transactions were selected by time
+	// range or direction
+	Txs []AddressTx `json:"txs,omitempty"`
+	// Position to read the next page from. Empty if there are no more
+	// matching transactions.
+	ActivityCursor string `json:"activityCursor,omitempty"`
+
+	// Local annotations of the returned transactions that have one, as set
+	// when they were issued through this node
+	Annotations map[ids.ID]string `json:"annotations,omitempty"`
 }
 
 // GetAddressTxs returns list of transactions for a given address
@@ -122,6 +274,10 @@ func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs,
 		return fmt.Errorf("specified `assetID` is invalid: %w", err)
 	}
 
+	if args.StartTime != 0 || args.EndTime != 0 || args.Direction != "" || args.ActivityCursor != "" {
+		return service.getAddressActivity(args, address, assetID, pageSize, reply)
+	}
+
 	cursor := uint64(args.Cursor)
 
 	service.vm.ctx.Log.Debug("Fetching up to %d transactions for address %s, assetID %s, cursor %d", pageSize, address, assetID, cursor)
@@ -132,6 +288,11 @@ func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs,
 	}
 	service.vm.ctx.Log.Debug("Fetched %d transactions for address %s, assetID %s, cursor %d", len(reply.TxIDs), address, assetID, cursor)
 
+	reply.Annotations, err = service.vm.getTxAnnotations(reply.TxIDs)
+	if err != nil {
+		return err
+	}
+
 	// To get the next set of tx IDs, the user should provide this cursor.
 	// e.g. if they provided cursor 5, and read 6 tx IDs, they should start
 	// next time from index (cursor) 11.
@@ -139,6 +300,150 @@ func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs,
 	return nil
 }
 
+// getAddressActivity replies with the transactions that changed [address]'s
+// balance of [assetID] in the time range and direction of [args].
+func (service *Service) getAddressActivity(
+	args *GetAddressTxsArgs,
+	address ids.ShortID,
+	assetID ids.ID,
+	pageSize uint64,
+	reply *GetAddressTxsReply,
+) error {
+	query := index.ActivityQuery{
+		StartTime: uint64(args.StartTime),
+		EndTime:   uint64(args.EndTime),
+		PageSize:  pageSize,
+	}
+	switch args.Direction {
+	case "":
+		query.Direction = index.AnyDirection
+	case "sent":
+		query.Direction = index.Sent
+	case "received":
+		query.Direction = index.Received
+	default:
+		return errInvalidDirection
+	}
+	if query.EndTime != 0 && query.EndTime < query.StartTime {
+		return errInvalidTimeRange
+	}
+	if args.ActivityCursor != "" {
+		cursor, err := formatting.Decode(formatting.Hex, args.ActivityCursor)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errInvalidActivityCursor, err)
+		}
+		query.Cursor = cursor
+	}
+
+	activities, next, err := service.vm.addressTxsIndexer.ReadActivity(address[:], assetID, query)
+	if err != nil {
+		return err
+	}
+	reply.TxIDs = make([]ids.ID, len(activities))
+	reply.Txs = make([]AddressTx, len(activities))
+	for i, activity := range activities {
+		reply.TxIDs[i] = activity.TxID
+		reply.Txs[i] = AddressTx{
+			TxID:      activity.TxID,
+			Timestamp: json.Uint64(activity.Timestamp),
+			Sent:      activity.Direction&index.Sent != 0,
+			Received:  activity.Direction&index.Received != 0,
+		}
+	}
+	reply.Annotations, err = service.vm.getTxAnnotations(reply.TxIDs)
+	if err != nil {
+		return err
+	}
+	if next != nil {
+		reply.ActivityCursor, err = formatting.EncodeWithChecksum(formatting.Hex, next)
+		if err != nil {
+			return fmt.Errorf("couldn't encode activityCursor: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetTxsByMemoArgs are arguments for passing into GetTxsByMemo requests
+type GetTxsByMemoArgs struct {
+	Memo string `json:"memo"`
+	// If true, txs whose memo starts with [Memo] are returned. Otherwise,
+	// only txs whose memo equals [Memo] are returned.
+	Prefix bool `json:"prefix"`
+	// Position to resume reading from, as returned by a previous call
+	Cursor string `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// MemoTx is a transaction returned by GetTxsByMemo
+type MemoTx struct {
+	TxID ids.ID `json:"txID"`
+	Memo string `json:"memo"`
+	// Local annotation set when the transaction was issued through this node
+	Annotation string `json:"annotation,omitempty"`
+}
+
+// GetTxsByMemoReply defines the GetTxsByMemo replies returned from the API
+type GetTxsByMemoReply struct {
+	Txs []MemoTx `json:"txs"`
+	// Position to read the next page from. Empty if there are no more
+	// matching transactions.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// GetTxsByMemo returns the accepted transactions whose memo matches
+// [args.Memo], ordered by memo and then by ID. The node must index memos.
+func (service *Service) GetTxsByMemo(_ *http.Request, args *GetTxsByMemoArgs, reply *GetTxsByMemoReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetTxsByMemo called with memo=%q, prefix=%t, pageSize=%d", args.Memo, args.Prefix, args.PageSize)
+
+	if args.Memo == "" {
+		return errEmptyMemo
+	}
+	pageSize := uint64(args.PageSize)
+	if pageSize > maxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+
+	query := index.MemoQuery{
+		Memo:     []byte(args.Memo),
+		Prefix:   args.Prefix,
+		PageSize: pageSize,
+	}
+	if args.Cursor != "" {
+		cursor, err := formatting.Decode(formatting.Hex, args.Cursor)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errInvalidMemoCursor, err)
+		}
+		query.Cursor = cursor
+	}
+
+	memoTxs, next, err := service.vm.memoIndexer.Read(query)
+	if err != nil {
+		return err
+	}
+	reply.Txs = make([]MemoTx, len(memoTxs))
+	for i, memoTx := range memoTxs {
+		annotation, err := service.vm.getTxAnnotation(memoTx.TxID)
+		if err != nil {
+			return err
+		}
+		reply.Txs[i] = MemoTx{
+			TxID:       memoTx.TxID,
+			Memo:       string(memoTx.Memo),
+			Annotation: annotation,
+		}
+	}
+	if next != nil {
+		reply.Cursor, err = formatting.EncodeWithChecksum(formatting.Hex, next)
+		if err != nil {
+			return fmt.Errorf("couldn't encode cursor: %w", err)
+		}
+	}
+	return nil
+}
+
 // GetTxStatus returns the status of the specified transaction
 func (service *Service) GetTxStatus(r *http.Request, args *api.JSONTxID, reply *GetTxStatusReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetTxStatus called with %s", args.TxID)
@@ -156,6 +461,86 @@ func (service *Service) GetTxStatus(r *http.Request, args *api.JSONTxID, reply *
 	return nil
 }
 
+// PendingTx describes a locally issued transaction that hasn't been decided
+type PendingTx struct {
+	TxID ids.ID `json:"txID"`
+	// Size of the signed transaction in bytes
+	Size json.Uint64 `json:"size"`
+	// Time since the transaction was issued to this node. Empty if unknown.
+	Age    string         `json:"age"`
+	Status choices.Status `json:"status"`
+	// Other pending transactions whose outputs this transaction consumes
+	DependsOn []ids.ID `json:"dependsOn"`
+}
+
+// GetPendingTxsReply defines the GetPendingTxs replies returned from the API
+type GetPendingTxsReply struct {
+	// Transactions waiting to be batched into a vertex, in issuance order
+	Unflushed []PendingTx `json:"unflushed"`
+	// Undecided transactions issued by the wallet service, in issuance order
+	Wallet []PendingTx `json:"wallet"`
+}
+
+// GetPendingTxs returns the transactions that were issued to this node but
+// haven't reached consensus or haven't been decided yet.
+func (service *Service) GetPendingTxs(_ *http.Request, _ *struct{}, reply *GetPendingTxsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetPendingTxs called")
+
+	unflushed := make([]*txs.Tx, 0, len(service.vm.txs))
+	for _, tx := range service.vm.txs {
+		if uniqueTx, ok := tx.(*UniqueTx); ok && uniqueTx.TxCachedState != nil {
+			unflushed = append(unflushed, uniqueTx.Tx)
+		}
+	}
+	wallet := make([]*txs.Tx, 0, service.vm.walletService.pendingTxOrdering.Len())
+	for e := service.vm.walletService.pendingTxOrdering.Front(); e != nil; e = e.Next() {
+		wallet = append(wallet, e.Value.(*txs.Tx))
+	}
+
+	pendingTxIDs := ids.NewSet(len(unflushed) + len(wallet))
+	for _, tx := range unflushed {
+		pendingTxIDs.Add(tx.ID())
+	}
+	for _, tx := range wallet {
+		pendingTxIDs.Add(tx.ID())
+	}
+
+	now := service.vm.clock.Time()
+	describe := func(pendingTxs []*txs.Tx) []PendingTx {
+		described := make([]PendingTx, len(pendingTxs))
+		for i, tx := range pendingTxs {
+			txID := tx.ID()
+			dependsOn := ids.NewSet(0)
+			for _, utxoID := range tx.InputUTXOs() {
+				if utxoID.Symbolic() {
+					continue
+				}
+				if sourceTxID, _ := utxoID.InputSource(); pendingTxIDs.Contains(sourceTxID) {
+					dependsOn.Add(sourceTxID)
+				}
+			}
+			age := ""
+			if issueTime, ok := service.vm.txIssueTimes[txID]; ok {
+				age = now.Sub(issueTime).String()
+			}
+			dependsOnList := dependsOn.List()
+			ids.SortIDs(dependsOnList)
+			described[i] = PendingTx{
+				TxID:      txID,
+				Size:      json.Uint64(len(tx.Bytes())),
+				Age:       age,
+				Status:    (&UniqueTx{vm: service.vm, txID: txID}).Status(),
+				DependsOn: dependsOnList,
+			}
+		}
+		return described
+	}
+
+	reply.Unflushed = describe(unflushed)
+	reply.Wallet = describe(wallet)
+	return nil
+}
+
 // GetTx returns the specified transaction
 func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.GetTxReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetTx called with %s", args.TxID)
@@ -175,13 +560,29 @@ func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.G
 	reply.Encoding = args.Encoding
 
 	if args.Encoding == formatting.JSON {
-		reply.Tx = tx
-		return tx.Visit(&txInit{
+		err := tx.Visit(&txInit{
 			tx:            tx.Tx,
 			ctx:           service.vm.ctx,
 			typeToFxIndex: service.vm.typeToFxIndex,
 			fxs:           service.vm.fxs,
 		})
+		if err != nil {
+			return err
+		}
+		decodedInputs, err := service.vm.decodeInputs(tx.Tx)
+		if err != nil {
+			return fmt.Errorf("couldn't decode inputs: %w", err)
+		}
+		decodedOperations, err := service.vm.decodeOperations(tx.Tx)
+		if err != nil {
+			return fmt.Errorf("couldn't decode operations: %w", err)
+		}
+		reply.Tx = JSONTx{
+			Tx:                tx.Tx,
+			DecodedInputs:     decodedInputs,
+			DecodedOperations: decodedOperations,
+		}
+		return nil
 	}
 
 	var err error
@@ -192,6 +593,89 @@ func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.G
 	return nil
 }
 
+// EstimateFeeArgs are arguments for passing into EstimateFee requests.
+// Either [Tx] or [TxType] must be provided.
+type EstimateFeeArgs struct {
+	// Signed or unsigned transaction to estimate the fee of
+	Tx       string              `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// Type of the transaction to estimate the fee of if [Tx] isn't provided.
+	// One of "base", "createAsset", "operation", "import" or "export".
+	TxType string `json:"txType"`
+	// If true, the fee is also returned in whole units of the fee asset
+	FormatAmounts bool `json:"formatAmounts"`
+}
+
+// EstimateFeeReply defines the EstimateFee replies returned from the API
+type EstimateFeeReply struct {
+	// Amount of [FeeAssetID] that must be burned by the transaction
+	Fee        json.Uint64 `json:"fee"`
+	FeeAssetID ids.ID      `json:"feeAssetID"`
+	// [Fee] in whole units of [FeeAssetID], if amounts were requested to be
+	// formatted
+	FormattedFee string `json:"formattedFee,omitempty"`
+	// Size, in bytes, of the provided transaction. 0 if [TxType] was provided
+	// instead.
+	Size json.Uint64 `json:"size"`
+}
+
+// EstimateFee returns the fee that the provided transaction, or a transaction
+// of the provided type, must pay.
+func (service *Service) EstimateFee(_ *http.Request, args *EstimateFeeArgs, reply *EstimateFeeReply) error {
+	service.vm.ctx.Log.Debug("AVM: EstimateFee called with txType %q", args.TxType)
+
+	var utx txs.UnsignedTx
+	switch {
+	case args.Tx != "":
+		txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+		if err != nil {
+			return fmt.Errorf("problem decoding transaction: %w", err)
+		}
+		utx, err = service.vm.parseUnsignedTx(txBytes)
+		if err != nil {
+			return err
+		}
+		reply.Size = json.Uint64(len(txBytes))
+	case args.TxType != "":
+		var err error
+		utx, err = emptyTxOfType(args.TxType)
+		if err != nil {
+			return err
+		}
+	default:
+		return errNoTxOrTxType
+	}
+
+	fee, err := service.vm.calculateFee(utx)
+	if err != nil {
+		return err
+	}
+	reply.Fee = json.Uint64(fee)
+	reply.FeeAssetID = service.vm.feeAssetID
+	if args.FormatAmounts {
+		reply.FormattedFee, err = service.vm.formatAmount(service.vm.feeAssetID, fee)
+	}
+	return err
+}
+
+// GetFeeAssetIDReply defines the GetFeeAssetID replies returned from the API
+type GetFeeAssetIDReply struct {
+	AssetID ids.ID `json:"assetID"`
+	// Primary alias of the asset, if it has one
+	Alias string `json:"alias,omitempty"`
+}
+
+// GetFeeAssetID returns the asset that fees are paid in
+func (service *Service) GetFeeAssetID(_ *http.Request, _ *struct{}, reply *GetFeeAssetIDReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetFeeAssetID called")
+
+	reply.AssetID = service.vm.feeAssetID
+	if alias, err := service.vm.PrimaryAlias(service.vm.feeAssetID); err == nil {
+		reply.Alias = alias
+	}
+	return nil
+}
+
 // GetUTXOs gets all utxos for passed in addresses
 func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply *api.GetUTXOsReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetUTXOs called for with %s", args.Addresses)
@@ -287,6 +771,263 @@ func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 	return nil
 }
 
+// GetMultisigUTXOsArgs are arguments for passing into GetMultisigUTXOs requests
+type GetMultisigUTXOsArgs struct {
+	// Addresses that must be one of the owners of the returned UTXOs
+	Addresses []string `json:"addresses"`
+	// Only UTXOs that need at least this many signatures to be spent are
+	// returned. Defaults to 2.
+	MinThreshold json.Uint32 `json:"minThreshold"`
+	// Max number of UTXOs to return
+	Limit json.Uint32 `json:"limit"`
+	// Start fetching UTXOs after this index
+	StartIndex api.Index `json:"startIndex"`
+	// Encoding of the returned UTXOs
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// multisigUTXOReader only returns the UTXOs indexed as being owned by
+// multiple addresses with a threshold of at least [minThreshold].
+type multisigUTXOReader struct {
+	states.State
+	minThreshold uint32
+}
+
+func (r *multisigUTXOReader) UTXOIDs(addr []byte, previous ids.ID, limit int) ([]ids.ID, error) {
+	return r.MultisigUTXOIDs(addr, r.minThreshold, previous, limit)
+}
+
+// GetMultisigUTXOs gets the UTXOs that are owned by the passed in addresses
+// along with other addresses, and that need multiple signatures to be spent
+func (service *Service) GetMultisigUTXOs(_ *http.Request, args *GetMultisigUTXOsArgs, reply *api.GetUTXOsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetMultisigUTXOs called for with %s", args.Addresses)
+
+	if len(args.Addresses) == 0 {
+		return errNoAddresses
+	}
+	if len(args.Addresses) > maxGetUTXOsAddrs {
+		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	}
+
+	addrSet, err := djtx.ParseServiceAddresses(service.vm, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	startAddr := ids.ShortEmpty
+	startUTXO := ids.Empty
+	if args.StartIndex.Address != "" || args.StartIndex.UTXO != "" {
+		startAddr, err = djtx.ParseServiceAddress(service.vm, args.StartIndex.Address)
+		if err != nil {
+			return fmt.Errorf("couldn't parse start index address %q: %w", args.StartIndex.Address, err)
+		}
+		startUTXO, err = ids.FromString(args.StartIndex.UTXO)
+		if err != nil {
+			return fmt.Errorf("couldn't parse start index utxo: %w", err)
+		}
+	}
+
+	minThreshold := uint32(args.MinThreshold)
+	if minThreshold == 0 {
+		minThreshold = 2
+	}
+	limit := int(args.Limit)
+	if limit <= 0 || int(maxPageSize) < limit {
+		limit = int(maxPageSize)
+	}
+	utxos, endAddr, endUTXOID, err := djtx.GetPaginatedUTXOs(
+		&multisigUTXOReader{
+			State:        service.vm.state,
+			minThreshold: minThreshold,
+		},
+		addrSet,
+		startAddr,
+		startUTXO,
+		limit,
+	)
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+
+	reply.UTXOs = make([]string, len(utxos))
+	codec := service.vm.parser.Codec()
+	for i, utxo := range utxos {
+		b, err := codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("problem marshalling UTXO: %w", err)
+		}
+		reply.UTXOs[i], err = formatting.EncodeWithChecksum(args.Encoding, b)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as string: %w", utxo.InputID(), err)
+		}
+	}
+
+	endAddress, err := service.vm.FormatLocalAddress(endAddr)
+	if err != nil {
+		return fmt.Errorf("problem formatting address: %w", err)
+	}
+
+	reply.EndIndex.Address = endAddress
+	reply.EndIndex.UTXO = endUTXOID.String()
+	reply.NumFetched = json.Uint64(len(utxos))
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// GetNFTsArgs are arguments for passing into GetNFTs requests
+type GetNFTsArgs struct {
+	// Addresses whose NFTs are returned
+	Addresses []string `json:"addresses"`
+	// If provided, only NFTs of this asset are returned
+	AssetID string `json:"assetID"`
+	// Max number of NFTs to return
+	Limit json.Uint32 `json:"limit"`
+	// Start fetching NFTs after this index
+	StartIndex api.Index `json:"startIndex"`
+	// Encoding of the returned payloads
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// NFT describes an NFT UTXO
+type NFT struct {
+	UTXOID    string      `json:"utxoID"`
+	AssetID   ids.ID      `json:"assetID"`
+	GroupID   json.Uint32 `json:"groupID"`
+	Payload   string      `json:"payload"`
+	Locktime  json.Uint64 `json:"locktime"`
+	Threshold json.Uint32 `json:"threshold"`
+	Owners    []string    `json:"owners"`
+}
+
+// GetNFTsReply defines the GetNFTs replies returned from the API
+type GetNFTsReply struct {
+	// Number of NFTs returned
+	NumFetched json.Uint64 `json:"numFetched"`
+	// The NFTs
+	NFTs []NFT `json:"nfts"`
+	// The last UTXO that was examined. Use this as the start index of the
+	// next call to fetch the following page.
+	EndIndex api.Index `json:"endIndex"`
+	// Encoding of the payloads
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetNFTs returns the NFT UTXOs controlled by the passed in addresses
+func (service *Service) GetNFTs(_ *http.Request, args *GetNFTsArgs, reply *GetNFTsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetNFTs called for with %s", args.Addresses)
+
+	if len(args.Addresses) == 0 {
+		return errNoAddresses
+	}
+	if len(args.Addresses) > maxGetUTXOsAddrs {
+		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	}
+
+	addrSet, err := djtx.ParseServiceAddresses(service.vm, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	filterAsset := args.AssetID != ""
+	var assetID ids.ID
+	if filterAsset {
+		assetID, err = service.vm.lookupAssetID(args.AssetID)
+		if err != nil {
+			return err
+		}
+	}
+
+	startAddr := ids.ShortEmpty
+	startUTXO := ids.Empty
+	if args.StartIndex.Address != "" || args.StartIndex.UTXO != "" {
+		startAddr, err = djtx.ParseServiceAddress(service.vm, args.StartIndex.Address)
+		if err != nil {
+			return fmt.Errorf("couldn't parse start index address %q: %w", args.StartIndex.Address, err)
+		}
+		startUTXO, err = ids.FromString(args.StartIndex.UTXO)
+		if err != nil {
+			return fmt.Errorf("couldn't parse start index utxo: %w", err)
+		}
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || int(maxPageSize) < limit {
+		limit = int(maxPageSize)
+	}
+
+	// Most UTXOs may not be NFTs, so keep paging through the UTXOs of the
+	// addresses until enough NFTs have been found or the UTXOs run out.
+	reply.NFTs = []NFT{}
+	endAddr, endUTXOID := startAddr, startUTXO
+	for len(reply.NFTs) < limit {
+		pageSize := limit - len(reply.NFTs)
+		utxos, lastAddr, lastUTXOID, err := djtx.GetPaginatedUTXOs(
+			service.vm.state,
+			addrSet,
+			endAddr,
+			endUTXOID,
+			pageSize,
+		)
+		if err != nil {
+			return fmt.Errorf("problem retrieving UTXOs: %w", err)
+		}
+		endAddr, endUTXOID = lastAddr, lastUTXOID
+
+		for _, utxo := range utxos {
+			out, ok := utxo.Out.(*nftfx.TransferOutput)
+			if !ok {
+				continue
+			}
+			utxoAssetID := utxo.AssetID()
+			if filterAsset && utxoAssetID != assetID {
+				continue
+			}
+			nft, err := service.formatNFT(utxo, utxoAssetID, out, args.Encoding)
+			if err != nil {
+				return err
+			}
+			reply.NFTs = append(reply.NFTs, nft)
+		}
+		if len(utxos) < pageSize {
+			break
+		}
+	}
+
+	endAddress, err := service.vm.FormatLocalAddress(endAddr)
+	if err != nil {
+		return fmt.Errorf("problem formatting address: %w", err)
+	}
+
+	reply.EndIndex.Address = endAddress
+	reply.EndIndex.UTXO = endUTXOID.String()
+	reply.NumFetched = json.Uint64(len(reply.NFTs))
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+func (service *Service) formatNFT(utxo *djtx.UTXO, assetID ids.ID, out *nftfx.TransferOutput, encoding formatting.Encoding) (NFT, error) {
+	payload, err := formatting.EncodeWithChecksum(encoding, out.Payload)
+	if err != nil {
+		return NFT{}, fmt.Errorf("couldn't encode payload of %s: %w", utxo.InputID(), err)
+	}
+	owners := make([]string, len(out.Addrs))
+	for i, addr := range out.Addrs {
+		owners[i], err = service.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return NFT{}, fmt.Errorf("problem formatting address: %w", err)
+		}
+	}
+	return NFT{
+		UTXOID:    utxo.InputID().String(),
+		AssetID:   assetID,
+		GroupID:   json.Uint32(out.GroupID),
+		Payload:   payload,
+		Locktime:  json.Uint64(out.Locktime),
+		Threshold: json.Uint32(out.Threshold),
+		Owners:    owners,
+	}, nil
+}
+
 // GetAssetDescriptionArgs are arguments for passing into GetAssetDescription requests
 type GetAssetDescriptionArgs struct {
 	AssetID string `json:"assetID"`
@@ -309,23 +1050,223 @@ func (service *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescr
 		return err
 	}
 
-	tx := &UniqueTx{
-		vm:   service.vm,
-		txID: assetID,
+	metadata, err := service.vm.getAssetMetadata(assetID)
+	if err != nil {
+		return err
 	}
-	if status := tx.Status(); !status.Fetched() {
-		return errUnknownAssetID
+
+	reply.AssetID = assetID
+	reply.Name = metadata.Name
+	reply.Symbol = metadata.Symbol
+	reply.Denomination = json.Uint8(metadata.Denomination)
+
+	return nil
+}
+
+// GetAssetMetadataArgs are arguments for passing into GetAssetMetadata requests
+type GetAssetMetadataArgs struct {
+	// IDs or aliases of the assets to describe
+	AssetIDs []string `json:"assetIDs"`
+}
+
+// AssetMetadata describes an asset
+type AssetMetadata struct {
+	FormattedAssetID
+	Name         string     `json:"name"`
+	Symbol       string     `json:"symbol"`
+	Denomination json.Uint8 `json:"denomination"`
+	CreationTxID ids.ID     `json:"creationTxID"`
+}
+
+// GetAssetMetadataReply defines the GetAssetMetadata replies returned from the
+// API
+type GetAssetMetadataReply struct {
+	// Assets[i] describes AssetIDs[i] of the request
+	Assets []AssetMetadata `json:"assets"`
+}
+
+// GetAssetMetadata describes each of the provided assets
+func (service *Service) GetAssetMetadata(_ *http.Request, args *GetAssetMetadataArgs, reply *GetAssetMetadataReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetAssetMetadata called with %d assets", len(args.AssetIDs))
+
+	if len(args.AssetIDs) > int(maxPageSize) {
+		return fmt.Errorf("number of assetIDs (%d) > maximum allowed (%d)", len(args.AssetIDs), maxPageSize)
 	}
-	createAssetTx, ok := tx.UnsignedTx.(*txs.CreateAssetTx)
-	if !ok {
-		return errTxNotCreateAsset
+
+	reply.Assets = make([]AssetMetadata, len(args.AssetIDs))
+	for i, asset := range args.AssetIDs {
+		assetID, err := service.vm.lookupAssetID(asset)
+		if err != nil {
+			return err
+		}
+		metadata, err := service.vm.getAssetMetadata(assetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get metadata of asset %s: %w", assetID, err)
+		}
+		reply.Assets[i] = AssetMetadata{
+			FormattedAssetID: FormattedAssetID{AssetID: assetID},
+			Name:             metadata.Name,
+			Symbol:           metadata.Symbol,
+			Denomination:     json.Uint8(metadata.Denomination),
+			CreationTxID:     metadata.CreationTxID,
+		}
+	}
+	return nil
+}
+
+// GetAssetStatsArgs are arguments for passing into GetAssetStats requests
+type GetAssetStatsArgs struct {
+	AssetID string `json:"assetID"`
+}
+
+// GetAssetStatsReply defines the GetAssetStats replies returned from the API
+type GetAssetStatsReply struct {
+	FormattedAssetID
+	// Number of accepted transactions that transferred the asset
+	NumTxs json.Uint64 `json:"numTxs"`
+	// Number of UTXOs of the asset produced by accepted transactions
+	NumOutputs json.Uint64 `json:"numOutputs"`
+	// Total amount of the asset held by the produced UTXOs
+	Volume json.Uint64 `json:"volume"`
+}
+
+// GetAssetStats returns the accepted transfer statistics of an asset. Only
+// transactions accepted while indexing was enabled are accounted for.
+func (service *Service) GetAssetStats(_ *http.Request, args *GetAssetStatsArgs, reply *GetAssetStatsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetAssetStats called with %s", args.AssetID)
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	stats, err := service.vm.addressTxsIndexer.ReadAssetStats(assetID)
+	if err != nil {
+		return fmt.Errorf("couldn't read stats of asset %s: %w", assetID, err)
 	}
 
 	reply.AssetID = assetID
-	reply.Name = createAssetTx.Name
-	reply.Symbol = createAssetTx.Symbol
-	reply.Denomination = json.Uint8(createAssetTx.Denomination)
+	reply.NumTxs = json.Uint64(stats.NumTxs)
+	reply.NumOutputs = json.Uint64(stats.NumOutputs)
+	reply.Volume = json.Uint64(stats.Volume)
+	return nil
+}
+
+// GetAssetSupplyArgs are arguments for passing into GetAssetSupply requests
+type GetAssetSupplyArgs struct {
+	AssetID string `json:"assetID"`
+}
+
+// GetAssetSupplyReply defines the GetAssetSupply replies returned from the API
+type GetAssetSupplyReply struct {
+	FormattedAssetID
+	// Amount of the asset minted by accepted transactions. Saturates at
+	// MaxUint64.
+	Minted json.Uint64 `json:"minted"`
+	// Amount of the asset burned by accepted transactions, such as by paying
+	// fees. Saturates at MaxUint64.
+	Burned json.Uint64 `json:"burned"`
+	// Amount of the asset minted and not burned
+	Circulating json.Uint64 `json:"circulating"`
+}
+
+// GetAssetSupply returns the supply of an asset. The supply is maintained as
+// transactions are accepted, so this doesn't replay the operations of the
+// asset.
+func (service *Service) GetAssetSupply(_ *http.Request, args *GetAssetSupplyArgs, reply *GetAssetSupplyReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetAssetSupply called with %s", args.AssetID)
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	supply, err := service.vm.state.GetAssetSupply(assetID)
+	if err != nil {
+		return fmt.Errorf("couldn't read supply of asset %s: %w", assetID, err)
+	}
 
+	reply.AssetID = assetID
+	reply.Minted = json.Uint64(supply.Minted)
+	reply.Burned = json.Uint64(supply.Burned)
+	reply.Circulating = json.Uint64(supply.Circulating())
+	return nil
+}
+
+// GetUTXOStatsArgs are arguments for passing into GetUTXOStats requests
+type GetUTXOStatsArgs struct {
+	// Assets to return the stats of. If empty, the stats of every asset with
+	// UTXOs are returned.
+	AssetIDs []string `json:"assetIDs"`
+}
+
+// UTXOSizeBucket is a bucket of the UTXO size histogram
+type UTXOSizeBucket struct {
+	// Inclusive upper bound of the size, in bytes, of the UTXOs in the bucket.
+	// Omitted for the bucket of the largest UTXOs.
+	MaxSize  *json.Uint64 `json:"maxSize,omitempty"`
+	NumUTXOs json.Uint64  `json:"numUTXOs"`
+}
+
+// AssetUTXOStats are the stats of the UTXOs of an asset
+type AssetUTXOStats struct {
+	FormattedAssetID
+	NumUTXOs json.Uint64 `json:"numUTXOs"`
+	// Total amount held by the UTXOs. Saturates at MaxUint64.
+	Amount json.Uint64 `json:"amount"`
+}
+
+// GetUTXOStatsReply defines the GetUTXOStats replies returned from the API
+type GetUTXOStatsReply struct {
+	NumUTXOs      json.Uint64      `json:"numUTXOs"`
+	SizeHistogram []UTXOSizeBucket `json:"sizeHistogram"`
+	Assets        []AssetUTXOStats `json:"assets"`
+}
+
+// GetUTXOStats returns the statistics of the current UTXO set. The statistics
+// are maintained as transactions are accepted, so this doesn't scan the UTXOs.
+func (service *Service) GetUTXOStats(_ *http.Request, args *GetUTXOStatsArgs, reply *GetUTXOStatsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetUTXOStats called with %d assetIDs", len(args.AssetIDs))
+
+	stats, err := service.vm.state.UTXOStats()
+	if err != nil {
+		return fmt.Errorf("couldn't read UTXO stats: %w", err)
+	}
+	reply.NumUTXOs = json.Uint64(stats.NumUTXOs)
+	reply.SizeHistogram = make([]UTXOSizeBucket, len(stats.SizeHistogram))
+	for i, count := range stats.SizeHistogram {
+		reply.SizeHistogram[i].NumUTXOs = json.Uint64(count)
+		if i < len(states.UTXOSizeBuckets) {
+			maxSize := json.Uint64(states.UTXOSizeBuckets[i])
+			reply.SizeHistogram[i].MaxSize = &maxSize
+		}
+	}
+
+	reply.Assets = []AssetUTXOStats{}
+	addAsset := func(assetID ids.ID, assetStats states.AssetUTXOStats) {
+		reply.Assets = append(reply.Assets, AssetUTXOStats{
+			FormattedAssetID: FormattedAssetID{AssetID: assetID},
+			NumUTXOs:         json.Uint64(assetStats.NumUTXOs),
+			Amount:           json.Uint64(assetStats.Amount),
+		})
+	}
+	if len(args.AssetIDs) == 0 {
+		return service.vm.state.ForEachAssetUTXOStats(func(assetID ids.ID, assetStats states.AssetUTXOStats) error {
+			addAsset(assetID, assetStats)
+			return nil
+		})
+	}
+	for _, assetIDStr := range args.AssetIDs {
+		assetID, err := service.vm.lookupAssetID(assetIDStr)
+		if err != nil {
+			return err
+		}
+		assetStats, err := service.vm.state.AssetUTXOStats(assetID)
+		if err != nil {
+			return fmt.Errorf("couldn't read UTXO stats of asset %s: %w", assetID, err)
+		}
+		addAsset(assetID, assetStats)
+	}
 	return nil
 }
 
@@ -334,12 +1275,17 @@ type GetBalanceArgs struct {
 	Address        string `json:"address"`
 	AssetID        string `json:"assetID"`
 	IncludePartial bool   `json:"includePartial"`
+	// If true, the balance is also returned in whole units of the asset
+	FormatAmounts bool `json:"formatAmounts"`
 }
 
 // GetBalanceReply defines the GetBalance replies returned from the API
 type GetBalanceReply struct {
-	Balance json.Uint64   `json:"balance"`
-	UTXOIDs []djtx.UTXOID `json:"utxoIDs"`
+	Balance json.Uint64 `json:"balance"`
+	// [Balance] in whole units of the asset, if amounts were requested to be
+	// formatted
+	FormattedBalance string        `json:"formattedBalance,omitempty"`
+	UTXOIDs          []djtx.UTXOID `json:"utxoIDs"`
 }
 
 // GetBalance returns the balance of an asset held by an address.
@@ -391,17 +1337,141 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 		reply.UTXOIDs = append(reply.UTXOIDs, utxo.UTXOID)
 	}
 
+	if args.FormatAmounts {
+		reply.FormattedBalance, err = service.vm.formatAmount(assetID, uint64(reply.Balance))
+	}
+	return err
+}
+
+// GetUTXOsAtHeightArgs are arguments for passing into GetUTXOsAtHeight
+// requests
+type GetUTXOsAtHeightArgs struct {
+	Addresses []string `json:"addresses"`
+	// Number of accepted txs after which the UTXOs are returned
+	Height   json.Uint64         `json:"height"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetUTXOsAtHeightReply defines the GetUTXOsAtHeight replies returned from the
+// API
+type GetUTXOsAtHeightReply struct {
+	UTXOs    []string            `json:"utxos"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetUTXOsAtHeight returns the UTXOs that referenced any of [args.Addresses]
+// once [args.Height] txs had been accepted. The node only serves heights
+// within its historical UTXO window.
+func (service *Service) GetUTXOsAtHeight(_ *http.Request, args *GetUTXOsAtHeightArgs, reply *GetUTXOsAtHeightReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetUTXOsAtHeight called for %s at height %d", args.Addresses, args.Height)
+
+	if len(args.Addresses) == 0 {
+		return errNoAddresses
+	}
+	if len(args.Addresses) > maxGetUTXOsAddrs {
+		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	}
+
+	addrSet, err := djtx.ParseServiceAddresses(service.vm, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	utxos, err := service.vm.getUTXOsAtHeight(addrSet, uint64(args.Height))
+	if err != nil {
+		return err
+	}
+
+	codec := service.vm.parser.Codec()
+	reply.UTXOs = make([]string, len(utxos))
+	for i, utxo := range utxos {
+		b, err := codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("problem marshalling UTXO: %w", err)
+		}
+		reply.UTXOs[i], err = formatting.EncodeWithChecksum(args.Encoding, b)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as string: %w", utxo.InputID(), err)
+		}
+	}
+	reply.Encoding = args.Encoding
 	return nil
 }
 
+// GetBalanceAtHeightArgs are arguments for passing into GetBalanceAtHeight
+// requests
+type GetBalanceAtHeightArgs struct {
+	GetBalanceArgs
+	// Number of accepted txs after which the balance is returned
+	Height json.Uint64 `json:"height"`
+}
+
+// GetBalanceAtHeight returns the balance of an asset held by an address once
+// [args.Height] txs had been accepted. Locktimes are compared with the current
+// time, as in GetBalance. The node only serves heights within its historical
+// UTXO window.
+func (service *Service) GetBalanceAtHeight(_ *http.Request, args *GetBalanceAtHeightArgs, reply *GetBalanceReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetBalanceAtHeight called with address: %s assetID: %s height: %d", args.Address, args.AssetID, args.Height)
+
+	addr, err := djtx.ParseServiceAddress(service.vm, args.Address)
+	if err != nil {
+		return fmt.Errorf("problem parsing address '%s': %w", args.Address, err)
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	addrSet := ids.ShortSet{}
+	addrSet.Add(addr)
+
+	utxos, err := service.vm.getUTXOsAtHeight(addrSet, uint64(args.Height))
+	if err != nil {
+		return err
+	}
+
+	now := service.vm.clock.Unix()
+	reply.UTXOIDs = make([]djtx.UTXOID, 0, len(utxos))
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		transferable, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		owners := transferable.OutputOwners
+		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
+			continue
+		}
+		amt, err := safemath.Add64(transferable.Amount(), uint64(reply.Balance))
+		if err != nil {
+			return err
+		}
+		reply.Balance = json.Uint64(amt)
+		reply.UTXOIDs = append(reply.UTXOIDs, utxo.UTXOID)
+	}
+
+	if args.FormatAmounts {
+		reply.FormattedBalance, err = service.vm.formatAmount(assetID, uint64(reply.Balance))
+	}
+	return err
+}
+
 type Balance struct {
 	AssetID string      `json:"asset"`
 	Balance json.Uint64 `json:"balance"`
+	// [Balance] in whole units of the asset, if amounts were requested to be
+	// formatted
+	FormattedBalance string `json:"formattedBalance,omitempty"`
 }
 
 type GetAllBalancesArgs struct {
 	api.JSONAddress
 	IncludePartial bool `json:"includePartial"`
+	// If true, balances are also returned in whole units of their assets
+	FormatAmounts bool `json:"formatAmounts"`
 }
 
 // GetAllBalancesReply is the response from a call to GetAllBalances
@@ -425,9 +1495,17 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 	addrSet := ids.ShortSet{}
 	addrSet.Add(address)
 
+	reply.Balances, err = service.getAllBalances(addrSet, args.IncludePartial, args.FormatAmounts)
+	return err
+}
+
+// getAllBalances returns the balances of [addrSet], as described by
+// GetAllBalances. If [formatAmounts], the balances are also formatted in whole
+// units of their assets.
+func (service *Service) getAllBalances(addrSet ids.ShortSet, includePartial, formatAmounts bool) ([]Balance, error) {
 	utxos, err := djtx.GetAllUTXOs(service.vm.state, addrSet)
 	if err != nil {
-		return fmt.Errorf("couldn't get address's UTXOs: %w", err)
+		return nil, fmt.Errorf("couldn't get address's UTXOs: %w", err)
 	}
 
 	now := service.vm.clock.Unix()
@@ -440,7 +1518,7 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 			continue
 		}
 		owners := transferable.OutputOwners
-		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
+		if !includePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
 			continue
 		}
 		assetID := utxo.AssetID()
@@ -454,18 +1532,23 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 		}
 	}
 
-	reply.Balances = make([]Balance, assetIDs.Len())
+	reply := make([]Balance, assetIDs.Len())
 	i := 0
 	for assetID := range assetIDs {
 		alias := service.vm.PrimaryAliasOrDefault(assetID)
-		reply.Balances[i] = Balance{
+		reply[i] = Balance{
 			AssetID: alias,
 			Balance: json.Uint64(balances[assetID]),
 		}
+		if formatAmounts {
+			reply[i].FormattedBalance, err = service.vm.formatAmount(assetID, balances[assetID])
+			if err != nil {
+				return nil, err
+			}
+		}
 		i++
 	}
-
-	return nil
+	return reply, nil
 }
 
 // Holder describes how much an address owns of an asset
@@ -777,35 +1860,83 @@ func (service *Service) CreateAddress(r *http.Request, args *api.UserPass, reply
 	return user.Close()
 }
 
-// ListAddresses returns all of the addresses controlled by user [args.Username]
-func (service *Service) ListAddresses(_ *http.Request, args *api.UserPass, response *api.JSONAddresses) error {
-	service.vm.ctx.Log.Debug("AVM: ListAddresses called for user '%s'", args.Username)
+// ReadUserArgs identify a keystore user by its username and password, or by a
+// read-only token of the user. Read-only tokens are only accepted by the
+// methods that don't use the user's keys.
+type ReadUserArgs struct {
+	api.UserPass
+	// Read-only token of the user. If set, the username and password are
+	// ignored.
+	Token string `json:"token"`
+}
 
-	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+// loadUserAddresses returns the addresses controlled by the user identified
+// by [args]
+func (service *Service) loadUserAddresses(args *ReadUserArgs) ([]ids.ShortID, error) {
+	var (
+		user keystore.ReadOnlyUser
+		err  error
+	)
+	if args.Token != "" {
+		user, err = keystore.NewReadOnlyUserFromKeystore(service.vm.ctx.Keystore, args.Token)
+	} else {
+		user, err = keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	response.Addresses = []string{}
-
 	addresses, err := user.GetAddresses()
 	if err != nil {
 		// An error fetching the addresses may just mean that the user has no
 		// addresses.
-		return user.Close()
+		return nil, user.Close()
+	}
+	return addresses, user.Close()
+}
+
+// ListAddresses returns all of the addresses controlled by user [args.Username]
+func (service *Service) ListAddresses(_ *http.Request, args *ReadUserArgs, response *api.JSONAddresses) error {
+	service.vm.ctx.Log.Debug("AVM: ListAddresses called for user '%s'", args.Username)
+
+	addresses, err := service.loadUserAddresses(args)
+	if err != nil {
+		return err
 	}
 
+	response.Addresses = make([]string, 0, len(addresses))
 	for _, address := range addresses {
 		addr, err := service.vm.FormatLocalAddress(address)
 		if err != nil {
-			// Drop any potential error closing the database to report the
-			// original error
-			_ = user.Close()
 			return fmt.Errorf("problem formatting address: %w", err)
 		}
 		response.Addresses = append(response.Addresses, addr)
 	}
-	return user.Close()
+	return nil
+}
+
+// GetUserBalancesArgs are arguments for calling GetUserBalances
+type GetUserBalancesArgs struct {
+	ReadUserArgs
+	IncludePartial bool `json:"includePartial"`
+	// If true, balances are also returned in whole units of their assets
+	FormatAmounts bool `json:"formatAmounts"`
+}
+
+// GetUserBalances returns the balances held by all of the addresses controlled
+// by a keystore user, like GetAllBalances does for a single address.
+func (service *Service) GetUserBalances(_ *http.Request, args *GetUserBalancesArgs, reply *GetAllBalancesReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetUserBalances called for user '%s'", args.Username)
+
+	addresses, err := service.loadUserAddresses(&args.ReadUserArgs)
+	if err != nil {
+		return err
+	}
+	addrSet := ids.NewShortSet(len(addresses))
+	addrSet.Add(addresses...)
+
+	reply.Balances, err = service.getAllBalances(addrSet, args.IncludePartial, args.FormatAmounts)
+	return err
 }
 
 // ExportKeyArgs are arguments for ExportKey
@@ -905,6 +2036,15 @@ type SendArgs struct {
 
 	// Memo field
 	Memo string `json:"memo"`
+
+	// Strategy used to choose the UTXOs that fund the transaction. Defaults
+	// to spending UTXOs in the order they are loaded.
+	CoinSelection CoinSelection `json:"coinSelection"`
+
+	// Local annotation stored alongside the transaction by this node. It
+	// isn't included in the transaction.
+	Annotation string `json:"annotation"`
+
 }
 
 // SendMultipleArgs are arguments for passing into SendMultiple requests
@@ -917,6 +2057,15 @@ type SendMultipleArgs struct {
 
 	// Memo field
 	Memo string `json:"memo"`
+
+	// Strategy used to choose the UTXOs that fund the transaction. Defaults
+	// to spending UTXOs in the order they are loaded.
+	CoinSelection CoinSelection `json:"coinSelection"`
+
+	// Local annotation stored alongside the transaction by this node. It
+	// isn't included in the transaction.
+	Annotation string `json:"annotation"`
+
 }
 
 // Send returns the ID of the newly created transaction
@@ -925,12 +2074,81 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *api.JSONTxI
 		JSONSpendHeader: args.JSONSpendHeader,
 		Outputs:         []SendOutput{args.SendOutput},
 		Memo:            args.Memo,
+		CoinSelection:   args.CoinSelection,
+		Annotation:      args.Annotation,
 	}, reply)
 }
 
 // SendMultiple sends a transaction with multiple outputs.
 func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, reply *api.JSONTxIDChangeAddr) error {
-	service.vm.ctx.Log.Debug("AVM: SendMultiple called with username: %s", args.Username)
+	outputs := make([]SendMultisigOutput, len(args.Outputs))
+	for i, output := range args.Outputs {
+		outputs[i] = SendMultisigOutput{
+			Amount:    output.Amount,
+			AssetID:   output.AssetID,
+			To:        []string{output.To},
+			Threshold: 1,
+		}
+	}
+	return service.SendMultisig(r, &SendMultisigArgs{
+		JSONSpendHeader: args.JSONSpendHeader,
+		Outputs:         outputs,
+		Memo:            args.Memo,
+		CoinSelection:   args.CoinSelection,
+		Annotation:      args.Annotation,
+	}, reply)
+}
+
+// SendMultisigOutput specifies that [Amount] of asset [AssetID] be sent to an
+// output that can be spent by any [Threshold] of the [To] addresses after
+// [Locktime].
+type SendMultisigOutput struct {
+	// The amount of funds to send
+	Amount json.Uint64 `json:"amount"`
+
+	// ID of the asset being sent
+	AssetID string `json:"assetID"`
+
+	// Addresses that own the output
+	To []string `json:"to"`
+
+	// Number of the [To] addresses that must sign to spend the output
+	Threshold json.Uint32 `json:"threshold"`
+
+	// Unix time before which the output can't be spent
+	Locktime json.Uint64 `json:"locktime"`
+}
+
+// SendMultisigArgs are arguments for passing into SendMultisig requests
+type SendMultisigArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// Other keystore users whose keys may be used to sign for the spent UTXOs.
+	// This allows spending UTXOs that require signatures from keys held by
+	// multiple users.
+	AdditionalSigners []api.UserPass `json:"additionalSigners"`
+
+	// The outputs of the transaction
+	Outputs []SendMultisigOutput `json:"outputs"`
+
+	// Memo field
+	Memo string `json:"memo"`
+
+	// Strategy used to choose the UTXOs that fund the transaction. Defaults
+	// to spending UTXOs in the order they are loaded.
+	CoinSelection CoinSelection `json:"coinSelection"`
+
+	// Local annotation stored alongside the transaction by this node. It
+	// isn't included in the transaction.
+	Annotation string `json:"annotation"`
+
+}
+
+// SendMultisig sends a transaction whose outputs may be owned by multiple
+// addresses.
+func (service *Service) SendMultisig(r *http.Request, args *SendMultisigArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: SendMultisig called with username: %s", args.Username)
 
 	// Validate the memo field
 	memoBytes := []byte(args.Memo)
@@ -939,6 +2157,9 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	} else if len(args.Outputs) == 0 {
 		return errNoOutputs
 	}
+	if err := verifyAnnotation(args.Annotation); err != nil {
+		return err
+	}
 
 	// Parse the from addresses
 	fromAddrs, err := djtx.ParseServiceAddresses(service.vm, args.From)
@@ -961,6 +2182,14 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		return err
 	}
 
+	// Add the keys of the co-signers after the change address was selected so
+	// that change is always returned to the requesting user.
+	for _, signer := range args.AdditionalSigners {
+		if err := service.vm.AddUserKeys(kc, signer.Username, signer.Password); err != nil {
+			return fmt.Errorf("couldn't load keys of user %q: %w", signer.Username, err)
+		}
+	}
+
 	// Calculate required input amounts and create the desired outputs
 	// String repr. of asset ID --> asset ID
 	assetIDs := make(map[string]ids.ID)
@@ -987,22 +2216,18 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		}
 		amounts[assetID] = newAmount
 
-		// Parse the to address
-		to, err := djtx.ParseServiceAddress(service.vm, output.To)
+		// Parse the to addresses
+		owners, err := service.vm.parseOutputOwners(output.To, uint32(output.Threshold), uint64(output.Locktime))
 		if err != nil {
-			return fmt.Errorf("problem parsing to address %q: %w", output.To, err)
+			return err
 		}
 
 		// Create the Output
 		outs = append(outs, &djtx.TransferableOutput{
 			Asset: djtx.Asset{ID: assetID},
 			Out: &secp256k1fx.TransferOutput{
-				Amt: uint64(output.Amount),
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				},
+				Amt:          uint64(output.Amount),
+				OutputOwners: *owners,
 			},
 		})
 	}
@@ -1012,16 +2237,15 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		amountsWithFee[assetID] = amount
 	}
 
-	amountWithFee, err := safemath.Add64(amounts[service.vm.feeAssetID], service.vm.TxFee)
-	if err != nil {
-		return fmt.Errorf("problem calculating required spend amount: %w", err)
+	if err := service.vm.addFee(amountsWithFee, service.vm.TxFee); err != nil {
+		return err
 	}
-	amountsWithFee[service.vm.feeAssetID] = amountWithFee
 
-	amountsSpent, ins, keys, err := service.vm.Spend(
+	amountsSpent, ins, keys, err := service.vm.SpendWithCoinSelection(
 		utxos,
 		kc,
 		amountsWithFee,
+		args.CoinSelection,
 	)
 	if err != nil {
 		return err
@@ -1062,12 +2286,321 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	if err != nil {
 		return fmt.Errorf("problem issuing transaction: %w", err)
 	}
+	if err := service.vm.annotateTx(txID, args.Annotation); err != nil {
+		return err
+	}
 
 	reply.TxID = txID
 	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
 	return err
 }
 
+// BuildUnsignedTxArgs are arguments for passing into BuildUnsignedTx requests
+type BuildUnsignedTxArgs struct {
+	// Addresses whose UTXOs may be spent. Required.
+	api.JSONFromAddrs
+
+	// Address to send change to. Defaults to one of the from addresses.
+	api.JSONChangeAddr
+
+	// The outputs of the transaction
+	Outputs []SendMultisigOutput `json:"outputs"`
+
+	// Memo field
+	Memo string `json:"memo"`
+
+	// Encoding of the returned transaction
+	Encoding formatting.Encoding `json:"encoding"`
+
+	// Strategy used to choose the UTXOs that fund the transaction. Defaults
+	// to spending UTXOs in the order they are loaded.
+	CoinSelection CoinSelection `json:"coinSelection"`
+}
+
+// BuildUnsignedTxReply defines the BuildUnsignedTx replies returned from the
+// API
+type BuildUnsignedTxReply struct {
+	// The unsigned transaction
+	Tx       string              `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// For each credential of the transaction, the addresses that must sign it
+	// in order
+	Signers [][]string `json:"signers"`
+}
+
+// BuildUnsignedTx builds, but doesn't sign or issue, a transaction that spends
+// UTXOs owned by the from addresses. No keystore user is needed, so the
+// transaction can be signed by keys that are held outside of this node.
+func (service *Service) BuildUnsignedTx(_ *http.Request, args *BuildUnsignedTxArgs, reply *BuildUnsignedTxReply) error {
+	service.vm.ctx.Log.Debug("AVM: BuildUnsignedTx called with from: %s", args.From)
+
+	// Validate the memo field
+	memoBytes := []byte(args.Memo)
+	if l := len(memoBytes); l > djtx.MaxMemoSize {
+		return fmt.Errorf("max memo length is %d but provided memo field is length %d", djtx.MaxMemoSize, l)
+	} else if len(args.Outputs) == 0 {
+		return errNoOutputs
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseServiceAddresses(service.vm, args.From)
+	if err != nil {
+		return err
+	}
+	if fromAddrs.Len() == 0 {
+		return errNoAddresses
+	}
+	fromAddrsList := fromAddrs.List()
+	ids.SortShortIDs(fromAddrsList)
+
+	changeAddr, err := service.vm.selectChangeAddr(fromAddrsList[0], args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	utxos, err := djtx.GetAllUTXOs(service.vm.state, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+
+	// Asset ID --> amount of that asset being sent
+	amounts := make(map[ids.ID]uint64)
+	// Outputs of our tx
+	outs := []*djtx.TransferableOutput{}
+	for _, output := range args.Outputs {
+		if output.Amount == 0 {
+			return errZeroAmount
+		}
+		assetID, err := service.vm.lookupAssetID(output.AssetID)
+		if err != nil {
+			return fmt.Errorf("couldn't find asset %s", output.AssetID)
+		}
+		newAmount, err := safemath.Add64(amounts[assetID], uint64(output.Amount))
+		if err != nil {
+			return fmt.Errorf("problem calculating required spend amount: %w", err)
+		}
+		amounts[assetID] = newAmount
+
+		owners, err := service.vm.parseOutputOwners(output.To, uint32(output.Threshold), uint64(output.Locktime))
+		if err != nil {
+			return err
+		}
+		outs = append(outs, &djtx.TransferableOutput{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          uint64(output.Amount),
+				OutputOwners: *owners,
+			},
+		})
+	}
+
+	if err := service.vm.addFee(amounts, service.vm.TxFee); err != nil {
+		return err
+	}
+
+	amountsSpent, ins, err := service.vm.SpendWithAddresses(utxos, fromAddrs, amounts, args.CoinSelection)
+	if err != nil {
+		return err
+	}
+
+	// Add the required change outputs
+	for assetID, amount := range amounts {
+		amountSpent := amountsSpent[assetID]
+		if amountSpent > amount {
+			outs = append(outs, &djtx.TransferableOutput{
+				Asset: djtx.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amountSpent - amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{changeAddr},
+					},
+				},
+			})
+		}
+	}
+	codec := service.vm.parser.Codec()
+	djtx.SortTransferableOutputs(outs, codec)
+
+	var utx txs.UnsignedTx = &txs.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    service.vm.ctx.NetworkID,
+		BlockchainID: service.vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+		Memo:         memoBytes,
+	}}
+	unsignedBytes, err := codec.Marshal(txs.CodecVersion, &utx)
+	if err != nil {
+		return fmt.Errorf("problem marshalling transaction: %w", err)
+	}
+
+	reply.Tx, err = formatting.EncodeWithChecksum(args.Encoding, unsignedBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode transaction: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	reply.Signers, err = service.formatSigners(utx)
+	return err
+}
+
+// GetTxSigningHashesArgs are arguments for passing into GetTxSigningHashes
+// requests
+type GetTxSigningHashesArgs struct {
+	// The unsigned transaction
+	Tx       string              `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetTxSigningHashesReply defines the GetTxSigningHashes replies returned
+// from the API
+type GetTxSigningHashesReply struct {
+	// Hash that every signer must sign, encoded with [Encoding]
+	Hash     string              `json:"hash"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// For each credential of the transaction, the addresses that must sign
+	// [Hash] in order
+	Signers [][]string `json:"signers"`
+}
+
+// GetTxSigningHashes returns the hash that must be signed, and by which
+// addresses, to create the credentials of an unsigned transaction.
+func (service *Service) GetTxSigningHashes(_ *http.Request, args *GetTxSigningHashesArgs, reply *GetTxSigningHashesReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetTxSigningHashes called")
+
+	unsignedBytes, utx, err := service.decodeUnsignedTx(args.Tx, args.Encoding)
+	if err != nil {
+		return err
+	}
+
+	hash := hashing.ComputeHash256(unsignedBytes)
+	reply.Hash, err = formatting.EncodeWithChecksum(args.Encoding, hash)
+	if err != nil {
+		return fmt.Errorf("couldn't encode hash: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	reply.Signers, err = service.formatSigners(utx)
+	return err
+}
+
+// AttachSignaturesArgs are arguments for passing into AttachSignatures
+// requests
+type AttachSignaturesArgs struct {
+	// The unsigned transaction
+	Tx string `json:"tx"`
+	// For each credential of the transaction, the signatures of the
+	// transaction's signing hash in the order returned by GetTxSigningHashes
+	Signatures [][]string          `json:"signatures"`
+	Encoding   formatting.Encoding `json:"encoding"`
+	// If true, the signed transaction is issued
+	Issue bool `json:"issue"`
+}
+
+// AttachSignaturesReply defines the AttachSignatures replies returned from the
+// API
+type AttachSignaturesReply struct {
+	TxID ids.ID `json:"txID"`
+	// The signed transaction
+	Tx       string              `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// AttachSignatures adds externally created signatures to an unsigned
+// transaction and, if requested, issues it.
+func (service *Service) AttachSignatures(_ *http.Request, args *AttachSignaturesArgs, reply *AttachSignaturesReply) error {
+	service.vm.ctx.Log.Debug("AVM: AttachSignatures called with issue: %t", args.Issue)
+
+	_, utx, err := service.decodeUnsignedTx(args.Tx, args.Encoding)
+	if err != nil {
+		return err
+	}
+	if numCreds := utx.NumCredentials(); numCreds != len(args.Signatures) {
+		return fmt.Errorf("transaction requires %d credentials but %d were provided", numCreds, len(args.Signatures))
+	}
+
+	tx := &txs.Tx{
+		UnsignedTx: utx,
+		Creds:      make([]*fxs.FxCredential, len(args.Signatures)),
+	}
+	for i, sigStrs := range args.Signatures {
+		cred := &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(sigStrs)),
+		}
+		for j, sigStr := range sigStrs {
+			sig, err := formatting.Decode(args.Encoding, sigStr)
+			if err != nil {
+				return fmt.Errorf("couldn't decode signature %d of credential %d: %w", j, i, err)
+			}
+			if len(sig) != crypto.SECP256K1RSigLen {
+				return fmt.Errorf("signature %d of credential %d has length %d but expected %d", j, i, len(sig), crypto.SECP256K1RSigLen)
+			}
+			copy(cred.Sigs[j][:], sig)
+		}
+		tx.Creds[i] = &fxs.FxCredential{Verifiable: cred}
+	}
+
+	signedBytes, err := service.vm.parser.Codec().Marshal(txs.CodecVersion, tx)
+	if err != nil {
+		return fmt.Errorf("problem marshalling transaction: %w", err)
+	}
+	// Parsing the tx ensures it is well formed and initializes its ID
+	signedTx, err := service.vm.parser.Parse(signedBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing signed transaction: %w", err)
+	}
+
+	if args.Issue {
+		if _, err := service.vm.IssueTx(signedBytes); err != nil {
+			return fmt.Errorf("problem issuing transaction: %w", err)
+		}
+	}
+
+	reply.TxID = signedTx.ID()
+	reply.Tx, err = formatting.EncodeWithChecksum(args.Encoding, signedBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode transaction: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// decodeUnsignedTx returns the unsigned bytes of the signed or unsigned
+// transaction encoded in [txStr] and the parsed unsigned transaction.
+func (service *Service) decodeUnsignedTx(txStr string, encoding formatting.Encoding) ([]byte, txs.UnsignedTx, error) {
+	txBytes, err := formatting.Decode(encoding, txStr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	utx, err := service.vm.parseUnsignedTx(txBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	unsignedBytes, err := service.vm.parser.Codec().Marshal(txs.CodecVersion, &utx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem marshalling transaction: %w", err)
+	}
+	return unsignedBytes, utx, nil
+}
+
+// formatSigners returns the formatted addresses that must sign each
+// credential of [utx].
+func (service *Service) formatSigners(utx txs.UnsignedTx) ([][]string, error) {
+	signers, err := service.vm.getTxSigners(utx)
+	if err != nil {
+		return nil, err
+	}
+	formatted := make([][]string, len(signers))
+	for i, credSigners := range signers {
+		formatted[i] = make([]string, len(credSigners))
+		for j, addr := range credSigners {
+			formatted[i][j], err = service.vm.FormatLocalAddress(addr)
+			if err != nil {
+				return nil, fmt.Errorf("problem formatting address: %w", err)
+			}
+		}
+	}
+	return formatted, nil
+}
+
 // MintArgs are arguments for passing into Mint requests
 type MintArgs struct {
 	api.JSONSpendHeader             // User, password, from addrs, change addr
@@ -1590,16 +3123,11 @@ func (service *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSO
 		return err
 	}
 
-	amounts := map[ids.ID]uint64{}
-	if assetID == service.vm.feeAssetID {
-		amountWithFee, err := safemath.Add64(uint64(args.Amount), service.vm.TxFee)
-		if err != nil {
-			return fmt.Errorf("problem calculating required spend amount: %w", err)
-		}
-		amounts[service.vm.feeAssetID] = amountWithFee
-	} else {
-		amounts[service.vm.feeAssetID] = service.vm.TxFee
-		amounts[assetID] = uint64(args.Amount)
+	amounts := map[ids.ID]uint64{
+		assetID: uint64(args.Amount),
+	}
+	if err := service.vm.addFee(amounts, service.vm.TxFee); err != nil {
+		return err
 	}
 
 	amountsSpent, ins, keys, err := service.vm.Spend(utxos, kc, amounts)
@@ -18,6 +18,7 @@ import (
 
 	"github.com/lasthyphen/beacongo/api"
 	"github.com/lasthyphen/beacongo/chains/atomic"
+	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/database/manager"
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/snow"
@@ -38,6 +39,8 @@ import (
 	"github.com/lasthyphen/beacongo/vms/nftfx"
 	"github.com/lasthyphen/beacongo/vms/propertyfx"
 	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+
+	apikeystore "github.com/lasthyphen/beacongo/api/keystore"
 )
 
 var testChangeAddr = ids.GenerateTestShortID()
@@ -217,6 +220,114 @@ func TestServiceIssueTx(t *testing.T) {
 	}
 }
 
+func TestServiceVerifyTx(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, vm, s, _, _ := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	verifyTx := func(txBytes []byte) *VerifyTxReply {
+		txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+		assert.NoError(err)
+		reply := &VerifyTxReply{}
+		assert.NoError(s.VerifyTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply))
+		return reply
+	}
+
+	tx := NewTx(t, genesisBytes, vm)
+	reply := verifyTx(tx.Bytes())
+	assert.True(reply.Valid)
+	assert.Equal(tx.ID(), reply.TxID)
+	assert.Equal(choices.Unknown, reply.Status)
+	assert.Empty(reply.FailedCheck)
+
+	// Verifying the tx doesn't store it
+	_, err := vm.state.GetTx(tx.ID())
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	// Spending a UTXO that doesn't exist
+	missingTx := NewTx(t, genesisBytes, vm)
+	in := missingTx.UnsignedTx.(*txs.BaseTx).Ins[0]
+	in.OutputIndex = 7
+	missingTx.Creds = nil
+	assert.NoError(missingTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	reply = verifyTx(missingTx.Bytes())
+	assert.False(reply.Valid)
+	assert.Equal(verifyCheckSemantic, reply.FailedCheck)
+	assert.NotEmpty(reply.Reason)
+	assert.Equal([]ids.ID{in.InputID()}, reply.MissingUTXOs)
+
+	reply = verifyTx([]byte{1, 2, 3})
+	assert.False(reply.Valid)
+	assert.Equal(verifyCheckParse, reply.FailedCheck)
+}
+
+func TestAdminServiceTraceTx(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, vm, _, _, _ := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	s := &AdminService{vm: vm}
+
+	traceTx := func(txBytes []byte) *TraceTxReply {
+		txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+		assert.NoError(err)
+		reply := &TraceTxReply{}
+		assert.NoError(s.TraceTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply))
+		return reply
+	}
+
+	tx := NewTx(t, genesisBytes, vm)
+	reply := traceTx(tx.Bytes())
+	assert.True(reply.Valid)
+	assert.Equal(tx.ID(), reply.TxID)
+	assert.Empty(reply.Error)
+	assert.Len(reply.Steps, 2)
+	assert.Equal([]string{"parse", "syntax"}, reply.Steps[0].Checks)
+
+	inStep := reply.Steps[1]
+	assert.Equal(traceKindInput, inStep.Kind)
+	assert.Len(inStep.UTXOs, 1)
+	assert.Equal(tx.InputUTXOs()[0].InputID(), inStep.UTXOs[0].UTXOID)
+	assert.Equal("*secp256k1fx.TransferOutput", inStep.UTXOs[0].OutputType)
+	assert.NotNil(inStep.FxID)
+	assert.Equal("fxVerifyTransfer", inStep.Checks[len(inStep.Checks)-1])
+
+	// Spending a UTXO that doesn't exist fails the utxo check of the input
+	missingTx := NewTx(t, genesisBytes, vm)
+	in := missingTx.UnsignedTx.(*txs.BaseTx).Ins[0]
+	in.OutputIndex = 7
+	missingTx.Creds = nil
+	assert.NoError(missingTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}))
+	reply = traceTx(missingTx.Bytes())
+	assert.False(reply.Valid)
+	assert.NotEmpty(reply.Error)
+	lastStep := reply.Steps[len(reply.Steps)-1]
+	assert.Equal(traceKindInput, lastStep.Kind)
+	assert.Equal([]string{"utxo"}, lastStep.Checks)
+	assert.Equal(reply.Error, lastStep.Error)
+
+	reply = traceTx([]byte{1, 2, 3})
+	assert.False(reply.Valid)
+	assert.Equal([]string{"parse"}, reply.Steps[0].Checks)
+}
+
 func TestServiceGetTxStatus(t *testing.T) {
 	genesisBytes, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -467,6 +578,96 @@ func TestServiceGetTxs(t *testing.T) {
 	assert.Equal(t, getTxsReply.TxIDs, testTxs[10:20])
 }
 
+func TestServiceGetTxsAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, _ := setup(t, true)
+	var err error
+	vm.addressTxsIndexer, err = index.NewIndexer(vm.db, vm.ctx.Log, "", prometheus.NewRegistry(), false)
+	assert.NoError(err)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	addrStr, err := vm.FormatLocalAddress(addr)
+	assert.NoError(err)
+
+	testTxs := setupTestTxsInDB(t, vm.db, addr, assetID, 5)
+	assert.NoError(vm.annotateTx(testTxs[3], "order-3"))
+
+	getTxsArgs := &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		AssetID:     assetID.String(),
+	}
+	getTxsReply := &GetAddressTxsReply{}
+	assert.NoError(s.GetAddressTxs(nil, getTxsArgs, getTxsReply))
+	assert.Equal(testTxs, getTxsReply.TxIDs)
+	assert.Equal(map[ids.ID]string{testTxs[3]: "order-3"}, getTxsReply.Annotations)
+}
+
+func TestServiceGetBalanceFormatAmounts(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	addr := ids.GenerateTestShortID()
+	addrStr, err := vm.FormatLocalAddress(addr)
+	assert.NoError(err)
+
+	utxo := &djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1337,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	assert.NoError(vm.state.PutUTXO(utxo.InputID(), utxo))
+
+	metadata, err := vm.getAssetMetadata(assetID)
+	assert.NoError(err)
+	expected := formatDenominatedAmount(1337, metadata.Denomination)
+
+	balanceReply := &GetBalanceReply{}
+	err = s.GetBalance(nil, &GetBalanceArgs{
+		Address: addrStr,
+		AssetID: assetID.String(),
+	}, balanceReply)
+	assert.NoError(err)
+	assert.EqualValues(1337, balanceReply.Balance)
+	assert.Empty(balanceReply.FormattedBalance)
+
+	err = s.GetBalance(nil, &GetBalanceArgs{
+		Address:       addrStr,
+		AssetID:       assetID.String(),
+		FormatAmounts: true,
+	}, balanceReply)
+	assert.NoError(err)
+	assert.Equal(expected, balanceReply.FormattedBalance)
+
+	allBalancesReply := &GetAllBalancesReply{}
+	err = s.GetAllBalances(nil, &GetAllBalancesArgs{
+		JSONAddress:   api.JSONAddress{Address: addrStr},
+		FormatAmounts: true,
+	}, allBalancesReply)
+	assert.NoError(err)
+	assert.Len(allBalancesReply.Balances, 1)
+	assert.Equal(expected, allBalancesReply.Balances[0].FormattedBalance)
+}
+
 func TestServiceGetAllBalances(t *testing.T) {
 	_, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -732,6 +933,19 @@ func TestServiceGetTxJSON_BaseTx(t *testing.T) {
 	assert.Contains(t, jsonString, "\"memo\":\"0x0102030405060708\"")
 	assert.Contains(t, jsonString, "\"inputs\":[{\"txID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"outputIndex\":2,\"assetID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"fxID\":\"11111111111111111111111111111111LpoYY\",\"input\":{\"amount\":50000,\"signatureIndices\":[0]}}]")
 	assert.Contains(t, jsonString, "\"outputs\":[{\"assetID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"fxID\":\"11111111111111111111111111111111LpoYY\",\"output\":{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"amount\":49000,\"locktime\":0,\"threshold\":1}}]")
+
+	// The spent genesis UTXO is resolved, along with the signer of its input
+	decoded := struct {
+		DecodedInputs []DecodedInput `json:"decodedInputs"`
+	}{}
+	assert.NoError(t, stdjson.Unmarshal(jsonTxBytes, &decoded))
+	assert.Len(t, decoded.DecodedInputs, 1)
+	input := decoded.DecodedInputs[0]
+	assert.Equal(t, json.Uint64(50000), *input.Amount)
+	assert.Equal(t, []json.Uint32{0}, input.SignatureIndices)
+	assert.NotEmpty(t, input.SourceAddresses)
+	assert.Equal(t, json.Uint32(1), *input.Threshold)
+	assert.Equal(t, []string{input.SourceAddresses[0]}, input.Signers)
 }
 
 func TestServiceGetTxJSON_ExportTx(t *testing.T) {
@@ -974,6 +1188,23 @@ func TestServiceGetTxJSON_OperationTxWithNftxMintOp(t *testing.T) {
 	// contains the fxID
 	assert.Contains(t, jsonString, "\"operations\":[{\"assetID\":\"2MDgrsBHMRsEPa4D4NA1Bo1pjkVLUK173S3dd9BgT2nCJNiDuS\",\"inputIDs\":[{\"txID\":\"2MDgrsBHMRsEPa4D4NA1Bo1pjkVLUK173S3dd9BgT2nCJNiDuS\",\"outputIndex\":2}],\"fxID\":\"TtF4d2QWbk5vzQGTEPrN48x6vwgAoAmKQ9cbp79inpQmcRKES\"")
 	assert.Contains(t, jsonString, "\"credentials\":[{\"fxID\":\"TtF4d2QWbk5vzQGTEPrN48x6vwgAoAmKQ9cbp79inpQmcRKES\",\"credential\":{\"signatures\":[\"0x571f18cfdb254263ab6b987f742409bd5403eafe08b4dbc297c5cd8d1c85eb8812e4541e11d3dc692cd14b5f4bccc1835ec001df6d8935ce881caf97017c2a4801\"]}}]")
+
+	// The operation is decoded with the name of its fx
+	assert.Contains(t, jsonString, "\"payloadText\":\"hello\"")
+	jsonTx, ok := reply.Tx.(JSONTx)
+	assert.True(t, ok)
+	assert.Len(t, jsonTx.DecodedOperations, 1)
+	op := jsonTx.DecodedOperations[0]
+	assert.Equal(t, "nftfx", op.Fx)
+	assert.Equal(t, "mint", op.Type)
+	assert.Equal(t, []json.Uint32{0}, op.SignatureIndices)
+	assert.Len(t, op.Outputs, 1)
+	out := op.Outputs[0]
+	assert.Equal(t, "transfer", out.Type)
+	assert.Equal(t, "hello", out.PayloadText)
+	assert.Equal(t, []string{"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e"}, out.Addresses)
+	assert.NotNil(t, out.GroupID)
+	assert.Nil(t, out.Amount)
 }
 
 func TestServiceGetTxJSON_OperationTxWithMultipleNftxMintOp(t *testing.T) {
@@ -2047,6 +2278,94 @@ func TestServiceGetUTXOs(t *testing.T) {
 	}
 }
 
+func TestServiceGetNFTs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	rawAddr := ids.GenerateTestShortID()
+	addr, err := vm.FormatLocalAddress(rawAddr)
+	assert.NoError(err)
+
+	assetA := ids.GenerateTestID()
+	assetB := ids.GenerateTestID()
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{rawAddr},
+	}
+	putUTXO := func(assetID ids.ID, out verify.State) {
+		utxo := &djtx.UTXO{
+			UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  djtx.Asset{ID: assetID},
+			Out:    out,
+		}
+		assert.NoError(vm.state.PutUTXO(utxo.InputID(), utxo))
+	}
+	for i := 0; i < 3; i++ {
+		putUTXO(vm.ctx.DJTXAssetID, &secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: owners,
+		})
+		putUTXO(assetA, &nftfx.TransferOutput{
+			GroupID:      uint32(i),
+			Payload:      []byte{byte(i)},
+			OutputOwners: owners,
+		})
+		putUTXO(assetB, &nftfx.TransferOutput{
+			GroupID:      uint32(i),
+			OutputOwners: owners,
+		})
+	}
+
+	// Fetch all the NFTs one page at a time
+	args := &GetNFTsArgs{
+		Addresses: []string{addr},
+		Limit:     4,
+		Encoding:  formatting.Hex,
+	}
+	reply := &GetNFTsReply{}
+	assert.NoError(s.GetNFTs(nil, args, reply))
+	assert.Len(reply.NFTs, 4)
+	for _, nft := range reply.NFTs {
+		assert.Equal([]string{addr}, nft.Owners)
+		assert.Equal(json.Uint32(1), nft.Threshold)
+	}
+	fetched := len(reply.NFTs)
+
+	args.StartIndex = reply.EndIndex
+	reply = &GetNFTsReply{}
+	assert.NoError(s.GetNFTs(nil, args, reply))
+	assert.Len(reply.NFTs, 2)
+	fetched += len(reply.NFTs)
+	assert.Equal(6, fetched)
+
+	// Only NFTs of the requested asset are returned
+	reply = &GetNFTsReply{}
+	assert.NoError(s.GetNFTs(nil, &GetNFTsArgs{
+		Addresses: []string{addr},
+		AssetID:   assetA.String(),
+		Encoding:  formatting.Hex,
+	}, reply))
+	assert.Len(reply.NFTs, 3)
+	groupIDs := map[json.Uint32]bool{}
+	for _, nft := range reply.NFTs {
+		assert.Equal(assetA, nft.AssetID)
+		payload, err := formatting.Decode(formatting.Hex, nft.Payload)
+		assert.NoError(err)
+		assert.Equal([]byte{byte(nft.GroupID)}, payload)
+		groupIDs[nft.GroupID] = true
+	}
+	assert.Len(groupIDs, 3)
+
+	assert.ErrorIs(s.GetNFTs(nil, &GetNFTsArgs{}, &GetNFTsReply{}), errNoAddresses)
+}
+
 func TestGetAssetDescription(t *testing.T) {
 	_, vm, s, _, genesisTx := setup(t, true)
 	defer func() {
@@ -2074,6 +2393,44 @@ func TestGetAssetDescription(t *testing.T) {
 	}
 }
 
+func TestGetAssetMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	djtxAssetID := genesisTx.ID()
+
+	// The genesis asset's metadata is indexed when the state is initialized.
+	metadata, err := vm.state.GetAssetMetadata(djtxAssetID)
+	assert.NoError(err)
+	assert.Equal("DJTX", metadata.Name)
+	assert.Equal(djtxAssetID, metadata.CreationTxID)
+
+	reply := GetAssetMetadataReply{}
+	err = s.GetAssetMetadata(nil, &GetAssetMetadataArgs{
+		AssetIDs: []string{djtxAssetID.String(), "asset1"},
+	}, &reply)
+	assert.NoError(err)
+	assert.Len(reply.Assets, 2)
+	for _, asset := range reply.Assets {
+		assert.Equal(djtxAssetID, asset.AssetID)
+		assert.Equal("DJTX", asset.Name)
+		assert.Equal("SYMB", asset.Symbol)
+		assert.Equal(djtxAssetID, asset.CreationTxID)
+	}
+
+	err = s.GetAssetMetadata(nil, &GetAssetMetadataArgs{
+		AssetIDs: []string{ids.GenerateTestID().String()},
+	}, &reply)
+	assert.ErrorIs(err, errUnknownAssetID)
+}
+
 func TestGetBalance(t *testing.T) {
 	_, vm, s, _, genesisTx := setup(t, true)
 	defer func() {
@@ -2487,9 +2844,11 @@ func TestImportAVMKeyNoDuplicates(t *testing.T) {
 		t.Fatalf("Reply address: %s did not match expected address: %s", reply2.Address, expectedAddress)
 	}
 
-	addrsArgs := api.UserPass{
-		Username: username,
-		Password: password,
+	addrsArgs := ReadUserArgs{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
 	}
 	addrsReply := api.JSONAddresses{}
 	if err := s.ListAddresses(nil, &addrsArgs, &addrsReply); err != nil {
@@ -2651,9 +3010,11 @@ func TestCreateAndListAddresses(t *testing.T) {
 
 	newAddr := createReply.Address
 
-	listArgs := &api.UserPass{
-		Username: username,
-		Password: password,
+	listArgs := &ReadUserArgs{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
 	}
 	listReply := &api.JSONAddresses{}
 
@@ -2729,3 +3090,217 @@ func TestImport(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceEstimateFee(t *testing.T) {
+	genesisBytes, vm, s, _, _ := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	reply := &EstimateFeeReply{}
+	if err := s.EstimateFee(nil, &EstimateFeeArgs{}, reply); err == nil {
+		t.Fatal("Expected missing tx and tx type to return an error")
+	}
+
+	tx := NewTx(t, genesisBytes, vm)
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, tx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply = &EstimateFeeReply{}
+	err = s.EstimateFee(nil, &EstimateFeeArgs{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(reply.Fee) != vm.TxFee {
+		t.Fatalf("expected fee %d, got %d", vm.TxFee, reply.Fee)
+	}
+	if reply.FeeAssetID != vm.feeAssetID {
+		t.Fatalf("expected fee asset %s, got %s", vm.feeAssetID, reply.FeeAssetID)
+	}
+	if int(reply.Size) != len(tx.Bytes()) {
+		t.Fatalf("expected size %d, got %d", len(tx.Bytes()), reply.Size)
+	}
+
+	reply = &EstimateFeeReply{}
+	if err := s.EstimateFee(nil, &EstimateFeeArgs{TxType: "createAsset"}, reply); err != nil {
+		t.Fatal(err)
+	}
+	if uint64(reply.Fee) != vm.CreateAssetTxFee {
+		t.Fatalf("expected fee %d, got %d", vm.CreateAssetTxFee, reply.Fee)
+	}
+
+	if err := s.EstimateFee(nil, &EstimateFeeArgs{TxType: "unknown"}, reply); err == nil {
+		t.Fatal("Expected unknown tx type to return an error")
+	}
+}
+
+func TestServiceExternalSigning(t *testing.T) {
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	key := keys[0]
+	addrStr, err := vm.FormatLocalAddress(key.PublicKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buildReply := &BuildUnsignedTxReply{}
+	err = s.BuildUnsignedTx(nil, &BuildUnsignedTxArgs{
+		JSONFromAddrs: api.JSONFromAddrs{From: []string{addrStr}},
+		Outputs: []SendMultisigOutput{{
+			Amount:    500,
+			AssetID:   assetID.String(),
+			To:        []string{addrStr},
+			Threshold: 1,
+		}},
+		Encoding: formatting.Hex,
+	}, buildReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, signers := range buildReply.Signers {
+		assert.Equal(t, []string{addrStr}, signers)
+	}
+
+	hashesReply := &GetTxSigningHashesReply{}
+	err = s.GetTxSigningHashes(nil, &GetTxSigningHashesArgs{
+		Tx:       buildReply.Tx,
+		Encoding: formatting.Hex,
+	}, hashesReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, buildReply.Signers, hashesReply.Signers)
+
+	hash, err := formatting.Decode(hashesReply.Encoding, hashesReply.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := key.SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigStr, err := formatting.EncodeWithChecksum(formatting.Hex, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attachArgs := &AttachSignaturesArgs{
+		Tx:       buildReply.Tx,
+		Encoding: formatting.Hex,
+		Issue:    true,
+	}
+	attachReply := &AttachSignaturesReply{}
+	if err := s.AttachSignatures(nil, attachArgs, attachReply); err == nil {
+		t.Fatal("should have failed due to missing signatures")
+	}
+
+	for range hashesReply.Signers {
+		attachArgs.Signatures = append(attachArgs.Signatures, []string{sigStr})
+	}
+	vm.timer.Cancel()
+	if err := s.AttachSignatures(nil, attachArgs, attachReply); err != nil {
+		t.Fatal(err)
+	}
+	if len(vm.txs) != 1 || vm.txs[0].ID() != attachReply.TxID {
+		t.Fatal("signed transaction wasn't issued")
+	}
+}
+
+func TestServiceStrictAddresses(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, vm, _ := GenesisVMWithConfig(t, nil, nil, Config{
+		StrictAddresses: true,
+	})
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+
+	addrStr, err := vm.FormatLocalAddress(addrs[0])
+	assert.NoError(err)
+	reply := &GetBalanceReply{}
+	assert.NoError(s.GetBalance(nil, &GetBalanceArgs{
+		Address: addrStr,
+		AssetID: vm.feeAssetID.String(),
+	}, reply))
+	assert.EqualValues(startBalance, reply.Balance)
+
+	// Raw address IDs are rejected, and the expected address is reported
+	err = s.GetBalance(nil, &GetBalanceArgs{
+		Address: addrs[0].String(),
+		AssetID: vm.feeAssetID.String(),
+	}, &GetBalanceReply{})
+	assert.Error(err)
+	assert.Contains(err.Error(), addrStr)
+
+	// Addresses of other networks are rejected, and the expected prefix is
+	// reported
+	chainAlias, err := vm.ctx.BCLookup.PrimaryAlias(vm.ctx.ChainID)
+	assert.NoError(err)
+	otherNetworkAddrStr, err := address.Format(chainAlias, constants.MainnetHRP, addrs[0].Bytes())
+	assert.NoError(err)
+	err = s.GetBalance(nil, &GetBalanceArgs{
+		Address: otherNetworkAddrStr,
+		AssetID: vm.feeAssetID.String(),
+	}, &GetBalanceReply{})
+	assert.Error(err)
+	assert.Contains(err.Error(), chainAlias+"-"+constants.GetHRP(vm.ctx.NetworkID)+"1")
+}
+
+func TestServiceReadOnlyToken(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	ks, err := apikeystore.CreateTestKeystore()
+	assert.NoError(err)
+	assert.NoError(ks.CreateUser(username, password))
+	vm.ctx.Keystore = ks.NewBlockchainKeyStore(vm.ctx.ChainID)
+	user, err := keystore.NewUserFromKeystore(vm.ctx.Keystore, username, password)
+	assert.NoError(err)
+	assert.NoError(user.PutKeys(keys[0]))
+	assert.NoError(user.Close())
+
+	token, err := ks.CreateReadOnlyToken(username, password)
+	assert.NoError(err)
+	tokenArgs := ReadUserArgs{Token: token}
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	listReply := api.JSONAddresses{}
+	assert.NoError(s.ListAddresses(nil, &tokenArgs, &listReply))
+	assert.Equal([]string{addrStr}, listReply.Addresses)
+
+	balancesReply := GetAllBalancesReply{}
+	assert.NoError(s.GetUserBalances(nil, &GetUserBalancesArgs{
+		ReadUserArgs: tokenArgs,
+	}, &balancesReply))
+	assert.Len(balancesReply.Balances, 1)
+	assert.EqualValues(startBalance, balancesReply.Balances[0].Balance)
+
+	// Revoked tokens aren't accepted
+	assert.NoError(ks.RevokeReadOnlyTokens(username, password))
+	err = s.ListAddresses(nil, &tokenArgs, &api.JSONAddresses{})
+	assert.Error(err)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/engine/snowman/block"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+)
+
+var (
+	_ block.StateSyncableVM = &VM{}
+	_ block.StateSummary    = &stateSummary{}
+)
+
+// stateSummaryContent is the serialized content of a state summary
+type stateSummaryContent struct {
+	// Number of txs accepted by the chain when the summary was taken
+	Height uint64 `serialize:"true"`
+	// Digest of the committed state once [Height] txs were accepted
+	Digest ids.ID `serialize:"true"`
+}
+
+// stateSummary commits to the state of the chain once a number of txs were
+// accepted, so that nodes can agree on a state to download instead of
+// replaying the DAG.
+type stateSummary struct {
+	vm      *VM
+	content stateSummaryContent
+	id      ids.ID
+	bytes   []byte
+}
+
+func (vm *VM) newStateSummary(content stateSummaryContent) (*stateSummary, error) {
+	bytes, err := vm.parser.Codec().Marshal(txs.CodecVersion, &content)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal state summary: %w", err)
+	}
+	return &stateSummary{
+		vm:      vm,
+		content: content,
+		id:      hashing.ComputeHash256Array(bytes),
+		bytes:   bytes,
+	}, nil
+}
+
+func (s *stateSummary) ID() ids.ID     { return s.id }
+func (s *stateSummary) Height() uint64 { return s.content.Height }
+func (s *stateSummary) Bytes() []byte  { return s.bytes }
+
+// Accept skips state sync, as the DAG engine doesn't support syncing to a
+// summary yet. The chain bootstraps by fetching the DAG instead.
+func (s *stateSummary) Accept() (bool, error) {
+	s.vm.ctx.Log.Info("skipping state sync to summary %s at height %d", s.id, s.content.Height)
+	return false, nil
+}
+
+// StateSyncEnabled returns false, as this VM can serve state summaries but
+// can't sync to them yet.
+func (vm *VM) StateSyncEnabled() (bool, error) { return false, nil }
+
+// GetOngoingSyncStateSummary returns database.ErrNotFound, as this VM never
+// starts syncing to a summary.
+func (vm *VM) GetOngoingSyncStateSummary() (block.StateSummary, error) {
+	return nil, database.ErrNotFound
+}
+
+// GetLastStateSummary returns a summary of the committed state at the current
+// height. Only committed state is included.
+func (vm *VM) GetLastStateSummary() (block.StateSummary, error) {
+	height, err := vm.state.NumAccepted()
+	if err != nil {
+		return nil, err
+	}
+	digest, err := vm.StateDigest()
+	if err != nil {
+		return nil, err
+	}
+	return vm.newStateSummary(stateSummaryContent{
+		Height: height,
+		Digest: digest,
+	})
+}
+
+// ParseStateSummary parses a summary returned by GetLastStateSummary or
+// GetStateSummary.
+func (vm *VM) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
+	content := stateSummaryContent{}
+	if _, err := vm.parser.Codec().Unmarshal(summaryBytes, &content); err != nil {
+		return nil, fmt.Errorf("couldn't parse state summary: %w", err)
+	}
+	return &stateSummary{
+		vm:      vm,
+		content: content,
+		id:      hashing.ComputeHash256Array(summaryBytes),
+		bytes:   summaryBytes,
+	}, nil
+}
+
+// GetStateSummary returns a summary of the committed state at [height]. As
+// past states aren't retained, only the current height is served.
+func (vm *VM) GetStateSummary(height uint64) (block.StateSummary, error) {
+	numAccepted, err := vm.state.NumAccepted()
+	if err != nil {
+		return nil, err
+	}
+	if height != numAccepted {
+		return nil, database.ErrNotFound
+	}
+	return vm.GetLastStateSummary()
+}
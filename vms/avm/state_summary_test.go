@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database"
+)
+
+func TestStateSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	enabled, err := vm.StateSyncEnabled()
+	assert.NoError(err)
+	assert.False(enabled)
+
+	_, err = vm.GetOngoingSyncStateSummary()
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	summary, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	height := summary.Height()
+
+	parsed, err := vm.ParseStateSummary(summary.Bytes())
+	assert.NoError(err)
+	assert.Equal(summary.ID(), parsed.ID())
+	assert.Equal(height, parsed.Height())
+
+	current, err := vm.GetStateSummary(height)
+	assert.NoError(err)
+	assert.Equal(summary.ID(), current.ID())
+
+	_, err = vm.GetStateSummary(height + 1)
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	// Accepting a tx changes the state that the summary commits to
+	newTx := NewTx(t, genesisBytes, vm)
+	tx, err := vm.ParseTx(newTx.Bytes())
+	assert.NoError(err)
+	assert.NoError(tx.Verify())
+	assert.NoError(tx.Accept())
+
+	next, err := vm.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(height+1, next.Height())
+	assert.NotEqual(summary.ID(), next.ID())
+
+	// The chain bootstraps by fetching the DAG rather than syncing
+	accepted, err := next.Accept()
+	assert.NoError(err)
+	assert.False(accepted)
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+)
+
+var (
+	numAcceptedKey = []byte("numAccepted")
+	heightPrefix   = []byte("height")
+
+	_ AcceptanceState = &acceptanceState{}
+)
+
+// Acceptance describes when a transaction was accepted.
+type Acceptance struct {
+	// Number of transactions that were accepted on this chain, and recorded,
+	// before this one
+	Height uint64 `serialize:"true"`
+	// Unix time, in seconds, of when this node accepted the transaction
+	Timestamp int64 `serialize:"true"`
+}
+
+// AcceptanceState records the order and time in which transactions were
+// accepted. Transactions accepted before the node started recording
+// acceptances have no record.
+type AcceptanceState interface {
+	// GetAcceptance attempts to load the acceptance record of a transaction
+	// from storage.
+	GetAcceptance(txID ids.ID) (*Acceptance, error)
+
+	// PutAcceptance saves the acceptance record of a transaction to storage.
+	// The number of accepted transactions becomes [acceptance.Height] + 1.
+	PutAcceptance(txID ids.ID, acceptance *Acceptance) error
+
+	// NumAccepted returns the number of recorded acceptances, which is the
+	// height of the next accepted transaction.
+	NumAccepted() (uint64, error)
+
+	// GetAcceptedTxID returns the ID of the transaction accepted at [height].
+	// Returns database.ErrNotFound if the ID wasn't recorded or was deleted.
+	GetAcceptedTxID(height uint64) (ids.ID, error)
+
+	// PutAcceptedTxID records that [txID] was accepted at [height]. Unlike
+	// acceptance records, these are only kept for recent heights.
+	PutAcceptedTxID(height uint64, txID ids.ID) error
+
+	// DeleteAcceptedTxID removes the record of the transaction accepted at
+	// [height].
+	DeleteAcceptedTxID(height uint64) error
+}
+
+type acceptanceState struct {
+	parser   txs.Parser
+	db       database.Database
+	heightDB database.Database
+}
+
+func NewAcceptanceState(db database.Database, parser txs.Parser) AcceptanceState {
+	return &acceptanceState{
+		parser:   parser,
+		db:       db,
+		heightDB: prefixdb.New(heightPrefix, db),
+	}
+}
+
+func (s *acceptanceState) GetAcceptance(txID ids.ID) (*Acceptance, error) {
+	acceptanceBytes, err := s.db.Get(txID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	acceptance := &Acceptance{}
+	if _, err := s.parser.Codec().Unmarshal(acceptanceBytes, acceptance); err != nil {
+		return nil, err
+	}
+	return acceptance, nil
+}
+
+func (s *acceptanceState) PutAcceptance(txID ids.ID, acceptance *Acceptance) error {
+	acceptanceBytes, err := s.parser.Codec().Marshal(txs.CodecVersion, acceptance)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Put(txID[:], acceptanceBytes); err != nil {
+		return err
+	}
+	return database.PutUInt64(s.db, numAcceptedKey, acceptance.Height+1)
+}
+
+func (s *acceptanceState) NumAccepted() (uint64, error) {
+	numAccepted, err := database.GetUInt64(s.db, numAcceptedKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return numAccepted, err
+}
+
+func (s *acceptanceState) GetAcceptedTxID(height uint64) (ids.ID, error) {
+	txIDBytes, err := s.heightDB.Get(database.PackUInt64(height))
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ToID(txIDBytes)
+}
+
+func (s *acceptanceState) PutAcceptedTxID(height uint64, txID ids.ID) error {
+	return s.heightDB.Put(database.PackUInt64(height), txID[:])
+}
+
+func (s *acceptanceState) DeleteAcceptedTxID(height uint64) error {
+	return s.heightDB.Delete(database.PackUInt64(height))
+}
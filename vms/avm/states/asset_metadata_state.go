@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/beacongo/cache"
+	"github.com/lasthyphen/beacongo/cache/metercacher"
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+)
+
+const assetMetadataCacheSize = 2048
+
+var _ AssetMetadataState = &assetMetadataState{}
+
+// AssetMetadata is the description of an asset, as specified by the
+// transaction that created it.
+type AssetMetadata struct {
+	Name         string `serialize:"true"`
+	Symbol       string `serialize:"true"`
+	Denomination byte   `serialize:"true"`
+	// ID of the transaction that created the asset
+	CreationTxID ids.ID `serialize:"true"`
+}
+
+// AssetMetadataState is a thin wrapper around a database to provide caching,
+// serialization, and de-serialization of asset metadata.
+type AssetMetadataState interface {
+	// GetAssetMetadata attempts to load the metadata of an asset from
+	// storage.
+	GetAssetMetadata(assetID ids.ID) (*AssetMetadata, error)
+
+	// PutAssetMetadata saves the provided asset metadata to storage.
+	PutAssetMetadata(assetID ids.ID, metadata *AssetMetadata) error
+}
+
+type assetMetadataState struct {
+	parser txs.Parser
+
+	// Caches assetID -> *AssetMetadata. If the *AssetMetadata is nil, that
+	// means the metadata is not in storage.
+	metadataCache cache.Cacher
+	metadataDB    database.Database
+}
+
+func NewAssetMetadataState(db database.Database, parser txs.Parser, metrics prometheus.Registerer) (AssetMetadataState, error) {
+	cache, err := metercacher.New(
+		"asset_metadata_cache",
+		metrics,
+		&cache.LRU{Size: assetMetadataCacheSize},
+	)
+	return &assetMetadataState{
+		parser: parser,
+
+		metadataCache: cache,
+		metadataDB:    db,
+	}, err
+}
+
+func (s *assetMetadataState) GetAssetMetadata(assetID ids.ID) (*AssetMetadata, error) {
+	if metadataIntf, found := s.metadataCache.Get(assetID); found {
+		if metadataIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return metadataIntf.(*AssetMetadata), nil
+	}
+
+	metadataBytes, err := s.metadataDB.Get(assetID[:])
+	if err == database.ErrNotFound {
+		s.metadataCache.Put(assetID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &AssetMetadata{}
+	if _, err := s.parser.Codec().Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, err
+	}
+
+	s.metadataCache.Put(assetID, metadata)
+	return metadata, nil
+}
+
+func (s *assetMetadataState) PutAssetMetadata(assetID ids.ID, metadata *AssetMetadata) error {
+	metadataBytes, err := s.parser.Codec().Marshal(txs.CodecVersion, metadata)
+	if err != nil {
+		return err
+	}
+
+	s.metadataCache.Put(assetID, metadata)
+	return s.metadataDB.Put(assetID[:], metadataBytes)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+)
+
+const assetSupplyLen = 2 * wrappers.LongLen
+
+var (
+	assetSupplyAssetPrefix = []byte("asset")
+	assetSupplyBuiltKey    = []byte("built")
+
+	errWrongAssetSupplyLen = errors.New("unexpected asset supply length")
+
+	_ AssetSupplyState = &assetSupplyState{}
+)
+
+// AssetSupply is the amount of an asset that was minted and burned by the
+// accepted transactions.
+type AssetSupply struct {
+	// Saturates at MaxUint64.
+	Minted uint64
+	// Saturates at MaxUint64.
+	Burned uint64
+}
+
+// Circulating returns the amount of the asset that was minted and not burned.
+// Funds that were exported to another chain are still circulating.
+func (s AssetSupply) Circulating() uint64 {
+	if s.Burned > s.Minted {
+		return 0
+	}
+	return s.Minted - s.Burned
+}
+
+// AssetSupplyState maintains the supply of each asset as transactions are
+// accepted, so that it can be read without replaying the operations of the
+// asset.
+type AssetSupplyState interface {
+	// GetAssetSupply returns the supply of [assetID]. The supply of an asset
+	// without any minted funds is empty.
+	GetAssetSupply(assetID ids.ID) (AssetSupply, error)
+
+	// PutAssetSupply saves the supply of [assetID].
+	PutAssetSupply(assetID ids.ID, supply AssetSupply) error
+
+	// IsAssetSupplyBuilt returns true if the supplies account for every
+	// accepted transaction, rather than only those accepted after the
+	// supplies started being maintained.
+	IsAssetSupplyBuilt() (bool, error)
+
+	// SetAssetSupplyBuilt marks the supplies as built.
+	SetAssetSupplyBuilt() error
+}
+
+type assetSupplyState struct {
+	// Stores whether the supplies have been built
+	db database.Database
+	// assetID -> supply
+	assetDB database.Database
+}
+
+func NewAssetSupplyState(db database.Database) AssetSupplyState {
+	return &assetSupplyState{
+		db:      db,
+		assetDB: prefixdb.New(assetSupplyAssetPrefix, db),
+	}
+}
+
+func (s *assetSupplyState) GetAssetSupply(assetID ids.ID) (AssetSupply, error) {
+	supplyBytes, err := s.assetDB.Get(assetID[:])
+	if err == database.ErrNotFound {
+		return AssetSupply{}, nil
+	}
+	if err != nil {
+		return AssetSupply{}, err
+	}
+	if len(supplyBytes) != assetSupplyLen {
+		return AssetSupply{}, errWrongAssetSupplyLen
+	}
+	p := wrappers.Packer{Bytes: supplyBytes}
+	return AssetSupply{
+		Minted: p.UnpackLong(),
+		Burned: p.UnpackLong(),
+	}, p.Err
+}
+
+func (s *assetSupplyState) PutAssetSupply(assetID ids.ID, supply AssetSupply) error {
+	p := wrappers.Packer{Bytes: make([]byte, assetSupplyLen)}
+	p.PackLong(supply.Minted)
+	p.PackLong(supply.Burned)
+	return s.assetDB.Put(assetID[:], p.Bytes)
+}
+
+func (s *assetSupplyState) IsAssetSupplyBuilt() (bool, error) {
+	built, err := database.GetBool(s.db, assetSupplyBuiltKey)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	return built, err
+}
+
+func (s *assetSupplyState) SetAssetSupplyBuilt() error {
+	return database.PutBool(s.db, assetSupplyBuiltKey, true)
+}
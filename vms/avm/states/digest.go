@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"crypto/sha256"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+// Digest returns a deterministic digest of the UTXO set and of the IDs of the
+// accepted transactions stored in [db], which must be the database the state
+// was created with. Statuses of transactions that weren't accepted are
+// excluded because they depend on which transactions a node happened to see.
+func Digest(db database.Database) (ids.ID, error) {
+	utxoDigest, _, err := djtx.UTXODigest(prefixdb.New(utxoPrefix, db))
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	hasher := sha256.New()
+	_, _ = hasher.Write(utxoDigest[:])
+
+	it := prefixdb.New(statusPrefix, db).NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		status, err := database.ParseUInt32(it.Value())
+		if err != nil {
+			return ids.Empty, err
+		}
+		if choices.Status(status) == choices.Accepted {
+			_, _ = hasher.Write(it.Key())
+		}
+	}
+	if err := it.Error(); err != nil {
+		return ids.Empty, err
+	}
+	return ids.ToID(hasher.Sum(nil))
+}
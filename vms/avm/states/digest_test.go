@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database/memdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/vms/avm/fxs"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+func TestDigest(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+	})
+	assert.NoError(err)
+
+	utxo := &djtx.UTXO{
+		UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addrs[0]},
+			},
+		},
+	}
+	acceptedTxID := ids.GenerateTestID()
+
+	newState := func() (*memdb.Database, State) {
+		db := memdb.New()
+		s, err := New(db, parser, prometheus.NewRegistry())
+		assert.NoError(err)
+		assert.NoError(s.PutUTXO(utxo.InputID(), utxo))
+		assert.NoError(s.PutStatus(acceptedTxID, choices.Accepted))
+		return db, s
+	}
+
+	db0, _ := newState()
+	db1, s1 := newState()
+
+	digest0, err := Digest(db0)
+	assert.NoError(err)
+	digest1, err := Digest(db1)
+	assert.NoError(err)
+	assert.Equal(digest0, digest1)
+
+	// Statuses of transactions that weren't accepted are ignored
+	assert.NoError(s1.PutStatus(ids.GenerateTestID(), choices.Processing))
+	assert.NoError(s1.PutStatus(ids.GenerateTestID(), choices.Rejected))
+	digest1, err = Digest(db1)
+	assert.NoError(err)
+	assert.Equal(digest0, digest1)
+
+	assert.NoError(s1.PutStatus(ids.GenerateTestID(), choices.Accepted))
+	digest1, err = Digest(db1)
+	assert.NoError(err)
+	assert.NotEqual(digest0, digest1)
+
+	db2, s2 := newState()
+	assert.NoError(s2.DeleteUTXO(utxo.InputID()))
+	digest2, err := Digest(db2)
+	assert.NoError(err)
+	assert.NotEqual(digest0, digest2)
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/lasthyphen/beacongo/cache"
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+)
+
+const frozenAssetCacheSize = 256
+
+var _ FrozenAssetState = &frozenAssetState{}
+
+// FrozenAssetState records which assets have their transfers frozen.
+type FrozenAssetState interface {
+	// IsFrozen returns true if transfers of [assetID] are frozen.
+	IsFrozen(assetID ids.ID) (bool, error)
+
+	// SetFrozen saves whether transfers of [assetID] are frozen.
+	SetFrozen(assetID ids.ID, frozen bool) error
+}
+
+type frozenAssetState struct {
+	// Caches assetID -> bool
+	frozenCache cache.Cacher
+	frozenDB    database.Database
+}
+
+func NewFrozenAssetState(db database.Database) FrozenAssetState {
+	return &frozenAssetState{
+		frozenCache: &cache.LRU{Size: frozenAssetCacheSize},
+		frozenDB:    db,
+	}
+}
+
+func (s *frozenAssetState) IsFrozen(assetID ids.ID) (bool, error) {
+	if frozenIntf, found := s.frozenCache.Get(assetID); found {
+		return frozenIntf.(bool), nil
+	}
+
+	frozen, err := s.frozenDB.Has(assetID[:])
+	if err != nil {
+		return false, err
+	}
+	s.frozenCache.Put(assetID, frozen)
+	return frozen, nil
+}
+
+func (s *frozenAssetState) SetFrozen(assetID ids.ID, frozen bool) error {
+	s.frozenCache.Put(assetID, frozen)
+	if frozen {
+		return s.frozenDB.Put(assetID[:], nil)
+	}
+	return s.frozenDB.Delete(assetID[:])
+}
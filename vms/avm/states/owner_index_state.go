@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/lasthyphen/beacongo/cache"
+	"github.com/lasthyphen/beacongo/codec"
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/linkeddb"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+const ownerIndexCacheSize = 64
+
+var (
+	ownerIndexPrefix   = []byte("index")
+	ownerIndexBuiltKey = []byte("built")
+
+	_ OwnerIndexState = &ownerIndexState{}
+)
+
+// OwnerIndexState indexes the UTXOs that are owned by more than one address,
+// along with the number of signatures needed to spend them.
+type OwnerIndexState interface {
+	// MultisigUTXOIDs returns the IDs of UTXOs that are owned by [addr] and
+	// at least one other address, and that need at least [minThreshold]
+	// signatures to be spent, starting after [previous].
+	// If [previous] is not in the list, starts at beginning.
+	// Returns at most [limit] IDs.
+	MultisigUTXOIDs(addr []byte, minThreshold uint32, previous ids.ID, limit int) ([]ids.ID, error)
+
+	// PutOwners indexes [utxo] under each of its owners.
+	PutOwners(utxoID ids.ID, utxo *djtx.UTXO) error
+
+	// DeleteOwners removes [utxo] from the index.
+	DeleteOwners(utxoID ids.ID, utxo *djtx.UTXO) error
+}
+
+type ownerIndexState struct {
+	// Stores whether the index has been built from the existing UTXOs
+	db database.Database
+
+	indexDB    database.Database
+	indexCache cache.Cacher
+}
+
+// NewOwnerIndexState returns the owner index stored in [db]. If the index
+// hasn't been built yet, every UTXO stored in [utxoDB] is indexed.
+func NewOwnerIndexState(db, utxoDB database.Database, c codec.Manager) (OwnerIndexState, error) {
+	s := &ownerIndexState{
+		db: db,
+
+		indexDB:    prefixdb.New(ownerIndexPrefix, db),
+		indexCache: &cache.LRU{Size: ownerIndexCacheSize},
+	}
+
+	built, err := database.GetBool(db, ownerIndexBuiltKey)
+	if err != nil && err != database.ErrNotFound {
+		return nil, err
+	}
+	if built {
+		return s, nil
+	}
+
+	err = djtx.ForEachUTXO(utxoDB, c, func(utxo *djtx.UTXO) error {
+		return s.PutOwners(utxo.InputID(), utxo)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, database.PutBool(db, ownerIndexBuiltKey, true)
+}
+
+func (s *ownerIndexState) MultisigUTXOIDs(addr []byte, minThreshold uint32, start ids.ID, limit int) ([]ids.ID, error) {
+	indexList := s.getIndexDB(addr)
+	iter := indexList.NewIteratorWithStart(start[:])
+	defer iter.Release()
+
+	utxoIDs := []ids.ID(nil)
+	for len(utxoIDs) < limit && iter.Next() {
+		utxoID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if utxoID == start {
+			continue
+		}
+		start = ids.Empty
+
+		threshold, err := database.ParseUInt32(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if threshold < minThreshold {
+			continue
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, iter.Error()
+}
+
+func (s *ownerIndexState) PutOwners(utxoID ids.ID, utxo *djtx.UTXO) error {
+	owned, ok := utxo.Out.(djtx.ThresholdOwned)
+	if !ok {
+		return nil
+	}
+	addresses := owned.Addresses()
+	if len(addresses) < 2 {
+		return nil
+	}
+
+	thresholdBytes := database.PackUInt32(owned.SpendThreshold())
+	for _, addr := range addresses {
+		indexList := s.getIndexDB(addr)
+		if err := indexList.Put(utxoID[:], thresholdBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ownerIndexState) DeleteOwners(utxoID ids.ID, utxo *djtx.UTXO) error {
+	owned, ok := utxo.Out.(djtx.ThresholdOwned)
+	if !ok {
+		return nil
+	}
+	addresses := owned.Addresses()
+	if len(addresses) < 2 {
+		return nil
+	}
+
+	for _, addr := range addresses {
+		indexList := s.getIndexDB(addr)
+		if err := indexList.Delete(utxoID[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ownerIndexState) getIndexDB(addr []byte) linkeddb.LinkedDB {
+	addrStr := string(addr)
+	if indexList, exists := s.indexCache.Get(addrStr); exists {
+		return indexList.(linkeddb.LinkedDB)
+	}
+
+	indexDB := prefixdb.NewNested(addr, s.indexDB)
+	indexList := linkeddb.NewDefault(indexDB)
+	s.indexCache.Put(addrStr, indexList)
+	return indexList
+}
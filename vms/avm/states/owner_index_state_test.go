@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database/memdb"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/fxs"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+func newOwnedUTXO(threshold uint32, owners ...ids.ShortID) *djtx.UTXO {
+	return &djtx.UTXO{
+		UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: threshold,
+				Addrs:     owners,
+			},
+		},
+	}
+}
+
+func TestOwnerIndexState(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+	})
+	assert.NoError(err)
+
+	s, err := New(memdb.New(), parser, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	single := newOwnedUTXO(1, addrs[0])
+	oneOfTwo := newOwnedUTXO(1, addrs[0], addrs[1])
+	twoOfThree := newOwnedUTXO(2, addrs[0], addrs[1], addrs[2])
+	for _, utxo := range []*djtx.UTXO{single, oneOfTwo, twoOfThree} {
+		assert.NoError(s.PutUTXO(utxo.InputID(), utxo))
+	}
+
+	utxoIDs, err := s.MultisigUTXOIDs(addrs[0].Bytes(), 1, ids.Empty, 10)
+	assert.NoError(err)
+	assert.ElementsMatch([]ids.ID{oneOfTwo.InputID(), twoOfThree.InputID()}, utxoIDs)
+
+	utxoIDs, err = s.MultisigUTXOIDs(addrs[2].Bytes(), 2, ids.Empty, 10)
+	assert.NoError(err)
+	assert.Equal([]ids.ID{twoOfThree.InputID()}, utxoIDs)
+
+	utxoIDs, err = s.MultisigUTXOIDs(addrs[0].Bytes(), 3, ids.Empty, 10)
+	assert.NoError(err)
+	assert.Empty(utxoIDs)
+
+	// Spent UTXOs are removed from the index
+	assert.NoError(s.DeleteUTXO(twoOfThree.InputID()))
+	utxoIDs, err = s.MultisigUTXOIDs(addrs[1].Bytes(), 1, ids.Empty, 10)
+	assert.NoError(err)
+	assert.Equal([]ids.ID{oneOfTwo.InputID()}, utxoIDs)
+}
+
+func TestOwnerIndexStateBuildsFromExistingUTXOs(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+	})
+	assert.NoError(err)
+
+	// UTXOs stored before the owner index existed
+	db := memdb.New()
+	utxoState := djtx.NewUTXOState(prefixdb.New(utxoPrefix, db), parser.Codec())
+	utxo := newOwnedUTXO(2, addrs[0], addrs[1])
+	assert.NoError(utxoState.PutUTXO(utxo.InputID(), utxo))
+
+	s, err := New(db, parser, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	utxoIDs, err := s.MultisigUTXOIDs(addrs[1].Bytes(), 2, ids.Empty, 10)
+	assert.NoError(err)
+	assert.Equal([]ids.ID{utxo.InputID()}, utxoIDs)
+}
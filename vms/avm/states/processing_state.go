@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"time"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+)
+
+var _ ProcessingState = &processingState{}
+
+// ProcessingState records when transactions started processing, so that
+// transactions that are never decided can be found and removed.
+type ProcessingState interface {
+	// PutProcessing records that [txID] started processing at [timestamp].
+	PutProcessing(txID ids.ID, timestamp time.Time) error
+
+	// DeleteProcessing removes the record of [txID], if there is one.
+	DeleteProcessing(txID ids.ID) error
+
+	// ForEachProcessing calls [f] with every recorded transaction, and the
+	// time it started processing, in order of their IDs. [f] must not modify
+	// the state.
+	ForEachProcessing(f func(txID ids.ID, timestamp time.Time) error) error
+}
+
+type processingState struct {
+	db database.Database
+}
+
+func NewProcessingState(db database.Database) ProcessingState {
+	return &processingState{
+		db: db,
+	}
+}
+
+func (s *processingState) PutProcessing(txID ids.ID, timestamp time.Time) error {
+	return database.PutTimestamp(s.db, txID[:], timestamp)
+}
+
+func (s *processingState) DeleteProcessing(txID ids.ID) error {
+	return s.db.Delete(txID[:])
+}
+
+func (s *processingState) ForEachProcessing(f func(txID ids.ID, timestamp time.Time) error) error {
+	it := s.db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		txID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+		timestamp, err := database.ParseTimestamp(it.Value())
+		if err != nil {
+			return err
+		}
+		if err := f(txID, timestamp); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
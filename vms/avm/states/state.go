@@ -6,28 +6,57 @@ package states
 import (
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/lasthyphen/beacongo/codec"
 	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
 	"github.com/lasthyphen/beacongo/vms/avm/txs"
 	"github.com/lasthyphen/beacongo/vms/components/djtx"
 )
 
 var (
-	utxoPrefix      = []byte("utxo")
-	statusPrefix    = []byte("status")
-	singletonPrefix = []byte("singleton")
-	txPrefix        = []byte("tx")
+	utxoPrefix       = []byte("utxo")
+	statusPrefix     = []byte("status")
+	singletonPrefix  = []byte("singleton")
+	txPrefix         = []byte("tx")
+	assetPrefix      = []byte("assetMetadata")
+	ownerPrefix      = []byte("owner")
+	acceptedPrefix   = []byte("accepted")
+	utxoStatsPrefix  = []byte("utxoStats")
+	frozenPrefix     = []byte("frozen")
+	supplyPrefix     = []byte("assetSupply")
+	processingPrefix = []byte("processing")
 
 	_ State = &state{}
 )
 
-// State persistently maintains a set of UTXOs, transaction, statuses, and
-// singletons.
+// State persistently maintains a set of UTXOs, transaction, statuses,
+// singletons, asset metadata, acceptance records, frozen assets, asset
+// supplies, and the times transactions started processing. UTXOs owned by multiple addresses are also indexed by their owners,
+// and statistics of the UTXO set are maintained as UTXOs are added and
+// removed.
 type State interface {
 	djtx.UTXOState
 	djtx.StatusState
 	djtx.SingletonState
 	TxState
+	AssetMetadataState
+	OwnerIndexState
+	AcceptanceState
+	UTXOStatsState
+	FrozenAssetState
+	AssetSupplyState
+	ProcessingState
+
+	// ForEachUTXO calls [f] with every UTXO in the UTXO set, in order of
+	// their IDs.
+	ForEachUTXO(f func(*djtx.UTXO) error) error
+
+	// ForEachStatus calls [f] with the ID and status of every transaction
+	// that has a status, in order of their IDs. [f] must not modify the
+	// state.
+	ForEachStatus(f func(txID ids.ID, status choices.Status) error) error
 }
 
 type state struct {
@@ -35,6 +64,17 @@ type state struct {
 	djtx.StatusState
 	djtx.SingletonState
 	TxState
+	AssetMetadataState
+	OwnerIndexState
+	AcceptanceState
+	UTXOStatsState
+	FrozenAssetState
+	AssetSupplyState
+	ProcessingState
+
+	utxoDB   database.Database
+	statusDB database.Database
+	codec    codec.Manager
 }
 
 func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer) (State, error) {
@@ -42,6 +82,13 @@ func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer)
 	statusDB := prefixdb.New(statusPrefix, db)
 	singletonDB := prefixdb.New(singletonPrefix, db)
 	txDB := prefixdb.New(txPrefix, db)
+	assetDB := prefixdb.New(assetPrefix, db)
+	ownerDB := prefixdb.New(ownerPrefix, db)
+	acceptedDB := prefixdb.New(acceptedPrefix, db)
+	utxoStatsDB := prefixdb.New(utxoStatsPrefix, db)
+	frozenDB := prefixdb.New(frozenPrefix, db)
+	supplyDB := prefixdb.New(supplyPrefix, db)
+	processingDB := prefixdb.New(processingPrefix, db)
 
 	utxoState, err := djtx.NewMeteredUTXOState(utxoDB, parser.Codec(), metrics)
 	if err != nil {
@@ -54,10 +101,83 @@ func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer)
 	}
 
 	txState, err := NewTxState(txDB, parser, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	assetMetadataState, err := NewAssetMetadataState(assetDB, parser, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerIndexState, err := NewOwnerIndexState(ownerDB, utxoDB, parser.Codec())
+	if err != nil {
+		return nil, err
+	}
+
+	utxoStatsState, err := NewUTXOStatsState(utxoStatsDB, utxoDB, parser.Codec())
 	return &state{
-		UTXOState:      utxoState,
-		StatusState:    statusState,
-		SingletonState: djtx.NewSingletonState(singletonDB),
-		TxState:        txState,
+		UTXOState:          utxoState,
+		StatusState:        statusState,
+		SingletonState:     djtx.NewSingletonState(singletonDB),
+		TxState:            txState,
+		AssetMetadataState: assetMetadataState,
+		OwnerIndexState:    ownerIndexState,
+		AcceptanceState:    NewAcceptanceState(acceptedDB, parser),
+		UTXOStatsState:     utxoStatsState,
+		FrozenAssetState:   NewFrozenAssetState(frozenDB),
+		AssetSupplyState:   NewAssetSupplyState(supplyDB),
+		ProcessingState:    NewProcessingState(processingDB),
+		utxoDB:             utxoDB,
+		statusDB:           statusDB,
+		codec:              parser.Codec(),
 	}, err
 }
+
+func (s *state) PutUTXO(utxoID ids.ID, utxo *djtx.UTXO) error {
+	if err := s.UTXOState.PutUTXO(utxoID, utxo); err != nil {
+		return err
+	}
+	if err := s.PutOwners(utxoID, utxo); err != nil {
+		return err
+	}
+	return s.AddUTXOStats(utxo)
+}
+
+func (s *state) DeleteUTXO(utxoID ids.ID) error {
+	utxo, err := s.GetUTXO(utxoID)
+	if err != nil {
+		return err
+	}
+	if err := s.UTXOState.DeleteUTXO(utxoID); err != nil {
+		return err
+	}
+	if err := s.DeleteOwners(utxoID, utxo); err != nil {
+		return err
+	}
+	return s.RemoveUTXOStats(utxo)
+}
+
+func (s *state) ForEachUTXO(f func(*djtx.UTXO) error) error {
+	return djtx.ForEachUTXO(s.utxoDB, s.codec, f)
+}
+
+func (s *state) ForEachStatus(f func(txID ids.ID, status choices.Status) error) error {
+	it := s.statusDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		txID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+		status, err := database.ParseUInt32(it.Value())
+		if err != nil {
+			return err
+		}
+		if err := f(txID, choices.Status(status)); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
@@ -28,6 +28,10 @@ type TxState interface {
 
 	// DeleteTx removes the provided transaction from storage.
 	DeleteTx(txID ids.ID) error
+
+	// TxIDs returns up to [limit] IDs of stored transactions, in increasing
+	// order, starting at [start].
+	TxIDs(start ids.ID, limit int) ([]ids.ID, error)
 }
 
 type txState struct {
@@ -89,3 +93,18 @@ func (s *txState) DeleteTx(txID ids.ID) error {
 	s.txCache.Put(txID, nil)
 	return s.txDB.Delete(txID[:])
 }
+
+func (s *txState) TxIDs(start ids.ID, limit int) ([]ids.ID, error) {
+	it := s.txDB.NewIteratorWithStart(start[:])
+	defer it.Release()
+
+	txIDs := []ids.ID(nil)
+	for len(txIDs) < limit && it.Next() {
+		txID, err := ids.ToID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, it.Error()
+}
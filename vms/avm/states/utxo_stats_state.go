@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"errors"
+	"math"
+
+	"github.com/lasthyphen/beacongo/codec"
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+const assetUTXOStatsLen = 2 * wrappers.LongLen
+
+var (
+	// UTXOSizeBuckets are the inclusive upper bounds, in bytes, of the buckets
+	// of the UTXO size histogram. UTXOs larger than the last bound are counted
+	// in an additional bucket.
+	UTXOSizeBuckets = []uint64{128, 256, 512, 1024, 4096}
+
+	utxoStatsAssetPrefix = []byte("asset")
+	utxoStatsKey         = []byte("utxos")
+	utxoStatsBuiltKey    = []byte("built")
+
+	errWrongUTXOStatsLen = errors.New("unexpected UTXO stats length")
+
+	_ UTXOStatsState = &utxoStatsState{}
+)
+
+// UTXOStats summarizes the current UTXO set.
+type UTXOStats struct {
+	NumUTXOs uint64
+	// SizeHistogram[i] is the number of UTXOs in the i-th bucket of
+	// UTXOSizeBuckets. The last entry is the number of UTXOs larger than every
+	// bucket.
+	SizeHistogram []uint64
+}
+
+// AssetUTXOStats summarizes the UTXOs of an asset in the current UTXO set.
+type AssetUTXOStats struct {
+	NumUTXOs uint64
+	// Total amount held by the UTXOs of the asset. Saturates at MaxUint64.
+	Amount uint64
+}
+
+// UTXOStatsState incrementally maintains statistics of the UTXO set, so that
+// they can be read without scanning every UTXO.
+type UTXOStatsState interface {
+	// UTXOStats returns the statistics of the whole UTXO set.
+	UTXOStats() (*UTXOStats, error)
+
+	// AssetUTXOStats returns the statistics of the UTXOs of [assetID].
+	AssetUTXOStats(assetID ids.ID) (AssetUTXOStats, error)
+
+	// ForEachAssetUTXOStats calls [f] with the statistics of every asset that
+	// has UTXOs in the UTXO set.
+	ForEachAssetUTXOStats(f func(assetID ids.ID, stats AssetUTXOStats) error) error
+
+	// AddUTXOStats accounts for [utxo] being added to the UTXO set.
+	AddUTXOStats(utxo *djtx.UTXO) error
+
+	// RemoveUTXOStats accounts for [utxo] being removed from the UTXO set.
+	RemoveUTXOStats(utxo *djtx.UTXO) error
+}
+
+type utxoStatsState struct {
+	codec codec.Manager
+
+	// Stores the stats of the whole UTXO set and whether they have been
+	// built from the existing UTXOs
+	db database.Database
+
+	assetDB database.Database
+}
+
+// NewUTXOStatsState returns the UTXO set statistics stored in [db]. If they
+// haven't been built yet, every UTXO stored in [utxoDB] is accounted for.
+func NewUTXOStatsState(db, utxoDB database.Database, c codec.Manager) (UTXOStatsState, error) {
+	s := &utxoStatsState{
+		codec:   c,
+		db:      db,
+		assetDB: prefixdb.New(utxoStatsAssetPrefix, db),
+	}
+
+	built, err := database.GetBool(db, utxoStatsBuiltKey)
+	if err != nil && err != database.ErrNotFound {
+		return nil, err
+	}
+	if built {
+		return s, nil
+	}
+
+	if err := djtx.ForEachUTXO(utxoDB, c, s.AddUTXOStats); err != nil {
+		return nil, err
+	}
+	return s, database.PutBool(db, utxoStatsBuiltKey, true)
+}
+
+func (s *utxoStatsState) UTXOStats() (*UTXOStats, error) {
+	stats := &UTXOStats{
+		SizeHistogram: make([]uint64, len(UTXOSizeBuckets)+1),
+	}
+	statsBytes, err := s.db.Get(utxoStatsKey)
+	if err == database.ErrNotFound {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(statsBytes) != (len(stats.SizeHistogram)+1)*wrappers.LongLen {
+		return nil, errWrongUTXOStatsLen
+	}
+
+	p := wrappers.Packer{Bytes: statsBytes}
+	stats.NumUTXOs = p.UnpackLong()
+	for i := range stats.SizeHistogram {
+		stats.SizeHistogram[i] = p.UnpackLong()
+	}
+	return stats, p.Err
+}
+
+func (s *utxoStatsState) AssetUTXOStats(assetID ids.ID) (AssetUTXOStats, error) {
+	statsBytes, err := s.assetDB.Get(assetID[:])
+	if err == database.ErrNotFound {
+		return AssetUTXOStats{}, nil
+	}
+	if err != nil {
+		return AssetUTXOStats{}, err
+	}
+	return parseAssetUTXOStats(statsBytes)
+}
+
+func (s *utxoStatsState) ForEachAssetUTXOStats(f func(assetID ids.ID, stats AssetUTXOStats) error) error {
+	it := s.assetDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		assetID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+		stats, err := parseAssetUTXOStats(it.Value())
+		if err != nil {
+			return err
+		}
+		if err := f(assetID, stats); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func (s *utxoStatsState) AddUTXOStats(utxo *djtx.UTXO) error {
+	return s.update(utxo, true)
+}
+
+func (s *utxoStatsState) RemoveUTXOStats(utxo *djtx.UTXO) error {
+	return s.update(utxo, false)
+}
+
+func (s *utxoStatsState) update(utxo *djtx.UTXO, add bool) error {
+	utxoBytes, err := s.codec.Marshal(txs.CodecVersion, utxo)
+	if err != nil {
+		return err
+	}
+
+	stats, err := s.UTXOStats()
+	if err != nil {
+		return err
+	}
+	bucket := sizeBucket(uint64(len(utxoBytes)))
+	stats.NumUTXOs = adjust(stats.NumUTXOs, 1, add)
+	stats.SizeHistogram[bucket] = adjust(stats.SizeHistogram[bucket], 1, add)
+
+	p := wrappers.Packer{Bytes: make([]byte, (len(stats.SizeHistogram)+1)*wrappers.LongLen)}
+	p.PackLong(stats.NumUTXOs)
+	for _, count := range stats.SizeHistogram {
+		p.PackLong(count)
+	}
+	if err := s.db.Put(utxoStatsKey, p.Bytes); err != nil {
+		return err
+	}
+
+	assetID := utxo.AssetID()
+	assetStats, err := s.AssetUTXOStats(assetID)
+	if err != nil {
+		return err
+	}
+	assetStats.NumUTXOs = adjust(assetStats.NumUTXOs, 1, add)
+	if out, ok := utxo.Out.(djtx.Amounter); ok {
+		assetStats.Amount = adjust(assetStats.Amount, out.Amount(), add)
+	}
+	if assetStats.NumUTXOs == 0 {
+		return s.assetDB.Delete(assetID[:])
+	}
+
+	p = wrappers.Packer{Bytes: make([]byte, assetUTXOStatsLen)}
+	p.PackLong(assetStats.NumUTXOs)
+	p.PackLong(assetStats.Amount)
+	return s.assetDB.Put(assetID[:], p.Bytes)
+}
+
+func parseAssetUTXOStats(b []byte) (AssetUTXOStats, error) {
+	if len(b) != assetUTXOStatsLen {
+		return AssetUTXOStats{}, errWrongUTXOStatsLen
+	}
+	p := wrappers.Packer{Bytes: b}
+	return AssetUTXOStats{
+		NumUTXOs: p.UnpackLong(),
+		Amount:   p.UnpackLong(),
+	}, p.Err
+}
+
+// sizeBucket returns the index of the histogram bucket of a UTXO of [size]
+// bytes.
+func sizeBucket(size uint64) int {
+	for i, bound := range UTXOSizeBuckets {
+		if size <= bound {
+			return i
+		}
+	}
+	return len(UTXOSizeBuckets)
+}
+
+// adjust returns [value] increased or decreased by [delta], saturating at 0
+// and MaxUint64.
+func adjust(value, delta uint64, add bool) uint64 {
+	if add {
+		newValue, err := safemath.Add64(value, delta)
+		if err != nil {
+			return math.MaxUint64
+		}
+		return newValue
+	}
+	newValue, err := safemath.Sub64(value, delta)
+	if err != nil {
+		return 0
+	}
+	return newValue
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database/memdb"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/fxs"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+func TestUTXOStatsState(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+	})
+	assert.NoError(err)
+
+	// UTXOs stored before the stats were maintained
+	db := memdb.New()
+	utxoState := djtx.NewUTXOState(prefixdb.New(utxoPrefix, db), parser.Codec())
+	existing := newOwnedUTXO(1, ids.GenerateTestShortID())
+	assert.NoError(utxoState.PutUTXO(existing.InputID(), existing))
+
+	s, err := New(db, parser, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	otherAssetID := ids.GenerateTestID()
+	other := &djtx.UTXO{
+		UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  djtx.Asset{ID: otherAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 5,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}
+	assert.NoError(s.PutUTXO(other.InputID(), other))
+
+	stats, err := s.UTXOStats()
+	assert.NoError(err)
+	assert.Equal(uint64(2), stats.NumUTXOs)
+	assert.Len(stats.SizeHistogram, len(UTXOSizeBuckets)+1)
+	// Both UTXOs have a single owner, so they're in the smallest bucket
+	assert.Equal(uint64(2), stats.SizeHistogram[0])
+
+	assetStats, err := s.AssetUTXOStats(otherAssetID)
+	assert.NoError(err)
+	assert.Equal(AssetUTXOStats{NumUTXOs: 1, Amount: 5}, assetStats)
+
+	numAssets := 0
+	assert.NoError(s.ForEachAssetUTXOStats(func(ids.ID, AssetUTXOStats) error {
+		numAssets++
+		return nil
+	}))
+	assert.Equal(2, numAssets)
+
+	// Spent UTXOs are no longer accounted for
+	assert.NoError(s.DeleteUTXO(other.InputID()))
+	stats, err = s.UTXOStats()
+	assert.NoError(err)
+	assert.Equal(uint64(1), stats.NumUTXOs)
+	assert.Equal(uint64(1), stats.SizeHistogram[0])
+
+	assetStats, err = s.AssetUTXOStats(otherAssetID)
+	assert.NoError(err)
+	assert.Equal(AssetUTXOStats{}, assetStats)
+
+	// The existing UTXOs are only accounted for once
+	s, err = New(db, parser, prometheus.NewRegistry())
+	assert.NoError(err)
+	assetStats, err = s.AssetUTXOStats(assetID)
+	assert.NoError(err)
+	assert.Equal(AssetUTXOStats{NumUTXOs: 1, Amount: 1}, assetStats)
+}
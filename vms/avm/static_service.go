@@ -25,6 +25,7 @@ import (
 
 var (
 	errUnknownAssetType = errors.New("unknown asset type")
+	errUnknownFeeAsset  = errors.New("fee asset isn't defined in the genesis data")
 
 	_ djtx.TransferableIn  = &secp256k1fx.TransferInput{}
 	_ verify.State         = &secp256k1fx.MintOutput{}
@@ -56,7 +57,10 @@ func CreateStaticService() *StaticService {
 type BuildGenesisArgs struct {
 	NetworkID   json.Uint32                `json:"networkID"`
 	GenesisData map[string]AssetDefinition `json:"genesisData"`
-	Encoding    formatting.Encoding        `json:"encoding"`
+	// Alias, in [GenesisData], of the asset that fees are paid in. If empty,
+	// the fee asset is selected by the chain.
+	FeeAsset string              `json:"feeAsset"`
+	Encoding formatting.Encoding `json:"encoding"`
 }
 
 type AssetDefinition struct {
@@ -180,7 +184,14 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 	}
 	g.Sort()
 
-	b, err := genesisCodec.Marshal(txs.CodecVersion, &g)
+	if args.FeeAsset != "" {
+		if _, ok := args.GenesisData[args.FeeAsset]; !ok {
+			return fmt.Errorf("%w: %q", errUnknownFeeAsset, args.FeeAsset)
+		}
+		g.FeeAsset = args.FeeAsset
+	}
+
+	b, err := MarshalGenesis(genesisCodec, &g)
 	if err != nil {
 		return fmt.Errorf("problem marshaling genesis: %w", err)
 	}
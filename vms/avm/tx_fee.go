@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+var _ txs.Visitor = &feeCalculator{}
+
+// feeCalculator calculates the fee, denominated in the fee asset, that a tx
+// must burn to be valid.
+type feeCalculator struct {
+	txFee            uint64
+	createAssetTxFee uint64
+
+	// Fee is set by visiting the tx
+	fee uint64
+}
+
+func (fc *feeCalculator) BaseTx(*txs.BaseTx) error {
+	fc.fee = fc.txFee
+	return nil
+}
+
+func (fc *feeCalculator) CreateAssetTx(*txs.CreateAssetTx) error {
+	fc.fee = fc.createAssetTxFee
+	return nil
+}
+
+func (fc *feeCalculator) OperationTx(*txs.OperationTx) error {
+	fc.fee = fc.txFee
+	return nil
+}
+
+func (fc *feeCalculator) ImportTx(*txs.ImportTx) error {
+	fc.fee = fc.txFee
+	return nil
+}
+
+func (fc *feeCalculator) ExportTx(*txs.ExportTx) error {
+	fc.fee = fc.txFee
+	return nil
+}
+
+// calculateFee returns the fee [utx] must burn under this VM's fee schedule.
+func (vm *VM) calculateFee(utx txs.UnsignedTx) (uint64, error) {
+	fc := &feeCalculator{
+		txFee:            vm.TxFee,
+		createAssetTxFee: vm.CreateAssetTxFee,
+	}
+	err := utx.Visit(fc)
+	return fc.fee, err
+}
+
+// emptyTxOfType returns an unpopulated tx of the named type.
+func emptyTxOfType(txType string) (txs.UnsignedTx, error) {
+	switch txType {
+	case "base":
+		return &txs.BaseTx{}, nil
+	case "createAsset":
+		return &txs.CreateAssetTx{}, nil
+	case "operation":
+		return &txs.OperationTx{}, nil
+	case "import":
+		return &txs.ImportTx{}, nil
+	case "export":
+		return &txs.ExportTx{}, nil
+	default:
+		return nil, fmt.Errorf("unknown tx type %q", txType)
+	}
+}
+
+// addFee adds [fee] to the amount of the fee asset in [amounts]. If [fee] is 0
+// the fee asset isn't added, so that no inputs are selected to pay the fee.
+func (vm *VM) addFee(amounts map[ids.ID]uint64, fee uint64) error {
+	if fee == 0 {
+		return nil
+	}
+	amountWithFee, err := safemath.Add64(amounts[vm.feeAssetID], fee)
+	if err != nil {
+		return fmt.Errorf("problem calculating required spend amount: %w", err)
+	}
+	amounts[vm.feeAssetID] = amountWithFee
+	return nil
+}
+
+// requiresInputs returns true if any of the [amounts] is non-zero.
+func requiresInputs(amounts map[ids.ID]uint64) bool {
+	for _, amount := range amounts {
+		if amount != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// paidFee returns the amount of the fee asset burned by [utx].
+func (vm *VM) paidFee(utx *txs.BaseTx) (uint64, error) {
+	var (
+		consumed uint64
+		produced uint64
+		err      error
+	)
+	for _, in := range utx.Ins {
+		if in.AssetID() != vm.feeAssetID {
+			continue
+		}
+		consumed, err = safemath.Add64(consumed, in.In.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, out := range utx.Outs {
+		if out.AssetID() != vm.feeAssetID {
+			continue
+		}
+		produced, err = safemath.Add64(produced, out.Out.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return safemath.Sub64(consumed, produced)
+}
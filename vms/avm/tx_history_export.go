@@ -0,0 +1,261 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	stdjson "encoding/json"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/avm/states"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+)
+
+// Number of transactions read while holding the context lock
+const historyExportBatchSize = 256
+
+const (
+	historyExportJSON     = "json"
+	historyExportProtobuf = "protobuf"
+)
+
+var errUnknownExportFormat = errors.New("unknown export format")
+
+// txHistoryExporter streams every accepted transaction to the client.
+//
+// Transactions are exported in order of their IDs. The response is either
+// newline-delimited JSON, or a stream of varint length-delimited protobuf
+// messages with the schema:
+//
+//	message ExportedTx {
+//	    bytes  tx_id     = 1;
+//	    bytes  tx        = 2;
+//	    uint64 height    = 3;
+//	    int64  timestamp = 4;
+//	}
+//
+// The height and timestamp are only set for transactions whose acceptance was
+// recorded. If a height or time range is requested, transactions without an
+// acceptance record are skipped.
+//
+// The context lock is only held while a batch of transactions is read, and
+// the next batch isn't read until the previous one was written, so a slow
+// client doesn't block the chain or buffer the whole history in memory.
+type txHistoryExporter struct {
+	vm *VM
+}
+
+// historyExportRange is the inclusive height and time range of the exported
+// transactions. Times are unix times in seconds.
+type historyExportRange struct {
+	filtered    bool
+	startHeight uint64
+	endHeight   uint64
+	startTime   int64
+	endTime     int64
+}
+
+type exportedTx struct {
+	TxID      ids.ID       `json:"txID"`
+	Height    *json.Uint64 `json:"height,omitempty"`
+	Timestamp *int64       `json:"timestamp,omitempty"`
+	Tx        *txs.Tx      `json:"tx"`
+}
+
+func (e *txHistoryExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	format := query.Get("format")
+	switch format {
+	case "", historyExportJSON:
+		format = historyExportJSON
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	case historyExportProtobuf:
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	default:
+		http.Error(w, fmt.Sprintf("%s: %q", errUnknownExportFormat, format), http.StatusBadRequest)
+		return
+	}
+	rng, err := parseHistoryExportRange(query.Get)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	e.vm.ctx.Log.Debug("AVM Admin: exporting accepted txs as %s", format)
+
+	flusher, _ := w.(http.Flusher)
+	cursor := ids.Empty
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		default:
+		}
+
+		e.vm.ctx.Lock.Lock()
+		batch, next, done, err := e.exportBatch(cursor, format, rng)
+		e.vm.ctx.Lock.Unlock()
+		if err != nil {
+			// The response may have already been partially written, so the
+			// status can't be changed.
+			e.vm.ctx.Log.Warn("tx history export failed: %s", err)
+			return
+		}
+		if _, err := w.Write(batch); err != nil {
+			e.vm.ctx.Log.Debug("tx history export stopped: %s", err)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if done {
+			return
+		}
+		cursor = next
+	}
+}
+
+// exportBatch encodes the accepted transactions in the next batch of stored
+// transactions after [cursor]. Returns the encoded transactions, the last
+// transaction read, and true if there are no more transactions.
+//
+// Assumes the context lock is held.
+func (e *txHistoryExporter) exportBatch(cursor ids.ID, format string, rng historyExportRange) ([]byte, ids.ID, bool, error) {
+	txIDs, err := e.vm.state.TxIDs(cursor, historyExportBatchSize)
+	if err != nil {
+		return nil, ids.Empty, false, err
+	}
+	done := len(txIDs) < historyExportBatchSize
+
+	buf := bytes.Buffer{}
+	for _, txID := range txIDs {
+		if txID == cursor && cursor != ids.Empty {
+			// Exported in the previous batch
+			continue
+		}
+		cursor = txID
+
+		status, err := e.vm.state.GetStatus(txID)
+		if err == database.ErrNotFound || (err == nil && status != choices.Accepted) {
+			continue
+		}
+		if err != nil {
+			return nil, ids.Empty, false, err
+		}
+		acceptance, err := e.vm.state.GetAcceptance(txID)
+		if err != nil && err != database.ErrNotFound {
+			return nil, ids.Empty, false, err
+		}
+		if !rng.contains(acceptance) {
+			continue
+		}
+
+		tx, err := e.vm.state.GetTx(txID)
+		if err != nil {
+			return nil, ids.Empty, false, err
+		}
+		if err := e.encode(&buf, txID, tx, acceptance, format); err != nil {
+			return nil, ids.Empty, false, fmt.Errorf("couldn't encode tx %s: %w", txID, err)
+		}
+	}
+	return buf.Bytes(), cursor, done, nil
+}
+
+func (e *txHistoryExporter) encode(buf *bytes.Buffer, txID ids.ID, tx *txs.Tx, acceptance *states.Acceptance, format string) error {
+	if format == historyExportProtobuf {
+		msg := protowire.AppendTag(nil, 1, protowire.BytesType)
+		msg = protowire.AppendBytes(msg, txID[:])
+		msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+		msg = protowire.AppendBytes(msg, tx.Bytes())
+		if acceptance != nil {
+			msg = protowire.AppendTag(msg, 3, protowire.VarintType)
+			msg = protowire.AppendVarint(msg, acceptance.Height)
+			msg = protowire.AppendTag(msg, 4, protowire.VarintType)
+			msg = protowire.AppendVarint(msg, uint64(acceptance.Timestamp))
+		}
+		_, _ = buf.Write(protowire.AppendVarint(nil, uint64(len(msg))))
+		_, _ = buf.Write(msg)
+		return nil
+	}
+
+	err := tx.Visit(&txInit{
+		tx:            tx,
+		ctx:           e.vm.ctx,
+		typeToFxIndex: e.vm.typeToFxIndex,
+		fxs:           e.vm.fxs,
+	})
+	if err != nil {
+		return err
+	}
+	record := exportedTx{
+		TxID: txID,
+		Tx:   tx,
+	}
+	if acceptance != nil {
+		height := json.Uint64(acceptance.Height)
+		record.Height = &height
+		record.Timestamp = &acceptance.Timestamp
+	}
+	recordBytes, err := stdjson.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, _ = buf.Write(recordBytes)
+	return buf.WriteByte('\n')
+}
+
+func parseHistoryExportRange(get func(string) string) (historyExportRange, error) {
+	rng := historyExportRange{
+		endHeight: math.MaxUint64,
+		endTime:   math.MaxInt64,
+	}
+	for _, field := range []struct {
+		name string
+		dst  interface{}
+	}{
+		{"startHeight", &rng.startHeight},
+		{"endHeight", &rng.endHeight},
+		{"startTime", &rng.startTime},
+		{"endTime", &rng.endTime},
+	} {
+		valueStr := get(field.name)
+		if valueStr == "" {
+			continue
+		}
+		rng.filtered = true
+
+		var err error
+		switch dst := field.dst.(type) {
+		case *uint64:
+			*dst, err = strconv.ParseUint(valueStr, 10, 64)
+		case *int64:
+			*dst, err = strconv.ParseInt(valueStr, 10, 64)
+		}
+		if err != nil {
+			return rng, fmt.Errorf("couldn't parse %s: %w", field.name, err)
+		}
+	}
+	return rng, nil
+}
+
+// contains returns true if a transaction with [acceptance] is in the range.
+func (rng historyExportRange) contains(acceptance *states.Acceptance) bool {
+	if !rng.filtered {
+		return true
+	}
+	return acceptance != nil &&
+		rng.startHeight <= acceptance.Height && acceptance.Height <= rng.endHeight &&
+		rng.startTime <= acceptance.Timestamp && acceptance.Timestamp <= rng.endTime
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	stdjson "encoding/json"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/avm/states"
+)
+
+func exportHistory(h http.Handler, query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/export?"+query, nil))
+	return w
+}
+
+func TestTxHistoryExport(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, vm, _ := GenesisVM(t)
+	defer func() {
+		vm.ctx.Lock.Lock()
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	genesisTxIDs, err := vm.state.TxIDs(ids.Empty, historyExportBatchSize)
+	assert.NoError(err)
+	assert.NotEmpty(genesisTxIDs)
+
+	acceptedTxID := genesisTxIDs[0]
+	assert.NoError(vm.state.PutAcceptance(acceptedTxID, &states.Acceptance{
+		Height:    0,
+		Timestamp: 100,
+	}))
+	vm.ctx.Lock.Unlock()
+
+	h := &txHistoryExporter{vm: vm}
+
+	// Every accepted tx is exported
+	w := exportHistory(h, "")
+	assert.Equal(http.StatusOK, w.Code)
+	scanner := bufio.NewScanner(w.Body)
+	scanner.Buffer(nil, 1<<20)
+	type exportedRecord struct {
+		TxID      ids.ID       `json:"txID"`
+		Height    *json.Uint64 `json:"height"`
+		Timestamp *int64       `json:"timestamp"`
+	}
+	exported := map[ids.ID]exportedRecord{}
+	for scanner.Scan() {
+		record := exportedRecord{}
+		assert.NoError(stdjson.Unmarshal(scanner.Bytes(), &record))
+		exported[record.TxID] = record
+	}
+	assert.Len(exported, len(genesisTxIDs))
+	assert.NotNil(exported[acceptedTxID].Height)
+	assert.Equal(int64(100), *exported[acceptedTxID].Timestamp)
+
+	// Only txs with an acceptance record in the range are exported
+	w = exportHistory(h, "startTime=50&endTime=150")
+	assert.Equal(1, strings.Count(w.Body.String(), "\n"))
+	w = exportHistory(h, "startHeight=1")
+	assert.Empty(w.Body.String())
+
+	w = exportHistory(h, "format=protobuf&endHeight=0")
+	assert.Equal(http.StatusOK, w.Code)
+	msg := w.Body.Bytes()
+	msgLen, n := protowire.ConsumeVarint(msg)
+	assert.Greater(n, 0)
+	assert.Len(msg[n:], int(msgLen))
+	num, typ, n := protowire.ConsumeTag(msg[n:])
+	assert.Equal(protowire.Number(1), num)
+	assert.Equal(protowire.BytesType, typ)
+	assert.Greater(n, 0)
+
+	w = exportHistory(h, "format=xml")
+	assert.Equal(http.StatusBadRequest, w.Code)
+	w = exportHistory(h, "startHeight=abc")
+	assert.Equal(http.StatusBadRequest, w.Code)
+}
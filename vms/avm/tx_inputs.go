@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/managedassetfx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+var _ txs.Visitor = &txInputs{}
+
+// JSONTx is the JSON representation of a tx returned by GetTx. Along with the
+// tx itself, it describes the UTXOs the tx consumes and, if it's an operation
+// tx, its operations.
+type JSONTx struct {
+	*txs.Tx
+	DecodedInputs     []DecodedInput     `json:"decodedInputs"`
+	DecodedOperations []DecodedOperation `json:"decodedOperations,omitempty"`
+}
+
+// DecodedInput describes a UTXO consumed by a tx and how its spend is
+// authorized.
+type DecodedInput struct {
+	TxID        ids.ID      `json:"txID"`
+	OutputIndex json.Uint32 `json:"outputIndex"`
+	AssetID     ids.ID      `json:"assetID"`
+	// Amount of the consumed UTXO, if it's fungible
+	Amount *json.Uint64 `json:"amount,omitempty"`
+	// Set if the UTXO was imported from another chain
+	SourceChain *ids.ID `json:"sourceChain,omitempty"`
+	// Index of the credential that authorizes the spend. Every UTXO consumed
+	// by an operation shares the credential of the operation.
+	CredentialIndex json.Uint32 `json:"credentialIndex"`
+	// Indices, in [SourceAddresses], of the addresses that must sign a
+	// transfer input
+	SignatureIndices []json.Uint32 `json:"signatureIndices,omitempty"`
+	// Owners of the consumed UTXO. Empty if the UTXO was imported or isn't
+	// owned by addresses.
+	SourceAddresses []string     `json:"sourceAddresses"`
+	Threshold       *json.Uint32 `json:"threshold,omitempty"`
+	// Addresses recovered from the signatures of the credential
+	Signers []string `json:"signers"`
+}
+
+// signedCredential is implemented by credentials made of secp256k1
+// signatures.
+type signedCredential interface {
+	Signers(factory *crypto.FactorySECP256K1R, txHash []byte) ([]ids.ShortID, error)
+}
+
+type consumedInput struct {
+	utxoID  *djtx.UTXOID
+	assetID ids.ID
+	// Transfer input or operation that spends the UTXO
+	in          interface{}
+	credIndex   int
+	sourceChain *ids.ID
+}
+
+// txInputs lists the UTXOs consumed by a tx along with the index of the
+// credential that authorizes each spend.
+type txInputs struct {
+	// Inputs is set by visiting the tx
+	inputs []consumedInput
+
+	numCreds int
+}
+
+func (t *txInputs) BaseTx(tx *txs.BaseTx) error {
+	t.addInputs(tx.Ins, nil)
+	return nil
+}
+
+func (t *txInputs) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	t.addInputs(tx.Ins, nil)
+	return nil
+}
+
+func (t *txInputs) OperationTx(tx *txs.OperationTx) error {
+	t.addInputs(tx.Ins, nil)
+	for _, op := range tx.Ops {
+		for _, utxoID := range op.UTXOIDs {
+			t.inputs = append(t.inputs, consumedInput{
+				utxoID:    utxoID,
+				assetID:   op.AssetID(),
+				in:        op.Op,
+				credIndex: t.numCreds,
+			})
+		}
+		t.numCreds++
+	}
+	return nil
+}
+
+func (t *txInputs) ImportTx(tx *txs.ImportTx) error {
+	t.addInputs(tx.Ins, nil)
+	sourceChain := tx.SourceChain
+	t.addInputs(tx.ImportedIns, &sourceChain)
+	return nil
+}
+
+func (t *txInputs) ExportTx(tx *txs.ExportTx) error {
+	t.addInputs(tx.Ins, nil)
+	return nil
+}
+
+func (t *txInputs) addInputs(ins []*djtx.TransferableInput, sourceChain *ids.ID) {
+	for _, in := range ins {
+		t.inputs = append(t.inputs, consumedInput{
+			utxoID:      &in.UTXOID,
+			assetID:     in.AssetID(),
+			in:          in.In,
+			credIndex:   t.numCreds,
+			sourceChain: sourceChain,
+		})
+		t.numCreds++
+	}
+}
+
+// decodeInputs describes the UTXOs consumed by [tx]. The owners of consumed
+// UTXOs are read from the txs that produced them.
+func (vm *VM) decodeInputs(tx *txs.Tx) ([]DecodedInput, error) {
+	visitor := &txInputs{}
+	if err := tx.Visit(visitor); err != nil {
+		return nil, err
+	}
+
+	factory := crypto.FactorySECP256K1R{}
+	txHash := hashing.ComputeHash256(tx.UnsignedBytes())
+	credSigners := make([][]string, len(tx.Creds))
+	for i, cred := range tx.Creds {
+		signed, ok := cred.Verifiable.(signedCredential)
+		if !ok {
+			continue
+		}
+		signers, err := signed.Signers(&factory, txHash)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't recover the signers of credential %d: %w", i, err)
+		}
+		credSigners[i], err = vm.formatLocalAddresses(signers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	decoded := make([]DecodedInput, len(visitor.inputs))
+	for i, in := range visitor.inputs {
+		d := DecodedInput{
+			TxID:            in.utxoID.TxID,
+			OutputIndex:     json.Uint32(in.utxoID.OutputIndex),
+			AssetID:         in.assetID,
+			SourceChain:     in.sourceChain,
+			CredentialIndex: json.Uint32(in.credIndex),
+			SourceAddresses: []string{},
+			Signers:         []string{},
+		}
+		if amounter, ok := in.in.(djtx.Amounter); ok {
+			amount := json.Uint64(amounter.Amount())
+			d.Amount = &amount
+		}
+		var sigIndices []uint32
+		switch transferIn := in.in.(type) {
+		case *secp256k1fx.TransferInput:
+			sigIndices = transferIn.SigIndices
+		case *managedassetfx.TransferInput:
+			sigIndices = transferIn.SigIndices
+		}
+		for _, sigIndex := range sigIndices {
+			d.SignatureIndices = append(d.SignatureIndices, json.Uint32(sigIndex))
+		}
+		if in.credIndex < len(credSigners) && credSigners[in.credIndex] != nil {
+			d.Signers = credSigners[in.credIndex]
+		}
+
+		if in.sourceChain == nil {
+			if err := vm.decodeSource(in.utxoID, &d); err != nil {
+				return nil, err
+			}
+		}
+		decoded[i] = d
+	}
+	return decoded, nil
+}
+
+// decodeSource sets the owners of the UTXO [utxoID] in [d], if the tx that
+// produced it is known.
+func (vm *VM) decodeSource(utxoID *djtx.UTXOID, d *DecodedInput) error {
+	parent := UniqueTx{
+		vm:   vm,
+		txID: utxoID.TxID,
+	}
+	if status := parent.Status(); !status.Fetched() {
+		return nil
+	}
+	utxos := parent.UTXOs()
+	if uint64(utxoID.OutputIndex) >= uint64(len(utxos)) {
+		return nil
+	}
+
+	owned, ok := utxos[utxoID.OutputIndex].Out.(djtx.ThresholdOwned)
+	if !ok {
+		return nil
+	}
+	for _, addrBytes := range owned.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return err
+		}
+		addrStr, err := vm.FormatLocalAddress(addr)
+		if err != nil {
+			return fmt.Errorf("couldn't format address %s: %w", addr, err)
+		}
+		d.SourceAddresses = append(d.SourceAddresses, addrStr)
+	}
+	threshold := json.Uint32(owned.SpendThreshold())
+	d.Threshold = &threshold
+	return nil
+}
+
+func (vm *VM) formatLocalAddresses(addrs []ids.ShortID) ([]string, error) {
+	addrStrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := vm.FormatLocalAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't format address %s: %w", addr, err)
+		}
+		addrStrs[i] = addrStr
+	}
+	return addrStrs, nil
+}
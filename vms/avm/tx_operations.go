@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/components/verify"
+	"github.com/lasthyphen/beacongo/vms/managedassetfx"
+	"github.com/lasthyphen/beacongo/vms/nftfx"
+	"github.com/lasthyphen/beacongo/vms/propertyfx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+	"github.com/lasthyphen/beacongo/vms/types"
+)
+
+// DecodedOperation describes an operation of a tx, so that it can be rendered
+// without knowing the types of the fx that defines it.
+type DecodedOperation struct {
+	AssetID ids.ID `json:"assetID"`
+	// IDs of the UTXOs consumed by the operation
+	InputIDs []ids.ID `json:"inputIDs"`
+	FxID     ids.ID   `json:"fxID"`
+	// Name of the fx that defines the operation, such as "nftfx", or the ID
+	// of the fx if its name isn't known
+	Fx string `json:"fx"`
+	// Kind of the operation within its fx, such as "mint" or "transfer"
+	Type string `json:"type"`
+	// Indices, in the owners of the consumed UTXOs, of the addresses that
+	// must sign the operation
+	SignatureIndices []json.Uint32 `json:"signatureIndices,omitempty"`
+	// Outputs produced by the operation
+	Outputs []DecodedOperationOutput `json:"outputs"`
+}
+
+// DecodedOperationOutput describes an output produced by an operation.
+type DecodedOperationOutput struct {
+	// Kind of the output within its fx, such as "mint" or "transfer"
+	Type string `json:"type"`
+	// Amount of the output, if it's fungible
+	Amount *json.Uint64 `json:"amount,omitempty"`
+	// Group of the output, if it's an NFT
+	GroupID *json.Uint32 `json:"groupID,omitempty"`
+	// Payload of the output, if it's an NFT
+	Payload types.JSONByteSlice `json:"payload,omitempty"`
+	// Payload of the output, if it's valid printable UTF-8
+	PayloadText string `json:"payloadText,omitempty"`
+	// Owners of the output. Empty if the output isn't owned by addresses.
+	Addresses []string    `json:"addresses"`
+	Threshold json.Uint32 `json:"threshold"`
+	Locktime  json.Uint64 `json:"locktime"`
+}
+
+// decodeOperations describes the operations of [tx], if it's an operation tx.
+func (vm *VM) decodeOperations(tx *txs.Tx) ([]DecodedOperation, error) {
+	operationTx, ok := tx.UnsignedTx.(*txs.OperationTx)
+	if !ok {
+		return nil, nil
+	}
+
+	decoded := make([]DecodedOperation, len(operationTx.Ops))
+	for i, op := range operationTx.Ops {
+		d, err := vm.decodeOperation(op)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode operation %d: %w", i, err)
+		}
+		decoded[i] = d
+	}
+	return decoded, nil
+}
+
+func (vm *VM) decodeOperation(op *txs.Operation) (DecodedOperation, error) {
+	d := DecodedOperation{
+		AssetID:  op.AssetID(),
+		InputIDs: make([]ids.ID, len(op.UTXOIDs)),
+		Type:     typeName(op.Op, "Operation"),
+		Outputs:  []DecodedOperationOutput{},
+	}
+	for i, utxoID := range op.UTXOIDs {
+		d.InputIDs[i] = utxoID.InputID()
+	}
+
+	fxIndex, ok := vm.typeToFxIndex[reflect.TypeOf(op.Op)]
+	if !ok {
+		return d, errUnknownFx
+	}
+	fx := vm.fxs[fxIndex]
+	d.FxID = fx.ID
+	d.Fx = fxName(fx.Fx)
+	if d.Fx == "" {
+		d.Fx = fx.ID.String()
+	}
+
+	var sigIndices []uint32
+	switch op := op.Op.(type) {
+	case *secp256k1fx.MintOperation:
+		sigIndices = op.MintInput.SigIndices
+	case *nftfx.MintOperation:
+		sigIndices = op.MintInput.SigIndices
+	case *nftfx.TransferOperation:
+		sigIndices = op.Input.SigIndices
+	case *propertyfx.MintOperation:
+		sigIndices = op.MintInput.SigIndices
+	case *propertyfx.BurnOperation:
+		sigIndices = op.Input.SigIndices
+	}
+	for _, sigIndex := range sigIndices {
+		d.SignatureIndices = append(d.SignatureIndices, json.Uint32(sigIndex))
+	}
+
+	for _, out := range op.Op.Outs() {
+		decodedOut, err := vm.decodeOperationOutput(out)
+		if err != nil {
+			return d, err
+		}
+		d.Outputs = append(d.Outputs, decodedOut)
+	}
+	return d, nil
+}
+
+func (vm *VM) decodeOperationOutput(out verify.State) (DecodedOperationOutput, error) {
+	d := DecodedOperationOutput{
+		Type:      typeName(out, "Output"),
+		Addresses: []string{},
+	}
+	if amounter, ok := out.(djtx.Amounter); ok {
+		amount := json.Uint64(amounter.Amount())
+		d.Amount = &amount
+	}
+
+	var owners *secp256k1fx.OutputOwners
+	switch out := out.(type) {
+	case *secp256k1fx.MintOutput:
+		owners = &out.OutputOwners
+	case *secp256k1fx.TransferOutput:
+		owners = &out.OutputOwners
+	case *nftfx.MintOutput:
+		groupID := json.Uint32(out.GroupID)
+		d.GroupID = &groupID
+		owners = &out.OutputOwners
+	case *nftfx.TransferOutput:
+		groupID := json.Uint32(out.GroupID)
+		d.GroupID = &groupID
+		d.Payload = out.Payload
+		if utf8.Valid(out.Payload) && isPrintable(string(out.Payload)) {
+			d.PayloadText = string(out.Payload)
+		}
+		owners = &out.OutputOwners
+	case *propertyfx.MintOutput:
+		owners = &out.OutputOwners
+	case *propertyfx.OwnedOutput:
+		owners = &out.OutputOwners
+	case *managedassetfx.TransferOutput:
+		owners = &out.OutputOwners
+	}
+	if owners == nil {
+		return d, nil
+	}
+
+	var err error
+	d.Addresses, err = vm.formatLocalAddresses(owners.Addrs)
+	if err != nil {
+		return d, err
+	}
+	d.Threshold = json.Uint32(owners.Threshold)
+	d.Locktime = json.Uint64(owners.Locktime)
+	return d, nil
+}
+
+// fxName returns the name of [fx], or "" if it isn't known.
+func fxName(fx interface{}) string {
+	switch fx.(type) {
+	case *secp256k1fx.Fx:
+		return "secp256k1fx"
+	case *nftfx.Fx:
+		return "nftfx"
+	case *propertyfx.Fx:
+		return "propertyfx"
+	case *managedassetfx.Fx:
+		return "managedassetfx"
+	default:
+		return ""
+	}
+}
+
+// typeName returns the name of the type of [v], without [suffix] and starting
+// with a lower case letter. For example, a *nftfx.MintOperation with the
+// suffix "Operation" is named "mint".
+func typeName(v interface{}, suffix string) string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := strings.TrimSuffix(t.Name(), suffix)
+	if name == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// isPrintable returns true if [s] only contains printable characters.
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
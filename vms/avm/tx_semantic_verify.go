@@ -16,6 +16,9 @@ var _ txs.Visitor = &txSemanticVerify{}
 type txSemanticVerify struct {
 	tx *txs.Tx
 	vm *VM
+
+	// If non-nil, the verification steps are recorded in [trace]
+	trace *TxTrace
 }
 
 func (t *txSemanticVerify) BaseTx(tx *txs.BaseTx) error {
@@ -23,20 +26,24 @@ func (t *txSemanticVerify) BaseTx(tx *txs.BaseTx) error {
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := t.tx.Creds[i].Verifiable
-		if err := t.vm.verifyTransfer(t.tx, in, cred); err != nil {
+		step := t.trace.step(traceKindInput, i)
+		if err := t.vm.verifyTransfer(t.tx, in, cred, step); err != nil {
 			return err
 		}
 	}
 
-	for _, out := range tx.Outs {
+	for i, out := range tx.Outs {
+		step := t.trace.step(traceKindOutput, i)
 		fxIndex, err := t.vm.getFx(out.Out)
-		if err != nil {
+		if err := step.check("outputFx", err); err != nil {
 			return err
 		}
+		step.fx(t.vm, fxIndex)
 
 		if assetID := out.AssetID(); !t.vm.verifyFxUsage(fxIndex, assetID) {
-			return errIncompatibleFx
+			return step.check("fxUsage", errIncompatibleFx)
 		}
+		step.pass("fxUsage")
 	}
 	return nil
 }
@@ -50,7 +57,7 @@ func (t *txSemanticVerify) ImportTx(tx *txs.ImportTx) error {
 		return nil
 	}
 
-	if err := verify.SameSubnet(t.vm.ctx, tx.SourceChain); err != nil {
+	if err := t.trace.step(traceKindTx, 0).check("sameSubnet", verify.SameSubnet(t.vm.ctx, tx.SourceChain)); err != nil {
 		return err
 	}
 
@@ -61,22 +68,25 @@ func (t *txSemanticVerify) ImportTx(tx *txs.ImportTx) error {
 	}
 
 	allUTXOBytes, err := t.vm.ctx.SharedMemory.Get(tx.SourceChain, utxoIDs)
-	if err != nil {
+	if err := t.trace.step(traceKindTx, 0).check("sharedMemory", err); err != nil {
 		return err
 	}
 
 	codec := t.vm.parser.Codec()
 	offset := tx.BaseTx.NumCredentials()
 	for i, in := range tx.ImportedIns {
+		step := t.trace.step(traceKindImportedInput, i)
 		utxo := djtx.UTXO{}
 		if _, err := codec.Unmarshal(allUTXOBytes[i], &utxo); err != nil {
-			return err
+			return step.check("utxo", err)
 		}
+		step.pass("utxo")
+		step.utxo(&utxo)
 
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := t.tx.Creds[i+offset].Verifiable
-		if err := t.vm.verifyTransferOfUTXO(tx, in, cred, &utxo); err != nil {
+		if err := t.vm.verifyTransferOfUTXO(tx, in, cred, &utxo, step); err != nil {
 			return err
 		}
 	}
@@ -85,25 +95,29 @@ func (t *txSemanticVerify) ImportTx(tx *txs.ImportTx) error {
 
 func (t *txSemanticVerify) ExportTx(tx *txs.ExportTx) error {
 	if t.vm.bootstrapped {
-		if err := verify.SameSubnet(t.vm.ctx, tx.DestinationChain); err != nil {
+		if err := t.trace.step(traceKindTx, 0).check("sameSubnet", verify.SameSubnet(t.vm.ctx, tx.DestinationChain)); err != nil {
 			return err
 		}
 	}
 
-	for _, out := range tx.ExportedOuts {
+	for i, out := range tx.ExportedOuts {
+		step := t.trace.step(traceKindExportedOutput, i)
 		fxIndex, err := t.vm.getFx(out.Out)
-		if err != nil {
+		if err := step.check("outputFx", err); err != nil {
 			return err
 		}
+		step.fx(t.vm, fxIndex)
 
 		assetID := out.AssetID()
 		if assetID != t.vm.ctx.DJTXAssetID && tx.DestinationChain == constants.PlatformChainID {
-			return errWrongAssetID
+			return step.check("assetID", errWrongAssetID)
 		}
+		step.pass("assetID")
 
 		if !t.vm.verifyFxUsage(fxIndex, assetID) {
-			return errIncompatibleFx
+			return step.check("fxUsage", errIncompatibleFx)
 		}
+		step.pass("fxUsage")
 	}
 
 	return t.BaseTx(&tx.BaseTx)
@@ -119,7 +133,8 @@ func (t *txSemanticVerify) OperationTx(tx *txs.OperationTx) error {
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := t.tx.Creds[i+offset].Verifiable
-		if err := t.vm.verifyOperation(tx, op, cred); err != nil {
+		step := t.trace.step(traceKindOperation, i)
+		if err := t.vm.verifyOperation(tx, op, cred, step); err != nil {
 			return err
 		}
 	}
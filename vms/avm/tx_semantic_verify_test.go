@@ -20,6 +20,7 @@ import (
 	"github.com/lasthyphen/beacongo/vms/avm/fxs"
 	"github.com/lasthyphen/beacongo/vms/avm/txs"
 	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/managedassetfx"
 	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
 )
 
@@ -71,6 +72,55 @@ func TestBaseTxSemanticVerify(t *testing.T) {
 	}
 }
 
+func TestBaseTxSemanticVerifyFrozenAsset(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	tx := NewTx(t, genesisBytes, vm)
+	assetID := tx.UnsignedTx.(*txs.BaseTx).Ins[0].AssetID()
+
+	// Accepting a frozen manager output freezes the asset
+	freezeManager := func(frozen bool) {
+		err := vm.updateFrozenAssets([]*djtx.UTXO{{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &managedassetfx.ManagerOutput{
+				Frozen: frozen,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+				},
+			},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	freezeManager(true)
+	err := tx.Visit(&txSemanticVerify{
+		tx: tx,
+		vm: vm,
+	})
+	if err != errFrozenAsset {
+		t.Fatalf("should have failed with %s but returned %v", errFrozenAsset, err)
+	}
+
+	freezeManager(false)
+	err = tx.Visit(&txSemanticVerify{
+		tx: tx,
+		vm: vm,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestBaseTxSemanticVerifyUnknownFx(t *testing.T) {
 	genesisBytes, _, vm, _ := GenesisVMWithArgs(
 		t,
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+var (
+	errUnsupportedExternalSigning = errors.New("only base and export transactions can be signed externally")
+
+	_ txs.Visitor = &txSigners{}
+)
+
+// txSigners calculates the addresses that must sign each credential of a tx
+// that spends secp256k1fx UTXOs from this chain.
+type txSigners struct {
+	vm *VM
+
+	// Signers is set by visiting the tx
+	signers [][]ids.ShortID
+}
+
+func (s *txSigners) BaseTx(t *txs.BaseTx) error {
+	return s.inputSigners(t.Ins)
+}
+
+func (s *txSigners) CreateAssetTx(*txs.CreateAssetTx) error {
+	return errUnsupportedExternalSigning
+}
+
+func (s *txSigners) OperationTx(*txs.OperationTx) error {
+	return errUnsupportedExternalSigning
+}
+
+func (s *txSigners) ImportTx(*txs.ImportTx) error {
+	return errUnsupportedExternalSigning
+}
+
+func (s *txSigners) ExportTx(t *txs.ExportTx) error {
+	return s.inputSigners(t.Ins)
+}
+
+func (s *txSigners) inputSigners(ins []*djtx.TransferableInput) error {
+	s.signers = make([][]ids.ShortID, len(ins))
+	for i, in := range ins {
+		input, ok := in.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return fmt.Errorf("input %d has unexpected type %T", i, in.In)
+		}
+		utxo, err := s.vm.getUTXO(&in.UTXOID)
+		if err != nil {
+			return fmt.Errorf("couldn't get UTXO %s: %w", in.InputID(), err)
+		}
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return fmt.Errorf("UTXO %s has unexpected type %T", in.InputID(), utxo.Out)
+		}
+
+		signers := make([]ids.ShortID, len(input.SigIndices))
+		for j, sigIndex := range input.SigIndices {
+			if sigIndex >= uint32(len(out.Addrs)) {
+				return fmt.Errorf("input %d has signature index %d but UTXO %s has %d owners", i, sigIndex, in.InputID(), len(out.Addrs))
+			}
+			signers[j] = out.Addrs[sigIndex]
+		}
+		s.signers[i] = signers
+	}
+	return nil
+}
+
+// getTxSigners returns, for each credential of [utx], the addresses that must
+// sign [utx] in order.
+func (vm *VM) getTxSigners(utx txs.UnsignedTx) ([][]ids.ShortID, error) {
+	s := &txSigners{vm: vm}
+	err := utx.Visit(s)
+	return s.signers, err
+}
+
+// matchAddrs returns the signature indices and addresses of up to
+// [owners.Threshold] of [owners.Addrs] that are in [addrs]. Returns false if
+// [addrs] can't spend an output owned by [owners] at [time].
+func matchAddrs(owners *secp256k1fx.OutputOwners, addrs ids.ShortSet, time uint64) ([]uint32, []ids.ShortID, bool) {
+	if time < owners.Locktime {
+		return nil, nil, false
+	}
+	sigs := make([]uint32, 0, owners.Threshold)
+	signers := make([]ids.ShortID, 0, owners.Threshold)
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(signers)) < owners.Threshold; i++ {
+		if addr := owners.Addrs[i]; addrs.Contains(addr) {
+			sigs = append(sigs, i)
+			signers = append(signers, addr)
+		}
+	}
+	return sigs, signers, uint32(len(signers)) == owners.Threshold
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+// Kinds of the items verified by the steps of a TxTrace
+const (
+	traceKindTx             = "tx"
+	traceKindInput          = "input"
+	traceKindOutput         = "output"
+	traceKindImportedInput  = "importedInput"
+	traceKindExportedOutput = "exportedOutput"
+	traceKindOperation      = "operation"
+)
+
+// TxTrace records the verification of a tx step by step. A nil *TxTrace
+// records nothing, so verification code can record steps unconditionally.
+type TxTrace struct {
+	Steps []*TraceStep `json:"steps"`
+}
+
+// TraceStep is the verification of one item of a tx, such as an input.
+type TraceStep struct {
+	// What was verified. One of "tx", "input", "output", "importedInput",
+	// "exportedOutput" or "operation".
+	Kind string `json:"kind"`
+	// Index of the item in its list in the tx
+	Index int `json:"index"`
+	// UTXOs consumed by the item, if any, as they were found
+	UTXOs []TracedUTXO `json:"utxos,omitempty"`
+	// ID of the fx that verified the item, if it was found
+	FxID *ids.ID `json:"fxID,omitempty"`
+	// Checks that were run, in order. If the verification failed, the last
+	// check is the one that failed.
+	Checks []string `json:"checks"`
+	// Why the last check failed, if it did
+	Error string `json:"error,omitempty"`
+}
+
+// TracedUTXO describes a UTXO consumed by a traced item.
+type TracedUTXO struct {
+	UTXOID  ids.ID `json:"utxoID"`
+	AssetID ids.ID `json:"assetID"`
+	// Go type of the output of the UTXO
+	OutputType string `json:"outputType"`
+}
+
+// step starts recording the verification of the [index]-th item of [kind].
+func (t *TxTrace) step(kind string, index int) *TraceStep {
+	if t == nil {
+		return nil
+	}
+	s := &TraceStep{
+		Kind:   kind,
+		Index:  index,
+		Checks: []string{},
+	}
+	t.Steps = append(t.Steps, s)
+	return s
+}
+
+// check records that [name] was checked, failing with [err] if it's non-nil.
+// Returns [err].
+func (s *TraceStep) check(name string, err error) error {
+	if s == nil {
+		return err
+	}
+	s.Checks = append(s.Checks, name)
+	if err != nil {
+		s.Error = err.Error()
+	}
+	return err
+}
+
+// pass records that [name] was checked successfully.
+func (s *TraceStep) pass(name string) {
+	_ = s.check(name, nil)
+}
+
+// utxo records that [utxo] is consumed by the item.
+func (s *TraceStep) utxo(utxo *djtx.UTXO) {
+	if s == nil {
+		return
+	}
+	s.UTXOs = append(s.UTXOs, TracedUTXO{
+		UTXOID:     utxo.InputID(),
+		AssetID:    utxo.AssetID(),
+		OutputType: fmt.Sprintf("%T", utxo.Out),
+	})
+}
+
+// fx records that the item is verified by the fx at [fxIndex].
+func (s *TraceStep) fx(vm *VM, fxIndex int) {
+	if s == nil {
+		return
+	}
+	fxID := vm.fxs[fxIndex].ID
+	s.FxID = &fxID
+}
@@ -0,0 +1,370 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package txbuilder constructs signed AVM transactions from a set of UTXOs and
+// keys. It doesn't depend on a running VM or a keystore, so transactions can
+// be built offline. Given the same arguments, the same transaction is built.
+package txbuilder
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/beacongo/codec"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+var errZeroAmount = errors.New("amount must be positive")
+
+// Context describes the chain that transactions are built for.
+type Context struct {
+	NetworkID        uint32
+	BlockchainID     ids.ID
+	FeeAssetID       ids.ID
+	TxFee            uint64
+	CreateAssetTxFee uint64
+}
+
+// Funds are the UTXOs and keys that pay for a transaction.
+type Funds struct {
+	UTXOs []*djtx.UTXO
+	Keys  *secp256k1fx.Keychain
+	// ChangeAddr receives the funds that are consumed but not spent
+	ChangeAddr ids.ShortID
+	// Time is the unix time that the locktimes of the UTXOs are checked
+	// against
+	Time uint64
+}
+
+// Builder builds signed transactions for the chain described by its Context.
+type Builder struct {
+	ctx   Context
+	codec codec.Manager
+}
+
+// New returns a builder of transactions for the chain described by [ctx].
+// Transactions are serialized with the codec of [parser].
+func New(ctx Context, parser txs.Parser) *Builder {
+	return &Builder{
+		ctx:   ctx,
+		codec: parser.Codec(),
+	}
+}
+
+// NewBaseTx returns a transaction that creates [outs] and pays the fee from
+// [funds].
+func (b *Builder) NewBaseTx(
+	funds Funds,
+	outs []*djtx.TransferableOutput,
+	memo []byte,
+) (*txs.Tx, error) {
+	amounts := make(map[ids.ID]uint64)
+	for _, out := range outs {
+		assetID := out.AssetID()
+		amount, err := safemath.Add64(amounts[assetID], out.Out.Amount())
+		if err != nil {
+			return nil, err
+		}
+		amounts[assetID] = amount
+	}
+
+	baseTx, keys, err := b.spend(funds, amounts, b.ctx.TxFee, outs)
+	if err != nil {
+		return nil, err
+	}
+	baseTx.Memo = memo
+
+	tx := &txs.Tx{UnsignedTx: &txs.BaseTx{BaseTx: baseTx}}
+	if err := tx.SignSECP256K1Fx(b.codec, keys); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// NewCreateAssetTx returns a transaction that creates an asset with the
+// provided initial [states] and pays the fee from [funds].
+func (b *Builder) NewCreateAssetTx(
+	funds Funds,
+	name string,
+	symbol string,
+	denomination byte,
+	states []*txs.InitialState,
+) (*txs.Tx, error) {
+	baseTx, keys, err := b.spend(funds, nil, b.ctx.CreateAssetTxFee, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, state := range states {
+		state.Sort(b.codec)
+	}
+	txs.SortInitialStates(states)
+
+	tx := &txs.Tx{UnsignedTx: &txs.CreateAssetTx{
+		BaseTx:       txs.BaseTx{BaseTx: baseTx},
+		Name:         name,
+		Symbol:       symbol,
+		Denomination: denomination,
+		States:       states,
+	}}
+	if err := tx.SignSECP256K1Fx(b.codec, keys); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// NewMintTx returns a transaction that mints [amount] of [assetID] to [to]
+// using a mint output held by [funds] and pays the fee from [funds].
+func (b *Builder) NewMintTx(
+	funds Funds,
+	assetID ids.ID,
+	amount uint64,
+	to ids.ShortID,
+) (*txs.Tx, error) {
+	if amount == 0 {
+		return nil, errZeroAmount
+	}
+
+	baseTx, keys, err := b.spend(funds, nil, b.ctx.TxFee, nil)
+	if err != nil {
+		return nil, err
+	}
+	ops, opKeys, err := Mint(
+		b.codec,
+		funds.UTXOs,
+		funds.Keys,
+		map[ids.ID]uint64{assetID: amount},
+		to,
+		funds.Time,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &txs.Tx{UnsignedTx: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: baseTx},
+		Ops:    ops,
+	}}
+	if err := tx.SignSECP256K1Fx(b.codec, append(keys, opKeys...)); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// NewMintNFTTx returns a transaction that mints an NFT of [assetID] with
+// [payload] to [to] using a mint output held by [funds] and pays the fee from
+// [funds].
+func (b *Builder) NewMintNFTTx(
+	funds Funds,
+	assetID ids.ID,
+	payload []byte,
+	to ids.ShortID,
+) (*txs.Tx, error) {
+	baseTx, keys, err := b.spend(funds, nil, b.ctx.TxFee, nil)
+	if err != nil {
+		return nil, err
+	}
+	ops, opKeys, err := MintNFT(b.codec, funds.UTXOs, funds.Keys, assetID, payload, to, funds.Time)
+	if err != nil {
+		return nil, err
+	}
+	return b.signNFTTx(baseTx, ops, keys, opKeys)
+}
+
+// NewSendNFTTx returns a transaction that transfers an NFT of [assetID] in
+// group [groupID] from [funds] to [to] and pays the fee from [funds].
+func (b *Builder) NewSendNFTTx(
+	funds Funds,
+	assetID ids.ID,
+	groupID uint32,
+	to ids.ShortID,
+) (*txs.Tx, error) {
+	baseTx, keys, err := b.spend(funds, nil, b.ctx.TxFee, nil)
+	if err != nil {
+		return nil, err
+	}
+	ops, opKeys, err := SpendNFT(b.codec, funds.UTXOs, funds.Keys, assetID, groupID, to, funds.Time)
+	if err != nil {
+		return nil, err
+	}
+	return b.signNFTTx(baseTx, ops, keys, opKeys)
+}
+
+// NewImportTx returns a transaction that imports every UTXO in [atomicUTXOs]
+// that [funds] can spend from [sourceChain] to [to]. If the imported funds
+// can't pay the fee, the remainder is paid from [funds].
+func (b *Builder) NewImportTx(
+	funds Funds,
+	sourceChain ids.ID,
+	atomicUTXOs []*djtx.UTXO,
+	to ids.ShortID,
+) (*txs.Tx, error) {
+	amountsImported, importedIns, importKeys, err := SpendAll(atomicUTXOs, funds.Keys, funds.Time)
+	if err != nil {
+		return nil, err
+	}
+	if len(importedIns) == 0 {
+		return nil, ErrInsufficientFunds
+	}
+
+	ins := []*djtx.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	fee := b.ctx.TxFee
+	if imported := amountsImported[b.ctx.FeeAssetID]; imported < fee {
+		var amountsSpent map[ids.ID]uint64
+		amountsSpent, ins, keys, err = Spend(
+			funds.UTXOs,
+			funds.Keys,
+			map[ids.ID]uint64{b.ctx.FeeAssetID: fee - imported},
+			funds.Time,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for assetID, amount := range amountsSpent {
+			newAmount, err := safemath.Add64(amountsImported[assetID], amount)
+			if err != nil {
+				return nil, err
+			}
+			amountsImported[assetID] = newAmount
+		}
+	}
+
+	// Enough was consumed to pay the fee, so this can't underflow
+	amountsImported[b.ctx.FeeAssetID] -= fee
+
+	outs := []*djtx.TransferableOutput{}
+	for assetID, amount := range amountsImported {
+		if amount > 0 {
+			outs = append(outs, newOutput(assetID, amount, to))
+		}
+	}
+	djtx.SortTransferableOutputs(outs, b.codec)
+
+	tx := &txs.Tx{UnsignedTx: &txs.ImportTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.BlockchainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		SourceChain: sourceChain,
+		ImportedIns: importedIns,
+	}}
+	if err := tx.SignSECP256K1Fx(b.codec, append(keys, importKeys...)); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// NewExportTx returns a transaction that exports [amount] of [assetID] from
+// [funds] to [to] on [destinationChain] and pays the fee from [funds].
+func (b *Builder) NewExportTx(
+	funds Funds,
+	destinationChain ids.ID,
+	assetID ids.ID,
+	amount uint64,
+	to ids.ShortID,
+) (*txs.Tx, error) {
+	if amount == 0 {
+		return nil, errZeroAmount
+	}
+
+	baseTx, keys, err := b.spend(funds, map[ids.ID]uint64{assetID: amount}, b.ctx.TxFee, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &txs.Tx{UnsignedTx: &txs.ExportTx{
+		BaseTx:           txs.BaseTx{BaseTx: baseTx},
+		DestinationChain: destinationChain,
+		ExportedOuts: []*djtx.TransferableOutput{
+			newOutput(assetID, amount, to),
+		},
+	}}
+	if err := tx.SignSECP256K1Fx(b.codec, keys); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// spend returns a base tx that consumes [amounts] and [fee] from [funds]. The
+// outputs of the base tx are [outs] and the change, sorted.
+func (b *Builder) spend(
+	funds Funds,
+	amounts map[ids.ID]uint64,
+	fee uint64,
+	outs []*djtx.TransferableOutput,
+) (djtx.BaseTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	toSpend := make(map[ids.ID]uint64, len(amounts)+1)
+	for assetID, amount := range amounts {
+		toSpend[assetID] = amount
+	}
+	if fee > 0 {
+		amountWithFee, err := safemath.Add64(toSpend[b.ctx.FeeAssetID], fee)
+		if err != nil {
+			return djtx.BaseTx{}, nil, err
+		}
+		toSpend[b.ctx.FeeAssetID] = amountWithFee
+	}
+
+	amountsSpent, ins, keys, err := Spend(funds.UTXOs, funds.Keys, toSpend, funds.Time)
+	if err != nil {
+		return djtx.BaseTx{}, nil, err
+	}
+
+	allOuts := make([]*djtx.TransferableOutput, len(outs), len(outs)+len(amountsSpent))
+	copy(allOuts, outs)
+	for assetID, amountSpent := range amountsSpent {
+		if change := amountSpent - toSpend[assetID]; change > 0 {
+			allOuts = append(allOuts, newOutput(assetID, change, funds.ChangeAddr))
+		}
+	}
+	djtx.SortTransferableOutputs(allOuts, b.codec)
+
+	return djtx.BaseTx{
+		NetworkID:    b.ctx.NetworkID,
+		BlockchainID: b.ctx.BlockchainID,
+		Outs:         allOuts,
+		Ins:          ins,
+	}, keys, nil
+}
+
+// signNFTTx signs the inputs of [baseTx] with [keys] and the NFT [ops] with
+// [opKeys].
+func (b *Builder) signNFTTx(
+	baseTx djtx.BaseTx,
+	ops []*txs.Operation,
+	keys [][]*crypto.PrivateKeySECP256K1R,
+	opKeys [][]*crypto.PrivateKeySECP256K1R,
+) (*txs.Tx, error) {
+	tx := &txs.Tx{UnsignedTx: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: baseTx},
+		Ops:    ops,
+	}}
+	if err := tx.SignSECP256K1Fx(b.codec, keys); err != nil {
+		return nil, err
+	}
+	if err := tx.SignNFTFx(b.codec, opKeys); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func newOutput(assetID ids.ID, amount uint64, owner ids.ShortID) *djtx.TransferableOutput {
+	return &djtx.TransferableOutput{
+		Asset: djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{owner},
+			},
+		},
+	}
+}
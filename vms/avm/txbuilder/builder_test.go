@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txbuilder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/vms/avm/fxs"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/nftfx"
+	"github.com/lasthyphen/beacongo/vms/propertyfx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+func newTestBuilder(t *testing.T) (*Builder, txs.Parser) {
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(Context{
+		NetworkID:        10,
+		BlockchainID:     ids.ID{1},
+		FeeAssetID:       ids.ID{2},
+		TxFee:            1,
+		CreateAssetTxFee: 2,
+	}, parser), parser
+}
+
+func newTestFunds(t *testing.T, amounts ...uint64) Funds {
+	factory := crypto.FactorySECP256K1R{}
+	key, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc := secp256k1fx.NewKeychain(key.(*crypto.PrivateKeySECP256K1R))
+	addr := key.PublicKey().Address()
+
+	utxos := make([]*djtx.UTXO, len(amounts))
+	for i, amount := range amounts {
+		utxos[i] = &djtx.UTXO{
+			UTXOID: djtx.UTXOID{TxID: ids.ID{byte(i + 1)}},
+			Asset:  djtx.Asset{ID: ids.ID{2}},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+	}
+	return Funds{
+		UTXOs:      utxos,
+		Keys:       kc,
+		ChangeAddr: addr,
+	}
+}
+
+func TestNewBaseTx(t *testing.T) {
+	assert := assert.New(t)
+
+	b, parser := newTestBuilder(t)
+	funds := newTestFunds(t, 5, 10)
+	outs := []*djtx.TransferableOutput{
+		newOutput(ids.ID{2}, 8, ids.ShortID{3}),
+	}
+
+	tx, err := b.NewBaseTx(funds, outs, []byte("memo"))
+	assert.NoError(err)
+
+	// Both UTXOs are needed to pay 8 plus the fee, which leaves 6 as change
+	baseTx := tx.UnsignedTx.(*txs.BaseTx)
+	assert.Len(baseTx.Ins, 2)
+	assert.Len(baseTx.Outs, 2)
+	assert.Len(tx.Creds, 2)
+	assert.Equal([]byte("memo"), baseTx.Memo)
+
+	parsedTx, err := parser.Parse(tx.Bytes())
+	assert.NoError(err)
+	assert.Equal(tx.ID(), parsedTx.ID())
+	assert.NoError(parsedTx.VerifyCanonical(parser.Codec()))
+
+	// Building the same tx again results in the same bytes
+	sameTx, err := b.NewBaseTx(funds, outs, []byte("memo"))
+	assert.NoError(err)
+	assert.Equal(tx.Bytes(), sameTx.Bytes())
+}
+
+func TestNewExportTxInsufficientFunds(t *testing.T) {
+	b, _ := newTestBuilder(t)
+	funds := newTestFunds(t, 5)
+
+	_, err := b.NewExportTx(funds, ids.ID{4}, ids.ID{2}, 5, ids.ShortID{3})
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected %s but got %v", ErrInsufficientFunds, err)
+	}
+}
+
+func TestNewImportTx(t *testing.T) {
+	assert := assert.New(t)
+
+	b, parser := newTestBuilder(t)
+	funds := newTestFunds(t, 5)
+	atomicUTXOs := []*djtx.UTXO{{
+		UTXOID: djtx.UTXOID{TxID: ids.ID{9}},
+		Asset:  djtx.Asset{ID: ids.ID{2}},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 7,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{funds.ChangeAddr},
+			},
+		},
+	}}
+
+	tx, err := b.NewImportTx(funds, ids.ID{4}, atomicUTXOs, ids.ShortID{3})
+	assert.NoError(err)
+
+	// The imported funds pay the fee, so no local UTXOs are spent
+	importTx := tx.UnsignedTx.(*txs.ImportTx)
+	assert.Len(importTx.Ins, 0)
+	assert.Len(importTx.ImportedIns, 1)
+	assert.Len(importTx.Outs, 1)
+	assert.Equal(uint64(6), importTx.Outs[0].Out.Amount())
+
+	_, err = parser.Parse(tx.Bytes())
+	assert.NoError(err)
+}
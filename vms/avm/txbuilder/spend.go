@@ -0,0 +1,377 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/codec"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/vms/avm/txs"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/nftfx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrSpendOverflow     = errors.New("spent amount overflows uint64")
+	ErrCantMint          = errors.New("keys don't have the authority to mint the asset")
+)
+
+// Spend returns inputs that consume at least [amounts] of each asset from
+// [utxos], along with the keys that must sign each input and the amount of
+// each asset that is consumed. UTXOs are spent in the order they are given.
+// [now] is the unix time that the locktimes of the UTXOs are checked against.
+func Spend(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+	now uint64,
+) (
+	map[ids.ID]uint64,
+	[]*djtx.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	amountsSpent := make(map[ids.ID]uint64, len(amounts))
+
+	ins := []*djtx.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	for _, utxo := range utxos {
+		assetID := utxo.AssetID()
+		amount := amounts[assetID]
+		amountSpent := amountsSpent[assetID]
+		if amountSpent >= amount {
+			// we already have enough inputs allocated to this asset
+			continue
+		}
+
+		inputIntf, signers, err := kc.Spend(utxo.Out, now)
+		if err != nil {
+			// this utxo can't be spent with the current keys right now
+			continue
+		}
+		input, ok := inputIntf.(djtx.TransferableIn)
+		if !ok {
+			// this input doesn't have an amount, so I don't care about it here
+			continue
+		}
+		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
+		if err != nil {
+			return nil, nil, nil, ErrSpendOverflow
+		}
+		amountsSpent[assetID] = newAmountSpent
+
+		// add the new input to the array
+		ins = append(ins, &djtx.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  djtx.Asset{ID: assetID},
+			In:     input,
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+	}
+
+	for assetID, amount := range amounts {
+		if amountsSpent[assetID] < amount {
+			return nil, nil, nil, fmt.Errorf("%w of asset %s: needed %d but only %d is available",
+				ErrInsufficientFunds,
+				assetID,
+				amount,
+				amountsSpent[assetID],
+			)
+		}
+	}
+
+	djtx.SortTransferableInputsWithSigners(ins, keys)
+	return amountsSpent, ins, keys, nil
+}
+
+// SpendAll returns inputs that consume every UTXO in [utxos] that [kc] can
+// spend at [now], along with the keys that must sign each input and the amount
+// of each asset that is consumed.
+func SpendAll(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	now uint64,
+) (
+	map[ids.ID]uint64,
+	[]*djtx.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	amountsSpent := make(map[ids.ID]uint64)
+
+	ins := []*djtx.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	for _, utxo := range utxos {
+		assetID := utxo.AssetID()
+		amountSpent := amountsSpent[assetID]
+
+		inputIntf, signers, err := kc.Spend(utxo.Out, now)
+		if err != nil {
+			// this utxo can't be spent with the current keys right now
+			continue
+		}
+		input, ok := inputIntf.(djtx.TransferableIn)
+		if !ok {
+			// this input doesn't have an amount, so I don't care about it here
+			continue
+		}
+		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
+		if err != nil {
+			// there was an error calculating the consumed amount, just error
+			return nil, nil, nil, ErrSpendOverflow
+		}
+		amountsSpent[assetID] = newAmountSpent
+
+		// add the new input to the array
+		ins = append(ins, &djtx.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  djtx.Asset{ID: assetID},
+			In:     input,
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+	}
+
+	djtx.SortTransferableInputsWithSigners(ins, keys)
+	return amountsSpent, ins, keys, nil
+}
+
+// SpendNFT returns an operation that transfers an NFT of [assetID] in group
+// [groupID] from [utxos] to [to], along with the keys that must sign it.
+func SpendNFT(
+	c codec.Manager,
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	groupID uint32,
+	to ids.ShortID,
+	now uint64,
+) (
+	[]*txs.Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	ops := []*txs.Operation{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+
+	for _, utxo := range utxos {
+		// makes sure that the variable isn't overwritten with the next iteration
+		utxo := utxo
+
+		if len(ops) > 0 {
+			// we have already been able to create the operation needed
+			break
+		}
+
+		if utxo.AssetID() != assetID {
+			// wrong asset ID
+			continue
+		}
+		out, ok := utxo.Out.(*nftfx.TransferOutput)
+		if !ok {
+			// wrong output type
+			continue
+		}
+		if out.GroupID != groupID {
+			// wrong group id
+			continue
+		}
+		indices, signers, ok := kc.Match(&out.OutputOwners, now)
+		if !ok {
+			// unable to spend the output
+			continue
+		}
+
+		// add the new operation to the array
+		ops = append(ops, &txs.Operation{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*djtx.UTXOID{&utxo.UTXOID},
+			Op: &nftfx.TransferOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: indices,
+				},
+				Output: nftfx.TransferOutput{
+					GroupID: out.GroupID,
+					Payload: out.Payload,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
+				},
+			},
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+	}
+
+	if len(ops) == 0 {
+		return nil, nil, ErrInsufficientFunds
+	}
+
+	txs.SortOperationsWithSigners(ops, keys, c)
+	return ops, keys, nil
+}
+
+// Mint returns operations that mint [amounts] of each asset to [to] using the
+// mint outputs in [utxos], along with the keys that must sign each operation.
+func Mint(
+	c codec.Manager,
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+	to ids.ShortID,
+	now uint64,
+) (
+	[]*txs.Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	// copy [amounts] so that the caller's map isn't modified
+	remaining := make(map[ids.ID]uint64, len(amounts))
+	for assetID, amount := range amounts {
+		remaining[assetID] = amount
+	}
+
+	ops := []*txs.Operation{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+
+	for _, utxo := range utxos {
+		// makes sure that the variable isn't overwritten with the next iteration
+		utxo := utxo
+
+		assetID := utxo.AssetID()
+		amount := remaining[assetID]
+		if amount == 0 {
+			continue
+		}
+
+		out, ok := utxo.Out.(*secp256k1fx.MintOutput)
+		if !ok {
+			continue
+		}
+
+		inIntf, signers, err := kc.Spend(out, now)
+		if err != nil {
+			continue
+		}
+
+		in, ok := inIntf.(*secp256k1fx.Input)
+		if !ok {
+			continue
+		}
+
+		// add the operation to the array
+		ops = append(ops, &txs.Operation{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*djtx.UTXOID{&utxo.UTXOID},
+			Op: &secp256k1fx.MintOperation{
+				MintInput:  *in,
+				MintOutput: *out,
+				TransferOutput: secp256k1fx.TransferOutput{
+					Amt: amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
+				},
+			},
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+
+		// remove the asset from the required amounts to mint
+		delete(remaining, assetID)
+	}
+
+	for _, amount := range remaining {
+		if amount > 0 {
+			return nil, nil, ErrCantMint
+		}
+	}
+
+	txs.SortOperationsWithSigners(ops, keys, c)
+	return ops, keys, nil
+}
+
+// MintNFT returns an operation that mints an NFT of [assetID] with [payload]
+// to [to] using a mint output in [utxos], along with the keys that must sign
+// it.
+func MintNFT(
+	c codec.Manager,
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	payload []byte,
+	to ids.ShortID,
+	now uint64,
+) (
+	[]*txs.Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	ops := []*txs.Operation{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+
+	for _, utxo := range utxos {
+		// makes sure that the variable isn't overwritten with the next iteration
+		utxo := utxo
+
+		if len(ops) > 0 {
+			// we have already been able to create the operation needed
+			break
+		}
+
+		if utxo.AssetID() != assetID {
+			// wrong asset id
+			continue
+		}
+		out, ok := utxo.Out.(*nftfx.MintOutput)
+		if !ok {
+			// wrong output type
+			continue
+		}
+
+		indices, signers, ok := kc.Match(&out.OutputOwners, now)
+		if !ok {
+			// unable to spend the output
+			continue
+		}
+
+		// add the operation to the array
+		ops = append(ops, &txs.Operation{
+			Asset: djtx.Asset{ID: assetID},
+			UTXOIDs: []*djtx.UTXOID{
+				&utxo.UTXOID,
+			},
+			Op: &nftfx.MintOperation{
+				MintInput: secp256k1fx.Input{
+					SigIndices: indices,
+				},
+				GroupID: out.GroupID,
+				Payload: payload,
+				Outputs: []*secp256k1fx.OutputOwners{{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{to},
+				}},
+			},
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+	}
+
+	if len(ops) == 0 {
+		return nil, nil, ErrCantMint
+	}
+
+	txs.SortOperationsWithSigners(ops, keys, c)
+	return ops, keys, nil
+}
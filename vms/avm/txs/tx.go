@@ -4,6 +4,7 @@
 package txs
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -19,7 +20,10 @@ import (
 	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
 )
 
-var errNilTx = errors.New("nil tx is not valid")
+var (
+	errNilTx          = errors.New("nil tx is not valid")
+	errNonCanonicalTx = errors.New("tx isn't canonically serialized")
+)
 
 type UnsignedTx interface {
 	snow.ContextInitializable
@@ -90,6 +94,32 @@ func (t *Tx) SyntacticVerify(
 	return nil
 }
 
+// VerifyCanonical verifies that the bytes of this transaction are exactly the
+// bytes that [c] produces when serializing it. This prevents the same
+// transaction from being issued under multiple IDs.
+func (t *Tx) VerifyCanonical(c codec.Manager) error {
+	if t == nil || t.UnsignedTx == nil {
+		return errNilTx
+	}
+
+	unsignedBytes, err := c.Marshal(CodecVersion, &t.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("couldn't serialize unsigned tx: %w", err)
+	}
+	if !bytes.Equal(unsignedBytes, t.UnsignedBytes()) {
+		return errNonCanonicalTx
+	}
+
+	signedBytes, err := c.Marshal(CodecVersion, t)
+	if err != nil {
+		return fmt.Errorf("couldn't serialize signed tx: %w", err)
+	}
+	if !bytes.Equal(signedBytes, t.Bytes()) {
+		return errNonCanonicalTx
+	}
+	return nil
+}
+
 func (t *Tx) SignSECP256K1Fx(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) error {
 	unsignedBytes, err := c.Marshal(CodecVersion, &t.UnsignedTx)
 	if err != nil {
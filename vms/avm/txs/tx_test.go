@@ -246,3 +246,47 @@ func TestTxInvalidNumberOfCredentials(t *testing.T) {
 		t.Fatalf("Tx should have failed due to an invalid number of credentials")
 	}
 }
+
+func TestTxVerifyCanonical(t *testing.T) {
+	c := setupCodec()
+
+	tx := &Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*djtx.TransferableInput{{
+			UTXOID: djtx.UTXOID{
+				TxID:        ids.Empty,
+				OutputIndex: 0,
+			},
+			Asset: djtx.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: 20 * units.KiloDjtx,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{
+						0,
+					},
+				},
+			},
+		}},
+	}}}
+	if err := tx.SignSECP256K1Fx(c, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.VerifyCanonical(c); err != nil {
+		t.Fatal(err)
+	}
+
+	unsignedBytes := tx.UnsignedBytes()
+	signedBytes := tx.Bytes()
+
+	paddedBytes := append(append([]byte{}, signedBytes...), 0)
+	tx.Initialize(unsignedBytes, paddedBytes)
+	if err := tx.VerifyCanonical(c); err != errNonCanonicalTx {
+		t.Fatalf("Should have erred due to padded bytes but got %v", err)
+	}
+
+	tx.Initialize(unsignedBytes[:len(unsignedBytes)-1], signedBytes)
+	if err := tx.VerifyCanonical(c); err != errNonCanonicalTx {
+		t.Fatalf("Should have erred due to non-canonical unsigned bytes but got %v", err)
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"fmt"
 
 	"github.com/lasthyphen/beacongo/cache"
+	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/snow/choices"
 	"github.com/lasthyphen/beacongo/snow/consensus/snowstorm"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/version"
+	"github.com/lasthyphen/beacongo/vms/avm/states"
 	"github.com/lasthyphen/beacongo/vms/avm/txs"
 	"github.com/lasthyphen/beacongo/vms/components/djtx"
 )
@@ -19,8 +23,8 @@ var (
 	errAssetIDMismatch = errors.New("asset IDs in the input don't match the utxo")
 	errWrongAssetID    = errors.New("asset ID must be DJTX in the atomic tx")
 	errMissingUTXO     = errors.New("missing utxo")
-	errUnknownTx       = errors.New("transaction is unknown")
-	errRejectedTx      = errors.New("transaction is rejected")
+	errUnknownTx       = json.NewError(ErrorCodeInvalidTxState, "transaction is unknown")
+	errRejectedTx      = json.NewError(ErrorCodeInvalidTxState, "transaction is rejected")
 )
 
 var (
@@ -107,9 +111,23 @@ func (tx *UniqueTx) setStatus(status choices.Status) error {
 		return nil
 	}
 	tx.status = status
+	if status != choices.Processing {
+		if err := tx.vm.state.DeleteProcessing(tx.ID()); err != nil {
+			return err
+		}
+	}
 	return tx.vm.state.PutStatus(tx.ID(), status)
 }
 
+// restorePruned writes the tx back to state if it was pruned while it was
+// processing, so that it's kept once it's decided.
+func (tx *UniqueTx) restorePruned() error {
+	if _, err := tx.vm.state.GetStatus(tx.txID); err != database.ErrNotFound {
+		return err
+	}
+	return tx.vm.state.PutTx(tx.txID, tx.Tx)
+}
+
 // ID returns the wrapped txID
 func (tx *UniqueTx) ID() ids.ID       { return tx.txID }
 func (tx *UniqueTx) Key() interface{} { return tx.txID }
@@ -123,6 +141,10 @@ func (tx *UniqueTx) Accept() error {
 	txID := tx.ID()
 	defer tx.vm.db.Abort()
 
+	if err := tx.restorePruned(); err != nil {
+		return fmt.Errorf("couldn't restore tx %s: %w", txID, err)
+	}
+
 	// Fetch the input UTXOs
 	inputUTXOIDs := tx.InputUTXOs()
 	inputUTXOs := make([]*djtx.UTXO, 0, len(inputUTXOIDs))
@@ -142,11 +164,16 @@ func (tx *UniqueTx) Accept() error {
 	}
 
 	outputUTXOs := tx.UTXOs()
+	acceptedTime := tx.vm.clock.Time().Unix()
 	// index input and output UTXOs
-	if err := tx.vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs); err != nil {
+	if err := tx.vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs, uint64(acceptedTime)); err != nil {
 		return fmt.Errorf("error indexing tx: %w", err)
 	}
 
+	if err := tx.vm.memoIndexer.Accept(txID, txMemo(tx.UnsignedTx)); err != nil {
+		return fmt.Errorf("error indexing memo of tx: %w", err)
+	}
+
 	// Remove spent utxos
 	for _, utxo := range inputUTXOIDs {
 		if utxo.Symbolic() {
@@ -166,10 +193,40 @@ func (tx *UniqueTx) Accept() error {
 		}
 	}
 
+	if err := tx.vm.updateFrozenAssets(outputUTXOs); err != nil {
+		return fmt.Errorf("couldn't update frozen assets of tx %s: %w", txID, err)
+	}
+
+	if err := tx.vm.putAssetMetadata(txID, tx.UnsignedTx); err != nil {
+		return fmt.Errorf("couldn't put asset metadata of tx %s: %w", txID, err)
+	}
+
+	if err := tx.vm.updateAssetSupply(txID, tx.UnsignedTx); err != nil {
+		return fmt.Errorf("couldn't update asset supply with tx %s: %w", txID, err)
+	}
+
 	if err := tx.setStatus(choices.Accepted); err != nil {
 		return fmt.Errorf("couldn't set status of tx %s: %w", txID, err)
 	}
 
+	height, err := tx.vm.state.NumAccepted()
+	if err != nil {
+		return fmt.Errorf("couldn't get number of accepted txs: %w", err)
+	}
+	acceptance := &states.Acceptance{
+		Height:    height,
+		Timestamp: acceptedTime,
+	}
+	if err := tx.vm.state.PutAcceptance(txID, acceptance); err != nil {
+		return fmt.Errorf("couldn't record acceptance of tx %s: %w", txID, err)
+	}
+	if err := tx.vm.recordAcceptedHeight(height, txID); err != nil {
+		return fmt.Errorf("couldn't record height of tx %s: %w", txID, err)
+	}
+	if err := tx.vm.metrics.numTxsAccepted.Add(1); err != nil {
+		return fmt.Errorf("couldn't count acceptance of tx %s: %w", txID, err)
+	}
+
 	commitBatch, err := tx.vm.db.CommitBatch()
 	if err != nil {
 		return fmt.Errorf("couldn't create commitBatch while processing tx %s: %w", txID, err)
@@ -185,8 +242,16 @@ func (tx *UniqueTx) Accept() error {
 		return fmt.Errorf("ExecuteWithSideEffects erred while processing tx %s: %w", txID, err)
 	}
 
+	utxoStats, err := tx.vm.state.UTXOStats()
+	if err != nil {
+		return fmt.Errorf("couldn't read UTXO stats after accepting tx %s: %w", txID, err)
+	}
+	tx.vm.metrics.observeUTXOStats(utxoStats)
+
 	tx.vm.pubsub.Publish(NewPubSubFilterer(tx.Tx))
+	tx.vm.balancePubsub.Publish(NewPubSubBalanceFilterer(txID, inputUTXOs, outputUTXOs, tx.vm.FormatLocalAddress))
 	tx.vm.walletService.decided(txID)
+	delete(tx.vm.txIssueTimes, txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
 	return nil
@@ -196,6 +261,10 @@ func (tx *UniqueTx) Accept() error {
 func (tx *UniqueTx) Reject() error {
 	defer tx.vm.db.Abort()
 
+	if err := tx.restorePruned(); err != nil {
+		tx.vm.ctx.Log.Error("Failed to restore tx %s due to %s", tx.txID, err)
+		return err
+	}
 	if err := tx.setStatus(choices.Rejected); err != nil {
 		tx.vm.ctx.Log.Error("Failed to reject tx %s due to %s", tx.txID, err)
 		return err
@@ -204,12 +273,18 @@ func (tx *UniqueTx) Reject() error {
 	txID := tx.ID()
 	tx.vm.ctx.Log.Debug("Rejecting Tx: %s", txID)
 
+	if err := tx.vm.metrics.numTxsRejected.Add(1); err != nil {
+		tx.vm.ctx.Log.Error("Failed to count reject %s due to %s", tx.txID, err)
+		return err
+	}
+
 	if err := tx.vm.db.Commit(); err != nil {
 		tx.vm.ctx.Log.Error("Failed to commit reject %s due to %s", tx.txID, err)
 		return err
 	}
 
 	tx.vm.walletService.decided(txID)
+	delete(tx.vm.txIssueTimes, txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
 
@@ -353,6 +428,18 @@ func (tx *UniqueTx) SyntacticVerify() error {
 		tx.vm.CreateAssetTxFee,
 		len(tx.vm.fxs),
 	)
+	if tx.validity != nil {
+		return tx.validity
+	}
+
+	height, err := tx.vm.state.NumAccepted()
+	if err != nil {
+		tx.verifiedTx = false
+		return err
+	}
+	if height >= version.GetAVMStrictEncodingHeight(tx.vm.ctx.NetworkID) {
+		tx.validity = tx.Tx.VerifyCanonical(tx.vm.parser.Codec())
+	}
 	return tx.validity
 }
 
@@ -371,3 +458,21 @@ func (tx *UniqueTx) SemanticVerify() error {
 		vm: tx.vm,
 	})
 }
+
+// txMemo returns the memo of [utx].
+func txMemo(utx txs.UnsignedTx) []byte {
+	switch utx := utx.(type) {
+	case *txs.BaseTx:
+		return utx.Memo
+	case *txs.CreateAssetTx:
+		return utx.Memo
+	case *txs.OperationTx:
+		return utx.Memo
+	case *txs.ImportTx:
+		return utx.Memo
+	case *txs.ExportTx:
+		return utx.Memo
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/json"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+var (
+	errFutureHeight       = json.NewError(ErrorCodeInvalidArgument, "height hasn't been reached")
+	errHeightNotRetained  = json.NewError(ErrorCodeInvalidArgument, "height is older than the retained history")
+	errMissingSpentUTXO   = errors.New("couldn't find spent UTXO")
+	errHistoryUnsupported = json.NewError(ErrorCodeInvalidArgument, "historical UTXOs aren't retained by this node")
+)
+
+// recordAcceptedHeight records that [txID] was accepted at [height], so that
+// it can be undone to serve UTXOs at past heights, and forgets the tx that
+// left the retention window.
+func (vm *VM) recordAcceptedHeight(height uint64, txID ids.ID) error {
+	window := vm.config.HistoricalUTXOWindow
+	if window == 0 {
+		return nil
+	}
+	if err := vm.state.PutAcceptedTxID(height, txID); err != nil {
+		return err
+	}
+	if height < window {
+		return nil
+	}
+	return vm.state.DeleteAcceptedTxID(height - window)
+}
+
+// getUTXOsAtHeight returns the UTXOs that referenced any of [addrs] once the
+// first [height] recorded txs were accepted. They're found by undoing the txs
+// accepted since then, so [height] must be within the retention window.
+func (vm *VM) getUTXOsAtHeight(addrs ids.ShortSet, height uint64) ([]*djtx.UTXO, error) {
+	window := vm.config.HistoricalUTXOWindow
+	numAccepted, err := vm.state.NumAccepted()
+	if err != nil {
+		return nil, err
+	}
+	if height > numAccepted {
+		return nil, fmt.Errorf("%w: %d > %d", errFutureHeight, height, numAccepted)
+	}
+	if numAccepted-height > window {
+		if window == 0 {
+			return nil, errHistoryUnsupported
+		}
+		return nil, fmt.Errorf("%w: %d < %d", errHeightNotRetained, height, numAccepted-window)
+	}
+
+	current, err := djtx.GetAllUTXOs(vm.state, addrs)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get UTXOs: %w", err)
+	}
+	utxos := make(map[ids.ID]*djtx.UTXO, len(current))
+	for _, utxo := range current {
+		utxos[utxo.InputID()] = utxo
+	}
+
+	for h := numAccepted; h > height; h-- {
+		txID, err := vm.state.GetAcceptedTxID(h - 1)
+		if err == database.ErrNotFound {
+			return nil, fmt.Errorf("%w: tx at height %d isn't recorded", errHeightNotRetained, h-1)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := vm.undoTx(txID, addrs, utxos); err != nil {
+			return nil, fmt.Errorf("couldn't undo tx %s: %w", txID, err)
+		}
+	}
+
+	result := make([]*djtx.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		result = append(result, utxo)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		iID, jID := result[i].InputID(), result[j].InputID()
+		return bytes.Compare(iID[:], jID[:]) < 0
+	})
+	return result, nil
+}
+
+// undoTx updates [utxos], the UTXOs referencing [addrs], to how they were
+// before [txID] was accepted.
+func (vm *VM) undoTx(txID ids.ID, addrs ids.ShortSet, utxos map[ids.ID]*djtx.UTXO) error {
+	tx, err := vm.state.GetTx(txID)
+	if err != nil {
+		return err
+	}
+	for _, utxo := range tx.UTXOs() {
+		delete(utxos, utxo.InputID())
+	}
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbol {
+			// the UTXO was imported from another chain
+			continue
+		}
+		utxo, err := vm.getProducedUTXO(utxoID)
+		if err != nil {
+			return err
+		}
+		if referencesAny(utxo, addrs) {
+			utxos[utxo.InputID()] = utxo
+		}
+	}
+	return nil
+}
+
+// getProducedUTXO returns the UTXO that was produced by the tx of [utxoID],
+// even if it has since been spent.
+func (vm *VM) getProducedUTXO(utxoID *djtx.UTXOID) (*djtx.UTXO, error) {
+	tx, err := vm.state.GetTx(utxoID.TxID)
+	if err != nil {
+		return nil, err
+	}
+	inputID := utxoID.InputID()
+	for _, utxo := range tx.UTXOs() {
+		if utxo.InputID() == inputID {
+			return utxo, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", errMissingSpentUTXO, inputID)
+}
+
+// referencesAny returns true if the output of [utxo] references any of
+// [addrs].
+func referencesAny(utxo *djtx.UTXO, addrs ids.ShortSet) bool {
+	addressable, ok := utxo.Out.(djtx.Addressable)
+	if !ok {
+		return false
+	}
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err == nil && addrs.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/vms/avm/states"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+)
+
+func TestGetUTXOsAtHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVMWithConfig(t, nil, nil, Config{
+		HistoricalUTXOWindow: 1,
+	})
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	addrSet := ids.ShortSet{}
+	addrSet.Add(addrs[0])
+	genesisUTXOs, err := djtx.GetAllUTXOs(vm.state, addrSet)
+	assert.NoError(err)
+
+	// Accept a tx that spends one of the genesis UTXOs
+	newTx := NewTx(t, genesisBytes, vm)
+	tx, err := vm.ParseTx(newTx.Bytes())
+	assert.NoError(err)
+	assert.NoError(tx.Verify())
+	assert.NoError(tx.Accept())
+	spentUTXOID := newTx.InputUTXOs()[0].InputID()
+
+	utxos, err := vm.getUTXOsAtHeight(addrSet, 1)
+	assert.NoError(err)
+	assert.Len(utxos, len(genesisUTXOs)-1)
+
+	// Before the tx was accepted, the spent UTXO was still held
+	utxos, err = vm.getUTXOsAtHeight(addrSet, 0)
+	assert.NoError(err)
+	assert.Len(utxos, len(genesisUTXOs))
+	found := false
+	for _, utxo := range utxos {
+		found = found || utxo.InputID() == spentUTXOID
+	}
+	assert.True(found)
+
+	_, err = vm.getUTXOsAtHeight(addrSet, 2)
+	assert.True(errors.Is(err, errFutureHeight))
+
+	// Accepting another tx moves height 0 out of the window
+	assert.NoError(vm.recordAcceptedHeight(1, ids.GenerateTestID()))
+	assert.NoError(vm.state.PutAcceptance(ids.GenerateTestID(), &states.Acceptance{Height: 1}))
+	_, err = vm.getUTXOsAtHeight(addrSet, 0)
+	assert.True(errors.Is(err, errHeightNotRetained))
+}
@@ -4,7 +4,6 @@
 package avm
 
 import (
-	"container/list"
 	"errors"
 	"fmt"
 	"reflect"
@@ -16,9 +15,12 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"google.golang.org/grpc"
+
 	"github.com/lasthyphen/beacongo/cache"
 	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/database/manager"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
 	"github.com/lasthyphen/beacongo/database/versiondb"
 	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/pubsub"
@@ -32,17 +34,20 @@ import (
 	"github.com/lasthyphen/beacongo/utils/timer"
 	"github.com/lasthyphen/beacongo/utils/timer/mockable"
 	"github.com/lasthyphen/beacongo/version"
+	"github.com/lasthyphen/beacongo/vms/avm/admission"
+	"github.com/lasthyphen/beacongo/vms/avm/ratelimit"
 	"github.com/lasthyphen/beacongo/vms/avm/states"
+	"github.com/lasthyphen/beacongo/vms/avm/txbuilder"
 	"github.com/lasthyphen/beacongo/vms/avm/txs"
 	"github.com/lasthyphen/beacongo/vms/components/djtx"
 	"github.com/lasthyphen/beacongo/vms/components/index"
 	"github.com/lasthyphen/beacongo/vms/components/keystore"
 	"github.com/lasthyphen/beacongo/vms/components/verify"
-	"github.com/lasthyphen/beacongo/vms/nftfx"
 	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
 
-	safemath "github.com/lasthyphen/beacongo/utils/math"
 	extensions "github.com/lasthyphen/beacongo/vms/avm/fxs"
+
+	avmpb "github.com/lasthyphen/beacongo/proto/pb/avm"
 )
 
 const (
@@ -55,11 +60,19 @@ const (
 var (
 	errIncompatibleFx            = errors.New("incompatible feature extension")
 	errUnknownFx                 = errors.New("unknown feature extension")
+	errFrozenAsset               = json.NewError(ErrorCodeFrozenAsset, "transfers of the asset are frozen")
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
-	errBootstrapping             = errors.New("chain is currently bootstrapping")
-	errInsufficientFunds         = errors.New("insufficient funds")
+	errAmbiguousFeeAsset         = errors.New("genesis creates multiple assets, so the fee asset must be set in the genesis or the chain config")
+	errFeeAssetNotInGenesis      = errors.New("fee asset isn't created by the genesis")
+	errConflictingFeeAsset       = errors.New("fee asset in the chain config doesn't match the genesis")
+	errBootstrapping             = json.NewError(ErrorCodeBootstrapping, "chain is currently bootstrapping")
+	errInsufficientFunds         = json.NewError(ErrorCodeInsufficientFunds, "insufficient funds")
+	errRateLimited               = json.NewError(ErrorCodeRateLimited, "too many requests")
+
+	memoIndexPrefix = []byte("memoIndex")
 
-	_ vertex.DAGVM = &VM{}
+	_ vertex.DAGVM         = &VM{}
+	_ common.StateDigester = &VM{}
 )
 
 type VM struct {
@@ -72,6 +85,9 @@ type VM struct {
 	// Contains information of where this VM is executing
 	ctx *snow.Context
 
+	// Chain specific configuration
+	config Config
+
 	// Used to check local time
 	clock mockable.Clock
 
@@ -79,6 +95,9 @@ type VM struct {
 
 	pubsub *pubsub.Server
 
+	// Notifies subscribers of balance changes of their addresses
+	balancePubsub *pubsub.Server
+
 	// State management
 	state states.State
 
@@ -96,6 +115,10 @@ type VM struct {
 	batchTimeout time.Duration
 	txs          []snowstorm.Tx
 	toEngine     chan<- common.Message
+	// Time the first tx of [txs] was issued
+	batchStart time.Time
+	// Time each locally issued tx was issued, until it is decided
+	txIssueTimes map[ids.ID]time.Time
 
 	baseDB database.Database
 	db     *versiondb.Database
@@ -105,9 +128,26 @@ type VM struct {
 
 	walletService WalletService
 
+	// Local annotations of the txs issued through this node
+	annotationDB database.Database
+
+	// Decides which transactions may be issued into consensus by this node
+	admissionPolicy admission.Policy
+
+	// Throttles the requests to the chain's APIs
+	apiLimiter *ratelimit.Limiter
+
 	addressTxsIndexer index.AddressTxsIndexer
+	indexBackfill     indexBackfill
+	memoIndexer       index.MemoIndexer
+
+	// Removes the txs that were never decided
+	processingPruner processingPruner
 
 	uniqueTxs cache.Deduplicator
+
+	// Gossips the txs issued to this node
+	network *network
 }
 
 func (vm *VM) Connected(nodeID ids.NodeID, nodeVersion version.Application) error {
@@ -127,6 +167,67 @@ func (vm *VM) Disconnected(nodeID ids.NodeID) error {
 type Config struct {
 	IndexTransactions    bool `json:"index-transactions"`
 	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
+
+	// IndexMemos, if true, indexes accepted txs by their memo so that they can
+	// be looked up with getTxsByMemo. Only the txs accepted while this is
+	// enabled are indexed.
+	IndexMemos bool `json:"index-memos"`
+
+	// TxFee and CreateAssetTxFee, if set, override the fees the node was
+	// started with for this chain. Setting them to 0 allows chains, such as
+	// private subnets, to not charge fees. Every validator of the chain must
+	// use the same values.
+	TxFee            *uint64 `json:"tx-fee,omitempty"`
+	CreateAssetTxFee *uint64 `json:"create-asset-tx-fee,omitempty"`
+
+	// FeeAsset is the alias or ID of the genesis asset that fees are paid in.
+	// It must be set if the genesis creates multiple assets, the first of them
+	// isn't DJTX, and the genesis doesn't name the fee asset. If the genesis
+	// names the fee asset, it must match. Every validator of the chain must
+	// use the same value.
+	FeeAsset string `json:"fee-asset,omitempty"`
+
+	// AdmissionPolicy restricts which transactions this node issues into
+	// consensus. It doesn't affect the verification of transactions issued by
+	// other nodes.
+	AdmissionPolicy admission.Config `json:"admission-policy"`
+
+	// PubSub configures whether clients of the /events and /events/balances
+	// endpoints can resume their subscriptions after reconnecting.
+	PubSub pubsub.Config `json:"pubsub"`
+
+	// APIRateLimits limits how often the JSON-RPC methods of the chain's APIs
+	// can be called.
+	APIRateLimits ratelimit.Config `json:"api-rate-limits"`
+
+	// PersistentMetrics, if true, stores the counts of accepted and rejected
+	// txs in the database so that the metrics resume from their prior values
+	// after a restart.
+	PersistentMetrics bool `json:"persistent-metrics"`
+
+	// HistoricalUTXOWindow is the number of most recently accepted txs that
+	// are undone to serve the UTXOs and balances of addresses at past
+	// heights. If 0, only the current UTXOs are served.
+	HistoricalUTXOWindow uint64 `json:"historical-utxo-window"`
+
+	// ProcessingTxTimeout is how long a tx may stay processing before it's
+	// assumed to be orphaned and is removed from the database. If 0, it
+	// defaults to 1 hour.
+	ProcessingTxTimeout time.Duration `json:"processing-tx-timeout"`
+
+	// StartupVerifyDepth is the number of most recently accepted txs that are
+	// checked against their stored statuses and the UTXO set before the chain
+	// starts, so that a corrupted database is caught early. Only the heights
+	// retained for HistoricalUTXOWindow can be checked. If 0, nothing is
+	// checked.
+	StartupVerifyDepth uint64 `json:"startup-verify-depth"`
+
+	// StrictAddresses, if true, requires the addresses passed to the chain's
+	// APIs to name this chain and network, such as "X-dijetsx1...", so that
+	// funds aren't sent to an address meant for another network. If false,
+	// which tooling that passes raw address IDs relies on, raw address IDs are
+	// also accepted.
+	StrictAddresses bool `json:"strict-addresses"`
 }
 
 func (vm *VM) Initialize(
@@ -137,7 +238,7 @@ func (vm *VM) Initialize(
 	configBytes []byte,
 	toEngine chan<- common.Message,
 	fxs []*common.Fx,
-	_ common.AppSender,
+	appSender common.AppSender,
 ) error {
 	avmConfig := Config{}
 	if len(configBytes) > 0 {
@@ -146,6 +247,7 @@ func (vm *VM) Initialize(
 		}
 		ctx.Log.Info("VM config initialized %+v", avmConfig)
 	}
+	vm.config = avmConfig
 
 	registerer := prometheus.NewRegistry()
 	if err := ctx.Metrics.Register(registerer); err != nil {
@@ -156,9 +258,29 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return err
 	}
-	vm.AddressManager = djtx.NewAddressManager(ctx)
+	vm.apiLimiter, err = ratelimit.New(avmConfig.APIRateLimits, "", registerer)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize API rate limits: %w", err)
+	}
+	if avmConfig.TxFee != nil {
+		vm.TxFee = *avmConfig.TxFee
+	}
+	if avmConfig.CreateAssetTxFee != nil {
+		vm.CreateAssetTxFee = *avmConfig.CreateAssetTxFee
+	}
+
+	if avmConfig.StrictAddresses {
+		vm.AddressManager = djtx.NewStrictAddressManager(ctx)
+	} else {
+		vm.AddressManager = djtx.NewAddressManager(ctx)
+	}
 	vm.Aliaser = ids.NewAliaser()
 
+	vm.admissionPolicy, err = admission.New(avmConfig.AdmissionPolicy, vm.ParseLocalAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize admission policy: %w", err)
+	}
+
 	db := dbManager.Current().Database
 	vm.ctx = ctx
 	vm.toEngine = toEngine
@@ -166,7 +288,8 @@ func (vm *VM) Initialize(
 	vm.db = versiondb.New(db)
 	vm.assetToFxCache = &cache.LRU{Size: assetToFxCacheSize}
 
-	vm.pubsub = pubsub.New(ctx.NetworkID, ctx.Log)
+	vm.pubsub = pubsub.NewWithConfig(ctx.NetworkID, ctx.Log, avmConfig.PubSub)
+	vm.balancePubsub = pubsub.NewWithConfig(ctx.NetworkID, ctx.Log, avmConfig.PubSub)
 
 	typedFxs := make([]extensions.Fx, len(fxs))
 	vm.fxs = make([]*extensions.ParsedFx, len(fxs))
@@ -205,9 +328,22 @@ func (vm *VM) Initialize(
 
 	vm.state = state
 
+	// The counters are written to [vm.db] so that they're committed together
+	// with the txs they count
+	var metricsDB database.KeyValueReaderWriter
+	if avmConfig.PersistentMetrics {
+		metricsDB = prefixdb.New(metricsPrefix, vm.db)
+	}
+	if err := vm.metrics.initializePersistent("", registerer, metricsDB); err != nil {
+		return fmt.Errorf("failed to initialize persistent metrics: %w", err)
+	}
+
 	if err := vm.initGenesis(genesisBytes); err != nil {
 		return err
 	}
+	if err := vm.buildAssetSupply(); err != nil {
+		return fmt.Errorf("failed to build asset supplies: %w", err)
+	}
 
 	vm.timer = timer.NewTimer(func() {
 		ctx.Lock.Lock()
@@ -217,13 +353,21 @@ func (vm *VM) Initialize(
 	})
 	go ctx.Log.RecoverAndPanic(vm.timer.Dispatch)
 	vm.batchTimeout = batchTimeout
+	vm.txIssueTimes = make(map[ids.ID]time.Time)
 
 	vm.uniqueTxs = &cache.EvictableLRU{
 		Size: txDeduplicatorSize,
 	}
-	vm.walletService.vm = vm
-	vm.walletService.pendingTxMap = make(map[ids.ID]*list.Element)
-	vm.walletService.pendingTxOrdering = list.New()
+	vm.network = newNetwork(vm, appSender)
+	// Pending txs are written to the base database directly, as they change
+	// independently of the commits of the VM's state.
+	walletDB := prefixdb.New(walletPendingPrefix, vm.baseDB)
+	if err := vm.walletService.initialize(vm, walletDB); err != nil {
+		return fmt.Errorf("failed to restore pending wallet txs: %w", err)
+	}
+	// Annotations aren't part of the chain's state, so they're also written
+	// to the base database.
+	vm.annotationDB = prefixdb.New(txAnnotationPrefix, vm.baseDB)
 
 	// use no op impl when disabled in config
 	if avmConfig.IndexTransactions {
@@ -239,6 +383,33 @@ func (vm *VM) Initialize(
 			return fmt.Errorf("failed to initialize disabled indexer: %w", err)
 		}
 	}
+	if avmConfig.IndexMemos {
+		vm.ctx.Log.Info("memo indexing is enabled")
+		vm.memoIndexer = index.NewMemoIndexer(prefixdb.New(memoIndexPrefix, vm.db))
+	} else {
+		vm.memoIndexer = index.NewNoMemoIndexer()
+	}
+	if err := vm.indexBackfill.initialize(vm, vm.db, registerer); err != nil {
+		return fmt.Errorf("failed to initialize index backfill: %w", err)
+	}
+	if err := vm.processingPruner.initialize(vm, vm.db, avmConfig.ProcessingTxTimeout, registerer); err != nil {
+		return fmt.Errorf("failed to initialize processing tx pruner: %w", err)
+	}
+	// Nothing is processing yet, so the txs left processing by a previous run
+	// can be pruned before consensus starts.
+	if err := vm.processingPruner.prune(); err != nil {
+		return fmt.Errorf("failed to prune processing txs: %w", err)
+	}
+	if avmConfig.StartupVerifyDepth > 0 {
+		if err := vm.verifyIntegrity(avmConfig.StartupVerifyDepth); err != nil {
+			return fmt.Errorf("failed to verify the integrity of the state: %w", err)
+		}
+	}
+	utxoStats, err := vm.state.UTXOStats()
+	if err != nil {
+		return fmt.Errorf("failed to read UTXO stats: %w", err)
+	}
+	vm.metrics.observeUTXOStats(utxoStats)
 	return vm.db.Commit()
 }
 
@@ -259,6 +430,8 @@ func (vm *VM) onNormalOperationsStarted() error {
 		}
 	}
 	vm.bootstrapped = true
+	vm.walletService.reissuePending()
+	vm.processingPruner.start()
 	return nil
 }
 
@@ -273,6 +446,12 @@ func (vm *VM) SetState(state snow.State) error {
 	}
 }
 
+// StateDigest implements the common.StateDigester interface. Only committed
+// state is included.
+func (vm *VM) StateDigest() (ids.ID, error) {
+	return states.Digest(vm.baseDB)
+}
+
 func (vm *VM) Shutdown() error {
 	if vm.timer == nil {
 		return nil
@@ -284,6 +463,8 @@ func (vm *VM) Shutdown() error {
 	vm.timer.Stop()
 	vm.ctx.Lock.Lock()
 
+	vm.indexBackfill.shutdown()
+	vm.processingPruner.shutdown()
 	return vm.baseDB.Close()
 }
 
@@ -299,6 +480,7 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 	rpcServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	rpcServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
 	rpcServer.RegisterAfterFunc(vm.metrics.apiRequestMetric.AfterRequest)
+	rpcServer.RegisterValidateRequestFunc(vm.limitRequest)
 	// name this service "avm"
 	if err := rpcServer.RegisterService(&Service{vm: vm}, "avm"); err != nil {
 		return nil, err
@@ -309,16 +491,48 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 	walletServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	walletServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
 	walletServer.RegisterAfterFunc(vm.metrics.apiRequestMetric.AfterRequest)
+	walletServer.RegisterValidateRequestFunc(vm.limitRequest)
 	// name this service "wallet"
-	err := walletServer.RegisterService(&vm.walletService, "wallet")
+	if err := walletServer.RegisterService(&vm.walletService, "wallet"); err != nil {
+		return nil, err
+	}
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(codec, "application/json")
+	adminServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.apiRequestMetric.AfterRequest)
+	adminServer.RegisterValidateRequestFunc(vm.limitRequest)
+	// name this service "avmAdmin"
+	err := adminServer.RegisterService(&AdminService{vm: vm}, "avmAdmin")
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(vm.limitUnary),
+		grpc.StreamInterceptor(vm.limitStream),
+	)
+	avmpb.RegisterAVMServer(grpcServer, newGRPCService(vm))
 
 	return map[string]*common.HTTPHandler{
-		"":        {Handler: rpcServer},
-		"/wallet": {Handler: walletServer},
-		"/events": {LockOptions: common.NoLock, Handler: vm.pubsub},
+		"":                 {Handler: rpcServer},
+		"/wallet":          {Handler: walletServer},
+		"/admin":           {Handler: adminServer},
+		"/admin/export":    {LockOptions: common.NoLock, Handler: &txHistoryExporter{vm: vm}},
+		"/events":          {LockOptions: common.NoLock, Handler: vm.pubsub},
+		"/events/balances": {LockOptions: common.NoLock, Handler: vm.balancePubsub},
+		// The gRPC methods grab the lock themselves, so that streams don't
+		// hold it between messages
+		grpcEndpoint + "/": {LockOptions: common.NoLock, Handler: &grpcHandler{server: grpcServer}},
 	}, err
 }
 
+// limitRequest refuses requests that exceed the API rate limits.
+func (vm *VM) limitRequest(i *rpc.RequestInfo, _ interface{}) error {
+	if !vm.apiLimiter.Allow(i.Method, i.Request) {
+		return errRateLimited
+	}
+	return nil
+}
+
 func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
 	newServer := rpc.NewServer()
 	codec := json.NewCodec()
@@ -337,6 +551,11 @@ func (vm *VM) PendingTxs() []snowstorm.Tx {
 
 	txs := vm.txs
 	vm.txs = nil
+	if len(txs) != 0 {
+		vm.metrics.batchSize.Observe(float64(len(txs)))
+		vm.metrics.batchLatency.Observe(vm.clock.Time().Sub(vm.batchStart).Seconds())
+	}
+	vm.metrics.numPendingTxs.Set(0)
 	return txs
 }
 
@@ -375,10 +594,42 @@ func (vm *VM) IssueTx(b []byte) (ids.ID, error) {
 	if err := tx.verifyWithoutCacheWrites(); err != nil {
 		return ids.ID{}, err
 	}
+	if err := vm.admit(tx); err != nil {
+		return ids.ID{}, err
+	}
 	vm.issueTx(tx)
+	if err := vm.network.GossipTx(tx); err != nil {
+		vm.ctx.Log.Warn("couldn't gossip tx %s: %s", tx.ID(), err)
+	}
 	return tx.ID(), nil
 }
 
+// admit returns an error if the admission policy refuses [tx].
+func (vm *VM) admit(tx *UniqueTx) error {
+	if err := vm.admissionPolicy.Admit(tx.Tx, vm.consumedUTXOs(tx.InputUTXOs())); err != nil {
+		vm.metrics.numTxsNotAdmitted.Inc()
+		return fmt.Errorf("tx %s refused by admission policy: %w", tx.ID(), err)
+	}
+	return nil
+}
+
+// consumedUTXOs returns the UTXOs of [utxoIDs] that are known to this chain.
+func (vm *VM) consumedUTXOs(utxoIDs []*djtx.UTXOID) []*djtx.UTXO {
+	consumed := []*djtx.UTXO(nil)
+	for _, utxoID := range utxoIDs {
+		if utxoID.Symbolic() {
+			continue
+		}
+		// Imported UTXOs aren't stored on this chain
+		utxo, err := vm.getUTXO(utxoID)
+		if err != nil {
+			continue
+		}
+		consumed = append(consumed, utxo)
+	}
+	return consumed
+}
+
 func (vm *VM) issueStopVertex() error {
 	select {
 	case vm.toEngine <- common.StopVertex:
@@ -402,6 +653,7 @@ func (vm *VM) FlushTxs() {
 		case vm.toEngine <- common.PendingTxs:
 		default:
 			vm.ctx.Log.Debug("dropping message to engine due to contention")
+			vm.metrics.numDroppedPendingTxsMsgs.Inc()
 			vm.timer.SetTimeoutIn(vm.batchTimeout)
 		}
 	}
@@ -414,9 +666,8 @@ func (vm *VM) FlushTxs() {
  */
 
 func (vm *VM) initGenesis(genesisBytes []byte) error {
-	genesisCodec := vm.parser.GenesisCodec()
-	genesis := Genesis{}
-	if _, err := genesisCodec.Unmarshal(genesisBytes, &genesis); err != nil {
+	genesis, err := ParseGenesis(vm.parser.GenesisCodec(), genesisBytes)
+	if err != nil {
 		return err
 	}
 
@@ -425,10 +676,8 @@ func (vm *VM) initGenesis(genesisBytes []byte) error {
 		return err
 	}
 
-	// secure this by defaulting to djtxAsset
-	vm.feeAssetID = vm.ctx.DJTXAssetID
-
-	for index, genesisTx := range genesis.Txs {
+	genesisAssetIDs := make([]ids.ID, 0, len(genesis.Txs))
+	for _, genesisTx := range genesis.Txs {
 		if len(genesisTx.Outs) != 0 {
 			return errGenesisAssetMustHaveState
 		}
@@ -450,12 +699,15 @@ func (vm *VM) initGenesis(genesisBytes []byte) error {
 				return err
 			}
 		}
-		if index == 0 {
-			vm.ctx.Log.Info("Fee payments are using Asset with Alias: %s, AssetID: %s", genesisTx.Alias, txID)
-			vm.feeAssetID = txID
-		}
+		genesisAssetIDs = append(genesisAssetIDs, txID)
 	}
 
+	vm.feeAssetID, err = vm.selectFeeAsset(genesisAssetIDs, genesis.FeeAsset)
+	if err != nil {
+		return err
+	}
+	vm.ctx.Log.Info("Fee payments are using AssetID: %s", vm.feeAssetID)
+
 	if !stateInitialized {
 		return vm.state.SetInitialized()
 	}
@@ -463,6 +715,58 @@ func (vm *VM) initGenesis(genesisBytes []byte) error {
 	return nil
 }
 
+// selectFeeAsset returns the asset that fees are paid in, given the IDs of the
+// assets created by the genesis, in order, and the fee asset named by the
+// genesis, if any. If both the genesis and the chain config name the fee
+// asset, they must agree.
+func (vm *VM) selectFeeAsset(genesisAssetIDs []ids.ID, genesisFeeAsset string) (ids.ID, error) {
+	var genesisFeeAssetID ids.ID
+	if genesisFeeAsset != "" {
+		var err error
+		genesisFeeAssetID, err = vm.genesisAssetID(genesisAssetIDs, genesisFeeAsset)
+		if err != nil {
+			return ids.Empty, fmt.Errorf("invalid genesis fee asset: %w", err)
+		}
+	}
+	if vm.config.FeeAsset != "" {
+		feeAssetID, err := vm.genesisAssetID(genesisAssetIDs, vm.config.FeeAsset)
+		if err != nil {
+			return ids.Empty, fmt.Errorf("invalid fee asset: %w", err)
+		}
+		if genesisFeeAsset != "" && feeAssetID != genesisFeeAssetID {
+			return ids.Empty, fmt.Errorf("%w: %s != %s", errConflictingFeeAsset, vm.config.FeeAsset, genesisFeeAsset)
+		}
+		return feeAssetID, nil
+	}
+	if genesisFeeAsset != "" {
+		return genesisFeeAssetID, nil
+	}
+
+	switch {
+	case len(genesisAssetIDs) == 0:
+		return vm.ctx.DJTXAssetID, nil
+	case len(genesisAssetIDs) == 1, genesisAssetIDs[0] == vm.ctx.DJTXAssetID:
+		return genesisAssetIDs[0], nil
+	default:
+		return ids.Empty, errAmbiguousFeeAsset
+	}
+}
+
+// genesisAssetID returns the ID of [asset], which must be the alias or ID of
+// one of [genesisAssetIDs].
+func (vm *VM) genesisAssetID(genesisAssetIDs []ids.ID, asset string) (ids.ID, error) {
+	assetID, err := vm.lookupAssetID(asset)
+	if err != nil {
+		return ids.Empty, err
+	}
+	for _, genesisAssetID := range genesisAssetIDs {
+		if genesisAssetID == assetID {
+			return assetID, nil
+		}
+	}
+	return ids.Empty, fmt.Errorf("%w: %s", errFeeAssetNotInGenesis, asset)
+}
+
 func (vm *VM) initState(tx txs.Tx) error {
 	txID := tx.ID()
 	vm.ctx.Log.Info("initializing with AssetID %s", txID)
@@ -472,12 +776,16 @@ func (vm *VM) initState(tx txs.Tx) error {
 	if err := vm.state.PutStatus(txID, choices.Accepted); err != nil {
 		return err
 	}
-	for _, utxo := range tx.UTXOs() {
+	if err := vm.putAssetMetadata(txID, tx.UnsignedTx); err != nil {
+		return err
+	}
+	utxos := tx.UTXOs()
+	for _, utxo := range utxos {
 		if err := vm.state.PutUTXO(utxo.InputID(), utxo); err != nil {
 			return err
 		}
 	}
-	return nil
+	return vm.updateFrozenAssets(utxos)
 }
 
 func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
@@ -504,14 +812,38 @@ func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
 		if err := tx.setStatus(choices.Processing); err != nil {
 			return nil, err
 		}
+		if err := vm.state.PutProcessing(tx.ID(), vm.clock.Time()); err != nil {
+			return nil, err
+		}
 		return tx, vm.db.Commit()
 	}
 
 	return tx, nil
 }
 
+// parseUnsignedTx parses [bytes] as either a signed or an unsigned tx, without
+// verifying it or writing it to state.
+func (vm *VM) parseUnsignedTx(bytes []byte) (txs.UnsignedTx, error) {
+	if tx, err := vm.parser.Parse(bytes); err == nil {
+		return tx.UnsignedTx, nil
+	}
+
+	var utx txs.UnsignedTx
+	if _, err := vm.parser.Codec().Unmarshal(bytes, &utx); err != nil {
+		return nil, fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	return utx, nil
+}
+
 func (vm *VM) issueTx(tx snowstorm.Tx) {
+	if _, ok := vm.txIssueTimes[tx.ID()]; !ok {
+		vm.txIssueTimes[tx.ID()] = vm.clock.Time()
+	}
 	vm.txs = append(vm.txs, tx)
+	vm.metrics.numPendingTxs.Set(float64(len(vm.txs)))
+	if len(vm.txs) == 1 {
+		vm.batchStart = vm.clock.Time()
+	}
 	switch {
 	case len(vm.txs) == batchSize:
 		vm.FlushTxs()
@@ -520,6 +852,40 @@ func (vm *VM) issueTx(tx snowstorm.Tx) {
 	}
 }
 
+// cancelTx removes [txID] from the batch of txs that haven't been flushed into
+// consensus yet and rejects it, so that its inputs can be spent again.
+func (vm *VM) cancelTx(txID ids.ID) error {
+	index := -1
+	for i, tx := range vm.txs {
+		if tx.ID() == txID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return errTxAlreadyIssued
+	}
+	for _, tx := range vm.txs {
+		uniqueTx, ok := tx.(*UniqueTx)
+		if !ok {
+			continue
+		}
+		for _, utxoID := range uniqueTx.InputUTXOs() {
+			if sourceID, _ := utxoID.InputSource(); sourceID == txID {
+				return errTxHasDependents
+			}
+		}
+	}
+
+	tx := vm.txs[index]
+	vm.txs = append(vm.txs[:index], vm.txs[index+1:]...)
+	vm.metrics.numPendingTxs.Set(float64(len(vm.txs)))
+	if len(vm.txs) == 0 {
+		vm.timer.Cancel()
+	}
+	return tx.Reject()
+}
+
 func (vm *VM) getUTXO(utxoID *djtx.UTXOID) (*djtx.UTXO, error) {
 	inputID := utxoID.InputID()
 	utxo, err := vm.state.GetUTXO(inputID)
@@ -586,62 +952,81 @@ func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 	return fxIDs.Contains(uint(fxID))
 }
 
-func (vm *VM) verifyTransferOfUTXO(tx txs.UnsignedTx, in *djtx.TransferableInput, cred verify.Verifiable, utxo *djtx.UTXO) error {
+// verifyTransferOfUTXO verifies that [in] can spend [utxo]. The checks are
+// recorded in [step], if it's non-nil.
+func (vm *VM) verifyTransferOfUTXO(tx txs.UnsignedTx, in *djtx.TransferableInput, cred verify.Verifiable, utxo *djtx.UTXO, step *TraceStep) error {
 	fxIndex, err := vm.getFx(cred)
-	if err != nil {
+	if err := step.check("credentialFx", err); err != nil {
 		return err
 	}
 	fx := vm.fxs[fxIndex].Fx
+	step.fx(vm, fxIndex)
 
 	utxoAssetID := utxo.AssetID()
 	inAssetID := in.AssetID()
 	if utxoAssetID != inAssetID {
-		return errAssetIDMismatch
+		return step.check("assetID", errAssetIDMismatch)
 	}
+	step.pass("assetID")
 
 	if !vm.verifyFxUsage(fxIndex, inAssetID) {
-		return errIncompatibleFx
+		return step.check("fxUsage", errIncompatibleFx)
+	}
+	step.pass("fxUsage")
+
+	frozen, err := vm.state.IsFrozen(inAssetID)
+	if err != nil {
+		return step.check("frozen", err)
 	}
+	if frozen {
+		return step.check("frozen", errFrozenAsset)
+	}
+	step.pass("frozen")
 
-	return fx.VerifyTransfer(tx, in.In, cred, utxo.Out)
+	return step.check("fxVerifyTransfer", fx.VerifyTransfer(tx, in.In, cred, utxo.Out))
 }
 
-func (vm *VM) verifyTransfer(tx txs.UnsignedTx, in *djtx.TransferableInput, cred verify.Verifiable) error {
+func (vm *VM) verifyTransfer(tx txs.UnsignedTx, in *djtx.TransferableInput, cred verify.Verifiable, step *TraceStep) error {
 	utxo, err := vm.getUTXO(&in.UTXOID)
-	if err != nil {
+	if err := step.check("utxo", err); err != nil {
 		return err
 	}
-	return vm.verifyTransferOfUTXO(tx, in, cred, utxo)
+	step.utxo(utxo)
+	return vm.verifyTransferOfUTXO(tx, in, cred, utxo, step)
 }
 
-func (vm *VM) verifyOperation(tx *txs.OperationTx, op *txs.Operation, cred verify.Verifiable) error {
+func (vm *VM) verifyOperation(tx *txs.OperationTx, op *txs.Operation, cred verify.Verifiable, step *TraceStep) error {
 	opAssetID := op.AssetID()
 
 	numUTXOs := len(op.UTXOIDs)
 	utxos := make([]interface{}, numUTXOs)
 	for i, utxoID := range op.UTXOIDs {
 		utxo, err := vm.getUTXO(utxoID)
-		if err != nil {
+		if err := step.check("utxo", err); err != nil {
 			return err
 		}
+		step.utxo(utxo)
 
 		utxoAssetID := utxo.AssetID()
 		if utxoAssetID != opAssetID {
-			return errAssetIDMismatch
+			return step.check("assetID", errAssetIDMismatch)
 		}
+		step.pass("assetID")
 		utxos[i] = utxo.Out
 	}
 
 	fxIndex, err := vm.getFx(op.Op)
-	if err != nil {
+	if err := step.check("operationFx", err); err != nil {
 		return err
 	}
 	fx := vm.fxs[fxIndex].Fx
+	step.fx(vm, fxIndex)
 
 	if !vm.verifyFxUsage(fxIndex, opAssetID) {
-		return errIncompatibleFx
+		return step.check("fxUsage", errIncompatibleFx)
 	}
-	return fx.VerifyOperation(tx, op.Op, cred, utxos)
+	step.pass("fxUsage")
+	return step.check("fxVerifyOperation", fx.VerifyOperation(tx, op.Op, cred, utxos))
 }
 
 // LoadUser returns:
@@ -679,6 +1064,27 @@ func (vm *VM) LoadUser(
 	return utxos, kc, user.Close()
 }
 
+// AddUserKeys adds the keys controlled by the keystore user [username] to [kc].
+// This allows spending UTXOs whose owners are split across multiple users.
+func (vm *VM) AddUserKeys(kc *secp256k1fx.Keychain, username, password string) error {
+	user, err := keystore.NewUserFromKeystore(vm.ctx.Keystore, username, password)
+	if err != nil {
+		return err
+	}
+	// Drop any potential error closing the database to report the original
+	// error
+	defer user.Close()
+
+	userKC, err := keystore.GetKeychain(user, nil)
+	if err != nil {
+		return err
+	}
+	for _, key := range userKC.Keys {
+		kc.Add(key)
+	}
+	return user.Close()
+}
+
 func (vm *VM) Spend(
 	utxos []*djtx.UTXO,
 	kc *secp256k1fx.Keychain,
@@ -689,18 +1095,42 @@ func (vm *VM) Spend(
 	[][]*crypto.PrivateKeySECP256K1R,
 	error,
 ) {
+	return vm.SpendWithCoinSelection(utxos, kc, amounts, CoinSelectionInOrder)
+}
+
+// SpendWithCoinSelection is like Spend, but the UTXOs of each asset are chosen
+// according to [strategy].
+func (vm *VM) SpendWithCoinSelection(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	amounts map[ids.ID]uint64,
+	strategy CoinSelection,
+) (
+	map[ids.ID]uint64,
+	[]*djtx.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	if err := strategy.Verify(); err != nil {
+		return nil, nil, nil, err
+	}
+
 	amountsSpent := make(map[ids.ID]uint64, len(amounts))
 	time := vm.clock.Unix()
 
 	ins := []*djtx.TransferableInput{}
 	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	if !requiresInputs(amounts) {
+		// Nothing needs to be spent, such as when only the fee of a chain
+		// without fees is being paid.
+		return amountsSpent, ins, keys, nil
+	}
+
+	candidates := make(map[ids.ID][]spendCandidate, len(amounts))
 	for _, utxo := range utxos {
 		assetID := utxo.AssetID()
-		amount := amounts[assetID]
-		amountSpent := amountsSpent[assetID]
-
-		if amountSpent >= amount {
-			// we already have enough inputs allocated to this asset
+		if amounts[assetID] == 0 {
+			// we don't need to spend this asset
 			continue
 		}
 
@@ -714,30 +1144,39 @@ func (vm *VM) Spend(
 			// this input doesn't have an amount, so I don't care about it here
 			continue
 		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			// there was an error calculating the consumed amount, just error
-			return nil, nil, nil, errSpendOverflow
-		}
-		amountsSpent[assetID] = newAmountSpent
-
-		// add the new input to the array
-		ins = append(ins, &djtx.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  djtx.Asset{ID: assetID},
-			In:     input,
+		candidates[assetID] = append(candidates[assetID], spendCandidate{
+			utxo:    utxo,
+			in:      input,
+			signers: signers,
 		})
-		// add the required keys to the array
-		keys = append(keys, signers)
 	}
 
-	for asset, amount := range amounts {
-		if amountsSpent[asset] < amount {
-			return nil, nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
-				amount,
-				asset,
-				amountsSpent[asset],
-			)
+	for assetID, amount := range amounts {
+		if amount == 0 {
+			continue
+		}
+		selected, amountSpent, err := selectUTXOs(strategy, candidates[assetID], amount)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if amountSpent < amount {
+			return nil, nil, nil, &InsufficientFundsError{
+				AssetID:   assetID,
+				Needed:    amount,
+				Available: amountSpent,
+			}
+		}
+		amountsSpent[assetID] = amountSpent
+
+		for _, candidate := range selected {
+			// add the new input to the array
+			ins = append(ins, &djtx.TransferableInput{
+				UTXOID: candidate.utxo.UTXOID,
+				Asset:  djtx.Asset{ID: assetID},
+				In:     candidate.in,
+			})
+			// add the required keys to the array
+			keys = append(keys, candidate.signers)
 		}
 	}
 
@@ -745,78 +1184,100 @@ func (vm *VM) Spend(
 	return amountsSpent, ins, keys, nil
 }
 
-func (vm *VM) SpendNFT(
+// SpendWithAddresses is like SpendWithCoinSelection, but it selects the UTXOs
+// that can be spent by [addrs] rather than by the keys of a keychain. The
+// returned inputs must be signed externally.
+func (vm *VM) SpendWithAddresses(
 	utxos []*djtx.UTXO,
-	kc *secp256k1fx.Keychain,
-	assetID ids.ID,
-	groupID uint32,
-	to ids.ShortID,
+	addrs ids.ShortSet,
+	amounts map[ids.ID]uint64,
+	strategy CoinSelection,
 ) (
-	[]*txs.Operation,
-	[][]*crypto.PrivateKeySECP256K1R,
+	map[ids.ID]uint64,
+	[]*djtx.TransferableInput,
 	error,
 ) {
-	time := vm.clock.Unix()
+	if err := strategy.Verify(); err != nil {
+		return nil, nil, err
+	}
 
-	ops := []*txs.Operation{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	amountsSpent := make(map[ids.ID]uint64, len(amounts))
+	time := vm.clock.Unix()
 
+	candidates := make(map[ids.ID][]spendCandidate, len(amounts))
 	for _, utxo := range utxos {
-		// makes sure that the variable isn't overwritten with the next iteration
-		utxo := utxo
-
-		if len(ops) > 0 {
-			// we have already been able to create the operation needed
-			break
-		}
-
-		if utxo.AssetID() != assetID {
-			// wrong asset ID
+		assetID := utxo.AssetID()
+		if amounts[assetID] == 0 {
+			// we don't need to spend this asset
 			continue
 		}
-		out, ok := utxo.Out.(*nftfx.TransferOutput)
+
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
 		if !ok {
-			// wrong output type
-			continue
-		}
-		if out.GroupID != groupID {
-			// wrong group id
+			// this output can't be spent by a transfer input
 			continue
 		}
-		indices, signers, ok := kc.Match(&out.OutputOwners, time)
-		if !ok {
-			// unable to spend the output
+		sigIndices, _, able := matchAddrs(&out.OutputOwners, addrs, time)
+		if !able {
+			// this utxo can't be spent with the provided addresses right now
 			continue
 		}
-
-		// add the new operation to the array
-		ops = append(ops, &txs.Operation{
-			Asset:   utxo.Asset,
-			UTXOIDs: []*djtx.UTXOID{&utxo.UTXOID},
-			Op: &nftfx.TransferOperation{
-				Input: secp256k1fx.Input{
-					SigIndices: indices,
-				},
-				Output: nftfx.TransferOutput{
-					GroupID: out.GroupID,
-					Payload: out.Payload,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Threshold: 1,
-						Addrs:     []ids.ShortID{to},
-					},
-				},
+		candidates[assetID] = append(candidates[assetID], spendCandidate{
+			utxo: utxo,
+			in: &secp256k1fx.TransferInput{
+				Amt:   out.Amt,
+				Input: secp256k1fx.Input{SigIndices: sigIndices},
 			},
 		})
-		// add the required keys to the array
-		keys = append(keys, signers)
 	}
 
-	if len(ops) == 0 {
-		return nil, nil, errInsufficientFunds
+	ins := []*djtx.TransferableInput{}
+	for assetID, amount := range amounts {
+		if amount == 0 {
+			continue
+		}
+		selected, amountSpent, err := selectUTXOs(strategy, candidates[assetID], amount)
+		if err != nil {
+			return nil, nil, err
+		}
+		if amountSpent < amount {
+			return nil, nil, &InsufficientFundsError{
+				AssetID:   assetID,
+				Needed:    amount,
+				Available: amountSpent,
+			}
+		}
+		amountsSpent[assetID] = amountSpent
+
+		for _, candidate := range selected {
+			ins = append(ins, &djtx.TransferableInput{
+				UTXOID: candidate.utxo.UTXOID,
+				Asset:  djtx.Asset{ID: assetID},
+				In:     candidate.in,
+			})
+		}
 	}
 
-	txs.SortOperationsWithSigners(ops, keys, vm.parser.Codec())
-	return ops, keys, nil
+	djtx.SortTransferableInputs(ins)
+	return amountsSpent, ins, nil
+}
+
+func (vm *VM) SpendNFT(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	groupID uint32,
+	to ids.ShortID,
+) (
+	[]*txs.Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	ops, keys, err := txbuilder.SpendNFT(vm.parser.Codec(), utxos, kc, assetID, groupID, to, vm.clock.Unix())
+	if err == txbuilder.ErrInsufficientFunds {
+		return nil, nil, errInsufficientFunds
+	}
+	return ops, keys, err
 }
 
 func (vm *VM) SpendAll(
@@ -828,44 +1289,11 @@ func (vm *VM) SpendAll(
 	[][]*crypto.PrivateKeySECP256K1R,
 	error,
 ) {
-	amountsSpent := make(map[ids.ID]uint64)
-	time := vm.clock.Unix()
-
-	ins := []*djtx.TransferableInput{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-	for _, utxo := range utxos {
-		assetID := utxo.AssetID()
-		amountSpent := amountsSpent[assetID]
-
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
-		if err != nil {
-			// this utxo can't be spent with the current keys right now
-			continue
-		}
-		input, ok := inputIntf.(djtx.TransferableIn)
-		if !ok {
-			// this input doesn't have an amount, so I don't care about it here
-			continue
-		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			// there was an error calculating the consumed amount, just error
-			return nil, nil, nil, errSpendOverflow
-		}
-		amountsSpent[assetID] = newAmountSpent
-
-		// add the new input to the array
-		ins = append(ins, &djtx.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  djtx.Asset{ID: assetID},
-			In:     input,
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
+	amountsSpent, ins, keys, err := txbuilder.SpendAll(utxos, kc, vm.clock.Unix())
+	if err == txbuilder.ErrSpendOverflow {
+		return nil, nil, nil, errSpendOverflow
 	}
-
-	djtx.SortTransferableInputsWithSigners(ins, keys)
-	return amountsSpent, ins, keys, nil
+	return amountsSpent, ins, keys, err
 }
 
 func (vm *VM) Mint(
@@ -878,67 +1306,11 @@ func (vm *VM) Mint(
 	[][]*crypto.PrivateKeySECP256K1R,
 	error,
 ) {
-	time := vm.clock.Unix()
-
-	ops := []*txs.Operation{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-
-	for _, utxo := range utxos {
-		// makes sure that the variable isn't overwritten with the next iteration
-		utxo := utxo
-
-		assetID := utxo.AssetID()
-		amount := amounts[assetID]
-		if amount == 0 {
-			continue
-		}
-
-		out, ok := utxo.Out.(*secp256k1fx.MintOutput)
-		if !ok {
-			continue
-		}
-
-		inIntf, signers, err := kc.Spend(out, time)
-		if err != nil {
-			continue
-		}
-
-		in, ok := inIntf.(*secp256k1fx.Input)
-		if !ok {
-			continue
-		}
-
-		// add the operation to the array
-		ops = append(ops, &txs.Operation{
-			Asset:   utxo.Asset,
-			UTXOIDs: []*djtx.UTXOID{&utxo.UTXOID},
-			Op: &secp256k1fx.MintOperation{
-				MintInput:  *in,
-				MintOutput: *out,
-				TransferOutput: secp256k1fx.TransferOutput{
-					Amt: amount,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Threshold: 1,
-						Addrs:     []ids.ShortID{to},
-					},
-				},
-			},
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
-
-		// remove the asset from the required amounts to mint
-		delete(amounts, assetID)
-	}
-
-	for _, amount := range amounts {
-		if amount > 0 {
-			return nil, nil, errAddressesCantMintAsset
-		}
+	ops, keys, err := txbuilder.Mint(vm.parser.Codec(), utxos, kc, amounts, to, vm.clock.Unix())
+	if err == txbuilder.ErrCantMint {
+		return nil, nil, errAddressesCantMintAsset
 	}
-
-	txs.SortOperationsWithSigners(ops, keys, vm.parser.Codec())
-	return ops, keys, nil
+	return ops, keys, err
 }
 
 func (vm *VM) MintNFT(
@@ -952,64 +1324,11 @@ func (vm *VM) MintNFT(
 	[][]*crypto.PrivateKeySECP256K1R,
 	error,
 ) {
-	time := vm.clock.Unix()
-
-	ops := []*txs.Operation{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-
-	for _, utxo := range utxos {
-		// makes sure that the variable isn't overwritten with the next iteration
-		utxo := utxo
-
-		if len(ops) > 0 {
-			// we have already been able to create the operation needed
-			break
-		}
-
-		if utxo.AssetID() != assetID {
-			// wrong asset id
-			continue
-		}
-		out, ok := utxo.Out.(*nftfx.MintOutput)
-		if !ok {
-			// wrong output type
-			continue
-		}
-
-		indices, signers, ok := kc.Match(&out.OutputOwners, time)
-		if !ok {
-			// unable to spend the output
-			continue
-		}
-
-		// add the operation to the array
-		ops = append(ops, &txs.Operation{
-			Asset: djtx.Asset{ID: assetID},
-			UTXOIDs: []*djtx.UTXOID{
-				&utxo.UTXOID,
-			},
-			Op: &nftfx.MintOperation{
-				MintInput: secp256k1fx.Input{
-					SigIndices: indices,
-				},
-				GroupID: out.GroupID,
-				Payload: payload,
-				Outputs: []*secp256k1fx.OutputOwners{{
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				}},
-			},
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
-	}
-
-	if len(ops) == 0 {
+	ops, keys, err := txbuilder.MintNFT(vm.parser.Codec(), utxos, kc, assetID, payload, to, vm.clock.Unix())
+	if err == txbuilder.ErrCantMint {
 		return nil, nil, errAddressesCantMintAsset
 	}
-
-	txs.SortOperationsWithSigners(ops, keys, vm.parser.Codec())
-	return ops, keys, nil
+	return ops, keys, err
 }
 
 // selectChangeAddr returns the change address to be used for [kc] when [changeAddr] is given
@@ -1027,6 +1346,28 @@ func (vm *VM) selectChangeAddr(defaultAddr ids.ShortID, changeAddr string) (ids.
 
 // lookupAssetID looks for an ID aliased by [asset] and if it fails
 // attempts to parse [asset] into an ID
+// parseOutputOwners returns the owners of an output that can be spent by any
+// [threshold] of the [addrs] after [locktime].
+func (vm *VM) parseOutputOwners(addrs []string, threshold uint32, locktime uint64) (*secp256k1fx.OutputOwners, error) {
+	if len(addrs) == 0 {
+		return nil, errNoOwners
+	}
+	addrSet, err := djtx.ParseServiceAddresses(vm, addrs)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing to addresses: %w", err)
+	}
+	owners := &secp256k1fx.OutputOwners{
+		Locktime:  locktime,
+		Threshold: threshold,
+		Addrs:     addrSet.List(),
+	}
+	owners.Sort()
+	if err := owners.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid output owners: %w", err)
+	}
+	return owners, nil
+}
+
 func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
 	if assetID, err := vm.Lookup(asset); err == nil {
 		return assetID, nil
@@ -1037,24 +1378,77 @@ func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
 	return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
 }
 
-// This VM doesn't (currently) have any app-specific messages
-func (vm *VM) AppRequest(nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+// putAssetMetadata records the description of the asset created by [utx], if
+// it creates an asset.
+func (vm *VM) putAssetMetadata(txID ids.ID, utx txs.UnsignedTx) error {
+	createAssetTx, ok := utx.(*txs.CreateAssetTx)
+	if !ok {
+		return nil
+	}
+	return vm.state.PutAssetMetadata(txID, &states.AssetMetadata{
+		Name:         createAssetTx.Name,
+		Symbol:       createAssetTx.Symbol,
+		Denomination: createAssetTx.Denomination,
+		CreationTxID: txID,
+	})
+}
+
+// updateFrozenAssets records the frozen status set by each of [utxos] that
+// controls the status of its asset.
+func (vm *VM) updateFrozenAssets(utxos []*djtx.UTXO) error {
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(extensions.FreezableOutput)
+		if !ok {
+			continue
+		}
+		if err := vm.state.SetFrozen(utxo.AssetID(), out.IsFrozen()); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// This VM doesn't (currently) have any app-specific messages
+// getAssetMetadata returns the description of [assetID]. Assets created before
+// the metadata index existed are described by parsing their creation tx.
+func (vm *VM) getAssetMetadata(assetID ids.ID) (*states.AssetMetadata, error) {
+	metadata, err := vm.state.GetAssetMetadata(assetID)
+	if err != database.ErrNotFound {
+		return metadata, err
+	}
+
+	tx := &UniqueTx{
+		vm:   vm,
+		txID: assetID,
+	}
+	if status := tx.Status(); !status.Fetched() {
+		return nil, errUnknownAssetID
+	}
+	createAssetTx, ok := tx.UnsignedTx.(*txs.CreateAssetTx)
+	if !ok {
+		return nil, errTxNotCreateAsset
+	}
+	return &states.AssetMetadata{
+		Name:         createAssetTx.Name,
+		Symbol:       createAssetTx.Symbol,
+		Denomination: createAssetTx.Denomination,
+		CreationTxID: assetID,
+	}, nil
+}
+
+func (vm *VM) AppRequest(nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+	return vm.network.AppRequest(nodeID, requestID, deadline, request)
+}
+
 func (vm *VM) AppResponse(nodeID ids.NodeID, requestID uint32, response []byte) error {
-	return nil
+	return vm.network.AppResponse(nodeID, requestID, response)
 }
 
-// This VM doesn't (currently) have any app-specific messages
 func (vm *VM) AppRequestFailed(nodeID ids.NodeID, requestID uint32) error {
-	return nil
+	return vm.network.AppRequestFailed(nodeID, requestID)
 }
 
-// This VM doesn't (currently) have any app-specific messages
 func (vm *VM) AppGossip(nodeID ids.NodeID, msg []byte) error {
-	return nil
+	return vm.network.AppGossip(nodeID, msg)
 }
 
 // UniqueTx de-duplicates the transaction.
@@ -12,6 +12,9 @@ import (
 	stdjson "encoding/json"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	dto "github.com/prometheus/client_model/go"
 
 	"github.com/stretchr/testify/assert"
 
@@ -256,6 +259,10 @@ func GenesisVM(tb testing.TB) ([]byte, chan common.Message, *VM, *atomic.Memory)
 }
 
 func GenesisVMWithArgs(tb testing.TB, additionalFxs []*common.Fx, args *BuildGenesisArgs) ([]byte, chan common.Message, *VM, *atomic.Memory) {
+	return GenesisVMWithConfig(tb, additionalFxs, args, Config{IndexTransactions: true})
+}
+
+func GenesisVMWithConfig(tb testing.TB, additionalFxs []*common.Fx, args *BuildGenesisArgs, config Config) ([]byte, chan common.Message, *VM, *atomic.Memory) {
 	var genesisBytes []byte
 
 	if args != nil {
@@ -293,7 +300,7 @@ func GenesisVMWithArgs(tb testing.TB, additionalFxs []*common.Fx, args *BuildGen
 		TxFee:            testTxFee,
 		CreateAssetTxFee: testTxFee,
 	}}
-	configBytes, err := stdjson.Marshal(Config{IndexTransactions: true})
+	configBytes, err := stdjson.Marshal(config)
 	if err != nil {
 		tb.Fatal("should not have caused error in creating avm config bytes")
 	}
@@ -574,6 +581,40 @@ func TestIssueTx(t *testing.T) {
 	}
 }
 
+func TestIssueTxBatchMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, issuer, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	newTx := NewTx(t, genesisBytes, vm)
+	_, err := vm.IssueTx(newTx.Bytes())
+	assert.NoError(err)
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.numPendingTxs))
+
+	// The engine doesn't read the first message, so the next one is dropped
+	vm.FlushTxs()
+	vm.FlushTxs()
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.numDroppedPendingTxsMsgs))
+
+	ctx.Lock.Unlock()
+	msg := <-issuer
+	assert.Equal(common.PendingTxs, msg)
+	ctx.Lock.Lock()
+
+	assert.Len(vm.PendingTxs(), 1)
+	assert.Equal(0.0, testutil.ToFloat64(vm.metrics.numPendingTxs))
+
+	batchSize := &dto.Metric{}
+	assert.NoError(vm.metrics.batchSize.Write(batchSize))
+	assert.Equal(uint64(1), batchSize.GetHistogram().GetSampleCount())
+	assert.Equal(1.0, batchSize.GetHistogram().GetSampleSum())
+}
+
 // Test issuing a transaction that consumes a currently pending UTXO. The
 // transaction should be issued successfully.
 func TestIssueDependentTx(t *testing.T) {
@@ -951,13 +992,154 @@ func setupTxFeeAssets(t *testing.T) ([]byte, chan common.Message, *VM, *atomic.M
 			},
 		},
 	}
-	genesisBytes, issuer, vm, m := GenesisVMWithArgs(t, nil, customArgs)
+	genesisBytes, issuer, vm, m := GenesisVMWithConfig(t, nil, customArgs, Config{
+		IndexTransactions: true,
+		FeeAsset:          assetAlias,
+	})
 	expectedID, err := vm.Aliaser.Lookup(assetAlias)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedID, vm.feeAssetID)
 	return genesisBytes, issuer, vm, m
 }
 
+func TestFeeAssetSelection(t *testing.T) {
+	assert := assert.New(t)
+
+	addrStr, err := address.FormatBech32(testHRP, addrs[0].Bytes())
+	assert.NoError(err)
+	genesisArgs := &BuildGenesisArgs{
+		Encoding:    formatting.Hex,
+		GenesisData: map[string]AssetDefinition{},
+	}
+	for _, alias := range []string{"asset1", "asset2"} {
+		genesisArgs.GenesisData[alias] = AssetDefinition{
+			Name:   alias,
+			Symbol: "TST",
+			InitialState: map[string][]interface{}{
+				"fixedCap": {
+					Holder{
+						Amount:  json.Uint64(startBalance),
+						Address: addrStr,
+					},
+				},
+			},
+		}
+	}
+	genesisBytes := BuildGenesisTestWithArgs(t, genesisArgs)
+
+	initialize := func(config Config) (*VM, error) {
+		configBytes, err := stdjson.Marshal(config)
+		assert.NoError(err)
+		vm := &VM{}
+		ctx := NewContext(t)
+		ctx.Lock.Lock()
+		defer ctx.Lock.Unlock()
+		return vm, vm.Initialize(
+			ctx,
+			manager.NewMemDB(version.DefaultVersion1_0_0),
+			genesisBytes,
+			nil,
+			configBytes,
+			make(chan common.Message, 1),
+			[]*common.Fx{{
+				ID: ids.Empty,
+				Fx: &secp256k1fx.Fx{},
+			}},
+			nil,
+		)
+	}
+
+	// Neither genesis asset is DJTX, so the fee asset must be designated
+	_, err = initialize(Config{})
+	assert.ErrorIs(err, errAmbiguousFeeAsset)
+
+	_, err = initialize(Config{FeeAsset: ids.GenerateTestID().String()})
+	assert.ErrorIs(err, errFeeAssetNotInGenesis)
+
+	vm, err := initialize(Config{FeeAsset: "asset2"})
+	assert.NoError(err)
+	expectedID, err := vm.Lookup("asset2")
+	assert.NoError(err)
+
+	s := &Service{vm: vm}
+	reply := &GetFeeAssetIDReply{}
+	assert.NoError(s.GetFeeAssetID(nil, nil, reply))
+	assert.Equal(expectedID, reply.AssetID)
+	assert.Equal("asset2", reply.Alias)
+
+	vm.ctx.Lock.Lock()
+	assert.NoError(vm.Shutdown())
+	vm.ctx.Lock.Unlock()
+}
+
+func TestGenesisFeeAsset(t *testing.T) {
+	assert := assert.New(t)
+
+	addrStr, err := address.FormatBech32(testHRP, addrs[0].Bytes())
+	assert.NoError(err)
+	genesisArgs := &BuildGenesisArgs{
+		Encoding:    formatting.Hex,
+		GenesisData: map[string]AssetDefinition{},
+		FeeAsset:    "asset2",
+	}
+	for _, alias := range []string{"asset1", "asset2"} {
+		genesisArgs.GenesisData[alias] = AssetDefinition{
+			Name:   alias,
+			Symbol: "TST",
+			InitialState: map[string][]interface{}{
+				"fixedCap": {
+					Holder{
+						Amount:  json.Uint64(startBalance),
+						Address: addrStr,
+					},
+				},
+			},
+		}
+	}
+	genesisBytes := BuildGenesisTestWithArgs(t, genesisArgs)
+
+	initialize := func(config Config) (*VM, error) {
+		configBytes, err := stdjson.Marshal(config)
+		assert.NoError(err)
+		vm := &VM{}
+		ctx := NewContext(t)
+		ctx.Lock.Lock()
+		defer ctx.Lock.Unlock()
+		return vm, vm.Initialize(
+			ctx,
+			manager.NewMemDB(version.DefaultVersion1_0_0),
+			genesisBytes,
+			nil,
+			configBytes,
+			make(chan common.Message, 1),
+			[]*common.Fx{{
+				ID: ids.Empty,
+				Fx: &secp256k1fx.Fx{},
+			}},
+			nil,
+		)
+	}
+
+	// The chain config can't override the fee asset of the genesis
+	_, err = initialize(Config{FeeAsset: "asset1"})
+	assert.ErrorIs(err, errConflictingFeeAsset)
+
+	vm, err := initialize(Config{})
+	assert.NoError(err)
+	expectedID, err := vm.Lookup("asset2")
+	assert.NoError(err)
+	assert.Equal(expectedID, vm.feeAssetID)
+
+	vm.ctx.Lock.Lock()
+	assert.NoError(vm.Shutdown())
+	vm.ctx.Lock.Unlock()
+
+	// The fee asset must be defined by the genesis
+	genesisArgs.FeeAsset = "asset3"
+	err = (&StaticService{}).BuildGenesis(nil, genesisArgs, &BuildGenesisReply{})
+	assert.ErrorIs(err, errUnknownFeeAsset)
+}
+
 func TestIssueTxWithFeeAsset(t *testing.T) {
 	genesisBytes, issuer, vm, _ := setupTxFeeAssets(t)
 	ctx := vm.ctx
@@ -2024,3 +2206,110 @@ func TestClearForceAcceptedExportTx(t *testing.T) {
 		t.Fatalf("should have failed to read the utxo")
 	}
 }
+
+func TestZeroFeeConfig(t *testing.T) {
+	genesisBytes := BuildGenesisTest(t)
+	genesisTx := GetDJTXTxFromGenesisTest(genesisBytes, t)
+	ctx := NewContext(t)
+	baseDBManager := manager.NewMemDB(version.DefaultVersion1_0_0)
+	issuer := make(chan common.Message, 1)
+
+	zero := uint64(0)
+	configBytes, err := stdjson.Marshal(Config{
+		TxFee:            &zero,
+		CreateAssetTxFee: &zero,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx.Lock.Lock()
+	vm := &VM{Factory: Factory{
+		TxFee:            testTxFee,
+		CreateAssetTxFee: testTxFee,
+	}}
+	err = vm.Initialize(
+		ctx,
+		baseDBManager.NewPrefixDBManager([]byte{1}),
+		genesisBytes,
+		nil,
+		configBytes,
+		issuer,
+		[]*common.Fx{{
+			ID: ids.Empty,
+			Fx: &secp256k1fx.Fx{},
+		}},
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+	vm.batchTimeout = 0
+
+	if err := vm.SetState(snow.Bootstrapping); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.SetState(snow.NormalOp); err != nil {
+		t.Fatal(err)
+	}
+
+	if vm.TxFee != 0 || vm.CreateAssetTxFee != 0 {
+		t.Fatalf("expected fees to be overridden to 0 but got %d and %d", vm.TxFee, vm.CreateAssetTxFee)
+	}
+
+	// Paying the fee doesn't require any inputs
+	amounts := map[ids.ID]uint64{}
+	if err := vm.addFee(amounts, vm.TxFee); err != nil {
+		t.Fatal(err)
+	}
+	_, ins, _, err := vm.Spend(nil, secp256k1fx.NewKeychain(), amounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ins) != 0 {
+		t.Fatalf("expected no inputs but got %d", len(ins))
+	}
+
+	// A tx that doesn't burn any of the fee asset is valid
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	asset := djtx.Asset{ID: genesisTx.ID()}
+	utxoID := djtx.UTXOID{TxID: ids.GenerateTestID()}
+	utxo := buildPlatformUTXO(utxoID, asset, addr)
+	if err := vm.state.PutUTXO(utxo.InputID(), utxo); err != nil {
+		t.Fatal(err)
+	}
+	tx := buildTX(utxoID, asset, addr)
+	if err := signTX(vm.parser.Codec(), tx, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.IssueTx(tx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSpendInsufficientFunds(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, vm, _ := GenesisVM(t)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := ids.GenerateTestID()
+	_, _, _, err := vm.Spend(nil, secp256k1fx.NewKeychain(), map[ids.ID]uint64{assetID: 5})
+
+	insufficientFunds := &InsufficientFundsError{}
+	assert.True(errors.As(err, &insufficientFunds))
+	assert.Equal(assetID, insufficientFunds.AssetID)
+	assert.Equal(uint64(5), insufficientFunds.Needed)
+	assert.Equal(uint64(0), insufficientFunds.Available)
+	assert.Equal(ErrorCodeInsufficientFunds, insufficientFunds.ErrorCode())
+}
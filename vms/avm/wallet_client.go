@@ -43,6 +43,42 @@ type WalletClient interface {
 		memo string,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// SendMultisig sends a transaction from [user], co-signed by
+	// [additionalSigners], funding all [outputs]
+	SendMultisig(
+		ctx context.Context,
+		user api.UserPass,
+		additionalSigners []api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		outputs []ClientSendMultisigOutput,
+		memo string,
+		options ...rpc.Option,
+	) (ids.ID, error)
+	// ReplaceTx reissues the processing transaction [txID], which was issued
+	// by [user], paying [fee]. If [fee] is 0, twice the original fee is paid.
+	ReplaceTx(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		txID ids.ID,
+		fee uint64,
+		options ...rpc.Option,
+	) (ids.ID, error)
+	// CancelTx drops the transaction [txID], which was issued by the wallet
+	// but not yet flushed into consensus
+	CancelTx(ctx context.Context, txID ids.ID, options ...rpc.Option) (bool, error)
+	// Consolidate merges up to [maxUTXOs] of the smallest UTXOs of [assetID]
+	// owned by [addr] into one. If [maxUTXOs] is 0, up to 256 UTXOs are merged.
+	Consolidate(
+		ctx context.Context,
+		user api.UserPass,
+		addr ids.ShortID,
+		assetID string,
+		maxUTXOs uint32,
+		options ...rpc.Option,
+	) (*ConsolidateReply, error)
 }
 
 // implementation of an AVM wallet client for interacting with avm managed wallet on [chain]
@@ -51,16 +87,20 @@ type walletClient struct {
 }
 
 // NewWalletClient returns an AVM wallet client for interacting with avm managed wallet on [chain]
-func NewWalletClient(uri, chain string) WalletClient {
+func NewWalletClient(uri, chain string, options ...rpc.Option) WalletClient {
+	return &walletClient{
+		requester: newWalletRequester(uri, chain, options),
+	}
+}
+
+func newWalletRequester(uri, chain string, options []rpc.Option) rpc.EndpointRequester {
 	path := fmt.Sprintf(
 		"%s/ext/%s/%s/wallet",
 		uri,
 		constants.ChainAliasPrefix,
 		chain,
 	)
-	return &walletClient{
-		requester: rpc.NewEndpointRequester(path, "wallet"),
-	}
+	return rpc.NewEndpointRequester(path, "wallet", options...)
 }
 
 func (c *walletClient) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error) {
@@ -143,3 +183,129 @@ func (c *walletClient) SendMultiple(
 	}, res, options...)
 	return res.TxID, err
 }
+
+// ClientSendMultisigOutput specifies that [Amount] of asset [AssetID] be sent
+// to an output spendable by any [Threshold] of [To] after [Locktime]
+type ClientSendMultisigOutput struct {
+	// The amount of funds to send
+	Amount uint64
+
+	// ID of the asset being sent
+	AssetID string
+
+	// Addresses that own the output
+	To []ids.ShortID
+
+	// Number of the [To] addresses that must sign to spend the output
+	Threshold uint32
+
+	// Unix time before which the output can't be spent
+	Locktime uint64
+}
+
+func (c *walletClient) SendMultisig(
+	ctx context.Context,
+	user api.UserPass,
+	additionalSigners []api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	outputs []ClientSendMultisigOutput,
+	memo string,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	serviceOutputs := make([]SendMultisigOutput, len(outputs))
+	for i, output := range outputs {
+		serviceOutputs[i].Amount = json.Uint64(output.Amount)
+		serviceOutputs[i].AssetID = output.AssetID
+		serviceOutputs[i].To = ids.ShortIDsToStrings(output.To)
+		serviceOutputs[i].Threshold = json.Uint32(output.Threshold)
+		serviceOutputs[i].Locktime = json.Uint64(output.Locktime)
+	}
+	err := c.requester.SendRequest(ctx, "sendMultisig", &SendMultisigArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		AdditionalSigners: additionalSigners,
+		Outputs:           serviceOutputs,
+		Memo:              memo,
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *walletClient) ReplaceTx(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	txID ids.ID,
+	fee uint64,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	return replaceTx(ctx, c.requester, user, from, changeAddr, txID, fee, options...)
+}
+
+func replaceTx(
+	ctx context.Context,
+	requester rpc.EndpointRequester,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	txID ids.ID,
+	fee uint64,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxIDChangeAddr{}
+	err := requester.SendRequest(ctx, "replaceTx", &ReplaceTxArgs{
+		UserPass:       user,
+		JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		TxID:           txID,
+		Fee:            json.Uint64(fee),
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *walletClient) CancelTx(ctx context.Context, txID ids.ID, options ...rpc.Option) (bool, error) {
+	return cancelTx(ctx, c.requester, txID, options...)
+}
+
+func cancelTx(ctx context.Context, requester rpc.EndpointRequester, txID ids.ID, options ...rpc.Option) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := requester.SendRequest(ctx, "cancelTx", &api.JSONTxID{
+		TxID: txID,
+	}, res, options...)
+	return res.Success, err
+}
+
+func (c *walletClient) Consolidate(
+	ctx context.Context,
+	user api.UserPass,
+	addr ids.ShortID,
+	assetID string,
+	maxUTXOs uint32,
+	options ...rpc.Option,
+) (*ConsolidateReply, error) {
+	return consolidate(ctx, c.requester, user, addr, assetID, maxUTXOs, options...)
+}
+
+func consolidate(
+	ctx context.Context,
+	requester rpc.EndpointRequester,
+	user api.UserPass,
+	addr ids.ShortID,
+	assetID string,
+	maxUTXOs uint32,
+	options ...rpc.Option,
+) (*ConsolidateReply, error) {
+	res := &ConsolidateReply{}
+	err := requester.SendRequest(ctx, "consolidate", &ConsolidateArgs{
+		UserPass: user,
+		Address:  addr.String(),
+		AssetID:  assetID,
+		MaxUTXOs: json.Uint32(maxUTXOs),
+	}, res, options...)
+	return res, err
+}
@@ -7,10 +7,16 @@ import (
 	"container/list"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/lasthyphen/beacongo/api"
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/linkeddb"
 	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/utils/crypto"
 	"github.com/lasthyphen/beacongo/utils/formatting"
+	"github.com/lasthyphen/beacongo/utils/json"
 	"github.com/lasthyphen/beacongo/vms/avm/txs"
 	"github.com/lasthyphen/beacongo/vms/components/djtx"
 	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
@@ -18,11 +24,83 @@ import (
 	safemath "github.com/lasthyphen/beacongo/utils/math"
 )
 
+var (
+	walletPendingPrefix = []byte("walletPending")
+
+	errTxNotPending         = json.NewError(ErrorCodeInvalidTxState, "transaction wasn't issued by this wallet or was already decided")
+	errTxNotReplaceable     = json.NewError(ErrorCodeInvalidTxState, "only base transactions that consume UTXOs can be replaced")
+	errTxHasDependents      = json.NewError(ErrorCodeInvalidTxState, "transaction's outputs are spent by another pending transaction")
+	errTxAlreadyIssued      = json.NewError(ErrorCodeInvalidTxState, "transaction was already issued into consensus")
+	errFeeNotIncreased      = json.NewError(ErrorCodeInsufficientFee, "replacement fee must be greater than the original fee")
+	errReplacedInputsSpent  = json.NewError(ErrorCodeInvalidTxState, "none of the original transaction's inputs could be consumed")
+	errNothingToConsolidate = json.NewError(ErrorCodeInsufficientFunds, "fewer than two UTXOs can be consolidated")
+	errConsolidationDust    = json.NewError(ErrorCodeInsufficientFunds, "consolidated UTXOs are worth less than the fee")
+)
+
+const (
+	// Number of UTXOs consolidated if the request doesn't specify a limit
+	defaultConsolidateUTXOs = 256
+	// Max number of UTXOs that can be consolidated by a single tx
+	maxConsolidateUTXOs = 1024
+)
+
 type WalletService struct {
 	vm *VM
 
 	pendingTxMap      map[ids.ID]*list.Element
 	pendingTxOrdering *list.List
+
+	// Persists the pending txs so that they survive a restart. Keys are tx
+	// IDs and values are tx bytes. The outputs of a pending tx are derived
+	// from its bytes.
+	pendingTxDB linkeddb.LinkedDB
+}
+
+// initialize restores the pending txs that were persisted in [db]. The
+// restored txs are reissued once the chain is bootstrapped.
+func (w *WalletService) initialize(vm *VM, db database.Database) error {
+	w.vm = vm
+	w.pendingTxMap = make(map[ids.ID]*list.Element)
+	w.pendingTxOrdering = list.New()
+	w.pendingTxDB = linkeddb.NewDefault(db)
+
+	// The most recently issued tx is the head of the list, so the txs are
+	// pushed to the front to restore the order they were issued in.
+	it := w.pendingTxDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		tx, err := vm.parser.Parse(it.Value())
+		if err != nil {
+			return fmt.Errorf("couldn't parse pending tx: %w", err)
+		}
+		w.pendingTxMap[tx.ID()] = w.pendingTxOrdering.PushFront(tx)
+	}
+	return it.Error()
+}
+
+// reissuePending issues the pending txs that were restored from disk. Txs that
+// were decided or are no longer valid are dropped.
+func (w *WalletService) reissuePending() {
+	for e := w.pendingTxOrdering.Front(); e != nil; {
+		tx := e.Value.(*txs.Tx)
+		txID := tx.ID()
+		// [e] is removed from the list if [tx] is dropped
+		e = e.Next()
+
+		uniqueTx := &UniqueTx{
+			vm:   w.vm,
+			txID: txID,
+		}
+		if uniqueTx.Status().Decided() {
+			w.decided(txID)
+			continue
+		}
+		if _, err := w.vm.IssueTx(tx.Bytes()); err != nil {
+			w.vm.ctx.Log.Info("dropping pending wallet tx %s: %s", txID, err)
+			w.decided(txID)
+		}
+	}
 }
 
 func (w *WalletService) decided(txID ids.ID) {
@@ -32,6 +110,10 @@ func (w *WalletService) decided(txID ids.ID) {
 	}
 	delete(w.pendingTxMap, txID)
 	w.pendingTxOrdering.Remove(e)
+
+	if err := w.pendingTxDB.Delete(txID[:]); err != nil {
+		w.vm.ctx.Log.Error("couldn't delete pending wallet tx %s: %s", txID, err)
+	}
 }
 
 func (w *WalletService) issue(txBytes []byte) (ids.ID, error) {
@@ -50,10 +132,33 @@ func (w *WalletService) issue(txBytes []byte) (ids.ID, error) {
 	}
 
 	w.pendingTxMap[txID] = w.pendingTxOrdering.PushBack(tx)
+	if err := w.pendingTxDB.Put(txID[:], txBytes); err != nil {
+		return txID, fmt.Errorf("couldn't persist pending tx %s: %w", txID, err)
+	}
 	return txID, nil
 }
 
+// hasDependents returns true if a pending transaction spends an output of
+// [txID].
+func (w *WalletService) hasDependents(txID ids.ID) bool {
+	for e := w.pendingTxOrdering.Front(); e != nil; e = e.Next() {
+		tx := e.Value.(*txs.Tx)
+		for _, utxoID := range tx.InputUTXOs() {
+			if sourceID, _ := utxoID.InputSource(); sourceID == txID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (w *WalletService) update(utxos []*djtx.UTXO) ([]*djtx.UTXO, error) {
+	return w.updateWithout(utxos, ids.Empty)
+}
+
+// updateWithout applies the pending transactions, other than [excludedTxID],
+// to [utxos].
+func (w *WalletService) updateWithout(utxos []*djtx.UTXO, excludedTxID ids.ID) ([]*djtx.UTXO, error) {
 	utxoMap := make(map[ids.ID]*djtx.UTXO, len(utxos))
 	for _, utxo := range utxos {
 		utxoMap[utxo.InputID()] = utxo
@@ -61,6 +166,9 @@ func (w *WalletService) update(utxos []*djtx.UTXO) ([]*djtx.UTXO, error) {
 
 	for e := w.pendingTxOrdering.Front(); e != nil; e = e.Next() {
 		tx := e.Value.(*txs.Tx)
+		if tx.ID() == excludedTxID {
+			continue
+		}
 		for _, inputUTXO := range tx.InputUTXOs() {
 			if inputUTXO.Symbolic() {
 				continue
@@ -86,17 +194,32 @@ func (w *WalletService) update(utxos []*djtx.UTXO) ([]*djtx.UTXO, error) {
 	return newUTXOs, nil
 }
 
+// WalletIssueTxArgs are arguments for passing into wallet IssueTx requests
+type WalletIssueTxArgs struct {
+	api.FormattedTx
+
+	// Local annotation stored alongside the transaction by this node. It
+	// isn't included in the transaction.
+	Annotation string `json:"annotation"`
+}
+
 // IssueTx attempts to issue a transaction into consensus
-func (w *WalletService) IssueTx(r *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
+func (w *WalletService) IssueTx(r *http.Request, args *WalletIssueTxArgs, reply *api.JSONTxID) error {
 	w.vm.ctx.Log.Debug("AVM Wallet: IssueTx called with %s", args.Tx)
 
+	if err := verifyAnnotation(args.Annotation); err != nil {
+		return err
+	}
 	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
 	if err != nil {
 		return fmt.Errorf("problem decoding transaction: %w", err)
 	}
 	txID, err := w.issue(txBytes)
 	reply.TxID = txID
-	return err
+	if err != nil {
+		return err
+	}
+	return w.vm.annotateTx(txID, args.Annotation)
 }
 
 // Send returns the ID of the newly created transaction
@@ -105,12 +228,35 @@ func (w *WalletService) Send(r *http.Request, args *SendArgs, reply *api.JSONTxI
 		JSONSpendHeader: args.JSONSpendHeader,
 		Outputs:         []SendOutput{args.SendOutput},
 		Memo:            args.Memo,
+		CoinSelection:   args.CoinSelection,
+		Annotation:      args.Annotation,
 	}, reply)
 }
 
 // SendMultiple sends a transaction with multiple outputs.
 func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, reply *api.JSONTxIDChangeAddr) error {
-	w.vm.ctx.Log.Debug("AVM Wallet: SendMultiple called with username: %s", args.Username)
+	outputs := make([]SendMultisigOutput, len(args.Outputs))
+	for i, output := range args.Outputs {
+		outputs[i] = SendMultisigOutput{
+			Amount:    output.Amount,
+			AssetID:   output.AssetID,
+			To:        []string{output.To},
+			Threshold: 1,
+		}
+	}
+	return w.SendMultisig(r, &SendMultisigArgs{
+		JSONSpendHeader: args.JSONSpendHeader,
+		Outputs:         outputs,
+		Memo:            args.Memo,
+		CoinSelection:   args.CoinSelection,
+		Annotation:      args.Annotation,
+	}, reply)
+}
+
+// SendMultisig sends a transaction whose outputs may be owned by multiple
+// addresses.
+func (w *WalletService) SendMultisig(r *http.Request, args *SendMultisigArgs, reply *api.JSONTxIDChangeAddr) error {
+	w.vm.ctx.Log.Debug("AVM Wallet: SendMultisig called with username: %s", args.Username)
 
 	// Validate the memo field
 	memoBytes := []byte(args.Memo)
@@ -121,6 +267,9 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	} else if len(args.Outputs) == 0 {
 		return errNoOutputs
 	}
+	if err := verifyAnnotation(args.Annotation); err != nil {
+		return err
+	}
 
 	// Parse the from addresses
 	fromAddrs, err := djtx.ParseServiceAddresses(w.vm, args.From)
@@ -148,6 +297,14 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		return err
 	}
 
+	// Add the keys of the co-signers after the change address was selected so
+	// that change is always returned to the requesting user.
+	for _, signer := range args.AdditionalSigners {
+		if err := w.vm.AddUserKeys(kc, signer.Username, signer.Password); err != nil {
+			return fmt.Errorf("couldn't load keys of user %q: %w", signer.Username, err)
+		}
+	}
+
 	// Calculate required input amounts and create the desired outputs
 	// String repr. of asset ID --> asset ID
 	assetIDs := make(map[string]ids.ID)
@@ -174,22 +331,18 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		}
 		amounts[assetID] = newAmount
 
-		// Parse the to address
-		to, err := djtx.ParseServiceAddress(w.vm, output.To)
+		// Parse the to addresses
+		owners, err := w.vm.parseOutputOwners(output.To, uint32(output.Threshold), uint64(output.Locktime))
 		if err != nil {
-			return fmt.Errorf("problem parsing to address %q: %w", output.To, err)
+			return err
 		}
 
 		// Create the Output
 		outs = append(outs, &djtx.TransferableOutput{
 			Asset: djtx.Asset{ID: assetID},
 			Out: &secp256k1fx.TransferOutput{
-				Amt: uint64(output.Amount),
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				},
+				Amt:          uint64(output.Amount),
+				OutputOwners: *owners,
 			},
 		})
 	}
@@ -199,16 +352,15 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		amountsWithFee[assetKey] = amount
 	}
 
-	amountWithFee, err := safemath.Add64(amounts[w.vm.feeAssetID], w.vm.TxFee)
-	if err != nil {
-		return fmt.Errorf("problem calculating required spend amount: %w", err)
+	if err := w.vm.addFee(amountsWithFee, w.vm.TxFee); err != nil {
+		return err
 	}
-	amountsWithFee[w.vm.feeAssetID] = amountWithFee
 
-	amountsSpent, ins, keys, err := w.vm.Spend(
+	amountsSpent, ins, keys, err := w.vm.SpendWithCoinSelection(
 		utxos,
 		kc,
 		amountsWithFee,
+		args.CoinSelection,
 	)
 	if err != nil {
 		return err
@@ -251,8 +403,420 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	if err != nil {
 		return fmt.Errorf("problem issuing transaction: %w", err)
 	}
+	if err := w.vm.annotateTx(txID, args.Annotation); err != nil {
+		return err
+	}
 
 	reply.TxID = txID
 	reply.ChangeAddr, err = w.vm.FormatLocalAddress(changeAddr)
 	return err
 }
+
+// ReplaceTxArgs are arguments for passing into ReplaceTx requests
+type ReplaceTxArgs struct {
+	api.UserPass
+	api.JSONFromAddrs
+	api.JSONChangeAddr
+
+	// ID of the processing transaction to replace
+	TxID ids.ID `json:"txID"`
+
+	// Fee the replacement pays. If 0, twice the original fee is paid.
+	Fee json.Uint64 `json:"fee"`
+}
+
+// ReplaceTx reissues a processing transaction that was issued by this wallet
+// with a higher fee.
+//
+// The replacement pays the same outputs as the original and consumes all of
+// the original's inputs, along with any additional inputs needed to pay the
+// increased fee. Because the two transactions conflict, at most one of them
+// will be accepted.
+func (w *WalletService) ReplaceTx(_ *http.Request, args *ReplaceTxArgs, reply *api.JSONTxIDChangeAddr) error {
+	w.vm.ctx.Log.Debug("AVM Wallet: ReplaceTx called with username: %s, txID: %s", args.Username, args.TxID)
+
+	e, ok := w.pendingTxMap[args.TxID]
+	if !ok {
+		return errTxNotPending
+	}
+	originalTx := e.Value.(*txs.Tx)
+	originalUTX, ok := originalTx.UnsignedTx.(*txs.BaseTx)
+	if !ok || len(originalUTX.Ins) == 0 {
+		return errTxNotReplaceable
+	}
+	if status := (&UniqueTx{vm: w.vm, txID: args.TxID}).Status(); status != choices.Processing {
+		return errTxNotPending
+	}
+
+	// The replacement can't be issued if a pending transaction depends on the
+	// original, as it would no longer be valid.
+	if w.hasDependents(args.TxID) {
+		return errTxHasDependents
+	}
+
+	originalFee, err := w.vm.paidFee(originalUTX)
+	if err != nil {
+		return err
+	}
+	fee := uint64(args.Fee)
+	if fee == 0 {
+		fee, err = safemath.Mul64(originalFee, 2)
+		if err != nil {
+			return err
+		}
+	}
+	if fee <= originalFee {
+		return fmt.Errorf("%w: %d <= %d", errFeeNotIncreased, fee, originalFee)
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseServiceAddresses(w.vm, args.From)
+	if err != nil {
+		return fmt.Errorf("couldn't parse 'From' addresses: %w", err)
+	}
+
+	// Load user's UTXOs/keys
+	utxos, kc, err := w.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	utxos, err = w.updateWithout(utxos, args.TxID)
+	if err != nil {
+		return err
+	}
+
+	// Spend the original inputs first, so that the replacement conflicts with
+	// the original.
+	originalInputs := ids.NewSet(len(originalUTX.Ins))
+	orderedUTXOs := make([]*djtx.UTXO, 0, len(utxos)+len(originalUTX.Ins))
+	for _, in := range originalUTX.Ins {
+		utxo, err := w.vm.getUTXO(&in.UTXOID)
+		if err != nil {
+			return fmt.Errorf("couldn't get UTXO %s: %w", in.InputID(), err)
+		}
+		originalInputs.Add(in.InputID())
+		orderedUTXOs = append(orderedUTXOs, utxo)
+	}
+	for _, utxo := range utxos {
+		if !originalInputs.Contains(utxo.InputID()) {
+			orderedUTXOs = append(orderedUTXOs, utxo)
+		}
+	}
+
+	if len(kc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := w.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	// The replacement pays every output of the original, including its change
+	amountsWithFee := make(map[ids.ID]uint64)
+	outs := make([]*djtx.TransferableOutput, len(originalUTX.Outs))
+	for i, out := range originalUTX.Outs {
+		assetID := out.AssetID()
+		amountsWithFee[assetID], err = safemath.Add64(amountsWithFee[assetID], out.Out.Amount())
+		if err != nil {
+			return err
+		}
+		outs[i] = out
+	}
+	if err := w.vm.addFee(amountsWithFee, fee); err != nil {
+		return err
+	}
+
+	amountsSpent, ins, keys, err := w.vm.Spend(
+		orderedUTXOs,
+		kc,
+		amountsWithFee,
+	)
+	if err != nil {
+		return err
+	}
+
+	conflicts := false
+	for _, in := range ins {
+		if originalInputs.Contains(in.InputID()) {
+			conflicts = true
+			break
+		}
+	}
+	if !conflicts {
+		return errReplacedInputsSpent
+	}
+
+	// Add the required change outputs
+	for assetID, amountWithFee := range amountsWithFee {
+		amountSpent := amountsSpent[assetID]
+
+		if amountSpent > amountWithFee {
+			outs = append(outs, &djtx.TransferableOutput{
+				Asset: djtx.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amountSpent - amountWithFee,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{changeAddr},
+					},
+				},
+			})
+		}
+	}
+
+	codec := w.vm.parser.Codec()
+	djtx.SortTransferableOutputs(outs, codec)
+
+	tx := txs.Tx{UnsignedTx: &txs.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    w.vm.ctx.NetworkID,
+		BlockchainID: w.vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+		Memo:         originalUTX.Memo,
+	}}}
+	if err := tx.SignSECP256K1Fx(codec, keys); err != nil {
+		return err
+	}
+
+	txID, err := w.issue(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	// The original conflicts with the replacement, so it must no longer be
+	// applied to the user's UTXOs.
+	w.decided(args.TxID)
+
+	// The replacement keeps the annotation of the original
+	annotation, err := w.vm.getTxAnnotation(args.TxID)
+	if err != nil {
+		return err
+	}
+	if err := w.vm.annotateTx(txID, annotation); err != nil {
+		return err
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = w.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// CancelTx drops a transaction issued by this wallet that hasn't been flushed
+// into consensus yet. The transaction is marked as rejected and the UTXOs it
+// consumed can be spent by the wallet again.
+//
+// Transactions that were already flushed into consensus can't be cancelled,
+// but may be replaced with ReplaceTx.
+func (w *WalletService) CancelTx(_ *http.Request, args *api.JSONTxID, reply *api.SuccessResponse) error {
+	w.vm.ctx.Log.Debug("AVM Wallet: CancelTx called with txID: %s", args.TxID)
+
+	if _, ok := w.pendingTxMap[args.TxID]; !ok {
+		return errTxNotPending
+	}
+	// Cancelling the transaction would invalidate the pending transactions
+	// that spend its outputs.
+	if w.hasDependents(args.TxID) {
+		return errTxHasDependents
+	}
+	if err := w.vm.cancelTx(args.TxID); err != nil {
+		return err
+	}
+	w.decided(args.TxID)
+	reply.Success = true
+	return nil
+}
+
+// ConsolidateArgs are arguments for passing into Consolidate requests
+type ConsolidateArgs struct {
+	api.UserPass
+
+	// Address whose UTXOs are merged. The merged output is owned by it.
+	Address string `json:"address"`
+
+	// Asset whose UTXOs are merged
+	AssetID string `json:"assetID"`
+
+	// Max number of UTXOs to merge. The smallest UTXOs are merged first. If 0,
+	// up to 256 UTXOs are merged.
+	MaxUTXOs json.Uint32 `json:"maxUTXOs"`
+}
+
+// ConsolidateReply defines the Consolidate replies returned from the API
+type ConsolidateReply struct {
+	api.JSONTxID
+
+	// Number of UTXOs merged into one
+	NumConsolidated json.Uint32 `json:"numConsolidated"`
+
+	// Amount of the merged output
+	Amount json.Uint64 `json:"amount"`
+}
+
+// Consolidate merges the smallest UTXOs of an asset that are owned by a single
+// address into one output owned by that address.
+//
+// The fee is paid out of the merged UTXOs if the asset is the fee asset.
+// Otherwise, the fee is paid from other UTXOs of the user.
+func (w *WalletService) Consolidate(_ *http.Request, args *ConsolidateArgs, reply *ConsolidateReply) error {
+	w.vm.ctx.Log.Debug("AVM Wallet: Consolidate called with username: %s, address: %s, assetID: %s", args.Username, args.Address, args.AssetID)
+
+	maxUTXOs := int(args.MaxUTXOs)
+	switch {
+	case maxUTXOs == 0:
+		maxUTXOs = defaultConsolidateUTXOs
+	case maxUTXOs > maxConsolidateUTXOs:
+		return fmt.Errorf("maxUTXOs (%d) > maximum allowed (%d)", maxUTXOs, maxConsolidateUTXOs)
+	}
+
+	addr, err := djtx.ParseServiceAddress(w.vm, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+	assetID, err := w.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	utxos, kc, err := w.vm.LoadUser(args.Username, args.Password, ids.ShortSet{addr: struct{}{}})
+	if err != nil {
+		return err
+	}
+	utxos, err = w.update(utxos)
+	if err != nil {
+		return err
+	}
+
+	// Find the UTXOs of the asset that are owned only by [addr] and can be
+	// spent right now. Jointly owned UTXOs are left alone, as merging them
+	// would transfer them to [addr].
+	type spendable struct {
+		utxo    *djtx.UTXO
+		in      djtx.TransferableIn
+		signers []*crypto.PrivateKeySECP256K1R
+	}
+	now := w.vm.clock.Unix()
+	candidates := []spendable(nil)
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok || len(out.Addrs) != 1 || out.Addrs[0] != addr {
+			continue
+		}
+		inputIntf, signers, err := kc.Spend(utxo.Out, now)
+		if err != nil {
+			continue
+		}
+		in, ok := inputIntf.(djtx.TransferableIn)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, spendable{
+			utxo:    utxo,
+			in:      in,
+			signers: signers,
+		})
+	}
+	if len(candidates) < 2 {
+		return errNothingToConsolidate
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].in.Amount() < candidates[j].in.Amount()
+	})
+	if len(candidates) > maxUTXOs {
+		candidates = candidates[:maxUTXOs]
+	}
+
+	consolidated := ids.NewSet(len(candidates))
+	ins := make([]*djtx.TransferableInput, 0, len(candidates))
+	keys := make([][]*crypto.PrivateKeySECP256K1R, 0, len(candidates))
+	amount := uint64(0)
+	for _, candidate := range candidates {
+		amount, err = safemath.Add64(amount, candidate.in.Amount())
+		if err != nil {
+			return errSpendOverflow
+		}
+		consolidated.Add(candidate.utxo.InputID())
+		ins = append(ins, &djtx.TransferableInput{
+			UTXOID: candidate.utxo.UTXOID,
+			Asset:  djtx.Asset{ID: assetID},
+			In:     candidate.in,
+		})
+		keys = append(keys, candidate.signers)
+	}
+
+	fee := w.vm.TxFee
+	outs := []*djtx.TransferableOutput(nil)
+	if assetID == w.vm.feeAssetID {
+		if amount <= fee {
+			return fmt.Errorf("%w: %d <= %d", errConsolidationDust, amount, fee)
+		}
+		amount -= fee
+	} else if fee > 0 {
+		feeUTXOs := make([]*djtx.UTXO, 0, len(utxos))
+		for _, utxo := range utxos {
+			if !consolidated.Contains(utxo.InputID()) {
+				feeUTXOs = append(feeUTXOs, utxo)
+			}
+		}
+		feeSpent, feeIns, feeKeys, err := w.vm.Spend(
+			feeUTXOs,
+			kc,
+			map[ids.ID]uint64{w.vm.feeAssetID: fee},
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't pay fee: %w", err)
+		}
+		ins = append(ins, feeIns...)
+		keys = append(keys, feeKeys...)
+		if change := feeSpent[w.vm.feeAssetID] - fee; change > 0 {
+			outs = append(outs, &djtx.TransferableOutput{
+				Asset: djtx.Asset{ID: w.vm.feeAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: change,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr},
+					},
+				},
+			})
+		}
+	}
+	outs = append(outs, &djtx.TransferableOutput{
+		Asset: djtx.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	})
+
+	codec := w.vm.parser.Codec()
+	djtx.SortTransferableInputsWithSigners(ins, keys)
+	djtx.SortTransferableOutputs(outs, codec)
+
+	tx := txs.Tx{UnsignedTx: &txs.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    w.vm.ctx.NetworkID,
+		BlockchainID: w.vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}}
+	if err := tx.SignSECP256K1Fx(codec, keys); err != nil {
+		return err
+	}
+
+	txID, err := w.issue(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.NumConsolidated = json.Uint32(len(candidates))
+	reply.Amount = json.Uint64(amount)
+	return nil
+}
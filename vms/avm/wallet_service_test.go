@@ -4,14 +4,19 @@
 package avm
 
 import (
-	"container/list"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/lasthyphen/beacongo/api"
 	"github.com/lasthyphen/beacongo/chains/atomic"
+	"github.com/lasthyphen/beacongo/database/memdb"
 	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/choices"
+	"github.com/lasthyphen/beacongo/utils/json"
 	"github.com/lasthyphen/beacongo/vms/avm/txs"
 	"github.com/lasthyphen/beacongo/vms/components/keystore"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
 )
 
 // Returns:
@@ -32,7 +37,10 @@ func setupWS(t *testing.T, isDJTXAsset bool) ([]byte, *VM, *WalletService, *atom
 		genesisTx = GetCreateTxFromGenesisTest(t, genesisBytes, feeAssetName)
 	}
 
-	ws := &WalletService{vm: vm, pendingTxMap: make(map[ids.ID]*list.Element), pendingTxOrdering: list.New()}
+	ws := &WalletService{}
+	if err := ws.initialize(vm, memdb.New()); err != nil {
+		t.Fatal(err)
+	}
 	return genesisBytes, vm, ws, m, genesisTx
 }
 
@@ -129,3 +137,472 @@ func TestWalletService_SendMultiple(t *testing.T) {
 		})
 	}
 }
+
+func TestWalletService_SendAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ws, _, genesisTx := setupWSWithKeys(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	assert.NoError(err)
+	_, fromAddrsStr := sampleAddrs(t, vm, addrs)
+
+	args := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  500,
+			AssetID: genesisTx.ID().String(),
+			To:      addrStr,
+		},
+		Annotation: string(make([]byte, maxAnnotationSize+1)),
+	}
+	reply := &api.JSONTxIDChangeAddr{}
+	vm.timer.Cancel()
+
+	// Annotations that are too long are rejected before the tx is issued
+	assert.Error(ws.Send(nil, args, reply))
+	assert.Empty(vm.txs)
+
+	args.Annotation = "withdrawal-1234"
+	assert.NoError(ws.Send(nil, args, reply))
+
+	annotation, err := vm.getTxAnnotation(reply.TxID)
+	assert.NoError(err)
+	assert.Equal("withdrawal-1234", annotation)
+
+	// The annotation isn't part of the tx
+	tx, err := vm.GetTx(reply.TxID)
+	assert.NoError(err)
+	assert.NotContains(string(tx.Bytes()), "withdrawal-1234")
+}
+
+func TestWalletService_SendMultisig(t *testing.T) {
+	_, vm, ws, _, genesisTx := setupWSWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	ownerAddrsStr := make([]string, 0, len(addrs)+1)
+	for _, addr := range addrs {
+		addrStr, err := vm.FormatLocalAddress(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ownerAddrsStr = append(ownerAddrsStr, addrStr)
+	}
+	ownerAddrsStr = append(ownerAddrsStr, ownerAddrsStr[0])
+
+	args := &SendMultisigArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+		},
+		Outputs: []SendMultisigOutput{{
+			Amount:    500,
+			AssetID:   assetID.String(),
+			To:        ownerAddrsStr,
+			Threshold: json.Uint32(len(ownerAddrsStr)),
+		}},
+	}
+	reply := &api.JSONTxIDChangeAddr{}
+	vm.timer.Cancel()
+	if err := ws.SendMultisig(nil, args, reply); err == nil {
+		// duplicate owners are collapsed, so the threshold can't be met
+		t.Fatal("expected unspendable output to be rejected")
+	}
+
+	args.Outputs[0].To = ownerAddrsStr[:len(ownerAddrsStr)-1]
+	args.Outputs[0].Threshold = json.Uint32(len(args.Outputs[0].To))
+	if err := ws.SendMultisig(nil, args, reply); err != nil {
+		t.Fatalf("Failed to send transaction: %s", err)
+	}
+
+	tx, err := vm.GetTx(reply.TxID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve created transaction: %s", err)
+	}
+	var found bool
+	for _, utxo := range tx.(*UniqueTx).UTXOs() {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok || out.Amt != 500 {
+			continue
+		}
+		found = true
+		if int(out.Threshold) != len(args.Outputs[0].To) || len(out.Addrs) != len(args.Outputs[0].To) {
+			t.Fatalf("unexpected output owners %+v", out.OutputOwners)
+		}
+	}
+	if !found {
+		t.Fatal("multisig output wasn't created")
+	}
+}
+
+func TestWalletService_ReplaceTx(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ws, _, genesisTx := setupWSWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	addrStr, err := vm.FormatLocalAddress(keys[1].PublicKey().Address())
+	assert.NoError(err)
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	assert.NoError(err)
+	_, fromAddrsStr := sampleAddrs(t, vm, addrs)
+	user := api.UserPass{
+		Username: username,
+		Password: password,
+	}
+
+	vm.timer.Cancel()
+	sendReply := &api.JSONTxIDChangeAddr{}
+	err = ws.Send(nil, &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  500,
+			AssetID: assetID.String(),
+			To:      addrStr,
+		},
+	}, sendReply)
+	assert.NoError(err)
+
+	replaceArgs := &ReplaceTxArgs{
+		UserPass:       user,
+		JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		TxID:           sendReply.TxID,
+		Fee:            json.Uint64(testTxFee),
+	}
+	replaceReply := &api.JSONTxIDChangeAddr{}
+	err = ws.ReplaceTx(nil, replaceArgs, replaceReply)
+	assert.ErrorIs(err, errFeeNotIncreased)
+
+	replaceArgs.Fee = 0
+	err = ws.ReplaceTx(nil, replaceArgs, replaceReply)
+	assert.NoError(err)
+	assert.NotEqual(sendReply.TxID, replaceReply.TxID)
+	assert.Len(vm.txs, 2)
+
+	// Only the replacement is tracked by the wallet
+	_, tracked := ws.pendingTxMap[sendReply.TxID]
+	assert.False(tracked)
+	_, tracked = ws.pendingTxMap[replaceReply.TxID]
+	assert.True(tracked)
+
+	originalTx, err := vm.state.GetTx(sendReply.TxID)
+	assert.NoError(err)
+	replacementTx, err := vm.state.GetTx(replaceReply.TxID)
+	assert.NoError(err)
+
+	fee, err := vm.paidFee(replacementTx.UnsignedTx.(*txs.BaseTx))
+	assert.NoError(err)
+	assert.Equal(2*testTxFee, fee)
+
+	// The replacement must conflict with the original
+	replacementInputs := ids.Set{}
+	for _, utxoID := range replacementTx.InputUTXOs() {
+		replacementInputs.Add(utxoID.InputID())
+	}
+	conflicts := false
+	for _, utxoID := range originalTx.InputUTXOs() {
+		if replacementInputs.Contains(utxoID.InputID()) {
+			conflicts = true
+		}
+	}
+	assert.True(conflicts)
+
+	err = ws.ReplaceTx(nil, replaceArgs, replaceReply)
+	assert.ErrorIs(err, errTxNotPending)
+}
+
+func TestServiceGetPendingTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, _, _, genesisTx := setupWSWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	toStr, err := vm.FormatLocalAddress(keys[1].PublicKey().Address())
+	assert.NoError(err)
+
+	// Spending only from, and returning change to, a single address forces
+	// the second tx to consume the change of the first.
+	args := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStr}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  500,
+			AssetID: assetID.String(),
+			To:      toStr,
+		},
+	}
+
+	vm.timer.Cancel()
+	firstReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(vm.walletService.Send(nil, args, firstReply))
+	secondReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(vm.walletService.Send(nil, args, secondReply))
+
+	s := &Service{vm: vm}
+	reply := &GetPendingTxsReply{}
+	assert.NoError(s.GetPendingTxs(nil, nil, reply))
+
+	assert.Len(reply.Unflushed, 2)
+	assert.Len(reply.Wallet, 2)
+	for _, pendingTxs := range [][]PendingTx{reply.Unflushed, reply.Wallet} {
+		assert.Equal(firstReply.TxID, pendingTxs[0].TxID)
+		assert.Empty(pendingTxs[0].DependsOn)
+		assert.Equal(secondReply.TxID, pendingTxs[1].TxID)
+		assert.Equal([]ids.ID{firstReply.TxID}, pendingTxs[1].DependsOn)
+		for _, pendingTx := range pendingTxs {
+			assert.Greater(uint64(pendingTx.Size), uint64(0))
+			assert.NotEmpty(pendingTx.Age)
+			assert.Equal(choices.Processing, pendingTx.Status)
+		}
+	}
+
+	// Flushing the batch leaves the wallet's txs pending
+	vm.PendingTxs()
+	assert.NoError(s.GetPendingTxs(nil, nil, reply))
+	assert.Empty(reply.Unflushed)
+	assert.Len(reply.Wallet, 2)
+}
+
+func TestWalletService_CancelTx(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ws, _, genesisTx := setupWSWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	addrStr, err := vm.FormatLocalAddress(keys[1].PublicKey().Address())
+	assert.NoError(err)
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	assert.NoError(err)
+	_, fromAddrsStr := sampleAddrs(t, vm, addrs)
+	sendArgs := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  500,
+			AssetID: assetID.String(),
+			To:      addrStr,
+		},
+	}
+
+	vm.timer.Cancel()
+	sendReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(ws.Send(nil, sendArgs, sendReply))
+	txID := sendReply.TxID
+
+	cancelReply := &api.SuccessResponse{}
+	assert.NoError(ws.CancelTx(nil, &api.JSONTxID{TxID: txID}, cancelReply))
+	assert.True(cancelReply.Success)
+	assert.Empty(vm.txs)
+	assert.Zero(ws.pendingTxOrdering.Len())
+	assert.NotContains(ws.pendingTxMap, txID)
+
+	status, err := vm.state.GetStatus(txID)
+	assert.NoError(err)
+	assert.Equal(choices.Rejected, status)
+
+	// Cancelling the tx again fails, as it's no longer pending
+	err = ws.CancelTx(nil, &api.JSONTxID{TxID: txID}, cancelReply)
+	assert.ErrorIs(err, errTxNotPending)
+
+	// The UTXOs consumed by the cancelled tx can be spent again
+	sendArgs.Amount = 600
+	assert.NoError(ws.Send(nil, sendArgs, sendReply))
+	assert.NotEqual(txID, sendReply.TxID)
+	assert.Len(vm.txs, 1)
+
+	// Once flushed into consensus, the tx can't be cancelled
+	vm.PendingTxs()
+	err = ws.CancelTx(nil, &api.JSONTxID{TxID: sendReply.TxID}, cancelReply)
+	assert.ErrorIs(err, errTxAlreadyIssued)
+}
+
+func TestWalletService_RestorePending(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ws, _, genesisTx := setupWSWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	db := memdb.New()
+	assert.NoError(ws.initialize(vm, db))
+
+	assetID := genesisTx.ID()
+	addrStr, err := vm.FormatLocalAddress(keys[1].PublicKey().Address())
+	assert.NoError(err)
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	assert.NoError(err)
+	_, fromAddrsStr := sampleAddrs(t, vm, addrs)
+	sendArgs := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		SendOutput: SendOutput{
+			Amount:  500,
+			AssetID: assetID.String(),
+			To:      addrStr,
+		},
+	}
+
+	vm.timer.Cancel()
+	firstReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(ws.Send(nil, sendArgs, firstReply))
+	secondReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(ws.Send(nil, sendArgs, secondReply))
+
+	// Simulate a restart, which drops the txs that were issued to consensus
+	vm.PendingTxs()
+	restored := &WalletService{}
+	assert.NoError(restored.initialize(vm, db))
+	assert.Equal(2, restored.pendingTxOrdering.Len())
+	assert.Equal(firstReply.TxID, restored.pendingTxOrdering.Front().Value.(*txs.Tx).ID())
+	assert.Equal(secondReply.TxID, restored.pendingTxOrdering.Back().Value.(*txs.Tx).ID())
+
+	// Once the chain is bootstrapped, the pending txs are issued again
+	restored.reissuePending()
+	assert.Len(vm.txs, 2)
+	assert.Equal(2, restored.pendingTxOrdering.Len())
+
+	// Decided txs are no longer persisted
+	restored.decided(firstReply.TxID)
+	restored = &WalletService{}
+	assert.NoError(restored.initialize(vm, db))
+	assert.Equal(1, restored.pendingTxOrdering.Len())
+	assert.Contains(restored.pendingTxMap, secondReply.TxID)
+}
+
+func TestWalletService_Consolidate(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ws, _, genesisTx := setupWSWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	fromStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	addr := keys[1].PublicKey().Address()
+	addrStr, err := vm.FormatLocalAddress(addr)
+	assert.NoError(err)
+	user := api.UserPass{
+		Username: username,
+		Password: password,
+	}
+
+	// Fragment the funds of [addr]
+	amounts := []uint64{5000, 6000, 7000}
+	outputs := make([]SendOutput, len(amounts))
+	for i, amount := range amounts {
+		outputs[i] = SendOutput{
+			Amount:  json.Uint64(amount),
+			AssetID: assetID.String(),
+			To:      addrStr,
+		}
+	}
+	vm.timer.Cancel()
+	err = ws.SendMultiple(nil, &SendMultipleArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{fromStr}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: fromStr},
+		},
+		Outputs: outputs,
+	}, &api.JSONTxIDChangeAddr{})
+	assert.NoError(err)
+
+	reply := &ConsolidateReply{}
+	err = ws.Consolidate(nil, &ConsolidateArgs{
+		UserPass: user,
+		Address:  addrStr,
+		AssetID:  assetID.String(),
+		MaxUTXOs: json.Uint32(len(amounts)),
+	}, reply)
+	assert.NoError(err)
+	assert.EqualValues(len(amounts), reply.NumConsolidated)
+	assert.EqualValues(5000+6000+7000-testTxFee, reply.Amount)
+
+	tx, err := vm.state.GetTx(reply.TxID)
+	assert.NoError(err)
+	utx := tx.UnsignedTx.(*txs.BaseTx)
+	assert.Len(utx.Ins, len(amounts))
+	assert.Len(utx.Outs, 1)
+	assert.Equal([]ids.ShortID{addr}, utx.Outs[0].Out.(*secp256k1fx.TransferOutput).Addrs)
+
+	err = ws.Consolidate(nil, &ConsolidateArgs{
+		UserPass: user,
+		Address:  addrStr,
+		AssetID:  assetID.String(),
+		MaxUTXOs: maxConsolidateUTXOs + 1,
+	}, reply)
+	assert.Error(err)
+}
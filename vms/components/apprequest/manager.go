@@ -0,0 +1,339 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package apprequest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+	"github.com/lasthyphen/beacongo/utils/timer/mockable"
+)
+
+var (
+	// ErrAttemptsExhausted is returned to the callbacks of a request that
+	// failed on every allowed attempt.
+	ErrAttemptsExhausted = errors.New("request failed on every attempt")
+	// ErrDeadlineExceeded is returned to the callbacks of a request whose
+	// deadline passed before a valid response was received.
+	ErrDeadlineExceeded = errors.New("request deadline exceeded")
+	// ErrShutdown is returned to the callbacks of requests that were still
+	// outstanding when the manager was shut down.
+	ErrShutdown = errors.New("request manager shut down")
+
+	errNoPeers       = errors.New("no peers to send the request to")
+	errRequestFailed = errors.New("request to peer failed")
+	errNoMaxAttempts = errors.New("max attempts must be positive")
+)
+
+// Callback is called once with the outcome of a request. If [err] is nil,
+// [response] is a valid response from [nodeID].
+type Callback func(nodeID ids.NodeID, response []byte, err error)
+
+// Config configures a Manager.
+type Config struct {
+	Sender common.AppSender
+
+	// Lock is held while retries are sent, and callbacks called, after a
+	// backoff. VMs should use the lock of their snow context, which the engine
+	// holds when it calls AppResponse and AppRequestFailed.
+	Lock sync.Locker
+
+	// Peers returns the nodes that requests can currently be sent to.
+	Peers func() []ids.NodeID
+
+	// Verify, if non-nil, checks that [response] is a valid response to
+	// [request]. Invalid responses are treated like failed requests.
+	Verify func(request, response []byte) error
+
+	// MaxAttempts is the number of times a request is sent before giving up.
+	// Each attempt is sent to a peer that hasn't been tried yet, unless every
+	// peer has been tried.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. It doubles for every
+	// further retry, up to MaxBackoff. If zero, failed requests are retried
+	// immediately.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Manager sends AppRequests on behalf of a VM, retrying failed requests with
+// alternate peers. Concurrent requests with the same bytes are deduplicated, so
+// that only one of them is outstanding on the network.
+//
+// Request IDs are allocated by the manager, so every AppRequest of the VM
+// should be sent through it, and the VM's AppResponse and AppRequestFailed
+// should be forwarded to it.
+type Manager struct {
+	config Config
+	clock  mockable.Clock
+
+	lock          sync.Mutex
+	nextRequestID uint32
+	// request ID of the outstanding attempt -> request
+	inFlight map[uint32]*request
+	// hash of the request bytes -> request
+	requests map[ids.ID]*request
+}
+
+type request struct {
+	key       ids.ID
+	bytes     []byte
+	deadline  time.Time
+	callbacks []Callback
+
+	attempts int
+	tried    ids.NodeIDSet
+	// Peer and request ID of the outstanding attempt. Unset while waiting for
+	// a retry.
+	nodeID    ids.NodeID
+	requestID uint32
+	// Non-nil while waiting for a retry
+	timer *time.Timer
+}
+
+// NewManager returns a new request manager.
+func NewManager(config Config) (*Manager, error) {
+	if config.MaxAttempts <= 0 {
+		return nil, errNoMaxAttempts
+	}
+	return &Manager{
+		config:   config,
+		inFlight: make(map[uint32]*request),
+		requests: make(map[ids.ID]*request),
+	}, nil
+}
+
+// Request sends [requestBytes] to a peer. [callback] is called once the
+// request has succeeded or has been abandoned. If the request hasn't succeeded
+// by [deadline], it's abandoned with ErrDeadlineExceeded. A zero deadline means
+// the request is only abandoned once its attempts are exhausted.
+//
+// If a request with the same bytes is already outstanding, [callback] is added
+// to it and no new request is sent. The deadline of the outstanding request is
+// extended to [deadline] if it's later.
+func (m *Manager) Request(requestBytes []byte, deadline time.Time, callback Callback) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	key := hashing.ComputeHash256Array(requestBytes)
+	if r, ok := m.requests[key]; ok {
+		r.callbacks = append(r.callbacks, callback)
+		if r.deadline.IsZero() || deadline.IsZero() {
+			r.deadline = time.Time{}
+		} else if deadline.After(r.deadline) {
+			r.deadline = deadline
+		}
+		return nil
+	}
+
+	r := &request{
+		key:       key,
+		bytes:     requestBytes,
+		deadline:  deadline,
+		callbacks: []Callback{callback},
+	}
+	if err := m.send(r); err != nil {
+		return err
+	}
+	m.requests[key] = r
+	return nil
+}
+
+// AppResponse handles a response to a request. Returns false if the response
+// doesn't belong to an outstanding request of the manager.
+func (m *Manager) AppResponse(nodeID ids.NodeID, requestID uint32, response []byte) bool {
+	m.lock.Lock()
+	r, ok := m.removeInFlight(nodeID, requestID)
+	if !ok {
+		m.lock.Unlock()
+		return false
+	}
+
+	var done func()
+	if m.config.Verify == nil {
+		done = m.finish(r, nodeID, response, nil)
+	} else if err := m.config.Verify(r.bytes, response); err != nil {
+		done = m.retry(r, fmt.Errorf("invalid response from %s: %w", nodeID, err))
+	} else {
+		done = m.finish(r, nodeID, response, nil)
+	}
+	m.lock.Unlock()
+
+	done()
+	return true
+}
+
+// AppRequestFailed handles the failure of a request. Returns false if the
+// failure doesn't belong to an outstanding request of the manager.
+func (m *Manager) AppRequestFailed(nodeID ids.NodeID, requestID uint32) bool {
+	m.lock.Lock()
+	r, ok := m.removeInFlight(nodeID, requestID)
+	if !ok {
+		m.lock.Unlock()
+		return false
+	}
+	done := m.retry(r, fmt.Errorf("%w: %s", errRequestFailed, nodeID))
+	m.lock.Unlock()
+
+	done()
+	return true
+}
+
+// Len returns the number of outstanding requests.
+func (m *Manager) Len() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return len(m.requests)
+}
+
+// Shutdown abandons every outstanding request with ErrShutdown.
+func (m *Manager) Shutdown() {
+	m.lock.Lock()
+	callbacks := []func(){}
+	for _, r := range m.requests {
+		callbacks = append(callbacks, m.finish(r, ids.EmptyNodeID, nil, ErrShutdown))
+	}
+	m.lock.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// send the next attempt of [r]. Assumes [m.lock] is held.
+func (m *Manager) send(r *request) error {
+	nodeID, err := m.selectPeer(r)
+	if err != nil {
+		return err
+	}
+
+	requestID := m.nextRequestID
+	m.nextRequestID++
+	r.attempts++
+	r.tried.Add(nodeID)
+	r.nodeID = nodeID
+	r.requestID = requestID
+	m.inFlight[requestID] = r
+
+	nodeIDs := ids.NewNodeIDSet(1)
+	nodeIDs.Add(nodeID)
+	if err := m.config.Sender.SendAppRequest(nodeIDs, requestID, r.bytes); err != nil {
+		delete(m.inFlight, requestID)
+		return err
+	}
+	return nil
+}
+
+// selectPeer returns a random peer that [r] hasn't been sent to. If it has
+// been sent to every peer, any peer may be returned.
+func (m *Manager) selectPeer(r *request) (ids.NodeID, error) {
+	peers := m.config.Peers()
+	if len(peers) == 0 {
+		return ids.EmptyNodeID, errNoPeers
+	}
+
+	untried := make([]ids.NodeID, 0, len(peers))
+	for _, nodeID := range peers {
+		if !r.tried.Contains(nodeID) {
+			untried = append(untried, nodeID)
+		}
+	}
+	if len(untried) == 0 {
+		untried = peers
+	}
+	return untried[rand.Intn(len(untried))], nil // #nosec G404
+}
+
+// removeInFlight returns the request that is waiting for a response from
+// [nodeID] to [requestID]. Assumes [m.lock] is held.
+func (m *Manager) removeInFlight(nodeID ids.NodeID, requestID uint32) (*request, bool) {
+	r, ok := m.inFlight[requestID]
+	if !ok || r.nodeID != nodeID {
+		return nil, false
+	}
+	delete(m.inFlight, requestID)
+	return r, true
+}
+
+// retry [r] after its attempt failed with [cause], or abandon it if it can't
+// be retried. Assumes [m.lock] is held. Returns the callbacks to call once
+// [m.lock] is released.
+func (m *Manager) retry(r *request, cause error) func() {
+	if r.attempts >= m.config.MaxAttempts {
+		return m.finish(r, ids.EmptyNodeID, nil, fmt.Errorf("%w after %d attempts: %s", ErrAttemptsExhausted, r.attempts, cause))
+	}
+
+	backoff := m.backoff(r.attempts)
+	if !r.deadline.IsZero() && !m.clock.Time().Add(backoff).Before(r.deadline) {
+		return m.finish(r, ids.EmptyNodeID, nil, fmt.Errorf("%w: %s", ErrDeadlineExceeded, cause))
+	}
+
+	if backoff == 0 {
+		if err := m.send(r); err != nil {
+			return m.finish(r, ids.EmptyNodeID, nil, err)
+		}
+		return func() {}
+	}
+
+	r.timer = time.AfterFunc(backoff, func() {
+		m.config.Lock.Lock()
+		defer m.config.Lock.Unlock()
+
+		m.lock.Lock()
+		if m.requests[r.key] != r {
+			// The request was abandoned while waiting for the retry
+			m.lock.Unlock()
+			return
+		}
+		r.timer = nil
+		done := func() {}
+		if err := m.send(r); err != nil {
+			done = m.finish(r, ids.EmptyNodeID, nil, err)
+		}
+		m.lock.Unlock()
+
+		done()
+	})
+	return func() {}
+}
+
+// backoff returns the delay before the retry that follows [attempts] attempts.
+func (m *Manager) backoff(attempts int) time.Duration {
+	backoff := m.config.InitialBackoff
+	for i := 1; i < attempts && backoff < m.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if m.config.MaxBackoff > 0 && backoff > m.config.MaxBackoff {
+		backoff = m.config.MaxBackoff
+	}
+	return backoff
+}
+
+// finish removes [r] from the outstanding requests. Assumes [m.lock] is held.
+// Returns a function that calls the callbacks of [r] with the outcome, to be
+// called once [m.lock] is released.
+func (m *Manager) finish(r *request, nodeID ids.NodeID, response []byte, err error) func() {
+	delete(m.requests, r.key)
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	if m.inFlight[r.requestID] == r {
+		delete(m.inFlight, r.requestID)
+	}
+	callbacks := r.callbacks
+	return func() {
+		for _, callback := range callbacks {
+			callback(nodeID, response, err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package apprequest
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow/engine/common"
+)
+
+type sentRequest struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+func newTestManager(t *testing.T, config Config) (*Manager, *[]sentRequest) {
+	sent := []sentRequest{}
+	sender := &common.SenderTest{T: t}
+	sender.SendAppRequestF = func(nodeIDs ids.NodeIDSet, requestID uint32, _ []byte) error {
+		assert.Equal(t, 1, nodeIDs.Len())
+		sent = append(sent, sentRequest{
+			nodeID:    nodeIDs.List()[0],
+			requestID: requestID,
+		})
+		return nil
+	}
+	config.Sender = sender
+	config.Lock = &sync.Mutex{}
+
+	m, err := NewManager(config)
+	assert.NoError(t, err)
+	return m, &sent
+}
+
+func TestManagerRetriesAlternatePeer(t *testing.T) {
+	assert := assert.New(t)
+
+	peers := []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+	m, sent := newTestManager(t, Config{
+		Peers:       func() []ids.NodeID { return peers },
+		MaxAttempts: 2,
+	})
+
+	numCalls := 0
+	var response []byte
+	callback := func(_ ids.NodeID, r []byte, err error) {
+		assert.NoError(err)
+		numCalls++
+		response = r
+	}
+	assert.NoError(m.Request([]byte{1}, time.Time{}, callback))
+	// Identical requests are deduplicated
+	assert.NoError(m.Request([]byte{1}, time.Time{}, callback))
+	assert.Len(*sent, 1)
+	assert.Equal(1, m.Len())
+
+	first := (*sent)[0]
+	assert.False(m.AppRequestFailed(first.nodeID, first.requestID+1))
+	assert.True(m.AppRequestFailed(first.nodeID, first.requestID))
+	assert.Len(*sent, 2)
+	second := (*sent)[1]
+	assert.NotEqual(first.nodeID, second.nodeID)
+	assert.NotEqual(first.requestID, second.requestID)
+
+	// Responses to abandoned attempts are ignored
+	assert.False(m.AppResponse(first.nodeID, first.requestID, []byte{2}))
+	assert.True(m.AppResponse(second.nodeID, second.requestID, []byte{3}))
+	assert.Equal(2, numCalls)
+	assert.Equal([]byte{3}, response)
+	assert.Zero(m.Len())
+}
+
+func TestManagerAttemptsExhausted(t *testing.T) {
+	assert := assert.New(t)
+
+	errInvalid := errors.New("invalid")
+	m, sent := newTestManager(t, Config{
+		Peers: func() []ids.NodeID { return []ids.NodeID{ids.GenerateTestNodeID()} },
+		Verify: func(request, response []byte) error {
+			if !bytes.Equal(request, response) {
+				return errInvalid
+			}
+			return nil
+		},
+		MaxAttempts: 2,
+	})
+
+	var result error
+	assert.NoError(m.Request([]byte{1}, time.Time{}, func(_ ids.NodeID, _ []byte, err error) {
+		result = err
+	}))
+
+	// Invalid responses are retried
+	first := (*sent)[0]
+	assert.True(m.AppResponse(first.nodeID, first.requestID, []byte{2}))
+	assert.NoError(result)
+	assert.Len(*sent, 2)
+
+	second := (*sent)[1]
+	assert.True(m.AppRequestFailed(second.nodeID, second.requestID))
+	assert.ErrorIs(result, ErrAttemptsExhausted)
+	assert.Zero(m.Len())
+}
+
+func TestManagerDeadline(t *testing.T) {
+	assert := assert.New(t)
+
+	m, sent := newTestManager(t, Config{
+		Peers:          func() []ids.NodeID { return []ids.NodeID{ids.GenerateTestNodeID()} },
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+	})
+	now := time.Now()
+	m.clock.Set(now)
+
+	var result error
+	assert.NoError(m.Request([]byte{1}, now.Add(time.Second), func(_ ids.NodeID, _ []byte, err error) {
+		result = err
+	}))
+
+	// The retry wouldn't be sent before the deadline
+	first := (*sent)[0]
+	assert.True(m.AppRequestFailed(first.nodeID, first.requestID))
+	assert.ErrorIs(result, ErrDeadlineExceeded)
+	assert.Len(*sent, 1)
+}
+
+func TestManagerShutdown(t *testing.T) {
+	assert := assert.New(t)
+
+	m, _ := newTestManager(t, Config{
+		Peers:          func() []ids.NodeID { return []ids.NodeID{ids.GenerateTestNodeID()} },
+		MaxAttempts:    2,
+		InitialBackoff: time.Hour,
+	})
+
+	var result error
+	assert.NoError(m.Request([]byte{1}, time.Time{}, func(_ ids.NodeID, _ []byte, err error) {
+		result = err
+	}))
+	m.Shutdown()
+	assert.ErrorIs(result, ErrShutdown)
+	assert.Zero(m.Len())
+
+	_, err := NewManager(Config{})
+	assert.ErrorIs(err, errNoMaxAttempts)
+}
+
+func TestManagerBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	m, _ := newTestManager(t, Config{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	})
+	assert.Equal(time.Second, m.backoff(1))
+	assert.Equal(2*time.Second, m.backoff(2))
+	assert.Equal(4*time.Second, m.backoff(3))
+	assert.Equal(5*time.Second, m.backoff(4))
+}
@@ -4,6 +4,7 @@
 package djtx
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/lasthyphen/beacongo/ids"
@@ -12,7 +13,12 @@ import (
 	"github.com/lasthyphen/beacongo/utils/formatting/address"
 )
 
-var _ AddressManager = &addressManager{}
+var (
+	errWrongNetworkAddress = errors.New("address is for a different network")
+	errUnlocalizedAddress  = errors.New("address doesn't name its chain and network")
+
+	_ AddressManager = &addressManager{}
+)
 
 type AddressManager interface {
 	// ParseLocalAddress takes in an address for this chain and produces the ID
@@ -29,10 +35,16 @@ type AddressManager interface {
 	// FormatAddress takes in a chainID and a raw address and produces the
 	// formatted address for that chain
 	FormatAddress(chainID ids.ID, addr ids.ShortID) (string, error)
+
+	// StrictAddresses returns true if the addresses passed to the chain's
+	// services must be formatted for this chain, rather than also being
+	// accepted as raw IDs
+	StrictAddresses() bool
 }
 
 type addressManager struct {
-	ctx *snow.Context
+	ctx    *snow.Context
+	strict bool
 }
 
 func NewAddressManager(ctx *snow.Context) AddressManager {
@@ -41,6 +53,15 @@ func NewAddressManager(ctx *snow.Context) AddressManager {
 	}
 }
 
+// NewStrictAddressManager returns an AddressManager whose services only
+// accept addresses that are formatted for its chain and network.
+func NewStrictAddressManager(ctx *snow.Context) AddressManager {
+	return &addressManager{
+		ctx:    ctx,
+		strict: true,
+	}
+}
+
 func (a *addressManager) ParseLocalAddress(addrStr string) (ids.ShortID, error) {
 	chainID, addr, err := a.ParseAddress(addrStr)
 	if err != nil {
@@ -70,8 +91,10 @@ func (a *addressManager) ParseAddress(addrStr string) (ids.ID, ids.ShortID, erro
 	expectedHRP := constants.GetHRP(a.ctx.NetworkID)
 	if hrp != expectedHRP {
 		return ids.ID{}, ids.ShortID{}, fmt.Errorf(
-			"expected hrp %q but got %q",
+			"%w: expected hrp %q, so that the address starts with %q, but got %q",
+			errWrongNetworkAddress,
 			expectedHRP,
+			chainIDAlias+"-"+expectedHRP+"1",
 			hrp,
 		)
 	}
@@ -83,6 +106,10 @@ func (a *addressManager) ParseAddress(addrStr string) (ids.ID, ids.ShortID, erro
 	return chainID, addr, nil
 }
 
+func (a *addressManager) StrictAddresses() bool {
+	return a.strict
+}
+
 func (a *addressManager) FormatLocalAddress(addr ids.ShortID) (string, error) {
 	return a.FormatAddress(a.ctx.ChainID, addr)
 }
@@ -111,9 +138,14 @@ func ParseLocalAddresses(a AddressManager, addrStrs []string) (ids.ShortSet, err
 // ParseServiceAddress get address ID from address string, being it either localized (using address manager,
 // doing also components validations), or not localized.
 // If both attempts fail, reports error from localized address parsing
+// If [a] requires strict addresses, addresses that aren't localized are
+// rejected.
 func ParseServiceAddress(a AddressManager, addrStr string) (ids.ShortID, error) {
 	addr, err := ids.ShortFromString(addrStr)
 	if err == nil {
+		if a.StrictAddresses() {
+			return ids.ShortID{}, unlocalizedAddressError(a, addrStr, addr)
+		}
 		return addr, nil
 	}
 
@@ -136,3 +168,14 @@ func ParseServiceAddresses(a AddressManager, addrStrs []string) (ids.ShortSet, e
 	}
 	return addrs, nil
 }
+
+// unlocalizedAddressError reports that [addrStr], which is the raw ID [addr],
+// doesn't name its chain and network, along with the address it should be
+// passed as.
+func unlocalizedAddressError(a AddressManager, addrStr string, addr ids.ShortID) error {
+	localized, err := a.FormatLocalAddress(addr)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", addrStr, errUnlocalizedAddress)
+	}
+	return fmt.Errorf("couldn't parse address %q: %w, expected %q", addrStr, errUnlocalizedAddress, localized)
+}
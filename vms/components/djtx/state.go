@@ -12,3 +12,10 @@ const (
 type Addressable interface {
 	Addresses() [][]byte
 }
+
+// ThresholdOwned is implemented by outputs that can only be spent with
+// signatures from a threshold of their addresses
+type ThresholdOwned interface {
+	Addressable
+	SpendThreshold() uint32
+}
@@ -4,6 +4,8 @@
 package djtx
 
 import (
+	"crypto/sha256"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/lasthyphen/beacongo/cache"
@@ -13,6 +15,7 @@ import (
 	"github.com/lasthyphen/beacongo/database/linkeddb"
 	"github.com/lasthyphen/beacongo/database/prefixdb"
 	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/hashing"
 )
 
 var (
@@ -30,6 +33,9 @@ const (
 type UTXOState interface {
 	UTXOReader
 	UTXOWriter
+
+	// HasUTXOID returns true if [utxoID] is indexed under [addr].
+	HasUTXOID(addr []byte, utxoID ids.ID) (bool, error)
 }
 
 // UTXOReader is a thin wrapper around a database to provide fetching of UTXOs.
@@ -210,6 +216,10 @@ func (s *utxoState) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, err
 	return utxoIDs, iter.Error()
 }
 
+func (s *utxoState) HasUTXOID(addr []byte, utxoID ids.ID) (bool, error) {
+	return s.getIndexDB(addr).Has(utxoID[:])
+}
+
 func (s *utxoState) getIndexDB(addr []byte) linkeddb.LinkedDB {
 	addrStr := string(addr)
 	if indexList, exists := s.indexCache.Get(addrStr); exists {
@@ -221,3 +231,44 @@ func (s *utxoState) getIndexDB(addr []byte) linkeddb.LinkedDB {
 	s.indexCache.Put(addrStr, indexList)
 	return indexList
 }
+
+// UTXODigest returns a deterministic digest of the UTXOs stored in [db] by a
+// UTXOState, along with the number of UTXOs. The address index is excluded
+// because its layout depends on the order UTXOs were added.
+func UTXODigest(db database.Database) (ids.ID, int, error) {
+	it := prefixdb.New(utxoPrefix, db).NewIterator()
+	defer it.Release()
+
+	hasher := sha256.New()
+	numUTXOs := 0
+	for it.Next() {
+		// The key and the UTXO ID are the same length, so no delimiter is
+		// needed.
+		_, _ = hasher.Write(it.Key())
+		_, _ = hasher.Write(hashing.ComputeHash256(it.Value()))
+		numUTXOs++
+	}
+	if err := it.Error(); err != nil {
+		return ids.Empty, 0, err
+	}
+	digest, err := ids.ToID(hasher.Sum(nil))
+	return digest, numUTXOs, err
+}
+
+// ForEachUTXO calls [f] with every UTXO stored in [db] by a UTXOState, in order
+// of their IDs.
+func ForEachUTXO(db database.Database, c codec.Manager, f func(*UTXO) error) error {
+	it := prefixdb.New(utxoPrefix, db).NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		utxo := &UTXO{}
+		if _, err := c.Unmarshal(it.Value(), utxo); err != nil {
+			return err
+		}
+		if err := f(utxo); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
@@ -73,6 +73,10 @@ func TestUTXOState(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal([]ids.ID{utxoID}, utxoIDs)
 
+	indexed, err := s.HasUTXOID(addr[:], utxoID)
+	assert.NoError(err)
+	assert.True(indexed)
+
 	readUTXO, err := s.GetUTXO(utxoID)
 	assert.NoError(err)
 	assert.Equal(utxo, readUTXO)
@@ -83,6 +87,10 @@ func TestUTXOState(t *testing.T) {
 	_, err = s.GetUTXO(utxoID)
 	assert.Equal(database.ErrNotFound, err)
 
+	indexed, err = s.HasUTXOID(addr[:], utxoID)
+	assert.NoError(err)
+	assert.False(indexed)
+
 	err = s.PutUTXO(utxoID, utxo)
 	assert.NoError(err)
 
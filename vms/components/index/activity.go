@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/database/prefixdb"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+)
+
+const (
+	// Sent is set when the address owned a UTXO that the transaction consumed
+	Sent Direction = 1 << iota
+	// Received is set when the address owns a UTXO that the transaction
+	// produced
+	Received
+
+	// AnyDirection matches every transaction that changed a balance
+	AnyDirection = Sent | Received
+
+	activityKeyLen   = 2 * wrappers.LongLen
+	activityValueLen = wrappers.ByteLen + hashing.HashLen
+)
+
+var (
+	activityPrefix = []byte("activity")
+
+	errWrongActivityCursorLen = errors.New("unexpected activity cursor length")
+	errWrongActivityValueLen  = errors.New("unexpected activity value length")
+)
+
+// Direction describes how a transaction changed the balance of an address.
+type Direction byte
+
+// AddressActivity is a transaction that changed the balance of an address.
+type AddressActivity struct {
+	TxID ids.ID
+	// Unix time, in seconds, of when the transaction was accepted
+	Timestamp uint64
+	Direction Direction
+}
+
+// ActivityQuery selects the transactions that changed an address's balance of
+// an asset.
+type ActivityQuery struct {
+	// Inclusive bounds, in Unix seconds, on the acceptance time of the
+	// transactions. An EndTime of 0 means there is no upper bound.
+	StartTime, EndTime uint64
+	// Only transactions that changed the balance in one of these directions
+	// are returned. Defaults to AnyDirection.
+	Direction Direction
+	// Cursor, if non-empty, is the position to resume reading from, as
+	// returned by a previous read.
+	Cursor []byte
+	// Maximum number of transactions to return
+	PageSize uint64
+}
+
+// activityDB returns the database of the activity of [address] with
+// [assetID]. Keys are the acceptance time followed by the index of the
+// transaction in the address's transactions of the asset, so iterating visits
+// the transactions in order of acceptance time.
+func (i *indexer) activityDB(address []byte, assetID ids.ID) database.Database {
+	addressDB := prefixdb.New(address, i.activityPrefixDB)
+	return prefixdb.New(assetID[:], addressDB)
+}
+
+func (i *indexer) putActivity(address []byte, assetID ids.ID, idx uint64, activity AddressActivity) error {
+	key := make([]byte, activityKeyLen)
+	binary.BigEndian.PutUint64(key, activity.Timestamp)
+	binary.BigEndian.PutUint64(key[wrappers.LongLen:], idx)
+
+	value := make([]byte, activityValueLen)
+	value[0] = byte(activity.Direction)
+	copy(value[wrappers.ByteLen:], activity.TxID[:])
+	return i.activityDB(address, assetID).Put(key, value)
+}
+
+// ReadActivity returns the transactions that changed [address]'s balance of
+// [assetID] and match [query], in order of acceptance time. Also returns the
+// cursor to read the next page from, which is nil if there are no more
+// matching transactions.
+// See AddressTxsIndexer
+func (i *indexer) ReadActivity(address []byte, assetID ids.ID, query ActivityQuery) ([]AddressActivity, []byte, error) {
+	start := query.Cursor
+	switch {
+	case len(start) == 0:
+		start = make([]byte, wrappers.LongLen)
+		binary.BigEndian.PutUint64(start, query.StartTime)
+	case len(start) != activityKeyLen:
+		return nil, nil, errWrongActivityCursorLen
+	}
+	direction := query.Direction
+	if direction == 0 {
+		direction = AnyDirection
+	}
+
+	iter := i.activityDB(address, assetID).NewIteratorWithStart(start)
+	defer iter.Release()
+
+	activities := []AddressActivity(nil)
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != activityKeyLen {
+			return nil, nil, errWrongActivityCursorLen
+		}
+		timestamp := binary.BigEndian.Uint64(key)
+		if query.EndTime != 0 && timestamp > query.EndTime {
+			return activities, nil, nil
+		}
+
+		value := iter.Value()
+		if len(value) != activityValueLen {
+			return nil, nil, errWrongActivityValueLen
+		}
+		activityDirection := Direction(value[0])
+		if activityDirection&direction == 0 {
+			continue
+		}
+		if uint64(len(activities)) >= query.PageSize {
+			// Copy the key as it may be invalidated by the iterator
+			return activities, append([]byte(nil), key...), nil
+		}
+
+		txID, err := ids.ToID(value[wrappers.ByteLen:])
+		if err != nil {
+			return nil, nil, err
+		}
+		activities = append(activities, AddressActivity{
+			TxID:      txID,
+			Timestamp: timestamp,
+			Direction: activityDirection,
+		})
+	}
+	return activities, nil, iter.Error()
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"errors"
+	"math"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+const assetStatsLen = 3 * wrappers.LongLen
+
+var (
+	assetStatsPrefix = []byte("assetStats")
+
+	errWrongAssetStatsLen = errors.New("unexpected asset stats length")
+)
+
+// AssetStats summarizes the accepted transactions that transferred an asset.
+type AssetStats struct {
+	// NumTxs is the number of accepted transactions that produced or consumed
+	// a UTXO of the asset.
+	NumTxs uint64
+	// NumOutputs is the number of UTXOs of the asset that were produced by
+	// accepted transactions.
+	NumOutputs uint64
+	// Volume is the total amount of the asset held by the UTXOs that were
+	// produced by accepted transactions. Saturates at MaxUint64.
+	Volume uint64
+}
+
+func (s *AssetStats) marshal() []byte {
+	p := wrappers.Packer{Bytes: make([]byte, assetStatsLen)}
+	p.PackLong(s.NumTxs)
+	p.PackLong(s.NumOutputs)
+	p.PackLong(s.Volume)
+	return p.Bytes
+}
+
+func parseAssetStats(b []byte) (AssetStats, error) {
+	if len(b) != assetStatsLen {
+		return AssetStats{}, errWrongAssetStatsLen
+	}
+	p := wrappers.Packer{Bytes: b}
+	return AssetStats{
+		NumTxs:     p.UnpackLong(),
+		NumOutputs: p.UnpackLong(),
+		Volume:     p.UnpackLong(),
+	}, p.Err
+}
+
+func getAssetStats(db database.KeyValueReader, assetID ids.ID) (AssetStats, error) {
+	statsBytes, err := db.Get(assetID[:])
+	if err == database.ErrNotFound {
+		return AssetStats{}, nil
+	}
+	if err != nil {
+		return AssetStats{}, err
+	}
+	return parseAssetStats(statsBytes)
+}
+
+// assetStatsDeltas returns how much the stats of each asset changed as a
+// result of a transaction consuming [inputUTXOs] and producing [outputUTXOs].
+func assetStatsDeltas(inputUTXOs, outputUTXOs []*djtx.UTXO) map[ids.ID]*AssetStats {
+	deltas := make(map[ids.ID]*AssetStats)
+	getDelta := func(assetID ids.ID) *AssetStats {
+		delta, exists := deltas[assetID]
+		if !exists {
+			delta = &AssetStats{NumTxs: 1}
+			deltas[assetID] = delta
+		}
+		return delta
+	}
+	for _, utxo := range inputUTXOs {
+		getDelta(utxo.AssetID())
+	}
+	for _, utxo := range outputUTXOs {
+		delta := getDelta(utxo.AssetID())
+		delta.NumOutputs++
+		if out, ok := utxo.Out.(djtx.Amounter); ok {
+			delta.Volume = saturatingAdd(delta.Volume, out.Amount())
+		}
+	}
+	return deltas
+}
+
+func (s *AssetStats) add(delta *AssetStats) {
+	s.NumTxs = saturatingAdd(s.NumTxs, delta.NumTxs)
+	s.NumOutputs = saturatingAdd(s.NumOutputs, delta.NumOutputs)
+	s.Volume = saturatingAdd(s.Volume, delta.Volume)
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	sum, err := safemath.Add64(a, b)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return sum
+}
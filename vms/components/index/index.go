@@ -22,8 +22,10 @@ import (
 var (
 	idxKey                         = []byte("idx")
 	idxCompleteKey                 = []byte("complete")
+	indexedTxsPrefix               = []byte("indexedTxs")
 	errIndexingRequiredFromGenesis = errors.New("running would create incomplete index. Allow incomplete indices or re-sync from genesis with indexing enabled")
 	errCausesIncompleteIndex       = errors.New("running would create incomplete index. Allow incomplete indices or enable indexing")
+	errIndexingDisabled            = errors.New("indexing is disabled")
 
 	_ AddressTxsIndexer = &indexer{}
 	_ AddressTxsIndexer = &noIndexer{}
@@ -40,11 +42,13 @@ type AddressTxsIndexer interface {
 	// Persists data about [txID] and what balances it changed.
 	// [inputUTXOs] are the UTXOs [txID] consumes.
 	// [outputUTXOs] are the UTXOs [txID] creates.
+	// [timestamp] is the Unix time, in seconds, of when [txID] was accepted.
 	// If the error is non-nil, do not persist [txID] to disk as accepted in the VM
 	Accept(
 		txID ids.ID,
 		inputUTXOs []*djtx.UTXO,
 		outputUTXOs []*djtx.UTXO,
+		timestamp uint64,
 	) error
 
 	// Read returns the IDs of transactions that changed [address]'s balance of [assetID].
@@ -52,12 +56,37 @@ type AddressTxsIndexer interface {
 	// The length of the returned slice <= [pageSize].
 	// [cursor] is the offset to start reading from.
 	Read(address []byte, assetID ids.ID, cursor, pageSize uint64) ([]ids.ID, error)
+
+	// ReadActivity returns the transactions that changed [address]'s balance
+	// of [assetID] and match [query], in order of acceptance time, along with
+	// the cursor of the next page. The returned cursor is nil if there are no
+	// more matching transactions.
+	ReadActivity(address []byte, assetID ids.ID, query ActivityQuery) ([]AddressActivity, []byte, error)
+
+	// ReadAssetStats returns the transfer statistics of [assetID] accumulated
+	// from the transactions that were accepted while indexing was enabled.
+	ReadAssetStats(assetID ids.ID) (AssetStats, error)
+
+	// IsIndexed returns true if [txID] was previously passed to Accept.
+	// Transactions that were accepted before this was tracked are reported as
+	// not indexed.
+	IsIndexed(txID ids.ID) (bool, error)
+
+	// MarkComplete records that every accepted transaction has been indexed,
+	// such as after historical transactions were backfilled.
+	MarkComplete() error
 }
 
 type indexer struct {
 	log     logging.Logger
 	metrics metrics
 	db      database.Database
+	// assetID -> AssetStats
+	assetStatsDB database.Database
+	// txID -> nil
+	indexedTxsDB database.Database
+	// address -> assetID -> timestamp + index -> direction + txID
+	activityPrefixDB database.Database
 }
 
 // NewIndexer returns a new AddressTxsIndexer.
@@ -70,8 +99,12 @@ func NewIndexer(
 	allowIncompleteIndices bool,
 ) (AddressTxsIndexer, error) {
 	i := &indexer{
-		db:  db,
-		log: log,
+		db:           db,
+		assetStatsDB: prefixdb.New(assetStatsPrefix, db),
+		indexedTxsDB: prefixdb.New(indexedTxsPrefix, db),
+		log:          log,
+
+		activityPrefixDB: prefixdb.New(activityPrefix, db),
 	}
 	// initialize the indexer
 	if err := checkIndexStatus(i.db, true, allowIncompleteIndices); err != nil {
@@ -93,40 +126,42 @@ func NewIndexer(
 // |  | "idx" => 2 		Running transaction index key, represents the next index
 // |  | "0"   => txID1
 // |  | "1"   => txID1
+// The transactions are also recorded in the activity database, ordered by
+// their acceptance time. See ReadActivity.
 // See interface documentation AddressTxsIndexer.Accept
-func (i *indexer) Accept(txID ids.ID, inputUTXOs []*djtx.UTXO, outputUTXOs []*djtx.UTXO) error {
-	utxos := inputUTXOs
-	// Fetch and add the output UTXOs
-	utxos = append(utxos, outputUTXOs...)
-
+func (i *indexer) Accept(txID ids.ID, inputUTXOs []*djtx.UTXO, outputUTXOs []*djtx.UTXO, timestamp uint64) error {
 	// convert UTXOs into balance changes
-	// Address -> AssetID --> exists if the address's balance
+	// Address -> AssetID --> directions in which the address's balance
 	// of the asset is changed by processing tx [txID]
 	// we do this step separately to simplify the write process later
-	balanceChanges := make(map[string]map[ids.ID]struct{})
-	for _, utxo := range utxos {
-		out, ok := utxo.Out.(djtx.Addressable)
-		if !ok {
-			i.log.Verbo("skipping UTXO %s for indexing", utxo.InputID())
-			continue
-		}
+	balanceChanges := make(map[string]map[ids.ID]Direction)
+	addChanges := func(utxos []*djtx.UTXO, direction Direction) {
+		for _, utxo := range utxos {
+			out, ok := utxo.Out.(djtx.Addressable)
+			if !ok {
+				i.log.Verbo("skipping UTXO %s for indexing", utxo.InputID())
+				continue
+			}
 
-		for _, addressBytes := range out.Addresses() {
-			address := string(addressBytes)
+			for _, addressBytes := range out.Addresses() {
+				address := string(addressBytes)
 
-			addressChanges, exists := balanceChanges[address]
-			if !exists {
-				addressChanges = make(map[ids.ID]struct{})
-				balanceChanges[address] = addressChanges
+				addressChanges, exists := balanceChanges[address]
+				if !exists {
+					addressChanges = make(map[ids.ID]Direction)
+					balanceChanges[address] = addressChanges
+				}
+				addressChanges[utxo.AssetID()] |= direction
 			}
-			addressChanges[utxo.AssetID()] = struct{}{}
 		}
 	}
+	addChanges(inputUTXOs, Sent)
+	addChanges(outputUTXOs, Received)
 
 	// Process the balance changes
 	for address, assetIDs := range balanceChanges {
 		addressPrefixDB := prefixdb.New([]byte(address), i.db)
-		for assetID := range assetIDs {
+		for assetID, direction := range assetIDs {
 			assetPrefixDB := prefixdb.New(assetID[:], addressPrefixDB)
 
 			var idx uint64
@@ -148,6 +183,14 @@ func (i *indexer) Accept(txID ids.ID, inputUTXOs []*djtx.UTXO, outputUTXOs []*dj
 			if err := assetPrefixDB.Put(idxBytes, txID[:]); err != nil {
 				return fmt.Errorf("failed to write txID while indexing %s: %w", txID, err)
 			}
+			activity := AddressActivity{
+				TxID:      txID,
+				Timestamp: timestamp,
+				Direction: direction,
+			}
+			if err := i.putActivity([]byte(address), assetID, idx, activity); err != nil {
+				return fmt.Errorf("failed to write activity while indexing %s: %w", txID, err)
+			}
 
 			// increment and store the index for next use
 			idx++
@@ -158,6 +201,21 @@ func (i *indexer) Accept(txID ids.ID, inputUTXOs []*djtx.UTXO, outputUTXOs []*dj
 			}
 		}
 	}
+
+	// Update the per-asset statistics
+	for assetID, delta := range assetStatsDeltas(inputUTXOs, outputUTXOs) {
+		stats, err := getAssetStats(i.assetStatsDB, assetID)
+		if err != nil {
+			return fmt.Errorf("failed to read stats of asset %s while indexing %s: %w", assetID, txID, err)
+		}
+		stats.add(delta)
+		if err := i.assetStatsDB.Put(assetID[:], stats.marshal()); err != nil {
+			return fmt.Errorf("failed to write stats of asset %s while indexing %s: %w", assetID, txID, err)
+		}
+	}
+	if err := i.indexedTxsDB.Put(txID[:], nil); err != nil {
+		return fmt.Errorf("failed to mark %s as indexed: %w", txID, err)
+	}
 	i.metrics.numTxsIndexed.Inc()
 	return nil
 }
@@ -199,6 +257,24 @@ func (i *indexer) Read(address []byte, assetID ids.ID, cursor, pageSize uint64)
 	return txIDs, nil
 }
 
+// ReadAssetStats returns the statistics of [assetID].
+// See AddressTxsIndexer
+func (i *indexer) ReadAssetStats(assetID ids.ID) (AssetStats, error) {
+	return getAssetStats(i.assetStatsDB, assetID)
+}
+
+// IsIndexed returns true if [txID] has been indexed.
+// See AddressTxsIndexer
+func (i *indexer) IsIndexed(txID ids.ID) (bool, error) {
+	return i.indexedTxsDB.Has(txID[:])
+}
+
+// MarkComplete marks the index as complete.
+// See AddressTxsIndexer
+func (i *indexer) MarkComplete() error {
+	return database.PutBool(i.db, idxCompleteKey, true)
+}
+
 // checkIndexStatus checks the indexing status in the database, returning error if the state
 // with respect to provided parameters is invalid
 func checkIndexStatus(db database.KeyValueReaderWriter, enableIndexing, allowIncomplete bool) error {
@@ -242,10 +318,26 @@ func NewNoIndexer(db database.Database, allowIncomplete bool) (AddressTxsIndexer
 	return &noIndexer{}, checkIndexStatus(db, false, allowIncomplete)
 }
 
-func (i *noIndexer) Accept(ids.ID, []*djtx.UTXO, []*djtx.UTXO) error {
+func (i *noIndexer) Accept(ids.ID, []*djtx.UTXO, []*djtx.UTXO, uint64) error {
 	return nil
 }
 
 func (i *noIndexer) Read([]byte, ids.ID, uint64, uint64) ([]ids.ID, error) {
 	return nil, nil
 }
+
+func (i *noIndexer) ReadActivity([]byte, ids.ID, ActivityQuery) ([]AddressActivity, []byte, error) {
+	return nil, nil, nil
+}
+
+func (i *noIndexer) ReadAssetStats(ids.ID) (AssetStats, error) {
+	return AssetStats{}, errIndexingDisabled
+}
+
+func (i *noIndexer) IsIndexed(ids.ID) (bool, error) {
+	return false, errIndexingDisabled
+}
+
+func (i *noIndexer) MarkComplete() error {
+	return errIndexingDisabled
+}
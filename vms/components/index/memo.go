@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+)
+
+var (
+	errWrongMemoCursor = errors.New("memo cursor doesn't match the query")
+	errWrongMemoKeyLen = errors.New("unexpected memo key length")
+
+	_ MemoIndexer = &memoIndexer{}
+	_ MemoIndexer = &noMemoIndexer{}
+)
+
+// MemoIndexer maintains an index of accepted transactions by their memo, so
+// that transactions can be looked up by identifiers that clients, such as
+// exchanges, encode in the memo.
+type MemoIndexer interface {
+	// Accept is called when [txID], whose memo is [memo], is accepted.
+	// Transactions without a memo aren't indexed.
+	Accept(txID ids.ID, memo []byte) error
+
+	// Read returns the transactions that match [query], ordered by memo and
+	// then by ID, along with the cursor of the next page. The returned cursor
+	// is nil if there are no more matching transactions.
+	Read(query MemoQuery) ([]MemoTx, []byte, error)
+}
+
+// MemoQuery selects the transactions whose memo matches Memo.
+type MemoQuery struct {
+	Memo []byte
+	// If true, transactions whose memo starts with Memo are returned.
+	// Otherwise, only transactions whose memo equals Memo are returned.
+	Prefix bool
+	// Cursor, if non-empty, is the position to resume reading from, as
+	// returned by a previous read.
+	Cursor []byte
+	// Maximum number of transactions to return
+	PageSize uint64
+}
+
+// MemoTx is a transaction that matched a MemoQuery.
+type MemoTx struct {
+	TxID ids.ID
+	Memo []byte
+}
+
+type memoIndexer struct {
+	// memo + txID -> nil
+	db database.Database
+}
+
+// NewMemoIndexer returns a MemoIndexer that stores its index in [db].
+func NewMemoIndexer(db database.Database) MemoIndexer {
+	return &memoIndexer{db: db}
+}
+
+func (i *memoIndexer) Accept(txID ids.ID, memo []byte) error {
+	if len(memo) == 0 {
+		return nil
+	}
+	key := make([]byte, len(memo)+hashing.HashLen)
+	copy(key, memo)
+	copy(key[len(memo):], txID[:])
+	return i.db.Put(key, nil)
+}
+
+// Read iterates over the transactions whose memo starts with [query.Memo]. An
+// exact match skips those whose memo is longer.
+func (i *memoIndexer) Read(query MemoQuery) ([]MemoTx, []byte, error) {
+	start := query.Cursor
+	if len(start) != 0 && !bytes.HasPrefix(start, query.Memo) {
+		return nil, nil, errWrongMemoCursor
+	}
+
+	iter := i.db.NewIteratorWithStartAndPrefix(start, query.Memo)
+	defer iter.Release()
+
+	txs := []MemoTx(nil)
+	for iter.Next() {
+		key := iter.Key()
+		memoLen := len(key) - hashing.HashLen
+		if memoLen <= 0 {
+			return nil, nil, errWrongMemoKeyLen
+		}
+		if !query.Prefix && memoLen != len(query.Memo) {
+			continue
+		}
+		if uint64(len(txs)) >= query.PageSize {
+			// Copy the key as it may be invalidated by the iterator
+			return txs, append([]byte(nil), key...), nil
+		}
+
+		txID, err := ids.ToID(key[memoLen:])
+		if err != nil {
+			return nil, nil, err
+		}
+		txs = append(txs, MemoTx{
+			TxID: txID,
+			Memo: append([]byte(nil), key[:memoLen]...),
+		})
+	}
+	return txs, nil, iter.Error()
+}
+
+type noMemoIndexer struct{}
+
+// NewNoMemoIndexer returns a MemoIndexer that doesn't index transactions.
+func NewNoMemoIndexer() MemoIndexer {
+	return &noMemoIndexer{}
+}
+
+func (*noMemoIndexer) Accept(ids.ID, []byte) error {
+	return nil
+}
+
+func (*noMemoIndexer) Read(MemoQuery) ([]MemoTx, []byte, error) {
+	return nil, nil, errIndexingDisabled
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/database/memdb"
+	"github.com/lasthyphen/beacongo/ids"
+)
+
+func TestMemoIndexer(t *testing.T) {
+	assert := assert.New(t)
+
+	i := NewMemoIndexer(memdb.New())
+	txIDs := []ids.ID{{1}, {2}, {3}, {4}}
+	assert.NoError(i.Accept(txIDs[0], []byte("deposit-1")))
+	assert.NoError(i.Accept(txIDs[1], []byte("deposit-12")))
+	assert.NoError(i.Accept(txIDs[2], []byte("deposit-1")))
+	assert.NoError(i.Accept(txIDs[3], nil))
+
+	// An exact match skips longer memos
+	txs, cursor, err := i.Read(MemoQuery{
+		Memo:     []byte("deposit-1"),
+		PageSize: 10,
+	})
+	assert.NoError(err)
+	assert.Nil(cursor)
+	assert.Equal([]MemoTx{
+		{TxID: txIDs[0], Memo: []byte("deposit-1")},
+		{TxID: txIDs[2], Memo: []byte("deposit-1")},
+	}, txs)
+
+	// A prefix match is read one page at a time
+	query := MemoQuery{
+		Memo:     []byte("deposit-"),
+		Prefix:   true,
+		PageSize: 2,
+	}
+	txs, cursor, err = i.Read(query)
+	assert.NoError(err)
+	assert.Len(txs, 2)
+	assert.NotNil(cursor)
+
+	query.Cursor = cursor
+	txs, cursor, err = i.Read(query)
+	assert.NoError(err)
+	assert.Nil(cursor)
+	assert.Equal([]MemoTx{{TxID: txIDs[1], Memo: []byte("deposit-12")}}, txs)
+
+	// The cursor must belong to the queried memo
+	_, _, err = i.Read(MemoQuery{
+		Memo:     []byte("withdrawal"),
+		Cursor:   []byte("deposit-1"),
+		PageSize: 1,
+	})
+	assert.ErrorIs(err, errWrongMemoCursor)
+
+	_, _, err = NewNoMemoIndexer().Read(MemoQuery{Memo: []byte("deposit-1")})
+	assert.ErrorIs(err, errIndexingDisabled)
+}
@@ -4,6 +4,7 @@
 package keystore
 
 import (
+	"errors"
 	"fmt"
 	"io"
 
@@ -23,9 +24,11 @@ var (
 	// this user controls
 	addressesKey = ids.Empty[:]
 
-	errMaxAddresses = fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
+	errMaxAddresses           = fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
+	errReadOnlyTokensDisabled = errors.New("keystore doesn't support read-only tokens")
 
-	_ User = &user{}
+	_ User         = &user{}
+	_ ReadOnlyUser = &readOnlyUser{}
 )
 
 type User interface {
@@ -41,6 +44,15 @@ type User interface {
 	GetKey(address ids.ShortID) (*crypto.PrivateKeySECP256K1R, error)
 }
 
+// ReadOnlyUser is a keystore user that was loaded with a read-only token. It
+// can't reveal or use the user's keys.
+type ReadOnlyUser interface {
+	io.Closer
+
+	// Get the addresses controlled by this user
+	GetAddresses() ([]ids.ShortID, error)
+}
+
 type user struct {
 	factory crypto.FactorySECP256K1R
 	db      *encdb.Database
@@ -60,9 +72,27 @@ func NewUserFromDB(db *encdb.Database) User {
 	return &user{db: db}
 }
 
+// NewReadOnlyUserFromKeystore tracks the keystore user that [token] was
+// created for, without access to its keys
+func NewReadOnlyUserFromKeystore(ks keystore.BlockchainKeystore, token string) (ReadOnlyUser, error) {
+	readOnlyKS, ok := ks.(keystore.ReadOnlyBlockchainKeystore)
+	if !ok {
+		return nil, errReadOnlyTokensDisabled
+	}
+	db, err := readOnlyKS.GetReadOnlyDatabase(token)
+	if err != nil {
+		return nil, fmt.Errorf("problem retrieving user: %w", err)
+	}
+	return &readOnlyUser{db: db}, nil
+}
+
 func (u *user) GetAddresses() ([]ids.ShortID, error) {
+	return getAddresses(u.db)
+}
+
+func getAddresses(db *encdb.Database) ([]ids.ShortID, error) {
 	// Get user's addresses
-	addressBytes, err := u.db.Get(addressesKey)
+	addressBytes, err := db.Get(addressesKey)
 	if err == database.ErrNotFound {
 		// If user has no addresses, return empty list
 		return nil, nil
@@ -137,6 +167,16 @@ func (u *user) GetKey(address ids.ShortID) (*crypto.PrivateKeySECP256K1R, error)
 
 func (u *user) Close() error { return u.db.Close() }
 
+type readOnlyUser struct {
+	db *encdb.Database
+}
+
+func (u *readOnlyUser) GetAddresses() ([]ids.ShortID, error) {
+	return getAddresses(u.db)
+}
+
+func (u *readOnlyUser) Close() error { return u.db.Close() }
+
 // Create and store a new key that will be controlled by this user.
 func NewKey(u User) (*crypto.PrivateKeySECP256K1R, error) {
 	keys, err := NewKeys(u, 1)
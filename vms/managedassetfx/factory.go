@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package managedassetfx
+
+import (
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/vms"
+)
+
+var (
+	_ vms.Factory = &Factory{}
+
+	// ID that this Fx uses when labeled
+	ID = ids.ID{'m', 'a', 'n', 'a', 'g', 'e', 'd', 'a', 's', 's', 'e', 't', 'f', 'x'}
+)
+
+type Factory struct{}
+
+func (f *Factory) New(*snow.Context) (interface{}, error) { return &Fx{}, nil }
@@ -0,0 +1,17 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package managedassetfx
+
+import (
+	"testing"
+)
+
+func TestFactory(t *testing.T) {
+	factory := Factory{}
+	if fx, err := factory.New(nil); err != nil {
+		t.Fatal(err)
+	} else if fx == nil {
+		t.Fatalf("Factory.New returned nil")
+	}
+}
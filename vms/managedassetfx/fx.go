@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package managedassetfx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+	"github.com/lasthyphen/beacongo/vms/components/verify"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+)
+
+var (
+	errWrongTxType         = errors.New("wrong tx type")
+	errWrongInputType      = errors.New("wrong input type")
+	errWrongUTXOType       = errors.New("wrong utxo type")
+	errWrongOperationType  = errors.New("wrong operation type")
+	errWrongCredentialType = errors.New("wrong credential type")
+	errWrongNumberOfUTXOs  = errors.New("wrong number of UTXOs for the operation")
+	errWrongManagerOutput  = errors.New("manager output must be recreated unchanged")
+	errWrongAmount         = errors.New("reassigned amount must be preserved")
+	errNilOutput           = errors.New("nil output")
+)
+
+// Fx is a feature extension for assets that are controlled by a manager. The
+// manager can freeze and unfreeze transfers of the asset, and reassign the
+// ownership of any of the asset's outputs.
+//
+// Freezing is enforced by the VM, which must refuse to spend outputs of an
+// asset whose latest ManagerOutput is frozen.
+type Fx struct{ secp256k1fx.Fx }
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing managed asset fx")
+
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&TransferInput{}),
+		c.RegisterType(&TransferOutput{}),
+		c.RegisterType(&ManagerOutput{}),
+		c.RegisterType(&UpdateManagerOperation{}),
+		c.RegisterType(&ReassignOperation{}),
+		c.RegisterType(&Credential{}),
+	)
+	return errs.Err
+}
+
+func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.Tx)
+	if !ok {
+		return errWrongTxType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+
+	switch op := opIntf.(type) {
+	case *UpdateManagerOperation:
+		return fx.VerifyUpdateManagerOperation(tx, op, cred, utxosIntf)
+	case *ReassignOperation:
+		return fx.VerifyReassignOperation(tx, op, cred, utxosIntf)
+	default:
+		return errWrongOperationType
+	}
+}
+
+func (fx *Fx) VerifyUpdateManagerOperation(tx secp256k1fx.Tx, op *UpdateManagerOperation, cred *Credential, utxosIntf []interface{}) error {
+	if len(utxosIntf) != 1 {
+		return errWrongNumberOfUTXOs
+	}
+	out, ok := utxosIntf[0].(*ManagerOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+	if err := verify.All(op, cred, out); err != nil {
+		return err
+	}
+	return fx.Fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &out.OutputOwners)
+}
+
+func (fx *Fx) VerifyReassignOperation(tx secp256k1fx.Tx, op *ReassignOperation, cred *Credential, utxosIntf []interface{}) error {
+	if len(utxosIntf) < 2 {
+		return errWrongNumberOfUTXOs
+	}
+
+	// The UTXOs of an operation are sorted by ID, so the ManagerOutput can be
+	// anywhere among them.
+	var (
+		manager  *ManagerOutput
+		consumed uint64
+		err      error
+	)
+	for _, utxoIntf := range utxosIntf {
+		switch utxo := utxoIntf.(type) {
+		case *ManagerOutput:
+			if manager != nil {
+				return errWrongNumberOfUTXOs
+			}
+			manager = utxo
+		case *TransferOutput:
+			consumed, err = safemath.Add64(consumed, utxo.Amt)
+			if err != nil {
+				return err
+			}
+		default:
+			return errWrongUTXOType
+		}
+	}
+	if manager == nil {
+		return errWrongNumberOfUTXOs
+	}
+	if err := verify.All(op, cred, manager); err != nil {
+		return err
+	}
+	if !manager.Equals(&op.ManagerOutput) {
+		return errWrongManagerOutput
+	}
+
+	produced := uint64(0)
+	for _, out := range op.TransferOutputs {
+		produced, err = safemath.Add64(produced, out.Amt)
+		if err != nil {
+			return err
+		}
+	}
+	if produced != consumed {
+		return errWrongAmount
+	}
+	return fx.Fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &manager.OutputOwners)
+}
+
+func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.Tx)
+	if !ok {
+		return errWrongTxType
+	}
+	in, ok := inIntf.(*TransferInput)
+	if !ok {
+		return errWrongInputType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+	out, ok := utxoIntf.(*TransferOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+	return fx.Fx.VerifySpend(tx, &in.TransferInput, &cred.Credential, &out.TransferOutput)
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package managedassetfx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/codec/linearcodec"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	sigBytes = [crypto.SECP256K1RSigLen]byte{
+		0x0e, 0x33, 0x4e, 0xbc, 0x67, 0xa7, 0x3f, 0xe8,
+		0x24, 0x33, 0xac, 0xa3, 0x47, 0x88, 0xa6, 0x3d,
+		0x58, 0xe5, 0x8e, 0xf0, 0x3a, 0xd5, 0x84, 0xf1,
+		0xbc, 0xa3, 0xb2, 0xd2, 0x5d, 0x51, 0xd6, 0x9b,
+		0x0f, 0x28, 0x5d, 0xcd, 0x3f, 0x71, 0x17, 0x0a,
+		0xf9, 0xbf, 0x2d, 0xb1, 0x10, 0x26, 0x5c, 0xe9,
+		0xdc, 0xc3, 0x9d, 0x7a, 0x01, 0x50, 0x9d, 0xe8,
+		0x35, 0xbd, 0xcb, 0x29, 0x3a, 0xd1, 0x49, 0x32,
+		0x00,
+	}
+	addr = [hashing.AddrLen]byte{
+		0x01, 0x5c, 0xce, 0x6c, 0x55, 0xd6, 0xb5, 0x09,
+		0x84, 0x5c, 0x8c, 0x4e, 0x30, 0xbe, 0xd9, 0x8d,
+		0x39, 0x1a, 0xe7, 0xf0,
+	}
+)
+
+func newTestFx(t *testing.T) *Fx {
+	vm := secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	vm.CLK.Set(time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC))
+
+	fx := &Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	return fx
+}
+
+func newTestOwners(addrs ...ids.ShortID) secp256k1fx.OutputOwners {
+	return secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     addrs,
+	}
+}
+
+func newTestCredential() *Credential {
+	return &Credential{Credential: secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{sigBytes},
+	}}
+}
+
+func newTestTransferOutput(amount uint64, owner ids.ShortID) *TransferOutput {
+	return &TransferOutput{TransferOutput: secp256k1fx.TransferOutput{
+		Amt:          amount,
+		OutputOwners: newTestOwners(owner),
+	}}
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	assert := assert.New(t)
+
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	utxo := newTestTransferOutput(1, addr)
+	in := &TransferInput{TransferInput: secp256k1fx.TransferInput{
+		Amt:   1,
+		Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+	}}
+
+	assert.NoError(fx.VerifyTransfer(tx, in, newTestCredential(), utxo))
+
+	// Outputs of other fxs can't be spent
+	err := fx.VerifyTransfer(tx, in, newTestCredential(), &utxo.TransferOutput)
+	assert.ErrorIs(err, errWrongUTXOType)
+}
+
+func TestFxVerifyUpdateManagerOperation(t *testing.T) {
+	assert := assert.New(t)
+
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	utxo := &ManagerOutput{OutputOwners: newTestOwners(addr)}
+	op := &UpdateManagerOperation{
+		Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+		ManagerOutput: ManagerOutput{
+			Frozen:       true,
+			OutputOwners: newTestOwners(ids.GenerateTestShortID()),
+		},
+	}
+
+	assert.NoError(fx.VerifyOperation(tx, op, newTestCredential(), []interface{}{utxo}))
+
+	// Only the current manager can update the manager output
+	utxo.OutputOwners = newTestOwners(ids.GenerateTestShortID())
+	err := fx.VerifyOperation(tx, op, newTestCredential(), []interface{}{utxo})
+	assert.Error(err)
+
+	err = fx.VerifyOperation(tx, op, newTestCredential(), []interface{}{newTestTransferOutput(1, addr)})
+	assert.ErrorIs(err, errWrongUTXOType)
+}
+
+func TestFxVerifyReassignOperation(t *testing.T) {
+	assert := assert.New(t)
+
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	holder := ids.GenerateTestShortID()
+	newHolder := ids.GenerateTestShortID()
+	manager := &ManagerOutput{
+		Frozen:       true,
+		OutputOwners: newTestOwners(addr),
+	}
+	utxos := []interface{}{
+		newTestTransferOutput(2, holder),
+		manager,
+		newTestTransferOutput(3, holder),
+	}
+	op := &ReassignOperation{
+		Input:         secp256k1fx.Input{SigIndices: []uint32{0}},
+		ManagerOutput: *manager,
+		TransferOutputs: []*TransferOutput{
+			newTestTransferOutput(5, newHolder),
+		},
+	}
+
+	// The manager doesn't need the holder's signature, even while frozen
+	assert.NoError(fx.VerifyOperation(tx, op, newTestCredential(), utxos))
+	assert.Len(op.Outs(), 2)
+
+	// The reassigned amount must be preserved
+	op.TransferOutputs[0].Amt = 4
+	err := fx.VerifyOperation(tx, op, newTestCredential(), utxos)
+	assert.ErrorIs(err, errWrongAmount)
+	op.TransferOutputs[0].Amt = 5
+
+	// The manager output can't be changed by a reassignment
+	op.ManagerOutput.Frozen = false
+	err = fx.VerifyOperation(tx, op, newTestCredential(), utxos)
+	assert.ErrorIs(err, errWrongManagerOutput)
+	op.ManagerOutput.Frozen = true
+
+	// The manager output must be consumed
+	err = fx.VerifyOperation(tx, op, newTestCredential(), []interface{}{utxos[0], utxos[2]})
+	assert.ErrorIs(err, errWrongNumberOfUTXOs)
+}
+
+func TestReassignOperationVerifyNoOutputs(t *testing.T) {
+	op := &ReassignOperation{
+		Input:         secp256k1fx.Input{SigIndices: []uint32{0}},
+		ManagerOutput: ManagerOutput{OutputOwners: newTestOwners(addr)},
+	}
+	assert.ErrorIs(t, op.Verify(), errNoTransferOutputs)
+}
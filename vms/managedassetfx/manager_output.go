@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package managedassetfx
+
+import (
+	"encoding/json"
+
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+// ManagerOutput is held by the manager of an asset. Its owners can freeze and
+// unfreeze transfers of the asset, hand the output over to a new manager, and
+// reassign the ownership of the asset's TransferOutputs.
+type ManagerOutput struct {
+	// Frozen is true if transfers of the asset are frozen
+	Frozen bool `serialize:"true" json:"frozen"`
+
+	secp256k1fx.OutputOwners `serialize:"true"`
+}
+
+// MarshalJSON marshals the embedded OutputOwners along with the frozen flag
+func (out *ManagerOutput) MarshalJSON() ([]byte, error) {
+	result, err := out.OutputOwners.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	result["frozen"] = out.Frozen
+	return json.Marshal(result)
+}
+
+// IsFrozen returns true if transfers of the asset are frozen
+func (out *ManagerOutput) IsFrozen() bool { return out.Frozen }
+
+// Equals returns true if [other] has the same manager and frozen flag
+func (out *ManagerOutput) Equals(other *ManagerOutput) bool {
+	return out.Frozen == other.Frozen && out.OutputOwners.Equals(&other.OutputOwners)
+}
+
+func (out *ManagerOutput) Verify() error {
+	if out == nil {
+		return errNilOutput
+	}
+	return out.OutputOwners.Verify()
+}
+
+func (out *ManagerOutput) VerifyState() error { return out.Verify() }
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package managedassetfx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/vms/components/verify"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+var (
+	errNilReassignOperation = errors.New("nil reassign operation")
+	errNoTransferOutputs    = errors.New("no transfer outputs")
+)
+
+// ReassignOperation lets the manager of an asset move TransferOutputs of the
+// asset to new owners without their signatures. The operation consumes the
+// ManagerOutput of the asset, which must be recreated unchanged, along with
+// the reassigned TransferOutputs, whose total amount must be preserved.
+type ReassignOperation struct {
+	Input           secp256k1fx.Input `serialize:"true" json:"input"`
+	ManagerOutput   ManagerOutput     `serialize:"true" json:"managerOutput"`
+	TransferOutputs []*TransferOutput `serialize:"true" json:"transferOutputs"`
+}
+
+func (op *ReassignOperation) InitCtx(ctx *snow.Context) {
+	op.ManagerOutput.OutputOwners.InitCtx(ctx)
+	for _, out := range op.TransferOutputs {
+		out.OutputOwners.InitCtx(ctx)
+	}
+}
+
+func (op *ReassignOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *ReassignOperation) Outs() []verify.State {
+	outs := make([]verify.State, 0, len(op.TransferOutputs)+1)
+	outs = append(outs, &op.ManagerOutput)
+	for _, out := range op.TransferOutputs {
+		outs = append(outs, out)
+	}
+	return outs
+}
+
+func (op *ReassignOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilReassignOperation
+	case len(op.TransferOutputs) == 0:
+		return errNoTransferOutputs
+	}
+	if err := verify.All(&op.Input, &op.ManagerOutput); err != nil {
+		return err
+	}
+	for _, out := range op.TransferOutputs {
+		if out == nil {
+			return errNilOutput
+		}
+		if err := out.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
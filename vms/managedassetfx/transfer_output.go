@@ -0,0 +1,15 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package managedassetfx
+
+import (
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+// TransferOutput is an amount of a managed asset. It can be spent by its
+// owners while the asset isn't frozen, and reassigned by the asset's manager
+// at any time.
+type TransferOutput struct {
+	secp256k1fx.TransferOutput `serialize:"true"`
+}
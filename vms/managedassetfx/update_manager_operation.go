@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package managedassetfx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/vms/components/verify"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+var errNilUpdateManagerOperation = errors.New("nil update manager operation")
+
+// UpdateManagerOperation consumes the ManagerOutput of an asset and replaces
+// it with [ManagerOutput]. It's used to freeze or unfreeze the asset, or to
+// change its manager.
+type UpdateManagerOperation struct {
+	Input         secp256k1fx.Input `serialize:"true" json:"input"`
+	ManagerOutput ManagerOutput     `serialize:"true" json:"managerOutput"`
+}
+
+func (op *UpdateManagerOperation) InitCtx(ctx *snow.Context) {
+	op.ManagerOutput.OutputOwners.InitCtx(ctx)
+}
+
+func (op *UpdateManagerOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *UpdateManagerOperation) Outs() []verify.State {
+	return []verify.State{&op.ManagerOutput}
+}
+
+func (op *UpdateManagerOperation) Verify() error {
+	if op == nil {
+		return errNilUpdateManagerOperation
+	}
+	return verify.All(&op.Input, &op.ManagerOutput)
+}
@@ -39,6 +39,7 @@ var (
 	blockPrefix           = []byte("block")
 	txPrefix              = []byte("tx")
 	rewardUTXOsPrefix     = []byte("rewardUTXOs")
+	stakingHistoryPrefix  = []byte("stakingHistory")
 	utxoPrefix            = []byte("utxo")
 	subnetPrefix          = []byte("subnet")
 	chainPrefix           = []byte("chain")
@@ -84,6 +85,10 @@ type InternalState interface {
 	AddPendingStaker(tx *Tx)
 	DeletePendingStaker(tx *Tx)
 
+	// GetStakingPeriods returns the staking periods that rewarded [addr], in
+	// the order they ended.
+	GetStakingPeriods(addr ids.ShortID) ([]*StakingPeriod, error)
+
 	SetCurrentStakerChainState(currentStakerChainState)
 	SetPendingStakerChainState(pendingStakerChainState)
 
@@ -134,6 +139,8 @@ type InternalState interface {
  * | '-. txID
  * |   '-. list
  * |     '-- utxoID -> utxo bytes
+ * |- stakingHistory
+ * | '-- address + end time + txID -> staking period bytes
  * |- utxos
  * | '-- utxoDB
  * |-. subnets
@@ -165,6 +172,9 @@ type internalStateImpl struct {
 	uptimes               map[ids.NodeID]*currentValidatorState // nodeID -> uptimes
 	updatedUptimes        map[ids.NodeID]struct{}               // nodeID -> nil
 
+	// changes to the validator sets to publish once the stakers are written
+	validatorSetChanges []*ValidatorSetChangeEvent
+
 	validatorsDB                 database.Database
 	currentValidatorsDB          database.Database
 	currentValidatorBaseDB       database.Database
@@ -196,6 +206,9 @@ type internalStateImpl struct {
 	rewardUTXOsCache cache.Cacher            // cache of txID -> []*UTXO
 	rewardUTXODB     database.Database
 
+	addedStakingPeriods map[string]*StakingPeriod // map of key -> staking period
+	stakingHistoryDB    database.Database
+
 	modifiedUTXOs map[ids.ID]*djtx.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	utxoDB        database.Database
 	utxoState     djtx.UTXOState
@@ -290,6 +303,9 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 		addedRewardUTXOs: make(map[ids.ID][]*djtx.UTXO),
 		rewardUTXODB:     rewardUTXODB,
 
+		addedStakingPeriods: make(map[string]*StakingPeriod),
+		stakingHistoryDB:    prefixdb.New(stakingHistoryPrefix, baseDB),
+
 		modifiedUTXOs: make(map[ids.ID]*djtx.UTXO),
 		utxoDB:        utxoDB,
 
@@ -615,6 +631,25 @@ func (st *internalStateImpl) AddRewardUTXO(txID ids.ID, utxo *djtx.UTXO) {
 	st.addedRewardUTXOs[txID] = append(st.addedRewardUTXOs[txID], utxo)
 }
 
+func (st *internalStateImpl) GetStakingPeriods(addr ids.ShortID) ([]*StakingPeriod, error) {
+	it := st.stakingHistoryDB.NewIteratorWithPrefix(addr[:])
+	defer it.Release()
+
+	periods := []*StakingPeriod(nil)
+	for it.Next() {
+		period := &StakingPeriod{}
+		if _, err := GenesisCodec.Unmarshal(it.Value(), period); err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+	return periods, it.Error()
+}
+
+func (st *internalStateImpl) AddStakingPeriod(addr ids.ShortID, period *StakingPeriod) {
+	st.addedStakingPeriods[string(stakingPeriodKey(addr, period))] = period
+}
+
 func (st *internalStateImpl) GetUTXO(utxoID ids.ID) (*djtx.UTXO, error) {
 	if utxo, exists := st.modifiedUTXOs[utxoID]; exists {
 		if utxo == nil {
@@ -814,6 +849,9 @@ func (st *internalStateImpl) CommitBatch() (database.Batch, error) {
 	if err := st.writeRewardUTXOs(); err != nil {
 		return nil, fmt.Errorf("failed to write reward UTXOs with: %w", err)
 	}
+	if err := st.writeStakingHistory(); err != nil {
+		return nil, fmt.Errorf("failed to write staking history with: %w", err)
+	}
 	if err := st.writeUTXOs(); err != nil {
 		return nil, fmt.Errorf("failed to write UTXOs with: %w", err)
 	}
@@ -826,7 +864,12 @@ func (st *internalStateImpl) CommitBatch() (database.Batch, error) {
 	if err := st.writeSingletons(); err != nil {
 		return nil, fmt.Errorf("failed to write singletons with: %w", err)
 	}
-	return st.baseDB.CommitBatch()
+	batch, err := st.baseDB.CommitBatch()
+	if err != nil {
+		return nil, err
+	}
+	st.publishValidatorSetChanges()
+	return batch, nil
 }
 
 func (st *internalStateImpl) Close() error {
@@ -844,6 +887,7 @@ func (st *internalStateImpl) Close() error {
 		st.blockDB.Close(),
 		st.txDB.Close(),
 		st.rewardUTXODB.Close(),
+		st.stakingHistoryDB.Close(),
 		st.utxoDB.Close(),
 		st.subnetBaseDB.Close(),
 		st.chainDB.Close(),
@@ -865,6 +909,10 @@ type currentValidatorState struct {
 func (st *internalStateImpl) writeCurrentStakers() error {
 	weightDiffs := make(map[ids.ID]map[ids.NodeID]*ValidatorWeightDiff) // subnetID -> nodeID -> weightDiff
 	for _, currentStaker := range st.addedCurrentStakers {
+		if err := st.addValidatorSetChange(currentValidatorSet, true, currentStaker.addStakerTx); err != nil {
+			return err
+		}
+
 		txID := currentStaker.addStakerTx.ID()
 		potentialReward := currentStaker.potentialReward
 
@@ -939,6 +987,10 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 	st.addedCurrentStakers = nil
 
 	for _, tx := range st.deletedCurrentStakers {
+		if err := st.addValidatorSetChange(currentValidatorSet, false, tx); err != nil {
+			return err
+		}
+
 		var (
 			db       database.KeyValueDeleter
 			subnetID ids.ID
@@ -1057,6 +1109,10 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 
 func (st *internalStateImpl) writePendingStakers() error {
 	for _, tx := range st.addedPendingStakers {
+		if err := st.addValidatorSetChange(pendingValidatorSet, true, tx); err != nil {
+			return err
+		}
+
 		var db database.KeyValueWriter
 		switch tx.UnsignedTx.(type) {
 		case *UnsignedAddValidatorTx:
@@ -1077,6 +1133,10 @@ func (st *internalStateImpl) writePendingStakers() error {
 	st.addedPendingStakers = nil
 
 	for _, tx := range st.deletedPendingStakers {
+		if err := st.addValidatorSetChange(pendingValidatorSet, false, tx); err != nil {
+			return err
+		}
+
 		var db database.KeyValueDeleter
 		switch tx.UnsignedTx.(type) {
 		case *UnsignedAddValidatorTx:
@@ -1098,6 +1158,22 @@ func (st *internalStateImpl) writePendingStakers() error {
 	return nil
 }
 
+func (st *internalStateImpl) addValidatorSetChange(set string, added bool, tx *Tx) error {
+	event, err := newValidatorSetChangeEvent(st.currentHeight, set, added, tx)
+	if err != nil {
+		return err
+	}
+	st.validatorSetChanges = append(st.validatorSetChanges, event)
+	return nil
+}
+
+func (st *internalStateImpl) publishValidatorSetChanges() {
+	for _, event := range st.validatorSetChanges {
+		st.vm.validatorPubsub.Publish(NewPubSubValidatorSetFilterer(event))
+	}
+	st.validatorSetChanges = nil
+}
+
 func (st *internalStateImpl) writeUptimes() error {
 	for nodeID := range st.updatedUptimes {
 		delete(st.updatedUptimes, nodeID)
@@ -1184,6 +1260,21 @@ func (st *internalStateImpl) writeRewardUTXOs() error {
 	return nil
 }
 
+func (st *internalStateImpl) writeStakingHistory() error {
+	for key, period := range st.addedStakingPeriods {
+		delete(st.addedStakingPeriods, key)
+
+		periodBytes, err := GenesisCodec.Marshal(CodecVersion, period)
+		if err != nil {
+			return err
+		}
+		if err := st.stakingHistoryDB.Put([]byte(key), periodBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (st *internalStateImpl) writeUTXOs() error {
 	for utxoID, utxo := range st.modifiedUTXOs {
 		delete(st.modifiedUTXOs, utxoID)
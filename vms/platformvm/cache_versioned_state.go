@@ -39,6 +39,9 @@ type MutableState interface {
 	AddRewardUTXO(txID ids.ID, utxo *djtx.UTXO)
 	GetRewardUTXOs(txID ids.ID) ([]*djtx.UTXO, error)
 
+	// AddStakingPeriod records that [period] ended and rewarded [addr]
+	AddStakingPeriod(addr ids.ShortID, period *StakingPeriod)
+
 	GetTimestamp() time.Time
 	SetTimestamp(time.Time)
 
@@ -81,6 +84,8 @@ type versionedStateImpl struct {
 	// map of txID -> []*UTXO
 	addedRewardUTXOs map[ids.ID][]*djtx.UTXO
 
+	addedStakingPeriods []addressStakingPeriod
+
 	// map of txID -> {*Tx, Status}
 	addedTxs map[ids.ID]*txStatusImpl
 
@@ -93,6 +98,11 @@ type txStatusImpl struct {
 	status status.Status
 }
 
+type addressStakingPeriod struct {
+	addr   ids.ShortID
+	period *StakingPeriod
+}
+
 type utxoImpl struct {
 	utxoID ids.ID
 	utxo   *djtx.UTXO
@@ -240,6 +250,13 @@ func (vs *versionedStateImpl) GetRewardUTXOs(txID ids.ID) ([]*djtx.UTXO, error)
 	return vs.parentState.GetRewardUTXOs(txID)
 }
 
+func (vs *versionedStateImpl) AddStakingPeriod(addr ids.ShortID, period *StakingPeriod) {
+	vs.addedStakingPeriods = append(vs.addedStakingPeriods, addressStakingPeriod{
+		addr:   addr,
+		period: period,
+	})
+}
+
 func (vs *versionedStateImpl) AddRewardUTXO(txID ids.ID, utxo *djtx.UTXO) {
 	if vs.addedRewardUTXOs == nil {
 		vs.addedRewardUTXOs = make(map[ids.ID][]*djtx.UTXO)
@@ -316,6 +333,9 @@ func (vs *versionedStateImpl) Apply(is InternalState) {
 			is.AddRewardUTXO(txID, utxo)
 		}
 	}
+	for _, staked := range vs.addedStakingPeriods {
+		is.AddStakingPeriod(staked.addr, staked.period)
+	}
 	for _, utxo := range vs.modifiedUTXOs {
 		if utxo.utxo != nil {
 			is.AddUTXO(utxo.utxo)
@@ -63,6 +63,9 @@ type Client interface {
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ClientPrimaryValidator, error)
 	// GetPendingValidators returns the list of pending validators for subnet with ID [subnetID]
 	GetPendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]interface{}, []interface{}, error)
+	// GetValidatorUptimes returns the uptime that the node observed for every
+	// current primary network validator
+	GetValidatorUptimes(ctx context.Context, options ...rpc.Option) ([]APIValidatorUptime, error)
 	// GetCurrentSupply returns an upper bound on the supply of DJTX in the system
 	GetCurrentSupply(ctx context.Context, options ...rpc.Option) (uint64, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
@@ -154,6 +157,32 @@ type Client interface {
 		genesisData []byte,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// CreateSubnetWorkflow issues a transaction to create a subnet and, once
+	// it's accepted, a transaction to create a blockchain validated by it.
+	// Returns the ID of the new subnet.
+	CreateSubnetWorkflow(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		controlKeys []ids.ShortID,
+		threshold uint32,
+		vmID string,
+		fxIDs []string,
+		name string,
+		genesisData []byte,
+		options ...rpc.Option,
+	) (ids.ID, error)
+	// GetSubnetWorkflow returns the progress of the workflow creating [subnetID]
+	GetSubnetWorkflow(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*GetSubnetWorkflowReply, error)
+	// AwaitSubnetWorkflow polls [GetSubnetWorkflow] until the workflow
+	// creating [subnetID] has either created the blockchain or failed.
+	AwaitSubnetWorkflow(
+		ctx context.Context,
+		subnetID ids.ID,
+		freq time.Duration,
+		options ...rpc.Option,
+	) (*GetSubnetWorkflowReply, error)
 	// GetBlockchainStatus returns the current status of blockchain with ID: [blockchainID]
 	GetBlockchainStatus(ctx context.Context, blockchainID string, options ...rpc.Option) (status.BlockchainStatus, error)
 	// ValidatedBy returns the ID of the Subnet that validates [blockchainID]
@@ -164,6 +193,9 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// ValidateStakingTx returns every reason that the staking tx [tx] would
+	// be dropped if it were issued
+	ValidateStakingTx(ctx context.Context, tx []byte, options ...rpc.Option) (*ValidateStakingTxReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
@@ -196,6 +228,9 @@ type Client interface {
 	) (uint64, error)
 	// GetRewardUTXOs returns the reward UTXOs for a transaction
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetStakingHistory returns the staking periods that ended and rewarded
+	// [addrs], and the sum of the rewards paid to them
+	GetStakingHistory(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (*GetStakingHistoryReply, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided subnet
@@ -211,10 +246,11 @@ type client struct {
 }
 
 // NewClient returns a Client for interacting with the P Chain endpoint
-func NewClient(uri string) Client {
+func NewClient(uri string, options ...rpc.Option) Client {
 	return &client{requester: rpc.NewEndpointRequester(
 		uri+"/ext/P",
 		"platform",
+		options...,
 	)}
 }
 
@@ -396,6 +432,12 @@ func (c *client) GetPendingValidators(
 	return res.Validators, res.Delegators, err
 }
 
+func (c *client) GetValidatorUptimes(ctx context.Context, options ...rpc.Option) ([]APIValidatorUptime, error) {
+	res := &GetValidatorUptimesReply{}
+	err := c.requester.SendRequest(ctx, "getValidatorUptimes", struct{}{}, res, options...)
+	return res.Validators, err
+}
+
 func (c *client) GetCurrentSupply(ctx context.Context, options ...rpc.Option) (uint64, error) {
 	res := &GetCurrentSupplyReply{}
 	err := c.requester.SendRequest(ctx, "getCurrentSupply", struct{}{}, res, options...)
@@ -609,6 +651,73 @@ func (c *client) CreateBlockchain(
 	return res.TxID, err
 }
 
+func (c *client) CreateSubnetWorkflow(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	controlKeys []ids.ShortID,
+	threshold uint32,
+	vmID string,
+	fxIDs []string,
+	name string,
+	genesisData []byte,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	genesisDataStr, err := formatting.EncodeWithChecksum(formatting.Hex, genesisData)
+	if err != nil {
+		return ids.ID{}, err
+	}
+
+	res := &CreateSubnetWorkflowReply{}
+	err = c.requester.SendRequest(ctx, "createSubnetWorkflow", &CreateSubnetWorkflowArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		APISubnet: APISubnet{
+			ControlKeys: ids.ShortIDsToStrings(controlKeys),
+			Threshold:   json.Uint32(threshold),
+		},
+		VMID:        vmID,
+		FxIDs:       fxIDs,
+		Name:        name,
+		GenesisData: genesisDataStr,
+		Encoding:    formatting.Hex,
+	}, res, options...)
+	return res.SubnetID, err
+}
+
+func (c *client) GetSubnetWorkflow(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*GetSubnetWorkflowReply, error) {
+	res := &GetSubnetWorkflowReply{}
+	err := c.requester.SendRequest(ctx, "getSubnetWorkflow", &GetSubnetWorkflowArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) AwaitSubnetWorkflow(ctx context.Context, subnetID ids.ID, freq time.Duration, options ...rpc.Option) (*GetSubnetWorkflowReply, error) {
+	ticker := time.NewTicker(freq)
+	defer ticker.Stop()
+
+	for {
+		res, err := c.GetSubnetWorkflow(ctx, subnetID, options...)
+		if err == nil {
+			switch res.Status {
+			case SubnetWorkflowDone, SubnetWorkflowFailed:
+				return res, nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func (c *client) GetBlockchainStatus(ctx context.Context, blockchainID string, options ...rpc.Option) (status.BlockchainStatus, error) {
 	res := &GetBlockchainStatusReply{}
 	err := c.requester.SendRequest(ctx, "getBlockchainStatus", &GetBlockchainStatusArgs{
@@ -653,6 +762,20 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) ValidateStakingTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*ValidateStakingTxReply, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ValidateStakingTxReply{}
+	err = c.requester.SendRequest(ctx, "validateStakingTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "getTx", &api.GetTxArgs{
@@ -771,6 +894,14 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetStakingHistory(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (*GetStakingHistoryReply, error) {
+	res := &GetStakingHistoryReply{}
+	err := c.requester.SendRequest(ctx, "getStakingHistory", &GetStakingHistoryArgs{
+		Addresses: ids.ShortIDsToStrings(addrs),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "getTimestamp", struct{}{}, res, options...)
@@ -110,6 +110,18 @@ func (mr *MockInternalStateMockRecorder) AddRewardUTXO(txID, utxo interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRewardUTXO", reflect.TypeOf((*MockInternalState)(nil).AddRewardUTXO), txID, utxo)
 }
 
+// AddStakingPeriod mocks base method.
+func (m *MockInternalState) AddStakingPeriod(addr ids.ShortID, period *StakingPeriod) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddStakingPeriod", addr, period)
+}
+
+// AddStakingPeriod indicates an expected call of AddStakingPeriod.
+func (mr *MockInternalStateMockRecorder) AddStakingPeriod(addr, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStakingPeriod", reflect.TypeOf((*MockInternalState)(nil).AddStakingPeriod), addr, period)
+}
+
 // AddSubnet mocks base method.
 func (m *MockInternalState) AddSubnet(createSubnetTx *Tx) {
 	m.ctrl.T.Helper()
@@ -312,6 +324,21 @@ func (mr *MockInternalStateMockRecorder) GetRewardUTXOs(txID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockInternalState)(nil).GetRewardUTXOs), txID)
 }
 
+// GetStakingPeriods mocks base method.
+func (m *MockInternalState) GetStakingPeriods(addr ids.ShortID) ([]*StakingPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakingPeriods", addr)
+	ret0, _ := ret[0].([]*StakingPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakingPeriods indicates an expected call of GetStakingPeriods.
+func (mr *MockInternalStateMockRecorder) GetStakingPeriods(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakingPeriods", reflect.TypeOf((*MockInternalState)(nil).GetStakingPeriods), addr)
+}
+
 // GetStartTime mocks base method.
 func (m *MockInternalState) GetStartTime(nodeID ids.NodeID) (time.Time, error) {
 	m.ctrl.T.Helper()
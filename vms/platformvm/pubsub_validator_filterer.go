@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/pubsub"
+	"github.com/lasthyphen/beacongo/utils/constants"
+	"github.com/lasthyphen/beacongo/utils/json"
+)
+
+// Validator sets whose changes are published
+const (
+	currentValidatorSet = "current"
+	pendingValidatorSet = "pending"
+)
+
+// Changes to a validator set
+const (
+	validatorAdded           = "added"
+	validatorRemoved         = "removed"
+	validatorWeightIncreased = "weightIncreased"
+	validatorWeightDecreased = "weightDecreased"
+)
+
+var _ pubsub.Filterer = &validatorSetFilterer{}
+
+// ValidatorSetChangeEvent is published to the validator set subscribers when a
+// staker is added to or removed from the current or pending validator set.
+// Adding or removing a delegator changes the weight of the validator it
+// delegates to.
+type ValidatorSetChangeEvent struct {
+	// Height of the accepted block that made the change
+	Height json.Uint64 `json:"height"`
+	// "current" or "pending"
+	Set string `json:"set"`
+	// "added", "removed", "weightIncreased" or "weightDecreased"
+	Change   string     `json:"change"`
+	SubnetID ids.ID     `json:"subnetID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+	// ID of the tx that added the staker
+	TxID ids.ID `json:"txID"`
+	// Weight of the staker
+	Weight json.Uint64 `json:"weight"`
+}
+
+// newValidatorSetChangeEvent returns the change to [set] made by adding, or
+// removing, the staker added by [tx].
+func newValidatorSetChangeEvent(height uint64, set string, added bool, tx *Tx) (*ValidatorSetChangeEvent, error) {
+	event := &ValidatorSetChangeEvent{
+		Height: json.Uint64(height),
+		Set:    set,
+		TxID:   tx.ID(),
+	}
+	switch tx := tx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		event.Change = validatorRemoved
+		if added {
+			event.Change = validatorAdded
+		}
+		event.SubnetID = constants.PrimaryNetworkID
+		event.NodeID = tx.Validator.NodeID
+		event.Weight = json.Uint64(tx.Validator.Wght)
+	case *UnsignedAddDelegatorTx:
+		event.Change = validatorWeightDecreased
+		if added {
+			event.Change = validatorWeightIncreased
+		}
+		event.SubnetID = constants.PrimaryNetworkID
+		event.NodeID = tx.Validator.NodeID
+		event.Weight = json.Uint64(tx.Validator.Wght)
+	case *UnsignedAddSubnetValidatorTx:
+		event.Change = validatorRemoved
+		if added {
+			event.Change = validatorAdded
+		}
+		event.SubnetID = tx.Validator.Subnet
+		event.NodeID = tx.Validator.NodeID
+		event.Weight = json.Uint64(tx.Validator.Wght)
+	default:
+		return nil, errWrongTxType
+	}
+	return event, nil
+}
+
+type validatorSetFilterer struct {
+	event *ValidatorSetChangeEvent
+}
+
+// NewPubSubValidatorSetFilterer returns a filterer that notifies the
+// subscribers of the changed validator's node ID or subnet ID of [event].
+func NewPubSubValidatorSetFilterer(event *ValidatorSetChangeEvent) pubsub.Filterer {
+	return &validatorSetFilterer{event: event}
+}
+
+func (f *validatorSetFilterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	for i, c := range filters {
+		resp[i] = c.Check(f.event.NodeID[:]) || c.Check(f.event.SubnetID[:])
+	}
+	return resp, f.event
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/pubsub"
+	"github.com/lasthyphen/beacongo/utils/constants"
+
+	pChainValidator "github.com/lasthyphen/beacongo/vms/platformvm/validator"
+)
+
+type mockFilter struct {
+	addr []byte
+}
+
+func (f *mockFilter) Check(addr []byte) bool {
+	return bytes.Equal(addr, f.addr)
+}
+
+func TestValidatorSetFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+	tx := &Tx{UnsignedTx: &UnsignedAddSubnetValidatorTx{
+		Validator: pChainValidator.SubnetValidator{
+			Validator: pChainValidator.Validator{
+				NodeID: nodeID,
+				Wght:   5,
+			},
+			Subnet: subnetID,
+		},
+	}}
+	assert.NoError(tx.Sign(Codec, nil))
+
+	event, err := newValidatorSetChangeEvent(10, pendingValidatorSet, true, tx)
+	assert.NoError(err)
+	assert.EqualValues(10, event.Height)
+	assert.Equal(pendingValidatorSet, event.Set)
+	assert.Equal(validatorAdded, event.Change)
+	assert.Equal(subnetID, event.SubnetID)
+	assert.Equal(nodeID, event.NodeID)
+	assert.Equal(tx.ID(), event.TxID)
+	assert.EqualValues(5, event.Weight)
+
+	otherID := ids.GenerateTestID()
+	filters := []pubsub.Filter{
+		&mockFilter{addr: nodeID[:]},
+		&mockFilter{addr: subnetID[:]},
+		&mockFilter{addr: otherID[:]},
+	}
+	notify, msg := NewPubSubValidatorSetFilterer(event).Filter(filters)
+	assert.Equal([]bool{true, true, false}, notify)
+	assert.Equal(event, msg)
+}
+
+func TestValidatorSetChangeDelegator(t *testing.T) {
+	assert := assert.New(t)
+
+	tx := &Tx{UnsignedTx: &UnsignedAddDelegatorTx{
+		Validator: pChainValidator.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			Wght:   5,
+		},
+	}}
+	assert.NoError(tx.Sign(Codec, nil))
+
+	event, err := newValidatorSetChangeEvent(10, currentValidatorSet, false, tx)
+	assert.NoError(err)
+	assert.Equal(validatorWeightDecreased, event.Change)
+	assert.Equal(constants.PrimaryNetworkID, event.SubnetID)
+
+	_, err = newValidatorSetChangeEvent(10, currentValidatorSet, false, &Tx{UnsignedTx: &UnsignedCreateSubnetTx{}})
+	assert.ErrorIs(err, errWrongTxType)
+}
@@ -177,6 +177,16 @@ func (tx *UnsignedRewardValidatorTx) Execute(
 			onCommitState.AddRewardUTXO(tx.TxID, utxo)
 		}
 
+		addStakingPeriod(onCommitState, onAbortState, uStakerTx.RewardsOwner, StakingPeriod{
+			TxID:            tx.TxID,
+			NodeID:          uStakerTx.Validator.ID(),
+			Kind:            ValidatorStakingPeriod,
+			StartTime:       uStakerTx.Validator.Start,
+			EndTime:         uStakerTx.Validator.End,
+			Stake:           uStakerTx.Validator.Wght,
+			PotentialReward: stakerReward,
+		})
+
 		// Handle reward preferences
 		nodeID = uStakerTx.Validator.ID()
 		startTime = uStakerTx.StartTime()
@@ -267,6 +277,20 @@ func (tx *UnsignedRewardValidatorTx) Execute(
 			onCommitState.AddRewardUTXO(tx.TxID, utxo)
 		}
 
+		delegation := StakingPeriod{
+			TxID:            tx.TxID,
+			NodeID:          uStakerTx.Validator.ID(),
+			Kind:            DelegatorStakingPeriod,
+			StartTime:       uStakerTx.Validator.Start,
+			EndTime:         uStakerTx.Validator.End,
+			Stake:           uStakerTx.Validator.Wght,
+			PotentialReward: delegatorReward,
+		}
+		addStakingPeriod(onCommitState, onAbortState, uStakerTx.RewardsOwner, delegation)
+		delegation.Kind = DelegationFeeStakingPeriod
+		delegation.PotentialReward = delegateeReward
+		addStakingPeriod(onCommitState, onAbortState, vdrTx.RewardsOwner, delegation)
+
 		nodeID = uStakerTx.Validator.ID()
 		startTime = vdrTx.StartTime()
 	default:
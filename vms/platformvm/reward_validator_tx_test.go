@@ -283,6 +283,21 @@ func TestRewardDelegatorTxExecuteOnCommit(t *testing.T) {
 	assert.Less(vdrReward, delReward, "the delegator's reward should be greater than the delegatee's because the delegatee's share is 25%")
 	assert.Equal(expectedReward, delReward+vdrReward, "expected total reward to be %d but is %d", expectedReward, delReward+vdrReward)
 
+	// The rewards are recorded in the staking history of both addresses
+	delPeriods, err := vm.internalState.GetStakingPeriods(delRewardAddress)
+	assert.NoError(err)
+	assert.Len(delPeriods, 1)
+	assert.Equal(delTx.ID(), delPeriods[0].TxID)
+	assert.Equal(DelegatorStakingPeriod, delPeriods[0].Kind)
+	assert.Equal(vm.MinDelegatorStake, delPeriods[0].Stake)
+	assert.Equal(delReward, delPeriods[0].Reward())
+
+	vdrPeriods, err := vm.internalState.GetStakingPeriods(vdrRewardAddress)
+	assert.NoError(err)
+	assert.Len(vdrPeriods, 1)
+	assert.Equal(DelegationFeeStakingPeriod, vdrPeriods[0].Kind)
+	assert.Equal(vdrReward, vdrPeriods[0].Reward())
+
 	stake, ok = set.GetWeight(vdrNodeID)
 	assert.True(ok)
 	assert.Equal(vm.MinValidatorStake, stake)
@@ -378,6 +393,14 @@ func TestRewardDelegatorTxExecuteOnAbort(t *testing.T) {
 	assert.NoError(err)
 	assert.Zero(delReward, "expected delegator balance not to increase")
 
+	// The staking period is recorded without a reward
+	delPeriods, err := vm.internalState.GetStakingPeriods(delRewardAddress)
+	assert.NoError(err)
+	assert.Len(delPeriods, 1)
+	assert.False(delPeriods[0].Rewarded)
+	assert.NotZero(delPeriods[0].PotentialReward)
+	assert.Zero(delPeriods[0].Reward())
+
 	newSupply := vm.internalState.GetCurrentSupply()
 	assert.Equal(initialSupply-expectedReward, newSupply, "should have removed un-rewarded tokens from the potential supply")
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/lasthyphen/beacongo/api"
@@ -65,6 +66,7 @@ var (
 	errMissingVMID                = errors.New("argument 'vmID' not given")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
 	errMissingPrivateKey          = errors.New("argument 'privateKey' not given")
+	errWorkflowUnauthorized       = errors.New("user's keys can't authorize the new subnet's blockchain")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -743,6 +745,64 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 	return nil
 }
 
+// APIValidatorUptime is the uptime of a primary network validator, as observed
+// by this node
+type APIValidatorUptime struct {
+	TxID      ids.ID      `json:"txID"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// Percentage of the time since the validator started validating that this
+	// node observed it as connected
+	UptimePercentage json.Float32 `json:"uptimePercentage"`
+	Connected        bool         `json:"connected"`
+	// Unix time at which this node last observed the validator as connected.
+	// Omitted if it hasn't been connected since this node started.
+	LastSeen *json.Uint64 `json:"lastSeen,omitempty"`
+}
+
+// GetValidatorUptimesReply is the response from calling GetValidatorUptimes
+type GetValidatorUptimesReply struct {
+	Validators []APIValidatorUptime `json:"validators"`
+}
+
+// GetValidatorUptimes returns the uptime that this node observed for every
+// current primary network validator
+func (service *Service) GetValidatorUptimes(_ *http.Request, _ *struct{}, reply *GetValidatorUptimesReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetValidatorUptimes called")
+
+	reply.Validators = []APIValidatorUptime{}
+	currentValidators := service.vm.internalState.CurrentStakerChainState()
+	for _, tx := range currentValidators.Stakers() { // Iterates in order of increasing stop time
+		staker, ok := tx.UnsignedTx.(*UnsignedAddValidatorTx)
+		if !ok {
+			continue
+		}
+
+		nodeID := staker.Validator.ID()
+		startTime := staker.StartTime()
+		rawUptime, err := service.vm.uptimeManager.CalculateUptimePercentFrom(nodeID, startTime)
+		if err != nil {
+			return err
+		}
+
+		uptime := APIValidatorUptime{
+			TxID:             tx.ID(),
+			NodeID:           nodeID,
+			StartTime:        json.Uint64(startTime.Unix()),
+			EndTime:          json.Uint64(staker.EndTime().Unix()),
+			UptimePercentage: json.Float32(100 * rawUptime),
+			Connected:        service.vm.uptimeManager.IsConnected(nodeID),
+		}
+		if lastSeen, seen := service.vm.uptimeManager.LastSeen(nodeID); seen {
+			lastSeenUnix := json.Uint64(lastSeen.Unix())
+			uptime.LastSeen = &lastSeenUnix
+		}
+		reply.Validators = append(reply.Validators, uptime)
+	}
+	return nil
+}
+
 // GetPendingValidatorsArgs are the arguments for calling GetPendingValidators
 type GetPendingValidatorsArgs struct {
 	// Subnet we're getting the pending validators of
@@ -1490,16 +1550,83 @@ func (service *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchain
 		return fmt.Errorf("problem parsing genesis data: %w", err)
 	}
 
-	vmID, err := service.vm.Chains.LookupVM(args.VMID)
+	vmID, fxIDs, err := service.lookupVMAndFxs(args.VMID, args.FxIDs)
+	if err != nil {
+		return err
+	}
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errDSCantValidate
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseServiceAddresses(service.vm, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	keys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(keys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = djtx.ParseServiceAddress(service.vm, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newCreateChainTx(
+		args.SubnetID,
+		genesisBytes,
+		vmID,
+		fxIDs,
+		args.Name,
+		keys.Keys,
+		changeAddr, // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.blockBuilder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// lookupVMAndFxs returns the IDs of the VM and FXs a new blockchain runs
+func (service *Service) lookupVMAndFxs(vmIDStr string, fxIDStrs []string) (ids.ID, []ids.ID, error) {
+	vmID, err := service.vm.Chains.LookupVM(vmIDStr)
 	if err != nil {
-		return fmt.Errorf("no VM with ID '%s' found", args.VMID)
+		return ids.ID{}, nil, fmt.Errorf("no VM with ID '%s' found", vmIDStr)
 	}
 
 	fxIDs := []ids.ID(nil)
-	for _, fxIDStr := range args.FxIDs {
+	for _, fxIDStr := range fxIDStrs {
 		fxID, err := service.vm.Chains.LookupVM(fxIDStr)
 		if err != nil {
-			return fmt.Errorf("no FX with ID '%s' found", fxIDStr)
+			return ids.ID{}, nil, fmt.Errorf("no FX with ID '%s' found", fxIDStr)
 		}
 		fxIDs = append(fxIDs, fxID)
 	}
@@ -1510,9 +1637,65 @@ func (service *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchain
 	if vmID == constants.AVMID && !fxIDsSet.Contains(secp256k1fx.ID) {
 		fxIDs = append(fxIDs, secp256k1fx.ID)
 	}
+	return vmID, fxIDs, nil
+}
 
-	if args.SubnetID == constants.PrimaryNetworkID {
-		return errDSCantValidate
+// CreateSubnetWorkflowArgs are the arguments for calling CreateSubnetWorkflow
+type CreateSubnetWorkflowArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// Control keys and threshold of the new subnet. The ID member of
+	// APISubnet is ignored.
+	APISubnet
+	// ID of the VM the new blockchain is running
+	VMID string `json:"vmID"`
+	// IDs of the FXs the VM is running
+	FxIDs []string `json:"fxIDs"`
+	// Human-readable name for the new blockchain, not necessarily unique
+	Name string `json:"name"`
+	// Genesis state of the blockchain being created
+	GenesisData string `json:"genesisData"`
+	// Encoding format to use for genesis data
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// CreateSubnetWorkflowReply is the reply from calling CreateSubnetWorkflow
+type CreateSubnetWorkflowReply struct {
+	// ID of the subnet being created, which identifies the workflow
+	SubnetID ids.ID `json:"subnetID"`
+	api.JSONChangeAddr
+}
+
+// CreateSubnetWorkflow issues a transaction to create a new subnet and, once
+// it has been accepted, a transaction to create a blockchain validated by the
+// subnet. The user must control enough of the subnet's control keys to
+// authorize the blockchain's creation.
+//
+// The progress of the workflow is returned by GetSubnetWorkflow.
+func (service *Service) CreateSubnetWorkflow(_ *http.Request, args *CreateSubnetWorkflowArgs, response *CreateSubnetWorkflowReply) error {
+	service.vm.ctx.Log.Debug("Platform: CreateSubnetWorkflow called")
+
+	switch {
+	case args.Name == "":
+		return errMissingName
+	case args.VMID == "":
+		return errMissingVMID
+	}
+
+	genesisBytes, err := formatting.Decode(args.Encoding, args.GenesisData)
+	if err != nil {
+		return fmt.Errorf("problem parsing genesis data: %w", err)
+	}
+
+	vmID, fxIDs, err := service.lookupVMAndFxs(args.VMID, args.FxIDs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the control keys
+	controlKeys, err := djtx.ParseServiceAddresses(service.vm, args.ControlKeys)
+	if err != nil {
+		return err
 	}
 
 	// Parse the from addresses
@@ -1545,32 +1728,81 @@ func (service *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchain
 		}
 	}
 
+	// Make sure that the blockchain can be created once the subnet exists
+	controlAddrs := controlKeys.List()
+	subnetOwner := &secp256k1fx.OutputOwners{
+		Threshold: uint32(args.Threshold),
+		Addrs:     controlAddrs,
+	}
+	if _, _, ok := keys.Match(subnetOwner, service.vm.clock.Unix()); !ok {
+		return errWorkflowUnauthorized
+	}
+
 	// Create the transaction
-	tx, err := service.vm.newCreateChainTx(
-		args.SubnetID,
-		genesisBytes,
-		vmID,
-		fxIDs,
-		args.Name,
-		keys.Keys,
-		changeAddr, // Change address
+	tx, err := service.vm.newCreateSubnetTx(
+		uint32(args.Threshold), // Threshold
+		controlAddrs,           // Control Addresses
+		keys.Keys,              // Private keys
+		changeAddr,             // Change address
 	)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
 	}
 
-	response.TxID = tx.ID()
+	workflow := &subnetWorkflow{
+		genesisData: genesisBytes,
+		vmID:        vmID,
+		fxIDs:       fxIDs,
+		chainName:   args.Name,
+		keys:        keys.Keys,
+		changeAddr:  changeAddr,
+	}
+
+	response.SubnetID = tx.ID()
 	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		err,
-		service.vm.blockBuilder.AddUnverifiedTx(tx),
+		service.vm.startSubnetWorkflow(workflow, tx),
 		user.Close(),
 	)
 	return errs.Err
 }
 
+// GetSubnetWorkflowArgs are the arguments for calling GetSubnetWorkflow
+type GetSubnetWorkflowArgs struct {
+	// ID of the subnet created by the workflow
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetSubnetWorkflowReply is the reply from calling GetSubnetWorkflow
+type GetSubnetWorkflowReply struct {
+	Status   SubnetWorkflowStatus `json:"status"`
+	SubnetID ids.ID               `json:"subnetID"`
+	// ID of the new blockchain. Empty until the blockchain's creation has
+	// been issued.
+	BlockchainID ids.ID `json:"blockchainID"`
+	// Why the workflow failed, if it did
+	Reason string `json:"reason,omitempty"`
+}
+
+// GetSubnetWorkflow returns the progress of the workflow started by
+// CreateSubnetWorkflow that creates [args.SubnetID]
+func (service *Service) GetSubnetWorkflow(_ *http.Request, args *GetSubnetWorkflowArgs, reply *GetSubnetWorkflowReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetSubnetWorkflow called")
+
+	workflow, err := service.vm.getSubnetWorkflow(args.SubnetID)
+	if err != nil {
+		return err
+	}
+	reply.Status = workflow.status
+	reply.SubnetID = workflow.subnetID
+	reply.BlockchainID = workflow.blockchainID
+	reply.Reason = workflow.reason
+	return nil
+}
+
 // GetBlockchainStatusArgs is the arguments for calling GetBlockchainStatus
 // [BlockchainID] is the ID of or an alias of the blockchain to get the status of.
 type GetBlockchainStatusArgs struct {
@@ -1850,6 +2082,52 @@ func (service *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response
 	return nil
 }
 
+// ValidateStakingTxReply is the response from calling ValidateStakingTx
+type ValidateStakingTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// True if the tx would currently be accepted into the mempool
+	Valid bool `json:"valid"`
+	// Every reason that the tx would be dropped
+	Issues []StakingTxIssue `json:"issues"`
+}
+
+// ValidateStakingTx checks a signed AddValidatorTx or AddDelegatorTx against
+// the preferred state of the chain, without issuing it, and reports every
+// rule that it breaks.
+func (service *Service) ValidateStakingTx(_ *http.Request, args *api.FormattedTx, response *ValidateStakingTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: ValidateStakingTx called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx := &Tx{}
+	if _, err := Codec.Unmarshal(txBytes, tx); err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	if err := tx.Sign(Codec, nil); err != nil {
+		return fmt.Errorf("couldn't initialize tx: %w", err)
+	}
+
+	preferred, err := service.vm.Preferred()
+	if err != nil {
+		return fmt.Errorf("couldn't get preferred block: %w", err)
+	}
+	preferredDecision, ok := preferred.(decision)
+	if !ok {
+		return errInvalidBlockType
+	}
+
+	issues, err := service.vm.validateStakingTx(preferredDecision.onAccept(), tx)
+	if err != nil {
+		return err
+	}
+	response.TxID = tx.ID()
+	response.Valid = len(issues) == 0
+	response.Issues = issues
+	return nil
+}
+
 // GetTx gets a tx
 func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	service.vm.ctx.Log.Debug("Platform: GetTx called")
@@ -2210,6 +2488,89 @@ func (service *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, rep
 	return nil
 }
 
+// GetStakingHistoryArgs are the arguments for calling GetStakingHistory
+type GetStakingHistoryArgs struct {
+	// Reward addresses whose staking history is returned
+	Addresses []string `json:"addresses"`
+}
+
+// APIStakingPeriod is a staking period that ended, as it rewarded one of the
+// requested addresses
+type APIStakingPeriod struct {
+	RewardAddress string     `json:"rewardAddress"`
+	TxID          ids.ID     `json:"txID"`
+	NodeID        ids.NodeID `json:"nodeID"`
+	// "validator", "delegator" or "delegationFee"
+	Kind      string      `json:"kind"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// Amount, in nDJTX, staked by the staker
+	StakeAmount json.Uint64 `json:"stakeAmount"`
+	// Reward, in nDJTX, that could have been paid to the address
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// Reward, in nDJTX, that was paid to the address
+	Reward json.Uint64 `json:"reward"`
+}
+
+// GetStakingHistoryReply is the response from calling GetStakingHistory
+type GetStakingHistoryReply struct {
+	// The staking periods, in the order they ended
+	StakingPeriods []APIStakingPeriod `json:"stakingPeriods"`
+	// Sum of the rewards paid to the addresses
+	TotalReward json.Uint64 `json:"totalReward"`
+}
+
+// GetStakingHistory returns the staking periods that ended and that rewarded
+// [args.Addresses], with the rewards that were paid. Only staking periods that
+// ended after the staking history was first indexed are returned.
+func (service *Service) GetStakingHistory(_ *http.Request, args *GetStakingHistoryArgs, reply *GetStakingHistoryReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetStakingHistory called")
+
+	if len(args.Addresses) > maxGetStakeAddrs {
+		return fmt.Errorf("%d addresses provided but this method can take at most %d", len(args.Addresses), maxGetStakeAddrs)
+	}
+	addrs, err := djtx.ParseServiceAddresses(service.vm, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	reply.StakingPeriods = []APIStakingPeriod{}
+	totalReward := uint64(0)
+	for addr := range addrs {
+		periods, err := service.vm.internalState.GetStakingPeriods(addr)
+		if err != nil {
+			return fmt.Errorf("couldn't get staking history of %s: %w", addr, err)
+		}
+		addrStr, err := service.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return err
+		}
+		for _, period := range periods {
+			reward := period.Reward()
+			totalReward, err = math.Add64(totalReward, reward)
+			if err != nil {
+				return err
+			}
+			reply.StakingPeriods = append(reply.StakingPeriods, APIStakingPeriod{
+				RewardAddress:   addrStr,
+				TxID:            period.TxID,
+				NodeID:          period.NodeID,
+				Kind:            period.Kind.String(),
+				StartTime:       json.Uint64(period.StartTime),
+				EndTime:         json.Uint64(period.EndTime),
+				StakeAmount:     json.Uint64(period.Stake),
+				PotentialReward: json.Uint64(period.PotentialReward),
+				Reward:          json.Uint64(reward),
+			})
+		}
+	}
+	sort.SliceStable(reply.StakingPeriods, func(i, j int) bool {
+		return reply.StakingPeriods[i].EndTime < reply.StakingPeriods[j].EndTime
+	})
+	reply.TotalReward = json.Uint64(totalReward)
+	return nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -29,6 +29,7 @@ import (
 	"github.com/lasthyphen/beacongo/utils/logging"
 	"github.com/lasthyphen/beacongo/version"
 	"github.com/lasthyphen/beacongo/vms/components/djtx"
+	"github.com/lasthyphen/beacongo/vms/platformvm/reward"
 	"github.com/lasthyphen/beacongo/vms/platformvm/status"
 	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
 
@@ -802,3 +803,207 @@ func TestGetBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSubnetWorkflow(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	controlKey, err := service.vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	genesisData, err := formatting.EncodeWithChecksum(formatting.Hex, []byte("genesis"))
+	assert.NoError(err)
+	args := &CreateSubnetWorkflowArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: testUsername,
+				Password: testPassword,
+			},
+		},
+		APISubnet: APISubnet{
+			ControlKeys: []string{controlKey},
+			Threshold:   1,
+		},
+		VMID:        constants.AVMID.String(),
+		Name:        "chain",
+		GenesisData: genesisData,
+		Encoding:    formatting.Hex,
+	}
+
+	// The user must be able to authorize the blockchain's creation
+	unauthorizedArgs := *args
+	unauthorizedArgs.Threshold = 2
+	err = service.CreateSubnetWorkflow(nil, &unauthorizedArgs, &CreateSubnetWorkflowReply{})
+	assert.ErrorIs(err, errWorkflowUnauthorized)
+
+	createReply := CreateSubnetWorkflowReply{}
+	assert.NoError(service.CreateSubnetWorkflow(nil, args, &createReply))
+
+	getReply := GetSubnetWorkflowReply{}
+	assert.NoError(service.GetSubnetWorkflow(nil, &GetSubnetWorkflowArgs{SubnetID: createReply.SubnetID}, &getReply))
+	assert.Equal(SubnetWorkflowCreatingSubnet, getReply.Status)
+	assert.Equal(ids.Empty, getReply.BlockchainID)
+
+	acceptBlock := func() {
+		blk, err := service.vm.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		assert.NoError(service.vm.SetPreference(blk.ID()))
+		assert.NoError(blk.Accept())
+	}
+
+	// Accepting the subnet issues the blockchain's creation
+	acceptBlock()
+	_, txStatus, err := service.vm.internalState.GetTx(createReply.SubnetID)
+	assert.NoError(err)
+	assert.Equal(status.Committed, txStatus)
+
+	assert.NoError(service.GetSubnetWorkflow(nil, &GetSubnetWorkflowArgs{SubnetID: createReply.SubnetID}, &getReply))
+	assert.Equal(SubnetWorkflowCreatingBlockchain, getReply.Status)
+	assert.NotEqual(ids.Empty, getReply.BlockchainID)
+	assert.True(service.vm.blockBuilder.Has(getReply.BlockchainID))
+
+	acceptBlock()
+	assert.NoError(service.GetSubnetWorkflow(nil, &GetSubnetWorkflowArgs{SubnetID: createReply.SubnetID}, &getReply))
+	assert.Equal(SubnetWorkflowDone, getReply.Status)
+	assert.Empty(getReply.Reason)
+
+	chains, err := service.vm.internalState.GetChains(createReply.SubnetID)
+	assert.NoError(err)
+	assert.Len(chains, 1)
+	assert.Equal(getReply.BlockchainID, chains[0].ID())
+
+	err = service.GetSubnetWorkflow(nil, &GetSubnetWorkflowArgs{SubnetID: ids.GenerateTestID()}, &getReply)
+	assert.ErrorIs(err, errUnknownSubnetWorkflow)
+}
+
+func TestValidateStakingTx(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	validate := func(tx *Tx) *ValidateStakingTxReply {
+		txStr, err := formatting.EncodeWithChecksum(formatting.Hex, tx.Bytes())
+		assert.NoError(err)
+		reply := &ValidateStakingTxReply{}
+		assert.NoError(service.ValidateStakingTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply))
+		assert.Equal(tx.ID(), reply.TxID)
+		return reply
+	}
+	issueCodes := func(reply *ValidateStakingTxReply) []StakingTxIssueCode {
+		codes := make([]StakingTxIssueCode, len(reply.Issues))
+		for i, issue := range reply.Issues {
+			codes[i] = issue.Code
+		}
+		return codes
+	}
+
+	rewardAddr := ids.GenerateTestShortID()
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+
+	// A tx that breaks no rules
+	tx, err := service.vm.newAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestNodeID(),
+		rewardAddr,
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	reply := validate(tx)
+	assert.True(reply.Valid)
+	assert.Empty(reply.Issues)
+
+	// Every broken rule is reported
+	tooSoon := defaultGenesisTime.Add(time.Second)
+	tx, err = service.vm.newAddValidatorTx(
+		service.vm.MinValidatorStake-1,
+		uint64(tooSoon.Unix()),
+		uint64(tooSoon.Add(time.Hour).Unix()),
+		ids.NodeID(keys[0].PublicKey().Address()), // already validating
+		rewardAddr,
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	reply = validate(tx)
+	assert.False(reply.Valid)
+	assert.Equal([]StakingTxIssueCode{
+		StakingTxStakeTooSmall,
+		StakingTxDurationTooShort,
+		StakingTxStartTimeTooSoon,
+		StakingTxDuplicateNodeID,
+	}, issueCodes(reply))
+
+	// Delegators must delegate to a validator
+	tx, err = service.vm.newAddDelegatorTx(
+		service.vm.MinDelegatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestNodeID(),
+		rewardAddr,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	reply = validate(tx)
+	assert.False(reply.Valid)
+	assert.Equal([]StakingTxIssueCode{StakingTxValidatorNotFound}, issueCodes(reply))
+
+	// Other txs aren't staking txs
+	tx, err = service.vm.newCreateSubnetTx(1, []ids.ShortID{rewardAddr}, []*crypto.PrivateKeySECP256K1R{keys[0]}, ids.ShortEmpty)
+	assert.NoError(err)
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, tx.Bytes())
+	assert.NoError(err)
+	err = service.ValidateStakingTx(nil, &api.FormattedTx{Tx: txStr, Encoding: formatting.Hex}, &ValidateStakingTxReply{})
+	assert.ErrorIs(err, errNotStakingTx)
+}
+
+func TestGetValidatorUptimes(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	connectedID := ids.NodeID(keys[0].PublicKey().Address())
+	assert.NoError(service.vm.Connected(connectedID, version.CurrentApp))
+
+	reply := GetValidatorUptimesReply{}
+	assert.NoError(service.GetValidatorUptimes(nil, nil, &reply))
+
+	genesis, _ := defaultGenesis()
+	assert.Len(reply.Validators, len(genesis.Validators))
+	for _, vdr := range reply.Validators {
+		if vdr.NodeID != connectedID {
+			assert.False(vdr.Connected)
+			assert.Nil(vdr.LastSeen)
+			continue
+		}
+		assert.True(vdr.Connected)
+		assert.NotNil(vdr.LastSeen)
+		assert.LessOrEqual(float32(vdr.UptimePercentage), float32(100))
+	}
+}
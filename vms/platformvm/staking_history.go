@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"encoding/binary"
+
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+	"github.com/lasthyphen/beacongo/vms/platformvm/fx"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+// StakingPeriodKind describes how a reward address took part in a staking
+// period.
+type StakingPeriodKind byte
+
+const (
+	// The address was the reward address of a validator
+	ValidatorStakingPeriod StakingPeriodKind = iota
+	// The address was the reward address of a delegator
+	DelegatorStakingPeriod
+	// The address was the reward address of the validator that a delegator
+	// delegated to, and was paid the validator's share of the reward
+	DelegationFeeStakingPeriod
+)
+
+func (k StakingPeriodKind) String() string {
+	switch k {
+	case ValidatorStakingPeriod:
+		return "validator"
+	case DelegatorStakingPeriod:
+		return "delegator"
+	case DelegationFeeStakingPeriod:
+		return "delegationFee"
+	default:
+		return "unknown"
+	}
+}
+
+// StakingPeriod is a staking period that ended, as recorded for one of the
+// addresses it rewarded. It's written when the staker is removed from the
+// current staker set.
+type StakingPeriod struct {
+	// ID of the tx that added the staker
+	TxID   ids.ID            `serialize:"true"`
+	NodeID ids.NodeID        `serialize:"true"`
+	Kind   StakingPeriodKind `serialize:"true"`
+	// Unix times at which the staker started and stopped staking
+	StartTime uint64 `serialize:"true"`
+	EndTime   uint64 `serialize:"true"`
+	// Amount, in nDJTX, staked by the staker
+	Stake uint64 `serialize:"true"`
+	// Reward, in nDJTX, that could have been paid to the address
+	PotentialReward uint64 `serialize:"true"`
+	// True if the reward was paid, false if the staker wasn't rewarded
+	Rewarded bool `serialize:"true"`
+}
+
+// Reward returns the reward, in nDJTX, that was paid to the address.
+func (p *StakingPeriod) Reward() uint64 {
+	if !p.Rewarded {
+		return 0
+	}
+	return p.PotentialReward
+}
+
+// stakingPeriodKey returns the key that [period] is stored under for [addr].
+// Keys are sorted by address and then by end time, so an address's history is
+// iterated over in the order the periods ended. The kind is part of the key as
+// a delegator and its validator may share a reward address.
+func stakingPeriodKey(addr ids.ShortID, period *StakingPeriod) []byte {
+	key := make([]byte, hashing.AddrLen+wrappers.LongLen+hashing.HashLen+wrappers.ByteLen)
+	copy(key, addr[:])
+	binary.BigEndian.PutUint64(key[hashing.AddrLen:], period.EndTime)
+	copy(key[hashing.AddrLen+wrappers.LongLen:], period.TxID[:])
+	key[len(key)-1] = byte(period.Kind)
+	return key
+}
+
+// addStakingPeriod records [period] for every reward address of [owner]. The
+// reward is only paid if [onCommitState] is accepted.
+func addStakingPeriod(onCommitState, onAbortState MutableState, owner fx.Owner, period StakingPeriod) {
+	rewarded := period
+	rewarded.Rewarded = true
+	for _, addr := range rewardAddresses(owner) {
+		onCommitState.AddStakingPeriod(addr, &rewarded)
+		onAbortState.AddStakingPeriod(addr, &period)
+	}
+}
+
+// rewardAddresses returns the addresses that [owner] pays rewards to. Owners
+// that aren't secp256k1fx owners aren't indexed, so they have no addresses.
+func rewardAddresses(owner fx.Owner) []ids.ShortID {
+	outputOwners, ok := owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil
+	}
+	return outputOwners.Addrs
+}
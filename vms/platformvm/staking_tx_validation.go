@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/vms/components/djtx"
+
+	safemath "github.com/lasthyphen/beacongo/utils/math"
+	pChainValidator "github.com/lasthyphen/beacongo/vms/platformvm/validator"
+)
+
+var errNotStakingTx = errors.New("tx doesn't add a primary network validator or delegator")
+
+// StakingTxIssueCode identifies a rule that a staking tx breaks
+type StakingTxIssueCode string
+
+const (
+	// The tx isn't well-formed
+	StakingTxMalformed StakingTxIssueCode = "malformed"
+	// Less than the minimum amount is staked
+	StakingTxStakeTooSmall StakingTxIssueCode = "stakeTooSmall"
+	// More than the maximum amount is staked
+	StakingTxStakeTooLarge StakingTxIssueCode = "stakeTooLarge"
+	// The validator's delegation fee is below the minimum
+	StakingTxDelegationFeeTooLow StakingTxIssueCode = "delegationFeeTooLow"
+	// The staking period is shorter than the minimum
+	StakingTxDurationTooShort StakingTxIssueCode = "durationTooShort"
+	// The staking period is longer than the maximum
+	StakingTxDurationTooLong StakingTxIssueCode = "durationTooLong"
+	// The staking period starts before the tx could be accepted
+	StakingTxStartTimeTooSoon StakingTxIssueCode = "startTimeTooSoon"
+	// The staking period starts too far after the current chain time
+	StakingTxStartTimeTooLate StakingTxIssueCode = "startTimeTooLate"
+	// The node is already, or is about to become, a validator
+	StakingTxDuplicateNodeID StakingTxIssueCode = "duplicateNodeID"
+	// The node delegated to isn't, and isn't about to become, a validator
+	StakingTxValidatorNotFound StakingTxIssueCode = "validatorNotFound"
+	// The delegation period isn't within the validator's staking period
+	StakingTxOutsideValidatorPeriod StakingTxIssueCode = "outsideValidatorPeriod"
+	// The validator would exceed its maximum weight
+	StakingTxOverDelegated StakingTxIssueCode = "overDelegated"
+	// The inputs, outputs or credentials don't pay for the stake and fee
+	StakingTxInvalidSpend StakingTxIssueCode = "invalidSpend"
+	// The tx is invalid for a reason that isn't covered by another code
+	StakingTxInvalid StakingTxIssueCode = "invalid"
+)
+
+// StakingTxIssue is a reason that a staking tx would be dropped if it were
+// issued
+type StakingTxIssue struct {
+	Code    StakingTxIssueCode `json:"code"`
+	Message string             `json:"message"`
+}
+
+type stakingTxIssues []StakingTxIssue
+
+func (i *stakingTxIssues) add(code StakingTxIssueCode, format string, args ...interface{}) {
+	*i = append(*i, StakingTxIssue{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// validateStakingTx returns every reason that [stx] would be dropped if it was
+// issued on top of [parentState]. Unlike the verification of the tx, which
+// stops at the first rule that's broken, every rule is checked. [stx] must add
+// a primary network validator or delegator.
+func (vm *VM) validateStakingTx(parentState MutableState, stx *Tx) ([]StakingTxIssue, error) {
+	issues := stakingTxIssues{}
+	switch tx := stx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		if err := tx.SyntacticVerify(vm.ctx); err != nil {
+			issues.add(StakingTxMalformed, "%s", err)
+			return issues, nil
+		}
+		vm.validateValidatorTx(parentState, tx, &issues)
+		vm.validateStakingSpend(parentState, stx, &tx.BaseTx, tx.Stake, &issues)
+	case *UnsignedAddDelegatorTx:
+		if err := tx.SyntacticVerify(vm.ctx); err != nil {
+			issues.add(StakingTxMalformed, "%s", err)
+			return issues, nil
+		}
+		if err := vm.validateDelegatorTx(parentState, tx, &issues); err != nil {
+			return nil, err
+		}
+		vm.validateStakingSpend(parentState, stx, &tx.BaseTx, tx.Stake, &issues)
+	default:
+		return nil, errNotStakingTx
+	}
+
+	// The tx is verified as it would be when issued, in case it breaks a rule
+	// that isn't checked above.
+	if len(issues) == 0 {
+		if _, _, err := stx.UnsignedTx.(UnsignedProposalTx).Execute(vm, parentState, stx); err != nil {
+			issues.add(StakingTxInvalid, "%s", err)
+		}
+	}
+	return issues, nil
+}
+
+func (vm *VM) validateValidatorTx(parentState MutableState, tx *UnsignedAddValidatorTx, issues *stakingTxIssues) {
+	switch {
+	case tx.Validator.Wght < vm.MinValidatorStake:
+		issues.add(StakingTxStakeTooSmall, "stake (%d) is less than the minimum validator stake (%d)", tx.Validator.Wght, vm.MinValidatorStake)
+	case tx.Validator.Wght > vm.MaxValidatorStake:
+		issues.add(StakingTxStakeTooLarge, "stake (%d) is more than the maximum validator stake (%d)", tx.Validator.Wght, vm.MaxValidatorStake)
+	}
+	if tx.Shares < vm.MinDelegationFee {
+		issues.add(StakingTxDelegationFeeTooLow, "delegation fee (%d) is less than the minimum (%d)", tx.Shares, vm.MinDelegationFee)
+	}
+	vm.validateStakingPeriod(parentState, &tx.Validator, issues)
+
+	nodeID := tx.Validator.NodeID
+	if _, err := parentState.CurrentStakerChainState().GetValidator(nodeID); err == nil {
+		issues.add(StakingTxDuplicateNodeID, "%s is already a primary network validator", nodeID)
+	}
+	if _, err := parentState.PendingStakerChainState().GetValidatorTx(nodeID); err == nil {
+		issues.add(StakingTxDuplicateNodeID, "%s is about to become a primary network validator", nodeID)
+	}
+}
+
+func (vm *VM) validateDelegatorTx(parentState MutableState, tx *UnsignedAddDelegatorTx, issues *stakingTxIssues) error {
+	if tx.Validator.Wght < vm.MinDelegatorStake {
+		issues.add(StakingTxStakeTooSmall, "stake (%d) is less than the minimum delegator stake (%d)", tx.Validator.Wght, vm.MinDelegatorStake)
+	}
+	vm.validateStakingPeriod(parentState, &tx.Validator, issues)
+
+	nodeID := tx.Validator.NodeID
+	currentStakers := parentState.CurrentStakerChainState()
+	pendingStakers := parentState.PendingStakerChainState()
+
+	var (
+		vdrTx                  *UnsignedAddValidatorTx
+		currentDelegatorWeight uint64
+		currentDelegators      []*UnsignedAddDelegatorTx
+	)
+	currentValidator, err := currentStakers.GetValidator(nodeID)
+	switch err {
+	case nil:
+		vdrTx = currentValidator.AddValidatorTx()
+		currentDelegatorWeight = currentValidator.DelegatorWeight()
+		currentDelegators = currentValidator.Delegators()
+	case database.ErrNotFound:
+		vdrTx, err = pendingStakers.GetValidatorTx(nodeID)
+		if err == database.ErrNotFound {
+			issues.add(StakingTxValidatorNotFound, "%s isn't and isn't about to become a primary network validator", nodeID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	if !tx.Validator.BoundedBy(vdrTx.StartTime(), vdrTx.EndTime()) {
+		issues.add(
+			StakingTxOutsideValidatorPeriod,
+			"delegation period (%s to %s) isn't within the validator's staking period (%s to %s)",
+			tx.StartTime(),
+			tx.EndTime(),
+			vdrTx.StartTime(),
+			vdrTx.EndTime(),
+		)
+		return nil
+	}
+
+	vdrWeight := vdrTx.Weight()
+	currentWeight, err := safemath.Add64(vdrWeight, currentDelegatorWeight)
+	if err != nil {
+		return err
+	}
+	maximumWeight, err := safemath.Mul64(MaxValidatorWeightFactor, vdrWeight)
+	if err != nil {
+		issues.add(StakingTxInvalid, "%s", errStakeOverflow)
+		return nil
+	}
+	if !parentState.GetTimestamp().Before(vm.ApricotPhase3Time) {
+		maximumWeight = safemath.Min64(maximumWeight, vm.MaxValidatorStake)
+	}
+	pendingDelegators := pendingStakers.GetValidator(nodeID).Delegators()
+	canDelegate, err := CanDelegate(currentDelegators, pendingDelegators, tx, currentWeight, maximumWeight)
+	if err != nil {
+		return err
+	}
+	if !canDelegate {
+		issues.add(StakingTxOverDelegated, "delegating %d would make %s's weight exceed its maximum (%d)", tx.Validator.Wght, nodeID, maximumWeight)
+	}
+	return nil
+}
+
+// validateStakingPeriod checks the duration of [vdr] and that it starts late
+// enough to be accepted but not too far in the future
+func (vm *VM) validateStakingPeriod(parentState MutableState, vdr *pChainValidator.Validator, issues *stakingTxIssues) {
+	duration := vdr.Duration()
+	switch {
+	case duration < vm.MinStakeDuration:
+		issues.add(StakingTxDurationTooShort, "staking period (%s) is shorter than the minimum (%s)", duration, vm.MinStakeDuration)
+	case duration > vm.MaxStakeDuration:
+		issues.add(StakingTxDurationTooLong, "staking period (%s) is longer than the maximum (%s)", duration, vm.MaxStakeDuration)
+	}
+
+	startTime := vdr.StartTime()
+	currentTimestamp := parentState.GetTimestamp()
+	// Proposal txs that start within the synchrony bound of the local time
+	// are dropped from the mempool
+	syncTime := vm.clock.Time().Add(syncBound)
+	switch {
+	case !currentTimestamp.Before(startTime):
+		issues.add(StakingTxStartTimeTooSoon, "start time (%s) is at or before the current chain time (%s)", startTime, currentTimestamp)
+	case startTime.Before(syncTime):
+		issues.add(StakingTxStartTimeTooSoon, "start time (%s) must be at least %s after the current time", startTime, syncBound)
+	}
+	if maxStartTime := currentTimestamp.Add(maxFutureStartTime); startTime.After(maxStartTime) {
+		issues.add(StakingTxStartTimeTooLate, "start time (%s) is more than %s after the current chain time", startTime, maxFutureStartTime)
+	}
+}
+
+// validateStakingSpend checks that the inputs and credentials of the tx pay for
+// the stake and the fee
+func (vm *VM) validateStakingSpend(parentState MutableState, stx *Tx, baseTx *BaseTx, stake []*djtx.TransferableOutput, issues *stakingTxIssues) {
+	outs := make([]*djtx.TransferableOutput, len(baseTx.Outs)+len(stake))
+	copy(outs, baseTx.Outs)
+	copy(outs[len(baseTx.Outs):], stake)
+	if err := vm.semanticVerifySpend(parentState, stx.UnsignedTx, baseTx.Ins, outs, stx.Creds, vm.AddStakerTxFee, vm.ctx.DJTXAssetID); err != nil {
+		issues.add(StakingTxInvalidSpend, "%s", err)
+	}
+}
@@ -219,6 +219,9 @@ func (sb *StandardBlock) Accept() error {
 			return fmt.Errorf("failed to execute onAcceptFunc: %w", err)
 		}
 	}
+	if err := sb.vm.advanceSubnetWorkflows(); err != nil {
+		return fmt.Errorf("failed to advance subnet workflows: %w", err)
+	}
 
 	sb.free()
 	return nil
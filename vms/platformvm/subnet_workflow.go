@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/cache"
+	"github.com/lasthyphen/beacongo/database"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/vms/platformvm/status"
+)
+
+// Number of finished subnet workflows whose result is remembered
+const finishedSubnetWorkflowsSize = 1024
+
+var errUnknownSubnetWorkflow = errors.New("unknown subnet workflow")
+
+// SubnetWorkflowStatus is the progress of a subnet workflow
+type SubnetWorkflowStatus string
+
+const (
+	// The CreateSubnetTx was issued and hasn't been accepted yet
+	SubnetWorkflowCreatingSubnet SubnetWorkflowStatus = "CreatingSubnet"
+	// The subnet was created and the CreateChainTx was issued, but it hasn't
+	// been accepted yet
+	SubnetWorkflowCreatingBlockchain SubnetWorkflowStatus = "CreatingBlockchain"
+	// The subnet and its blockchain were created
+	SubnetWorkflowDone SubnetWorkflowStatus = "Done"
+	// One of the txs was dropped or aborted. If the subnet was created, the
+	// blockchain can still be added to it with CreateBlockchain.
+	SubnetWorkflowFailed SubnetWorkflowStatus = "Failed"
+)
+
+// subnetWorkflow creates a subnet, waits for it to be accepted and then
+// creates a blockchain validated by it.
+type subnetWorkflow struct {
+	subnetID     ids.ID
+	blockchainID ids.ID
+	status       SubnetWorkflowStatus
+	// Why the workflow failed, if it did
+	reason string
+
+	// The blockchain to create once the subnet has been accepted
+	genesisData []byte
+	vmID        ids.ID
+	fxIDs       []ids.ID
+	chainName   string
+
+	// Keys that pay for and authorize the CreateChainTx. They're dropped once
+	// the tx has been issued.
+	keys       []*crypto.PrivateKeySECP256K1R
+	changeAddr ids.ShortID
+}
+
+func (w *subnetWorkflow) fail(reason string) {
+	w.status = SubnetWorkflowFailed
+	w.reason = reason
+	w.keys = nil
+}
+
+// subnetWorkflows tracks the subnet workflows started on this node. Workflows
+// aren't persisted, so the ones in progress are abandoned if the node
+// restarts.
+type subnetWorkflows struct {
+	// Key: subnet ID
+	// Value: the workflow creating the subnet
+	pending map[ids.ID]*subnetWorkflow
+	// Key: subnet ID
+	// Value: the workflow that created the subnet
+	finished cache.LRU
+}
+
+func newSubnetWorkflows() *subnetWorkflows {
+	return &subnetWorkflows{
+		pending:  make(map[ids.ID]*subnetWorkflow),
+		finished: cache.LRU{Size: finishedSubnetWorkflowsSize},
+	}
+}
+
+// startSubnetWorkflow issues [createSubnetTx] and tracks [workflow] until the
+// blockchain has been created.
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) startSubnetWorkflow(workflow *subnetWorkflow, createSubnetTx *Tx) error {
+	if err := vm.blockBuilder.AddUnverifiedTx(createSubnetTx); err != nil {
+		return err
+	}
+	workflow.subnetID = createSubnetTx.ID()
+	workflow.status = SubnetWorkflowCreatingSubnet
+	vm.subnetWorkflows.pending[workflow.subnetID] = workflow
+	return nil
+}
+
+// getSubnetWorkflow returns the workflow that created [subnetID].
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) getSubnetWorkflow(subnetID ids.ID) (*subnetWorkflow, error) {
+	if workflow, ok := vm.subnetWorkflows.pending[subnetID]; ok {
+		// Dropped txs are only noticed when a block is accepted, so they're
+		// checked for here as well.
+		vm.checkSubnetWorkflowDropped(workflow)
+		return workflow, nil
+	}
+	if workflowIntf, ok := vm.subnetWorkflows.finished.Get(subnetID); ok {
+		return workflowIntf.(*subnetWorkflow), nil
+	}
+	return nil, errUnknownSubnetWorkflow
+}
+
+// advanceSubnetWorkflows moves every pending workflow whose tx has been
+// decided on to its next step. It's called after a block has been accepted.
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) advanceSubnetWorkflows() error {
+	for _, workflow := range vm.subnetWorkflows.pending {
+		if err := vm.advanceSubnetWorkflow(workflow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (vm *VM) advanceSubnetWorkflow(workflow *subnetWorkflow) error {
+	switch workflow.status {
+	case SubnetWorkflowCreatingSubnet:
+		decided, err := vm.checkSubnetWorkflowTx(workflow, workflow.subnetID)
+		if err != nil || !decided || workflow.status == SubnetWorkflowFailed {
+			return err
+		}
+
+		tx, err := vm.newCreateChainTx(
+			workflow.subnetID,
+			workflow.genesisData,
+			workflow.vmID,
+			workflow.fxIDs,
+			workflow.chainName,
+			workflow.keys,
+			workflow.changeAddr,
+		)
+		if err != nil {
+			vm.finishSubnetWorkflow(workflow, fmt.Sprintf("couldn't create CreateChainTx: %s", err))
+			return nil
+		}
+		if err := vm.blockBuilder.AddUnverifiedTx(tx); err != nil {
+			vm.finishSubnetWorkflow(workflow, fmt.Sprintf("couldn't issue CreateChainTx: %s", err))
+			return nil
+		}
+		workflow.blockchainID = tx.ID()
+		workflow.status = SubnetWorkflowCreatingBlockchain
+		workflow.keys = nil
+		vm.ctx.Log.Debug("subnet %s was created, issued tx %s to create its blockchain", workflow.subnetID, workflow.blockchainID)
+	case SubnetWorkflowCreatingBlockchain:
+		decided, err := vm.checkSubnetWorkflowTx(workflow, workflow.blockchainID)
+		if err != nil || !decided || workflow.status == SubnetWorkflowFailed {
+			return err
+		}
+		workflow.status = SubnetWorkflowDone
+		vm.finishSubnetWorkflow(workflow, "")
+	}
+	return nil
+}
+
+// checkSubnetWorkflowTx returns true if [txID] has been decided on. If the tx
+// wasn't committed, [workflow] fails.
+func (vm *VM) checkSubnetWorkflowTx(workflow *subnetWorkflow, txID ids.ID) (bool, error) {
+	_, txStatus, err := vm.internalState.GetTx(txID)
+	if err == database.ErrNotFound {
+		vm.checkSubnetWorkflowDropped(workflow)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if txStatus != status.Committed {
+		vm.finishSubnetWorkflow(workflow, fmt.Sprintf("tx %s was %s", txID, txStatus))
+	}
+	return true, nil
+}
+
+// checkSubnetWorkflowDropped fails [workflow] if its current tx was dropped
+// from the mempool and wasn't reissued.
+func (vm *VM) checkSubnetWorkflowDropped(workflow *subnetWorkflow) {
+	var txID ids.ID
+	switch workflow.status {
+	case SubnetWorkflowCreatingSubnet:
+		txID = workflow.subnetID
+	case SubnetWorkflowCreatingBlockchain:
+		txID = workflow.blockchainID
+	default:
+		return
+	}
+	if vm.blockBuilder.Has(txID) {
+		return
+	}
+	if reason, dropped := vm.blockBuilder.GetDropReason(txID); dropped {
+		vm.finishSubnetWorkflow(workflow, fmt.Sprintf("tx %s was dropped: %s", txID, reason))
+	}
+}
+
+// finishSubnetWorkflow stops tracking [workflow]. If [reason] isn't empty,
+// the workflow failed.
+func (vm *VM) finishSubnetWorkflow(workflow *subnetWorkflow, reason string) {
+	if reason != "" {
+		workflow.fail(reason)
+		vm.ctx.Log.Debug("workflow creating subnet %s failed: %s", workflow.subnetID, reason)
+	}
+	delete(vm.subnetWorkflows.pending, workflow.subnetID)
+	vm.subnetWorkflows.finished.Put(workflow.subnetID, workflow)
+}
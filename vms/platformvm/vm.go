@@ -19,6 +19,7 @@ import (
 	"github.com/lasthyphen/beacongo/database"
 	"github.com/lasthyphen/beacongo/database/manager"
 	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/pubsub"
 	"github.com/lasthyphen/beacongo/snow"
 	"github.com/lasthyphen/beacongo/snow/choices"
 	"github.com/lasthyphen/beacongo/snow/consensus/snowman"
@@ -114,6 +115,12 @@ type VM struct {
 
 	// sliding window of blocks that were recently accepted
 	recentlyAccepted *window.Window
+
+	// publishes changes to the current and pending validator sets
+	validatorPubsub *pubsub.Server
+
+	// subnets being created by the CreateSubnetWorkflow API
+	subnetWorkflows *subnetWorkflows
 }
 
 // Initialize this blockchain.
@@ -167,6 +174,8 @@ func (vm *VM) Initialize(
 	}
 	vm.network = newNetwork(vm.ApricotPhase4Time, appSender, vm)
 	vm.rewards = reward.NewCalculator(vm.RewardConfig)
+	vm.validatorPubsub = pubsub.New(ctx.NetworkID, ctx.Log)
+	vm.subnetWorkflows = newSubnetWorkflows()
 
 	is, err := NewMeteredInternalState(vm, vm.dbManager.Current().Database, genesisBytes, registerer)
 	if err != nil {
@@ -427,6 +436,10 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		"": {
 			Handler: server,
 		},
+		"/events/validators": {
+			LockOptions: common.NoLock,
+			Handler:     vm.validatorPubsub,
+		},
 	}, nil
 }
 
@@ -390,9 +390,18 @@ func (vm *VMClient) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		}
 
 		vm.conns = append(vm.conns, clientConn)
+
+		var sunset time.Time
+		if handler.Sunset != nil {
+			sunset, err = grpcutils.TimestampAsTime(handler.Sunset)
+			if err != nil {
+				return nil, err
+			}
+		}
 		handlers[handler.Prefix] = &common.HTTPHandler{
 			LockOptions: common.LockOption(handler.LockOptions),
 			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			Sunset:      sunset,
 		}
 	}
 	return handlers, nil
@@ -412,9 +421,18 @@ func (vm *VMClient) CreateStaticHandlers() (map[string]*common.HTTPHandler, erro
 		}
 
 		vm.conns = append(vm.conns, clientConn)
+
+		var sunset time.Time
+		if handler.Sunset != nil {
+			sunset, err = grpcutils.TimestampAsTime(handler.Sunset)
+			if err != nil {
+				return nil, err
+			}
+		}
 		handlers[handler.Prefix] = &common.HTTPHandler{
 			LockOptions: common.LockOption(handler.LockOptions),
 			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			Sunset:      sunset,
 		}
 	}
 	return handlers, nil
@@ -274,11 +274,15 @@ func (vm *VMServer) CreateHandlers(context.Context, *emptypb.Empty) (*vmpb.Creat
 			return server
 		})
 
-		resp.Handlers = append(resp.Handlers, &vmpb.Handler{
+		pbHandler := &vmpb.Handler{
 			Prefix:      prefix,
 			LockOptions: uint32(handler.LockOptions),
 			ServerAddr:  serverAddr,
-		})
+		}
+		if !handler.Sunset.IsZero() {
+			pbHandler.Sunset = grpcutils.TimestampFromTime(handler.Sunset)
+		}
+		resp.Handlers = append(resp.Handlers, pbHandler)
 	}
 	return resp, nil
 }
@@ -309,11 +313,15 @@ func (vm *VMServer) CreateStaticHandlers(context.Context, *emptypb.Empty) (*vmpb
 			return server
 		})
 
-		resp.Handlers = append(resp.Handlers, &vmpb.Handler{
+		pbHandler := &vmpb.Handler{
 			Prefix:      prefix,
 			LockOptions: uint32(handler.LockOptions),
 			ServerAddr:  serverAddr,
-		})
+		}
+		if !handler.Sunset.IsZero() {
+			pbHandler.Sunset = grpcutils.TimestampFromTime(handler.Sunset)
+		}
+		resp.Handlers = append(resp.Handlers, pbHandler)
 	}
 	return resp, nil
 }
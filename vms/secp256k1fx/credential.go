@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lasthyphen/beacongo/ids"
 	"github.com/lasthyphen/beacongo/utils/crypto"
 	"github.com/lasthyphen/beacongo/utils/formatting"
 )
@@ -39,6 +40,20 @@ func (cr *Credential) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonFieldMap)
 }
 
+// Signers returns the addresses of the keys that produced the signatures of
+// [cr] over a tx whose unsigned bytes hash to [txHash].
+func (cr *Credential) Signers(factory *crypto.FactorySECP256K1R, txHash []byte) ([]ids.ShortID, error) {
+	signers := make([]ids.ShortID, len(cr.Sigs))
+	for i, sig := range cr.Sigs {
+		pk, err := factory.RecoverHashPublicKey(txHash, sig[:])
+		if err != nil {
+			return nil, err
+		}
+		signers[i] = pk.Address()
+	}
+	return signers, nil
+}
+
 func (cr *Credential) Verify() error {
 	switch {
 	case cr == nil:
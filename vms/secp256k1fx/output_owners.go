@@ -93,6 +93,9 @@ func (out *OutputOwners) Addresses() [][]byte {
 	return addrs
 }
 
+// SpendThreshold returns the number of signatures needed to spend this output
+func (out *OutputOwners) SpendThreshold() uint32 { return out.Threshold }
+
 // AddressesSet returns addresses as a set
 func (out *OutputOwners) AddressesSet() ids.ShortSet {
 	set := ids.NewShortSet(len(out.Addrs))
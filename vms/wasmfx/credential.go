@@ -0,0 +1,12 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wasmfx
+
+import (
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+type Credential struct {
+	secp256k1fx.Credential `serialize:"true"`
+}
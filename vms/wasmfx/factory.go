@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wasmfx
+
+import (
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/snow"
+	"github.com/lasthyphen/beacongo/vms"
+)
+
+var (
+	_ vms.Factory = &Factory{}
+
+	// ID that this Fx uses when labeled
+	ID = ids.ID{'w', 'a', 's', 'm', 'f', 'x'}
+)
+
+// Factory creates WASM fxs that execute modules with [Runtime].
+type Factory struct {
+	Runtime Runtime
+}
+
+func (f *Factory) New(*snow.Context) (interface{}, error) { return &Fx{Runtime: f.Runtime}, nil }
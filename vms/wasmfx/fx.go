@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wasmfx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+	"github.com/lasthyphen/beacongo/vms/components/verify"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+var (
+	errWrongTxType         = errors.New("wrong tx type")
+	errWrongInputType      = errors.New("wrong input type")
+	errWrongUTXOType       = errors.New("wrong utxo type")
+	errWrongOperationType  = errors.New("wrong operation type")
+	errWrongCredentialType = errors.New("wrong credential type")
+	errWrongNumberOfUTXOs  = errors.New("wrong number of UTXOs for the operation")
+	errWrongMintOutput     = errors.New("wrong mint output provided")
+	errWrongModule         = errors.New("minted outputs must use the asset's module")
+	errNilOutput           = errors.New("nil output")
+)
+
+// Fx is an experimental feature extension whose outputs carry a WASM module.
+// Spending an output, or minting with it, additionally requires the module to
+// approve the transaction.
+//
+// The module is fixed when the asset is created, as every minted output must
+// carry the module of the mint output it was created from. Modules are
+// executed by [Runtime], which must be provided by the node.
+type Fx struct {
+	secp256k1fx.Fx
+
+	Runtime Runtime
+}
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing wasm fx")
+
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&TransferInput{}),
+		c.RegisterType(&MintOutput{}),
+		c.RegisterType(&TransferOutput{}),
+		c.RegisterType(&MintOperation{}),
+		c.RegisterType(&Credential{}),
+	)
+	return errs.Err
+}
+
+func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.Tx)
+	switch {
+	case !ok:
+		return errWrongTxType
+	case len(utxosIntf) != 1:
+		return errWrongNumberOfUTXOs
+	}
+	op, ok := opIntf.(*MintOperation)
+	if !ok {
+		return errWrongOperationType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+	out, ok := utxosIntf[0].(*MintOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+	return fx.VerifyMintOperation(tx, op, cred, out)
+}
+
+func (fx *Fx) VerifyMintOperation(tx secp256k1fx.Tx, op *MintOperation, cred *Credential, out *MintOutput) error {
+	if err := verify.All(op, cred, out); err != nil {
+		return err
+	}
+
+	switch {
+	case !out.OutputOwners.Equals(&op.MintOutput.OutputOwners):
+		return errWrongMintOutput
+	case !bytes.Equal(out.Module, op.MintOutput.Module),
+		!bytes.Equal(out.Module, op.TransferOutput.Module):
+		return errWrongModule
+	}
+	if err := fx.Fx.VerifyCredentials(tx, &op.MintInput, &cred.Credential, &out.OutputOwners); err != nil {
+		return err
+	}
+	return fx.call(out.Module, VerifyOperationEntrypoint, tx.UnsignedBytes(), op.TransferOutput.Amt)
+}
+
+func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.Tx)
+	if !ok {
+		return errWrongTxType
+	}
+	in, ok := inIntf.(*TransferInput)
+	if !ok {
+		return errWrongInputType
+	}
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+	out, ok := utxoIntf.(*TransferOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+	if err := out.Verify(); err != nil {
+		return err
+	}
+	if err := fx.Fx.VerifySpend(tx, &in.TransferInput, &cred.Credential, &out.TransferOutput); err != nil {
+		return err
+	}
+	return fx.call(out.Module, VerifyTransferEntrypoint, tx.UnsignedBytes(), in.Amt)
+}
+
+// call asks [module] whether the tx may spend or mint [amount].
+//
+// The module is passed the length prefixed unsigned tx bytes followed by the
+// amount, both big endian.
+func (fx *Fx) call(module []byte, entrypoint string, unsignedBytes []byte, amount uint64) error {
+	if fx.Runtime == nil {
+		return errNoRuntime
+	}
+
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.IntLen+len(unsignedBytes)+wrappers.LongLen)}
+	p.PackBytes(unsignedBytes)
+	p.PackLong(amount)
+	if p.Err != nil {
+		return p.Err
+	}
+
+	result, err := fx.Runtime.Call(module, entrypoint, p.Bytes, GasLimit)
+	if err != nil {
+		return fmt.Errorf("couldn't execute %s: %w", entrypoint, err)
+	}
+	if result != 0 {
+		return fmt.Errorf("%w: %s returned %d", errModuleRejection, entrypoint, result)
+	}
+	return nil
+}
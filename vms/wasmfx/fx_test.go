@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wasmfx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/beacongo/codec/linearcodec"
+	"github.com/lasthyphen/beacongo/ids"
+	"github.com/lasthyphen/beacongo/utils/crypto"
+	"github.com/lasthyphen/beacongo/utils/hashing"
+	"github.com/lasthyphen/beacongo/utils/logging"
+	"github.com/lasthyphen/beacongo/utils/wrappers"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	sigBytes = [crypto.SECP256K1RSigLen]byte{
+		0x0e, 0x33, 0x4e, 0xbc, 0x67, 0xa7, 0x3f, 0xe8,
+		0x24, 0x33, 0xac, 0xa3, 0x47, 0x88, 0xa6, 0x3d,
+		0x58, 0xe5, 0x8e, 0xf0, 0x3a, 0xd5, 0x84, 0xf1,
+		0xbc, 0xa3, 0xb2, 0xd2, 0x5d, 0x51, 0xd6, 0x9b,
+		0x0f, 0x28, 0x5d, 0xcd, 0x3f, 0x71, 0x17, 0x0a,
+		0xf9, 0xbf, 0x2d, 0xb1, 0x10, 0x26, 0x5c, 0xe9,
+		0xdc, 0xc3, 0x9d, 0x7a, 0x01, 0x50, 0x9d, 0xe8,
+		0x35, 0xbd, 0xcb, 0x29, 0x3a, 0xd1, 0x49, 0x32,
+		0x00,
+	}
+	addr = [hashing.AddrLen]byte{
+		0x01, 0x5c, 0xce, 0x6c, 0x55, 0xd6, 0xb5, 0x09,
+		0x84, 0x5c, 0x8c, 0x4e, 0x30, 0xbe, 0xd9, 0x8d,
+		0x39, 0x1a, 0xe7, 0xf0,
+	}
+	module = []byte{0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00}
+)
+
+// testRuntime approves spends of at most [maxAmount]
+type testRuntime struct {
+	maxAmount  uint64
+	entrypoint string
+	err        error
+}
+
+func (r *testRuntime) Call(_ []byte, entrypoint string, input []byte, _ uint64) (uint32, error) {
+	r.entrypoint = entrypoint
+	if r.err != nil {
+		return 0, r.err
+	}
+	p := wrappers.Packer{Bytes: input}
+	p.UnpackBytes()
+	amount := p.UnpackLong()
+	if p.Errored() {
+		return 0, p.Err
+	}
+	if amount > r.maxAmount {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func newTestFx(t *testing.T, runtime Runtime) *Fx {
+	vm := secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	vm.CLK.Set(time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC))
+
+	fx := &Fx{Runtime: runtime}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	return fx
+}
+
+func newTestOwners() secp256k1fx.OutputOwners {
+	return secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+}
+
+func newTestCredential() *Credential {
+	return &Credential{Credential: secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{sigBytes},
+	}}
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	assert := assert.New(t)
+
+	runtime := &testRuntime{maxAmount: 1}
+	fx := newTestFx(t, runtime)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	utxo := &TransferOutput{
+		TransferOutput: secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: newTestOwners(),
+		},
+		Module: module,
+	}
+	in := &TransferInput{TransferInput: secp256k1fx.TransferInput{
+		Amt:   1,
+		Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+	}}
+
+	assert.NoError(fx.VerifyTransfer(tx, in, newTestCredential(), utxo))
+	assert.Equal(VerifyTransferEntrypoint, runtime.entrypoint)
+
+	// The module refuses spends larger than its limit
+	runtime.maxAmount = 0
+	err := fx.VerifyTransfer(tx, in, newTestCredential(), utxo)
+	assert.ErrorIs(err, errModuleRejection)
+
+	// Traps are reported as errors
+	errTrap := errors.New("trap")
+	runtime.err = errTrap
+	err = fx.VerifyTransfer(tx, in, newTestCredential(), utxo)
+	assert.ErrorIs(err, errTrap)
+
+	// Without a runtime, nothing can be spent
+	fx.Runtime = nil
+	err = fx.VerifyTransfer(tx, in, newTestCredential(), utxo)
+	assert.ErrorIs(err, errNoRuntime)
+}
+
+func TestFxVerifyTransferInvalidModule(t *testing.T) {
+	assert := assert.New(t)
+
+	fx := newTestFx(t, &testRuntime{maxAmount: 1})
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	utxo := &TransferOutput{
+		TransferOutput: secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: newTestOwners(),
+		},
+		Module: []byte{1, 2, 3, 4},
+	}
+	in := &TransferInput{TransferInput: secp256k1fx.TransferInput{
+		Amt:   1,
+		Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+	}}
+
+	err := fx.VerifyTransfer(tx, in, newTestCredential(), utxo)
+	assert.ErrorIs(err, errNotWASM)
+
+	utxo.Module = nil
+	err = fx.VerifyTransfer(tx, in, newTestCredential(), utxo)
+	assert.ErrorIs(err, errEmptyModule)
+}
+
+func TestFxVerifyMintOperation(t *testing.T) {
+	assert := assert.New(t)
+
+	runtime := &testRuntime{maxAmount: 10}
+	fx := newTestFx(t, runtime)
+	tx := &secp256k1fx.TestTx{Bytes: txBytes}
+	utxo := &MintOutput{
+		OutputOwners: newTestOwners(),
+		Module:       module,
+	}
+	op := &MintOperation{
+		MintInput: secp256k1fx.Input{SigIndices: []uint32{0}},
+		MintOutput: MintOutput{
+			OutputOwners: newTestOwners(),
+			Module:       module,
+		},
+		TransferOutput: TransferOutput{
+			TransferOutput: secp256k1fx.TransferOutput{
+				Amt:          10,
+				OutputOwners: newTestOwners(),
+			},
+			Module: module,
+		},
+	}
+
+	assert.NoError(fx.VerifyOperation(tx, op, newTestCredential(), []interface{}{utxo}))
+	assert.Equal(VerifyOperationEntrypoint, runtime.entrypoint)
+
+	// Minted outputs must keep the asset's module
+	op.TransferOutput.Module = append([]byte{}, module...)
+	op.TransferOutput.Module = append(op.TransferOutput.Module, 0x00)
+	err := fx.VerifyOperation(tx, op, newTestCredential(), []interface{}{utxo})
+	assert.ErrorIs(err, errWrongModule)
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wasmfx
+
+import (
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+// MintOutput allows its owners to mint more of an asset whose outputs are
+// governed by [Module].
+type MintOutput struct {
+	secp256k1fx.OutputOwners `serialize:"true"`
+
+	Module []byte `serialize:"true" json:"module"`
+}
+
+func (out *MintOutput) Verify() error {
+	if out == nil {
+		return errNilOutput
+	}
+	if err := out.OutputOwners.Verify(); err != nil {
+		return err
+	}
+	return verifyModule(out.Module)
+}
+
+func (out *MintOutput) VerifyState() error { return out.Verify() }
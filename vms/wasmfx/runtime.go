@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wasmfx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/beacongo/utils/units"
+)
+
+const (
+	// MaxModuleSize is the largest WASM module that can be attached to an
+	// output.
+	MaxModuleSize = 64 * units.KiB
+
+	// GasLimit is the amount of gas a single module invocation may consume.
+	GasLimit = 1_000_000
+
+	// Exported functions invoked by the fx
+	VerifyTransferEntrypoint  = "verify_transfer"
+	VerifyOperationEntrypoint = "verify_operation"
+)
+
+var (
+	wasmMagic = []byte{0x00, 'a', 's', 'm'}
+
+	errNoRuntime       = errors.New("no WASM runtime configured")
+	errEmptyModule     = errors.New("module is empty")
+	errModuleTooLarge  = fmt.Errorf("module is larger than %d bytes", MaxModuleSize)
+	errNotWASM         = errors.New("module isn't a WASM binary")
+	errModuleRejection = errors.New("module rejected the spend")
+)
+
+// Runtime executes WASM modules.
+//
+// Implementations must be deterministic: every node must reach the same
+// result for the same module and input, including when the gas limit is
+// exhausted.
+type Runtime interface {
+	// Call instantiates [module], writes [input] into its linear memory, and
+	// invokes the exported function [entrypoint] with the input's offset and
+	// length. It returns the function's result, where 0 means that the module
+	// approves. An error is returned if the module traps, doesn't export
+	// [entrypoint], or consumes more than [gasLimit].
+	Call(module []byte, entrypoint string, input []byte, gasLimit uint64) (uint32, error)
+}
+
+func verifyModule(module []byte) error {
+	switch {
+	case len(module) == 0:
+		return errEmptyModule
+	case len(module) > MaxModuleSize:
+		return errModuleTooLarge
+	case !bytes.HasPrefix(module, wasmMagic):
+		return errNotWASM
+	default:
+		return nil
+	}
+}
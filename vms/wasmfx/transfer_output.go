@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wasmfx
+
+import (
+	"encoding/json"
+
+	"github.com/lasthyphen/beacongo/utils/formatting"
+	"github.com/lasthyphen/beacongo/vms/secp256k1fx"
+)
+
+// TransferOutput is a secp256k1fx transfer output that can only be spent if
+// [Module] approves the spending transaction.
+type TransferOutput struct {
+	secp256k1fx.TransferOutput `serialize:"true"`
+
+	Module []byte `serialize:"true" json:"module"`
+}
+
+// MarshalJSON marshals the embedded TransferOutput along with the module
+func (out *TransferOutput) MarshalJSON() ([]byte, error) {
+	result, err := out.OutputOwners.Fields()
+	if err != nil {
+		return nil, err
+	}
+	module, err := formatting.EncodeWithChecksum(formatting.Hex, out.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	result["amount"] = out.Amt
+	result["module"] = module
+	return json.Marshal(result)
+}
+
+func (out *TransferOutput) Verify() error {
+	if out == nil {
+		return errNilOutput
+	}
+	if err := out.TransferOutput.Verify(); err != nil {
+		return err
+	}
+	return verifyModule(out.Module)
+}
+
+func (out *TransferOutput) VerifyState() error { return out.Verify() }